@@ -0,0 +1,141 @@
+// Package action provides types for authoring GitHub Actions.
+//
+// An Action declared as a package-level variable is built into
+// .github/actions/<name>/action.yml, along with a typed wrapper that
+// workflows in the same module use to call it.
+package action
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+// Runtimes accepted by Runs.Using.
+const (
+	UsingComposite = "composite"
+	UsingDocker    = "docker"
+	UsingNode20    = "node20"
+	UsingNode24    = "node24"
+)
+
+// Action represents a GitHub Action metadata file (action.yml).
+type Action struct {
+	// Name is the name of the action.
+	Name string `yaml:"name"`
+
+	// Description is a short description of the action.
+	Description string `yaml:"description"`
+
+	// Author is the name of the action's author.
+	Author string `yaml:"author,omitempty"`
+
+	// Inputs defines the input parameters the action accepts.
+	Inputs map[string]Input `yaml:"inputs,omitempty"`
+
+	// Outputs defines the output parameters the action sets.
+	Outputs map[string]Output `yaml:"outputs,omitempty"`
+
+	// Runs configures how the action is executed.
+	Runs Runs `yaml:"runs"`
+
+	// Branding sets the icon and color shown on GitHub Marketplace.
+	Branding *Branding `yaml:"branding,omitempty"`
+}
+
+// ResourceType returns "action" for interface compliance.
+func (a Action) ResourceType() string {
+	return "action"
+}
+
+// Input defines an input parameter for an action.
+type Input struct {
+	// Description explains the input.
+	Description string `yaml:"description"`
+
+	// Required indicates whether the input must be supplied.
+	Required bool `yaml:"required,omitempty"`
+
+	// Default is the value used when the input is not supplied.
+	// A "true"/"false" default produces a bool wrapper field, and a
+	// numeric default an int field.
+	Default string `yaml:"default,omitempty"`
+
+	// DeprecationMessage warns users that the input is deprecated.
+	DeprecationMessage string `yaml:"deprecationMessage,omitempty"`
+}
+
+// Output defines an output parameter for an action.
+type Output struct {
+	// Description explains the output.
+	Description string `yaml:"description"`
+
+	// Value maps the output to a step output. Required for composite
+	// actions, e.g. workflow.Steps.Get("build", "path").
+	Value workflow.Expression `yaml:"value,omitempty"`
+}
+
+// Runs configures how an action is executed.
+// Set the fields that apply to the runtime chosen in Using.
+type Runs struct {
+	// Using is the runtime: composite, docker, node20 or node24.
+	Using string `yaml:"using"`
+
+	// Steps lists the steps of a composite action.
+	// Accepts workflow.Step values and typed action wrappers.
+	Steps []any `yaml:"steps,omitempty"`
+
+	// Main is the entry point file of a JavaScript action.
+	Main string `yaml:"main,omitempty"`
+
+	// Pre is a script run before Main.
+	Pre string `yaml:"pre,omitempty"`
+
+	// PreIf is the condition for running Pre.
+	PreIf string `yaml:"pre-if,omitempty"`
+
+	// Post is a script run after the job completes.
+	Post string `yaml:"post,omitempty"`
+
+	// PostIf is the condition for running Post.
+	PostIf string `yaml:"post-if,omitempty"`
+
+	// Image is the Docker image of a Docker action, e.g. "Dockerfile"
+	// or "docker://alpine:3".
+	Image string `yaml:"image,omitempty"`
+
+	// Entrypoint overrides the image ENTRYPOINT.
+	Entrypoint string `yaml:"entrypoint,omitempty"`
+
+	// PreEntrypoint runs before the entrypoint.
+	PreEntrypoint string `yaml:"pre-entrypoint,omitempty"`
+
+	// PostEntrypoint runs after the job completes.
+	PostEntrypoint string `yaml:"post-entrypoint,omitempty"`
+
+	// Args are passed to the container.
+	Args []string `yaml:"args,omitempty"`
+
+	// Env sets environment variables in the container.
+	Env map[string]any `yaml:"env,omitempty"`
+}
+
+// Branding sets how an action appears on GitHub Marketplace.
+type Branding struct {
+	// Icon is a Feather icon name.
+	Icon string `yaml:"icon,omitempty"`
+
+	// Color is the background color of the badge.
+	Color string `yaml:"color,omitempty"`
+}
+
+// Composite returns Runs for a composite action with the given steps.
+func Composite(steps ...any) Runs {
+	return Runs{Using: UsingComposite, Steps: steps}
+}
+
+// Docker returns Runs for a Docker container action.
+func Docker(image string, args ...string) Runs {
+	return Runs{Using: UsingDocker, Image: image, Args: args}
+}
+
+// Node returns Runs for a JavaScript action on the given Node.js runtime.
+func Node(using, main string) Runs {
+	return Runs{Using: using, Main: main}
+}
@@ -0,0 +1,50 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+func TestAction_ResourceType(t *testing.T) {
+	a := Action{}
+	if got := a.ResourceType(); got != "action" {
+		t.Errorf("ResourceType() = %q, want %q", got, "action")
+	}
+}
+
+func TestComposite(t *testing.T) {
+	r := Composite(workflow.Step{Run: "make", Shell: "bash"})
+
+	if r.Using != UsingComposite {
+		t.Errorf("Using = %q, want %q", r.Using, UsingComposite)
+	}
+	if len(r.Steps) != 1 {
+		t.Errorf("len(Steps) = %d, want 1", len(r.Steps))
+	}
+}
+
+func TestDocker(t *testing.T) {
+	r := Docker("Dockerfile", "--verbose", "${{ inputs.target }}")
+
+	if r.Using != UsingDocker {
+		t.Errorf("Using = %q, want %q", r.Using, UsingDocker)
+	}
+	if r.Image != "Dockerfile" {
+		t.Errorf("Image = %q, want %q", r.Image, "Dockerfile")
+	}
+	if len(r.Args) != 2 {
+		t.Errorf("len(Args) = %d, want 2", len(r.Args))
+	}
+}
+
+func TestNode(t *testing.T) {
+	r := Node(UsingNode20, "dist/index.js")
+
+	if r.Using != UsingNode20 {
+		t.Errorf("Using = %q, want %q", r.Using, UsingNode20)
+	}
+	if r.Main != "dist/index.js" {
+		t.Errorf("Main = %q, want %q", r.Main, "dist/index.js")
+	}
+}
@@ -1,6 +1,8 @@
 // Package actions_rs_toolchain provides a typed wrapper for actions-rs/toolchain.
 package actions_rs_toolchain
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // Toolchain wraps the actions-rs/toolchain@v1 action.
 // Install the Rust toolchain and add it to PATH.
 type Toolchain struct {
@@ -72,3 +74,37 @@ func Nightly() Toolchain {
 func Beta() Toolchain {
 	return Toolchain{ToolchainName: "beta"}
 }
+
+// ToolchainOutputs references the outputs of a actions-rs/toolchain step.
+type ToolchainOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a Toolchain) Outputs(stepID string) ToolchainOutputs {
+	return ToolchainOutputs{stepID: stepID}
+}
+
+// Rustc returns the rustc output.
+// Installed rustc version.
+func (o ToolchainOutputs) Rustc() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "rustc")
+}
+
+// RustcHash returns the rustc_hash output.
+// Installed rustc commit hash.
+func (o ToolchainOutputs) RustcHash() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "rustc_hash")
+}
+
+// Cargo returns the cargo output.
+// Installed cargo version.
+func (o ToolchainOutputs) Cargo() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cargo")
+}
+
+// Rustup returns the rustup output.
+// Installed rustup version.
+func (o ToolchainOutputs) Rustup() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "rustup")
+}
@@ -203,3 +203,24 @@ func TestToolchain_Helpers_Beta(t *testing.T) {
 func TestToolchain_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = Toolchain{}
 }
+
+func TestToolchain_Outputs(t *testing.T) {
+	o := Toolchain{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Rustc", o.Rustc().String(), "${{ steps.step.outputs.rustc }}"},
+		{"RustcHash", o.RustcHash().String(), "${{ steps.step.outputs.rustc_hash }}"},
+		{"Cargo", o.Cargo().String(), "${{ steps.step.outputs.cargo }}"},
+		{"Rustup", o.Rustup().String(), "${{ steps.step.outputs.rustup }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
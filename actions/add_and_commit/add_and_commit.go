@@ -1,6 +1,8 @@
 // Package add_and_commit provides a typed wrapper for EndBug/add-and-commit.
 package add_and_commit
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // AddAndCommit wraps the EndBug/add-and-commit@v9 action.
 // Add and commit files to a Git repository.
 type AddAndCommit struct {
@@ -54,3 +56,49 @@ func (a AddAndCommit) Inputs() map[string]any {
 
 	return with
 }
+
+// AddAndCommitOutputs references the outputs of a EndBug/add-and-commit step.
+type AddAndCommitOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a AddAndCommit) Outputs(stepID string) AddAndCommitOutputs {
+	return AddAndCommitOutputs{stepID: stepID}
+}
+
+// Committed returns the committed output.
+// Whether a commit was created.
+func (o AddAndCommitOutputs) Committed() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "committed")
+}
+
+// CommitLongSHA returns the commit_long_sha output.
+// Full SHA of the commit.
+func (o AddAndCommitOutputs) CommitLongSHA() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "commit_long_sha")
+}
+
+// CommitSHA returns the commit_sha output.
+// Short SHA of the commit.
+func (o AddAndCommitOutputs) CommitSHA() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "commit_sha")
+}
+
+// Pushed returns the pushed output.
+// Whether the commit was pushed.
+func (o AddAndCommitOutputs) Pushed() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "pushed")
+}
+
+// Tagged returns the tagged output.
+// Whether a tag was created.
+func (o AddAndCommitOutputs) Tagged() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "tagged")
+}
+
+// TagPushed returns the tag_pushed output.
+// Whether the tag was pushed.
+func (o AddAndCommitOutputs) TagPushed() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "tag_pushed")
+}
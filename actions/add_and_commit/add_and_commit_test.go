@@ -144,3 +144,26 @@ func TestAddAndCommit_Inputs_AllFields(t *testing.T) {
 func TestAddAndCommit_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = AddAndCommit{}
 }
+
+func TestAddAndCommit_Outputs(t *testing.T) {
+	o := AddAndCommit{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Committed", o.Committed().String(), "${{ steps.step.outputs.committed }}"},
+		{"CommitLongSHA", o.CommitLongSHA().String(), "${{ steps.step.outputs.commit_long_sha }}"},
+		{"CommitSHA", o.CommitSHA().String(), "${{ steps.step.outputs.commit_sha }}"},
+		{"Pushed", o.Pushed().String(), "${{ steps.step.outputs.pushed }}"},
+		{"Tagged", o.Tagged().String(), "${{ steps.step.outputs.tagged }}"},
+		{"TagPushed", o.TagPushed().String(), "${{ steps.step.outputs.tag_pushed }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package add_to_project provides a typed wrapper for actions/add-to-project.
 package add_to_project
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // AddToProject wraps the actions/add-to-project@v1 action.
 // Automate adding issues and pull requests to GitHub projects.
 type AddToProject struct {
@@ -41,3 +43,19 @@ func (a AddToProject) Inputs() map[string]any {
 
 	return with
 }
+
+// AddToProjectOutputs references the outputs of a actions/add-to-project step.
+type AddToProjectOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a AddToProject) Outputs(stepID string) AddToProjectOutputs {
+	return AddToProjectOutputs{stepID: stepID}
+}
+
+// ItemID returns the itemId output.
+// ID of the project item.
+func (o AddToProjectOutputs) ItemID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "itemId")
+}
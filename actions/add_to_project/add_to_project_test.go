@@ -188,3 +188,21 @@ func TestAddToProject_Inputs_MultipleLabels(t *testing.T) {
 		t.Errorf("inputs[labeled] = %v, want %q", inputs["labeled"], "bug,enhancement,documentation,good-first-issue")
 	}
 }
+
+func TestAddToProject_Outputs(t *testing.T) {
+	o := AddToProject{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ItemID", o.ItemID().String(), "${{ steps.step.outputs.itemId }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package attest_build_provenance provides a typed wrapper for actions/attest-build-provenance.
 package attest_build_provenance
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // AttestBuildProvenance wraps the actions/attest-build-provenance@v1 action.
 // Generate signed build provenance attestations for workflow artifacts.
 type AttestBuildProvenance struct {
@@ -70,3 +72,31 @@ func (a AttestBuildProvenance) Inputs() map[string]any {
 
 	return with
 }
+
+// AttestBuildProvenanceOutputs references the outputs of a actions/attest-build-provenance step.
+type AttestBuildProvenanceOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a AttestBuildProvenance) Outputs(stepID string) AttestBuildProvenanceOutputs {
+	return AttestBuildProvenanceOutputs{stepID: stepID}
+}
+
+// BundlePath returns the bundle-path output.
+// Path to the file containing the attestation bundle.
+func (o AttestBuildProvenanceOutputs) BundlePath() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "bundle-path")
+}
+
+// AttestationID returns the attestation-id output.
+// ID of the attestation.
+func (o AttestBuildProvenanceOutputs) AttestationID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "attestation-id")
+}
+
+// AttestationURL returns the attestation-url output.
+// URL of the attestation summary.
+func (o AttestBuildProvenanceOutputs) AttestationURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "attestation-url")
+}
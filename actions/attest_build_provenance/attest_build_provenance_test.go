@@ -211,3 +211,23 @@ func TestAttestBuildProvenance_Inputs_PushToRegistryWithToken(t *testing.T) {
 		t.Errorf("inputs[github-token] = %v, want %q", inputs["github-token"], "${{ secrets.GITHUB_TOKEN }}")
 	}
 }
+
+func TestAttestBuildProvenance_Outputs(t *testing.T) {
+	o := AttestBuildProvenance{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"BundlePath", o.BundlePath().String(), "${{ steps.step.outputs.bundle-path }}"},
+		{"AttestationID", o.AttestationID().String(), "${{ steps.step.outputs.attestation-id }}"},
+		{"AttestationURL", o.AttestationURL().String(), "${{ steps.step.outputs.attestation-url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package aws_configure_credentials provides a typed wrapper for aws-actions/configure-aws-credentials.
 package aws_configure_credentials
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // AWSConfigureCredentials wraps the aws-actions/configure-aws-credentials@v4 action.
 // Configure AWS credentials for use in subsequent steps.
 type AWSConfigureCredentials struct {
@@ -143,3 +145,43 @@ func (a AWSConfigureCredentials) Inputs() map[string]any {
 
 	return with
 }
+
+// AWSConfigureCredentialsOutputs references the outputs of a aws-actions/configure-aws-credentials step.
+type AWSConfigureCredentialsOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a AWSConfigureCredentials) Outputs(stepID string) AWSConfigureCredentialsOutputs {
+	return AWSConfigureCredentialsOutputs{stepID: stepID}
+}
+
+// AWSAccountID returns the aws-account-id output.
+// AWS account ID for the credentials.
+func (o AWSConfigureCredentialsOutputs) AWSAccountID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "aws-account-id")
+}
+
+// AWSAccessKeyID returns the aws-access-key-id output.
+// AWS access key ID.
+func (o AWSConfigureCredentialsOutputs) AWSAccessKeyID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "aws-access-key-id")
+}
+
+// AWSSecretAccessKey returns the aws-secret-access-key output.
+// AWS secret access key.
+func (o AWSConfigureCredentialsOutputs) AWSSecretAccessKey() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "aws-secret-access-key")
+}
+
+// AWSSessionToken returns the aws-session-token output.
+// AWS session token.
+func (o AWSConfigureCredentialsOutputs) AWSSessionToken() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "aws-session-token")
+}
+
+// AWSExpiration returns the aws-expiration output.
+// Expiration time of the credentials.
+func (o AWSConfigureCredentialsOutputs) AWSExpiration() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "aws-expiration")
+}
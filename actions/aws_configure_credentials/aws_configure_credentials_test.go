@@ -202,3 +202,25 @@ func TestAWSConfigureCredentials_ImplementsStepAction(t *testing.T) {
 	a := AWSConfigureCredentials{}
 	var _ workflow.StepAction = a
 }
+
+func TestAWSConfigureCredentials_Outputs(t *testing.T) {
+	o := AWSConfigureCredentials{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"AWSAccountID", o.AWSAccountID().String(), "${{ steps.step.outputs.aws-account-id }}"},
+		{"AWSAccessKeyID", o.AWSAccessKeyID().String(), "${{ steps.step.outputs.aws-access-key-id }}"},
+		{"AWSSecretAccessKey", o.AWSSecretAccessKey().String(), "${{ steps.step.outputs.aws-secret-access-key }}"},
+		{"AWSSessionToken", o.AWSSessionToken().String(), "${{ steps.step.outputs.aws-session-token }}"},
+		{"AWSExpiration", o.AWSExpiration().String(), "${{ steps.step.outputs.aws-expiration }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package aws_ecr_login provides a typed wrapper for aws-actions/amazon-ecr-login.
 package aws_ecr_login
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // AWSECRLogin wraps the aws-actions/amazon-ecr-login@v2 action.
 // Authenticate to Amazon ECR Private or Public registries.
 type AWSECRLogin struct {
@@ -48,3 +50,19 @@ func (a AWSECRLogin) Inputs() map[string]any {
 
 	return with
 }
+
+// AWSECRLoginOutputs references the outputs of a aws-actions/amazon-ecr-login step.
+type AWSECRLoginOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a AWSECRLogin) Outputs(stepID string) AWSECRLoginOutputs {
+	return AWSECRLoginOutputs{stepID: stepID}
+}
+
+// Registry returns the registry output.
+// URI of the ECR registry.
+func (o AWSECRLoginOutputs) Registry() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "registry")
+}
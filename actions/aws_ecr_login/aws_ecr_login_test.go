@@ -78,3 +78,21 @@ func TestAWSECRLogin_ImplementsStepAction(t *testing.T) {
 	a := AWSECRLogin{}
 	var _ workflow.StepAction = a
 }
+
+func TestAWSECRLogin_Outputs(t *testing.T) {
+	o := AWSECRLogin{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Registry", o.Registry().String(), "${{ steps.step.outputs.registry }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package azure_webapps_deploy provides a typed wrapper for azure/webapps-deploy.
 package azure_webapps_deploy
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // AzureWebappsDeploy wraps the azure/webapps-deploy@v3 action.
 // Deploy to Azure Web Apps or Azure Web App for Containers.
 type AzureWebappsDeploy struct {
@@ -89,3 +91,19 @@ func (a AzureWebappsDeploy) Inputs() map[string]any {
 
 	return with
 }
+
+// AzureWebappsDeployOutputs references the outputs of a azure/webapps-deploy step.
+type AzureWebappsDeployOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a AzureWebappsDeploy) Outputs(stepID string) AzureWebappsDeployOutputs {
+	return AzureWebappsDeployOutputs{stepID: stepID}
+}
+
+// WebappURL returns the webapp-url output.
+// URL of the deployed web app.
+func (o AzureWebappsDeployOutputs) WebappURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "webapp-url")
+}
@@ -175,3 +175,21 @@ func TestAzureWebappsDeploy_ImplementsStepAction(t *testing.T) {
 	a := AzureWebappsDeploy{}
 	var _ workflow.StepAction = a
 }
+
+func TestAzureWebappsDeploy_Outputs(t *testing.T) {
+	o := AzureWebappsDeploy{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"WebappURL", o.WebappURL().String(), "${{ steps.step.outputs.webapp-url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package cache provides a typed wrapper for actions/cache.
 package cache
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // Cache wraps the actions/cache@v4 action.
 // Cache dependencies and build outputs.
 type Cache struct {
@@ -65,3 +67,19 @@ func (a Cache) Inputs() map[string]any {
 
 	return with
 }
+
+// CacheOutputs references the outputs of a actions/cache step.
+type CacheOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a Cache) Outputs(stepID string) CacheOutputs {
+	return CacheOutputs{stepID: stepID}
+}
+
+// CacheHit returns the cache-hit output.
+// Whether an exact match was found for the key.
+func (o CacheOutputs) CacheHit() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cache-hit")
+}
@@ -90,3 +90,21 @@ func TestCache_Inputs_IntFields(t *testing.T) {
 func TestCache_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = Cache{}
 }
+
+func TestCache_Outputs(t *testing.T) {
+	o := Cache{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"CacheHit", o.CacheHit().String(), "${{ steps.step.outputs.cache-hit }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package checkout provides a typed wrapper for actions/checkout.
 package checkout
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // Checkout wraps the actions/checkout@v4 action.
 // Checkout a Git repository at a particular version.
 type Checkout struct {
@@ -131,3 +133,25 @@ func (a Checkout) Inputs() map[string]any {
 
 	return with
 }
+
+// CheckoutOutputs references the outputs of a actions/checkout step.
+type CheckoutOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a Checkout) Outputs(stepID string) CheckoutOutputs {
+	return CheckoutOutputs{stepID: stepID}
+}
+
+// Ref returns the ref output.
+// Branch, tag or SHA that was checked out.
+func (o CheckoutOutputs) Ref() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "ref")
+}
+
+// Commit returns the commit output.
+// Commit SHA that was checked out.
+func (o CheckoutOutputs) Commit() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "commit")
+}
@@ -327,3 +327,22 @@ func TestCheckout_Inputs_NegativeFetchDepth(t *testing.T) {
 		t.Errorf("inputs[fetch-depth] = %v, want -1", inputs["fetch-depth"])
 	}
 }
+
+func TestCheckout_Outputs(t *testing.T) {
+	o := Checkout{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Ref", o.Ref().String(), "${{ steps.step.outputs.ref }}"},
+		{"Commit", o.Commit().String(), "${{ steps.step.outputs.commit }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
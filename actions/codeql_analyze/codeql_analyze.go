@@ -1,6 +1,8 @@
 // Package codeql_analyze provides a typed wrapper for github/codeql-action/analyze.
 package codeql_analyze
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // CodeQLAnalyze wraps the github/codeql-action/analyze@v3 action.
 // Analyze code with CodeQL and upload results to GitHub Security.
 type CodeQLAnalyze struct {
@@ -59,3 +61,31 @@ func (a CodeQLAnalyze) Inputs() map[string]any {
 
 	return with
 }
+
+// CodeQLAnalyzeOutputs references the outputs of a github/codeql-action/analyze step.
+type CodeQLAnalyzeOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a CodeQLAnalyze) Outputs(stepID string) CodeQLAnalyzeOutputs {
+	return CodeQLAnalyzeOutputs{stepID: stepID}
+}
+
+// DBLocations returns the db-locations output.
+// Paths of the CodeQL databases, by language.
+func (o CodeQLAnalyzeOutputs) DBLocations() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "db-locations")
+}
+
+// SarifID returns the sarif-id output.
+// ID of the uploaded SARIF file.
+func (o CodeQLAnalyzeOutputs) SarifID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "sarif-id")
+}
+
+// SarifOutput returns the sarif-output output.
+// Path of the generated SARIF files.
+func (o CodeQLAnalyzeOutputs) SarifOutput() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "sarif-output")
+}
@@ -94,3 +94,23 @@ func TestCodeQLAnalyze_ImplementsStepAction(t *testing.T) {
 	a := CodeQLAnalyze{}
 	var _ workflow.StepAction = a
 }
+
+func TestCodeQLAnalyze_Outputs(t *testing.T) {
+	o := CodeQLAnalyze{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"DBLocations", o.DBLocations().String(), "${{ steps.step.outputs.db-locations }}"},
+		{"SarifID", o.SarifID().String(), "${{ steps.step.outputs.sarif-id }}"},
+		{"SarifOutput", o.SarifOutput().String(), "${{ steps.step.outputs.sarif-output }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
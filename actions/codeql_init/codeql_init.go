@@ -1,6 +1,8 @@
 // Package codeql_init provides a typed wrapper for github/codeql-action/init.
 package codeql_init
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // CodeQLInit wraps the github/codeql-action/init@v3 action.
 // Initialize CodeQL for scanning and set up the analysis environment.
 type CodeQLInit struct {
@@ -71,3 +73,25 @@ func (a CodeQLInit) Inputs() map[string]any {
 
 	return with
 }
+
+// CodeQLInitOutputs references the outputs of a github/codeql-action/init step.
+type CodeQLInitOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a CodeQLInit) Outputs(stepID string) CodeQLInitOutputs {
+	return CodeQLInitOutputs{stepID: stepID}
+}
+
+// CodeQLPath returns the codeql-path output.
+// Path of the CodeQL binary.
+func (o CodeQLInitOutputs) CodeQLPath() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "codeql-path")
+}
+
+// CodeQLVersion returns the codeql-version output.
+// Version of the CodeQL binary.
+func (o CodeQLInitOutputs) CodeQLVersion() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "codeql-version")
+}
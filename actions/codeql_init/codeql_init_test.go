@@ -114,3 +114,22 @@ func TestCodeQLInit_ImplementsStepAction(t *testing.T) {
 	a := CodeQLInit{}
 	var _ workflow.StepAction = a
 }
+
+func TestCodeQLInit_Outputs(t *testing.T) {
+	o := CodeQLInit{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"CodeQLPath", o.CodeQLPath().String(), "${{ steps.step.outputs.codeql-path }}"},
+		{"CodeQLVersion", o.CodeQLVersion().String(), "${{ steps.step.outputs.codeql-version }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package configure_pages provides a typed wrapper for actions/configure-pages.
 package configure_pages
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // ConfigurePages wraps the actions/configure-pages@v5 action.
 // Configures GitHub Pages for deployment.
 type ConfigurePages struct {
@@ -35,3 +37,37 @@ func (a ConfigurePages) Inputs() map[string]any {
 
 	return m
 }
+
+// ConfigurePagesOutputs references the outputs of a actions/configure-pages step.
+type ConfigurePagesOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a ConfigurePages) Outputs(stepID string) ConfigurePagesOutputs {
+	return ConfigurePagesOutputs{stepID: stepID}
+}
+
+// BaseURL returns the base_url output.
+// GitHub Pages site full base URL.
+func (o ConfigurePagesOutputs) BaseURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "base_url")
+}
+
+// Origin returns the origin output.
+// GitHub Pages site origin.
+func (o ConfigurePagesOutputs) Origin() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "origin")
+}
+
+// Host returns the host output.
+// GitHub Pages site host.
+func (o ConfigurePagesOutputs) Host() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "host")
+}
+
+// BasePath returns the base_path output.
+// GitHub Pages site full base path.
+func (o ConfigurePagesOutputs) BasePath() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "base_path")
+}
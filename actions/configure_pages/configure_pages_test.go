@@ -135,3 +135,24 @@ func TestConfigurePages_Generators(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigurePages_Outputs(t *testing.T) {
+	o := ConfigurePages{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"BaseURL", o.BaseURL().String(), "${{ steps.step.outputs.base_url }}"},
+		{"Origin", o.Origin().String(), "${{ steps.step.outputs.origin }}"},
+		{"Host", o.Host().String(), "${{ steps.step.outputs.host }}"},
+		{"BasePath", o.BasePath().String(), "${{ steps.step.outputs.base_path }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package create_github_app_token provides a typed wrapper for actions/create-github-app-token.
 package create_github_app_token
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // CreateGithubAppToken wraps the actions/create-github-app-token@v1 action.
 // Create GitHub App installation access tokens.
 type CreateGithubAppToken struct {
@@ -222,3 +224,31 @@ func (a CreateGithubAppToken) Inputs() map[string]any {
 
 	return with
 }
+
+// CreateGithubAppTokenOutputs references the outputs of a actions/create-github-app-token step.
+type CreateGithubAppTokenOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a CreateGithubAppToken) Outputs(stepID string) CreateGithubAppTokenOutputs {
+	return CreateGithubAppTokenOutputs{stepID: stepID}
+}
+
+// Token returns the token output.
+// GitHub installation access token.
+func (o CreateGithubAppTokenOutputs) Token() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "token")
+}
+
+// InstallationID returns the installation-id output.
+// GitHub App installation ID.
+func (o CreateGithubAppTokenOutputs) InstallationID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "installation-id")
+}
+
+// AppSlug returns the app-slug output.
+// GitHub App slug.
+func (o CreateGithubAppTokenOutputs) AppSlug() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "app-slug")
+}
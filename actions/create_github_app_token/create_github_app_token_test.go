@@ -404,3 +404,23 @@ func TestCreateGithubAppToken_Inputs_RepositoryHooks(t *testing.T) {
 		t.Errorf("inputs[permission-repository-hooks] = %v, want %q", inputs["permission-repository-hooks"], "write")
 	}
 }
+
+func TestCreateGithubAppToken_Outputs(t *testing.T) {
+	o := CreateGithubAppToken{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Token", o.Token().String(), "${{ steps.step.outputs.token }}"},
+		{"InstallationID", o.InstallationID().String(), "${{ steps.step.outputs.installation-id }}"},
+		{"AppSlug", o.AppSlug().String(), "${{ steps.step.outputs.app-slug }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
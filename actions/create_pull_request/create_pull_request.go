@@ -1,6 +1,8 @@
 // Package create_pull_request provides a typed wrapper for peter-evans/create-pull-request.
 package create_pull_request
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // CreatePullRequest wraps the peter-evans/create-pull-request@v6 action.
 // Create a pull request for changes to your repository in the actions workspace.
 type CreatePullRequest struct {
@@ -137,3 +139,43 @@ func (a CreatePullRequest) Inputs() map[string]any {
 
 	return with
 }
+
+// CreatePullRequestOutputs references the outputs of a peter-evans/create-pull-request step.
+type CreatePullRequestOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a CreatePullRequest) Outputs(stepID string) CreatePullRequestOutputs {
+	return CreatePullRequestOutputs{stepID: stepID}
+}
+
+// PullRequestNumber returns the pull-request-number output.
+// Pull request number.
+func (o CreatePullRequestOutputs) PullRequestNumber() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "pull-request-number")
+}
+
+// PullRequestURL returns the pull-request-url output.
+// URL of the pull request.
+func (o CreatePullRequestOutputs) PullRequestURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "pull-request-url")
+}
+
+// PullRequestOperation returns the pull-request-operation output.
+// Operation performed: created, updated or closed.
+func (o CreatePullRequestOutputs) PullRequestOperation() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "pull-request-operation")
+}
+
+// PullRequestHeadSHA returns the pull-request-head-sha output.
+// SHA of the pull request branch head.
+func (o CreatePullRequestOutputs) PullRequestHeadSHA() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "pull-request-head-sha")
+}
+
+// PullRequestBranch returns the pull-request-branch output.
+// Name of the pull request branch.
+func (o CreatePullRequestOutputs) PullRequestBranch() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "pull-request-branch")
+}
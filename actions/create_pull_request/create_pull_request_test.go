@@ -380,3 +380,25 @@ func TestCreatePullRequest_Inputs_BranchSuffixOptions(t *testing.T) {
 func TestCreatePullRequest_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = CreatePullRequest{}
 }
+
+func TestCreatePullRequest_Outputs(t *testing.T) {
+	o := CreatePullRequest{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"PullRequestNumber", o.PullRequestNumber().String(), "${{ steps.step.outputs.pull-request-number }}"},
+		{"PullRequestURL", o.PullRequestURL().String(), "${{ steps.step.outputs.pull-request-url }}"},
+		{"PullRequestOperation", o.PullRequestOperation().String(), "${{ steps.step.outputs.pull-request-operation }}"},
+		{"PullRequestHeadSHA", o.PullRequestHeadSHA().String(), "${{ steps.step.outputs.pull-request-head-sha }}"},
+		{"PullRequestBranch", o.PullRequestBranch().String(), "${{ steps.step.outputs.pull-request-branch }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package create_release provides a typed wrapper for actions/create-release.
 package create_release
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // CreateRelease wraps the actions/create-release@v1 action.
 // Create a release for a repository tag.
 //
@@ -81,3 +83,31 @@ func (a CreateRelease) Inputs() map[string]any {
 
 	return with
 }
+
+// CreateReleaseOutputs references the outputs of a actions/create-release step.
+type CreateReleaseOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a CreateRelease) Outputs(stepID string) CreateReleaseOutputs {
+	return CreateReleaseOutputs{stepID: stepID}
+}
+
+// ID returns the id output.
+// Release ID.
+func (o CreateReleaseOutputs) ID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "id")
+}
+
+// HTMLURL returns the html_url output.
+// URL of the release page.
+func (o CreateReleaseOutputs) HTMLURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "html_url")
+}
+
+// UploadURL returns the upload_url output.
+// URL for uploading release assets.
+func (o CreateReleaseOutputs) UploadURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "upload_url")
+}
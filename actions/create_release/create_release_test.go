@@ -220,3 +220,23 @@ func TestCreateRelease_Inputs_BooleanDefaults(t *testing.T) {
 func TestCreateRelease_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = CreateRelease{}
 }
+
+func TestCreateRelease_Outputs(t *testing.T) {
+	o := CreateRelease{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ID", o.ID().String(), "${{ steps.step.outputs.id }}"},
+		{"HTMLURL", o.HTMLURL().String(), "${{ steps.step.outputs.html_url }}"},
+		{"UploadURL", o.UploadURL().String(), "${{ steps.step.outputs.upload_url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
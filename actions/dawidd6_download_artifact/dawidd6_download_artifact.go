@@ -1,6 +1,8 @@
 // Package dawidd6_download_artifact provides a typed wrapper for dawidd6/action-download-artifact.
 package dawidd6_download_artifact
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // DownloadArtifact wraps the dawidd6/action-download-artifact@v6 action.
 // Download artifacts from a different workflow run or repository.
 type DownloadArtifact struct {
@@ -89,3 +91,25 @@ func (a DownloadArtifact) Inputs() map[string]any {
 
 	return with
 }
+
+// DownloadArtifactOutputs references the outputs of a dawidd6/action-download-artifact step.
+type DownloadArtifactOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a DownloadArtifact) Outputs(stepID string) DownloadArtifactOutputs {
+	return DownloadArtifactOutputs{stepID: stepID}
+}
+
+// FoundArtifact returns the found_artifact output.
+// Whether an artifact was found.
+func (o DownloadArtifactOutputs) FoundArtifact() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "found_artifact")
+}
+
+// Artifacts returns the artifacts output.
+// JSON list of the downloaded artifacts.
+func (o DownloadArtifactOutputs) Artifacts() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "artifacts")
+}
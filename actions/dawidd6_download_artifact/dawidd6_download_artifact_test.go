@@ -227,3 +227,22 @@ func TestDownloadArtifact_Inputs_FalseBoolFields(t *testing.T) {
 		t.Errorf("inputs for false bools has %d entries, want 0. Got: %v", len(inputs), inputs)
 	}
 }
+
+func TestDownloadArtifact_Outputs(t *testing.T) {
+	o := DownloadArtifact{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"FoundArtifact", o.FoundArtifact().String(), "${{ steps.step.outputs.found_artifact }}"},
+		{"Artifacts", o.Artifacts().String(), "${{ steps.step.outputs.artifacts }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
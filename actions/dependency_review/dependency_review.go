@@ -1,6 +1,8 @@
 // Package dependency_review provides a typed wrapper for actions/dependency-review-action.
 package dependency_review
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // DependencyReview wraps the actions/dependency-review-action@v4 action.
 // Review pull requests for dependency changes and identify security vulnerabilities.
 type DependencyReview struct {
@@ -95,3 +97,43 @@ func (a DependencyReview) Inputs() map[string]any {
 
 	return with
 }
+
+// DependencyReviewOutputs references the outputs of a actions/dependency-review-action step.
+type DependencyReviewOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a DependencyReview) Outputs(stepID string) DependencyReviewOutputs {
+	return DependencyReviewOutputs{stepID: stepID}
+}
+
+// CommentContent returns the comment-content output.
+// Content of the review summary comment.
+func (o DependencyReviewOutputs) CommentContent() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "comment-content")
+}
+
+// InvalidLicenseChanges returns the invalid-license-changes output.
+// JSON list of changes with invalid licenses.
+func (o DependencyReviewOutputs) InvalidLicenseChanges() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "invalid-license-changes")
+}
+
+// DeniedChanges returns the denied-changes output.
+// JSON list of denied changes.
+func (o DependencyReviewOutputs) DeniedChanges() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "denied-changes")
+}
+
+// VulnerableChanges returns the vulnerable-changes output.
+// JSON list of changes with vulnerabilities.
+func (o DependencyReviewOutputs) VulnerableChanges() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "vulnerable-changes")
+}
+
+// DependencyChanges returns the dependency-changes output.
+// JSON list of all dependency changes.
+func (o DependencyReviewOutputs) DependencyChanges() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "dependency-changes")
+}
@@ -270,3 +270,25 @@ func TestDependencyReview_Inputs_WarnOnlyMode(t *testing.T) {
 func TestDependencyReview_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = DependencyReview{}
 }
+
+func TestDependencyReview_Outputs(t *testing.T) {
+	o := DependencyReview{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"CommentContent", o.CommentContent().String(), "${{ steps.step.outputs.comment-content }}"},
+		{"InvalidLicenseChanges", o.InvalidLicenseChanges().String(), "${{ steps.step.outputs.invalid-license-changes }}"},
+		{"DeniedChanges", o.DeniedChanges().String(), "${{ steps.step.outputs.denied-changes }}"},
+		{"VulnerableChanges", o.VulnerableChanges().String(), "${{ steps.step.outputs.vulnerable-changes }}"},
+		{"DependencyChanges", o.DependencyChanges().String(), "${{ steps.step.outputs.dependency-changes }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package deploy_pages provides a typed wrapper for actions/deploy-pages.
 package deploy_pages
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // DeployPages wraps the actions/deploy-pages@v4 action.
 // Deploys an artifact to GitHub Pages.
 type DeployPages struct {
@@ -47,3 +49,19 @@ func (a DeployPages) Inputs() map[string]any {
 
 	return m
 }
+
+// DeployPagesOutputs references the outputs of a actions/deploy-pages step.
+type DeployPagesOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a DeployPages) Outputs(stepID string) DeployPagesOutputs {
+	return DeployPagesOutputs{stepID: stepID}
+}
+
+// PageURL returns the page_url output.
+// URL of the deployed Pages site.
+func (o DeployPagesOutputs) PageURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "page_url")
+}
@@ -197,3 +197,21 @@ func TestDeployPages_Inputs_ZeroReportingInterval(t *testing.T) {
 		t.Errorf("inputs[reporting_interval] should not exist for ReportingInterval=0")
 	}
 }
+
+func TestDeployPages_Outputs(t *testing.T) {
+	o := DeployPages{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"PageURL", o.PageURL().String(), "${{ steps.step.outputs.page_url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package docker_build_push provides a typed wrapper for docker/build-push-action.
 package docker_build_push
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // DockerBuildPush wraps the docker/build-push-action@v6 action.
 // Build and push Docker images with Buildx.
 type DockerBuildPush struct {
@@ -119,3 +121,32 @@ func (a DockerBuildPush) Inputs() map[string]any {
 
 	return with
 }
+
+// DockerBuildPushOutputs references the outputs of a docker/build-push-action step.
+type DockerBuildPushOutputs struct {
+	stepID string
+}
+
+// StepOutputs returns output references for the step with the given ID.
+// It is not named Outputs because that name is taken by the outputs input.
+func (a DockerBuildPush) StepOutputs(stepID string) DockerBuildPushOutputs {
+	return DockerBuildPushOutputs{stepID: stepID}
+}
+
+// ImageID returns the imageid output.
+// Image ID.
+func (o DockerBuildPushOutputs) ImageID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "imageid")
+}
+
+// Digest returns the digest output.
+// Image digest.
+func (o DockerBuildPushOutputs) Digest() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "digest")
+}
+
+// Metadata returns the metadata output.
+// Build result metadata.
+func (o DockerBuildPushOutputs) Metadata() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "metadata")
+}
@@ -144,3 +144,23 @@ func TestDockerBuildPush_Inputs_LoadAndNoCache(t *testing.T) {
 func TestDockerBuildPush_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = DockerBuildPush{}
 }
+
+func TestDockerBuildPush_StepOutputs(t *testing.T) {
+	o := DockerBuildPush{}.StepOutputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ImageID", o.ImageID().String(), "${{ steps.step.outputs.imageid }}"},
+		{"Digest", o.Digest().String(), "${{ steps.step.outputs.digest }}"},
+		{"Metadata", o.Metadata().String(), "${{ steps.step.outputs.metadata }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("StepOutputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package docker_metadata provides a typed wrapper for docker/metadata-action.
 package docker_metadata
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // DockerMetadata wraps the docker/metadata-action@v5 action.
 // GitHub Action to extract metadata (tags, labels) from Git reference and GitHub events for Docker.
 type DockerMetadata struct {
@@ -77,3 +79,67 @@ func (a DockerMetadata) Inputs() map[string]any {
 
 	return with
 }
+
+// DockerMetadataOutputs references the outputs of a docker/metadata-action step.
+type DockerMetadataOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a DockerMetadata) Outputs(stepID string) DockerMetadataOutputs {
+	return DockerMetadataOutputs{stepID: stepID}
+}
+
+// Version returns the version output.
+// Docker image version.
+func (o DockerMetadataOutputs) Version() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "version")
+}
+
+// Tags returns the tags output.
+// Generated Docker tags.
+func (o DockerMetadataOutputs) Tags() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "tags")
+}
+
+// Labels returns the labels output.
+// Generated Docker labels.
+func (o DockerMetadataOutputs) Labels() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "labels")
+}
+
+// Annotations returns the annotations output.
+// Generated annotations.
+func (o DockerMetadataOutputs) Annotations() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "annotations")
+}
+
+// JSON returns the json output.
+// JSON output of tags and labels.
+func (o DockerMetadataOutputs) JSON() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "json")
+}
+
+// BakeFile returns the bake-file output.
+// Bake definition file with tags and labels.
+func (o DockerMetadataOutputs) BakeFile() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "bake-file")
+}
+
+// BakeFileTags returns the bake-file-tags output.
+// Bake definition file with tags.
+func (o DockerMetadataOutputs) BakeFileTags() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "bake-file-tags")
+}
+
+// BakeFileLabels returns the bake-file-labels output.
+// Bake definition file with labels.
+func (o DockerMetadataOutputs) BakeFileLabels() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "bake-file-labels")
+}
+
+// BakeFileAnnotations returns the bake-file-annotations output.
+// Bake definition file with annotations.
+func (o DockerMetadataOutputs) BakeFileAnnotations() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "bake-file-annotations")
+}
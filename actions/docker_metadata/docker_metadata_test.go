@@ -136,3 +136,29 @@ func TestDockerMetadata_Inputs_OnlyContext(t *testing.T) {
 func TestDockerMetadata_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = DockerMetadata{}
 }
+
+func TestDockerMetadata_Outputs(t *testing.T) {
+	o := DockerMetadata{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Version", o.Version().String(), "${{ steps.step.outputs.version }}"},
+		{"Tags", o.Tags().String(), "${{ steps.step.outputs.tags }}"},
+		{"Labels", o.Labels().String(), "${{ steps.step.outputs.labels }}"},
+		{"Annotations", o.Annotations().String(), "${{ steps.step.outputs.annotations }}"},
+		{"JSON", o.JSON().String(), "${{ steps.step.outputs.json }}"},
+		{"BakeFile", o.BakeFile().String(), "${{ steps.step.outputs.bake-file }}"},
+		{"BakeFileTags", o.BakeFileTags().String(), "${{ steps.step.outputs.bake-file-tags }}"},
+		{"BakeFileLabels", o.BakeFileLabels().String(), "${{ steps.step.outputs.bake-file-labels }}"},
+		{"BakeFileAnnotations", o.BakeFileAnnotations().String(), "${{ steps.step.outputs.bake-file-annotations }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
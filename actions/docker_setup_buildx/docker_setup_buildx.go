@@ -1,6 +1,8 @@
 // Package docker_setup_buildx provides a typed wrapper for docker/setup-buildx-action.
 package docker_setup_buildx
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // DockerSetupBuildx wraps the docker/setup-buildx-action@v3 action.
 // Set up Docker Buildx for multi-platform builds and advanced features.
 type DockerSetupBuildx struct {
@@ -89,3 +91,55 @@ func (a DockerSetupBuildx) Inputs() map[string]any {
 
 	return with
 }
+
+// DockerSetupBuildxOutputs references the outputs of a docker/setup-buildx-action step.
+type DockerSetupBuildxOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a DockerSetupBuildx) Outputs(stepID string) DockerSetupBuildxOutputs {
+	return DockerSetupBuildxOutputs{stepID: stepID}
+}
+
+// Name returns the name output.
+// Builder name.
+func (o DockerSetupBuildxOutputs) Name() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "name")
+}
+
+// Driver returns the driver output.
+// Builder driver.
+func (o DockerSetupBuildxOutputs) Driver() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "driver")
+}
+
+// Platforms returns the platforms output.
+// Builder node platforms, comma-separated.
+func (o DockerSetupBuildxOutputs) Platforms() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "platforms")
+}
+
+// Nodes returns the nodes output.
+// Builder nodes metadata as JSON.
+func (o DockerSetupBuildxOutputs) Nodes() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "nodes")
+}
+
+// Endpoint returns the endpoint output.
+// Builder node endpoint.
+func (o DockerSetupBuildxOutputs) Endpoint() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "endpoint")
+}
+
+// Status returns the status output.
+// Builder node status.
+func (o DockerSetupBuildxOutputs) Status() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "status")
+}
+
+// Flags returns the flags output.
+// Builder node flags.
+func (o DockerSetupBuildxOutputs) Flags() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "flags")
+}
@@ -109,3 +109,27 @@ func TestDockerSetupBuildx_Inputs_ConfigInline(t *testing.T) {
 func TestDockerSetupBuildx_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = DockerSetupBuildx{}
 }
+
+func TestDockerSetupBuildx_Outputs(t *testing.T) {
+	o := DockerSetupBuildx{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Name", o.Name().String(), "${{ steps.step.outputs.name }}"},
+		{"Driver", o.Driver().String(), "${{ steps.step.outputs.driver }}"},
+		{"Platforms", o.Platforms().String(), "${{ steps.step.outputs.platforms }}"},
+		{"Nodes", o.Nodes().String(), "${{ steps.step.outputs.nodes }}"},
+		{"Endpoint", o.Endpoint().String(), "${{ steps.step.outputs.endpoint }}"},
+		{"Status", o.Status().String(), "${{ steps.step.outputs.status }}"},
+		{"Flags", o.Flags().String(), "${{ steps.step.outputs.flags }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
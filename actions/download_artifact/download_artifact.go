@@ -1,6 +1,8 @@
 // Package download_artifact provides a typed wrapper for actions/download-artifact.
 package download_artifact
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // DownloadArtifact wraps the actions/download-artifact@v4 action.
 // Download a build artifact previously uploaded in the workflow.
 type DownloadArtifact struct {
@@ -59,3 +61,19 @@ func (a DownloadArtifact) Inputs() map[string]any {
 
 	return with
 }
+
+// DownloadArtifactOutputs references the outputs of a actions/download-artifact step.
+type DownloadArtifactOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a DownloadArtifact) Outputs(stepID string) DownloadArtifactOutputs {
+	return DownloadArtifactOutputs{stepID: stepID}
+}
+
+// DownloadPath returns the download-path output.
+// Path the artifacts were downloaded to.
+func (o DownloadArtifactOutputs) DownloadPath() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "download-path")
+}
@@ -88,3 +88,21 @@ func TestDownloadArtifact_Inputs_AllFields(t *testing.T) {
 func TestDownloadArtifact_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = DownloadArtifact{}
 }
+
+func TestDownloadArtifact_Outputs(t *testing.T) {
+	o := DownloadArtifact{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"DownloadPath", o.DownloadPath().String(), "${{ steps.step.outputs.download-path }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package gcp_auth provides a typed wrapper for google-github-actions/auth.
 package gcp_auth
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // GCPAuth wraps the google-github-actions/auth@v2 action.
 // Authenticate to Google Cloud using Workload Identity Federation or service account keys.
 type GCPAuth struct {
@@ -107,3 +109,43 @@ func (a GCPAuth) Inputs() map[string]any {
 
 	return with
 }
+
+// GCPAuthOutputs references the outputs of a google-github-actions/auth step.
+type GCPAuthOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a GCPAuth) Outputs(stepID string) GCPAuthOutputs {
+	return GCPAuthOutputs{stepID: stepID}
+}
+
+// ProjectID returns the project_id output.
+// Google Cloud project ID.
+func (o GCPAuthOutputs) ProjectID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "project_id")
+}
+
+// CredentialsFilePath returns the credentials_file_path output.
+// Path of the generated credentials file.
+func (o GCPAuthOutputs) CredentialsFilePath() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "credentials_file_path")
+}
+
+// AuthToken returns the auth_token output.
+// Google Cloud federated token.
+func (o GCPAuthOutputs) AuthToken() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "auth_token")
+}
+
+// AccessToken returns the access_token output.
+// Google Cloud access token.
+func (o GCPAuthOutputs) AccessToken() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "access_token")
+}
+
+// IDToken returns the id_token output.
+// Google Cloud ID token.
+func (o GCPAuthOutputs) IDToken() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "id_token")
+}
@@ -314,3 +314,25 @@ func TestGCPAuth_Inputs_AllFields(t *testing.T) {
 		}
 	}
 }
+
+func TestGCPAuth_Outputs(t *testing.T) {
+	o := GCPAuth{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ProjectID", o.ProjectID().String(), "${{ steps.step.outputs.project_id }}"},
+		{"CredentialsFilePath", o.CredentialsFilePath().String(), "${{ steps.step.outputs.credentials_file_path }}"},
+		{"AuthToken", o.AuthToken().String(), "${{ steps.step.outputs.auth_token }}"},
+		{"AccessToken", o.AccessToken().String(), "${{ steps.step.outputs.access_token }}"},
+		{"IDToken", o.IDToken().String(), "${{ steps.step.outputs.id_token }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
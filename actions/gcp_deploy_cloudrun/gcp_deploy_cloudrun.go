@@ -1,6 +1,8 @@
 // Package gcp_deploy_cloudrun provides a typed wrapper for google-github-actions/deploy-cloudrun.
 package gcp_deploy_cloudrun
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // GCPDeployCloudRun wraps the google-github-actions/deploy-cloudrun@v2 action.
 // Deploy a container to Google Cloud Run.
 type GCPDeployCloudRun struct {
@@ -125,3 +127,19 @@ func (a GCPDeployCloudRun) Inputs() map[string]any {
 
 	return with
 }
+
+// GCPDeployCloudRunOutputs references the outputs of a google-github-actions/deploy-cloudrun step.
+type GCPDeployCloudRunOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a GCPDeployCloudRun) Outputs(stepID string) GCPDeployCloudRunOutputs {
+	return GCPDeployCloudRunOutputs{stepID: stepID}
+}
+
+// URL returns the url output.
+// URL of the Cloud Run service.
+func (o GCPDeployCloudRunOutputs) URL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "url")
+}
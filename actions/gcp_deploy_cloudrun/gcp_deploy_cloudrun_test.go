@@ -264,3 +264,21 @@ func TestGCPDeployCloudRun_Inputs_AllFields(t *testing.T) {
 		t.Errorf("inputs has %d fields, want %d", len(inputs), len(expectedFields))
 	}
 }
+
+func TestGCPDeployCloudRun_Outputs(t *testing.T) {
+	o := GCPDeployCloudRun{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"URL", o.URL().String(), "${{ steps.step.outputs.url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
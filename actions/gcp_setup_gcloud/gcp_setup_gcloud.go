@@ -1,6 +1,8 @@
 // Package gcp_setup_gcloud provides a typed wrapper for google-github-actions/setup-gcloud.
 package gcp_setup_gcloud
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // GCPSetupGcloud wraps the google-github-actions/setup-gcloud@v2 action.
 // Set up and configure the Google Cloud SDK (gcloud).
 type GCPSetupGcloud struct {
@@ -47,3 +49,19 @@ func (a GCPSetupGcloud) Inputs() map[string]any {
 
 	return with
 }
+
+// GCPSetupGcloudOutputs references the outputs of a google-github-actions/setup-gcloud step.
+type GCPSetupGcloudOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a GCPSetupGcloud) Outputs(stepID string) GCPSetupGcloudOutputs {
+	return GCPSetupGcloudOutputs{stepID: stepID}
+}
+
+// Version returns the version output.
+// Installed gcloud version.
+func (o GCPSetupGcloudOutputs) Version() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "version")
+}
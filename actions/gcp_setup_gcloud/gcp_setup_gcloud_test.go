@@ -70,3 +70,21 @@ func TestGCPSetupGcloud_ImplementsStepAction(t *testing.T) {
 	a := GCPSetupGcloud{}
 	var _ workflow.StepAction = a
 }
+
+func TestGCPSetupGcloud_Outputs(t *testing.T) {
+	o := GCPSetupGcloud{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Version", o.Version().String(), "${{ steps.step.outputs.version }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
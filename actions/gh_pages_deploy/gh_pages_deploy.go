@@ -1,6 +1,8 @@
 // Package gh_pages_deploy provides a typed wrapper for JamesIves/github-pages-deploy-action.
 package gh_pages_deploy
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // GitHubPagesDeploy wraps the JamesIves/github-pages-deploy-action@v4 action.
 // Deploy to GitHub Pages from your GitHub Actions workflow.
 type GitHubPagesDeploy struct {
@@ -119,3 +121,19 @@ func (a GitHubPagesDeploy) Inputs() map[string]any {
 
 	return with
 }
+
+// GitHubPagesDeployOutputs references the outputs of a JamesIves/github-pages-deploy-action step.
+type GitHubPagesDeployOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a GitHubPagesDeploy) Outputs(stepID string) GitHubPagesDeployOutputs {
+	return GitHubPagesDeployOutputs{stepID: stepID}
+}
+
+// DeploymentStatus returns the deployment-status output.
+// Deployment status: success, failed or skipped.
+func (o GitHubPagesDeployOutputs) DeploymentStatus() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "deployment-status")
+}
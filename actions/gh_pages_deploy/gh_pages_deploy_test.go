@@ -289,3 +289,21 @@ func TestGitHubPagesDeploy_ImplementsStepAction(t *testing.T) {
 	// Verify GitHubPagesDeploy implements StepAction interface
 	var _ workflow.StepAction = g
 }
+
+func TestGitHubPagesDeploy_Outputs(t *testing.T) {
+	o := GitHubPagesDeploy{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"DeploymentStatus", o.DeploymentStatus().String(), "${{ steps.step.outputs.deployment-status }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
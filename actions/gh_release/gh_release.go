@@ -1,6 +1,8 @@
 // Package gh_release provides a typed wrapper for softprops/action-gh-release.
 package gh_release
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // GHRelease wraps the softprops/action-gh-release@v2 action.
 // Create and upload assets to a GitHub Release.
 type GHRelease struct {
@@ -107,3 +109,37 @@ func (a GHRelease) Inputs() map[string]any {
 
 	return with
 }
+
+// GHReleaseOutputs references the outputs of a softprops/action-gh-release step.
+type GHReleaseOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a GHRelease) Outputs(stepID string) GHReleaseOutputs {
+	return GHReleaseOutputs{stepID: stepID}
+}
+
+// URL returns the url output.
+// URL of the release page.
+func (o GHReleaseOutputs) URL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "url")
+}
+
+// ID returns the id output.
+// Release ID.
+func (o GHReleaseOutputs) ID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "id")
+}
+
+// UploadURL returns the upload_url output.
+// URL for uploading release assets.
+func (o GHReleaseOutputs) UploadURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "upload_url")
+}
+
+// Assets returns the assets output.
+// JSON list of uploaded assets.
+func (o GHReleaseOutputs) Assets() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "assets")
+}
@@ -180,3 +180,24 @@ func TestGHRelease_Inputs_BodyPath(t *testing.T) {
 func TestGHRelease_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = GHRelease{}
 }
+
+func TestGHRelease_Outputs(t *testing.T) {
+	o := GHRelease{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"URL", o.URL().String(), "${{ steps.step.outputs.url }}"},
+		{"ID", o.ID().String(), "${{ steps.step.outputs.id }}"},
+		{"UploadURL", o.UploadURL().String(), "${{ steps.step.outputs.upload_url }}"},
+		{"Assets", o.Assets().String(), "${{ steps.step.outputs.assets }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
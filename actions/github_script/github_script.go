@@ -1,6 +1,8 @@
 // Package github_script provides a typed wrapper for actions/github-script.
 package github_script
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // GithubScript wraps the actions/github-script@v7 action.
 // Run JavaScript in your workflows using the GitHub API and workflow contexts.
 type GithubScript struct {
@@ -65,3 +67,19 @@ func (a GithubScript) Inputs() map[string]any {
 
 	return with
 }
+
+// GithubScriptOutputs references the outputs of a actions/github-script step.
+type GithubScriptOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a GithubScript) Outputs(stepID string) GithubScriptOutputs {
+	return GithubScriptOutputs{stepID: stepID}
+}
+
+// Result returns the result output.
+// Return value of the script.
+func (o GithubScriptOutputs) Result() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "result")
+}
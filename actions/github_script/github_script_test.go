@@ -121,3 +121,21 @@ func TestGithubScript_Inputs_ScriptOnly(t *testing.T) {
 func TestGithubScript_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = GithubScript{}
 }
+
+func TestGithubScript_Outputs(t *testing.T) {
+	o := GithubScript{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Result", o.Result().String(), "${{ steps.step.outputs.result }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package github_tag_action provides a typed wrapper for anothrNick/github-tag-action.
 package github_tag_action
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // GitHubTagAction wraps the anothrNick/github-tag-action@v1 action.
 // Automatically bump and tag with SemVer based on merged PR labels.
 type GitHubTagAction struct {
@@ -65,3 +67,37 @@ func (a GitHubTagAction) Inputs() map[string]any {
 
 	return with
 }
+
+// GitHubTagActionOutputs references the outputs of a anothrNick/github-tag-action step.
+type GitHubTagActionOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a GitHubTagAction) Outputs(stepID string) GitHubTagActionOutputs {
+	return GitHubTagActionOutputs{stepID: stepID}
+}
+
+// NewTag returns the new_tag output.
+// Value of the newly created tag.
+func (o GitHubTagActionOutputs) NewTag() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "new_tag")
+}
+
+// OldTag returns the old_tag output.
+// Value of the previous tag.
+func (o GitHubTagActionOutputs) OldTag() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "old_tag")
+}
+
+// Tag returns the tag output.
+// Value of the latest tag after running the action.
+func (o GitHubTagActionOutputs) Tag() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "tag")
+}
+
+// Part returns the part output.
+// Part of the version that was bumped.
+func (o GitHubTagActionOutputs) Part() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "part")
+}
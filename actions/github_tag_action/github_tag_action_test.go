@@ -169,3 +169,24 @@ func TestGitHubTagAction_Inputs_FalseDryRun(t *testing.T) {
 		t.Errorf("inputs for false DryRun has %d entries, want 0. Got: %v", len(inputs), inputs)
 	}
 }
+
+func TestGitHubTagAction_Outputs(t *testing.T) {
+	o := GitHubTagAction{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"NewTag", o.NewTag().String(), "${{ steps.step.outputs.new_tag }}"},
+		{"OldTag", o.OldTag().String(), "${{ steps.step.outputs.old_tag }}"},
+		{"Tag", o.Tag().String(), "${{ steps.step.outputs.tag }}"},
+		{"Part", o.Part().String(), "${{ steps.step.outputs.part }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package helm_chart_releaser provides a typed wrapper for helm/chart-releaser-action.
 package helm_chart_releaser
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // HelmChartReleaser wraps the helm/chart-releaser-action@v1 action.
 // Turn your GitHub repo into a self-hosted Helm chart repository.
 type HelmChartReleaser struct {
@@ -89,3 +91,25 @@ func (a HelmChartReleaser) Inputs() map[string]any {
 
 	return with
 }
+
+// HelmChartReleaserOutputs references the outputs of a helm/chart-releaser-action step.
+type HelmChartReleaserOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a HelmChartReleaser) Outputs(stepID string) HelmChartReleaserOutputs {
+	return HelmChartReleaserOutputs{stepID: stepID}
+}
+
+// ChangedCharts returns the changed_charts output.
+// Comma-separated list of changed charts.
+func (o HelmChartReleaserOutputs) ChangedCharts() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "changed_charts")
+}
+
+// ChartVersion returns the chart_version output.
+// Version of the most recently released chart.
+func (o HelmChartReleaserOutputs) ChartVersion() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "chart_version")
+}
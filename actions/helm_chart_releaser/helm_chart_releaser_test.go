@@ -232,3 +232,22 @@ func TestHelmChartReleaser_ImplementsStepAction(t *testing.T) {
 	// Verify HelmChartReleaser implements StepAction interface
 	var _ workflow.StepAction = h
 }
+
+func TestHelmChartReleaser_Outputs(t *testing.T) {
+	o := HelmChartReleaser{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ChangedCharts", o.ChangedCharts().String(), "${{ steps.step.outputs.changed_charts }}"},
+		{"ChartVersion", o.ChartVersion().String(), "${{ steps.step.outputs.chart_version }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package import_gpg provides a typed wrapper for crazy-max/ghaction-import-gpg.
 package import_gpg
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // ImportGPG wraps the crazy-max/ghaction-import-gpg@v6 action.
 // Import a GPG key for signing commits, tags, and pushes.
 type ImportGPG struct {
@@ -80,3 +82,37 @@ func (a ImportGPG) Inputs() map[string]any {
 
 	return with
 }
+
+// ImportGPGOutputs references the outputs of a crazy-max/ghaction-import-gpg step.
+type ImportGPGOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a ImportGPG) Outputs(stepID string) ImportGPGOutputs {
+	return ImportGPGOutputs{stepID: stepID}
+}
+
+// Fingerprint returns the fingerprint output.
+// Fingerprint of the GPG key.
+func (o ImportGPGOutputs) Fingerprint() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "fingerprint")
+}
+
+// KeyID returns the keyid output.
+// Long key ID of the GPG key.
+func (o ImportGPGOutputs) KeyID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "keyid")
+}
+
+// Name returns the name output.
+// Name associated with the GPG key.
+func (o ImportGPGOutputs) Name() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "name")
+}
+
+// Email returns the email output.
+// Email associated with the GPG key.
+func (o ImportGPGOutputs) Email() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "email")
+}
@@ -222,3 +222,24 @@ func TestImportGPG_Inputs_CommonUsage(t *testing.T) {
 		t.Errorf("inputs[gpg_private_key] = %v, want secret reference", inputs["gpg_private_key"])
 	}
 }
+
+func TestImportGPG_Outputs(t *testing.T) {
+	o := ImportGPG{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Fingerprint", o.Fingerprint().String(), "${{ steps.step.outputs.fingerprint }}"},
+		{"KeyID", o.KeyID().String(), "${{ steps.step.outputs.keyid }}"},
+		{"Name", o.Name().String(), "${{ steps.step.outputs.name }}"},
+		{"Email", o.Email().String(), "${{ steps.step.outputs.email }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
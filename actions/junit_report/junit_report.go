@@ -1,6 +1,8 @@
 // Package junit_report provides a typed wrapper for mikepenz/action-junit-report.
 package junit_report
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // JUnitReport wraps the mikepenz/action-junit-report@v4 action.
 // Publish JUnit test results as GitHub checks and PR comments.
 type JUnitReport struct {
@@ -269,3 +271,37 @@ func (a JUnitReport) Inputs() map[string]any {
 
 	return with
 }
+
+// JUnitReportOutputs references the outputs of a mikepenz/action-junit-report step.
+type JUnitReportOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a JUnitReport) Outputs(stepID string) JUnitReportOutputs {
+	return JUnitReportOutputs{stepID: stepID}
+}
+
+// Total returns the total output.
+// Total number of tests.
+func (o JUnitReportOutputs) Total() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "total")
+}
+
+// Passed returns the passed output.
+// Number of passed tests.
+func (o JUnitReportOutputs) Passed() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "passed")
+}
+
+// Skipped returns the skipped output.
+// Number of skipped tests.
+func (o JUnitReportOutputs) Skipped() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "skipped")
+}
+
+// Failed returns the failed output.
+// Number of failed tests.
+func (o JUnitReportOutputs) Failed() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "failed")
+}
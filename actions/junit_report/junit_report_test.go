@@ -368,3 +368,24 @@ func TestJUnitReport_Inputs_NumericFields(t *testing.T) {
 func TestJUnitReport_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = JUnitReport{}
 }
+
+func TestJUnitReport_Outputs(t *testing.T) {
+	o := JUnitReport{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Total", o.Total().String(), "${{ steps.step.outputs.total }}"},
+		{"Passed", o.Passed().String(), "${{ steps.step.outputs.passed }}"},
+		{"Skipped", o.Skipped().String(), "${{ steps.step.outputs.skipped }}"},
+		{"Failed", o.Failed().String(), "${{ steps.step.outputs.failed }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package labeler provides a typed wrapper for actions/labeler.
 package labeler
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // Labeler wraps the actions/labeler@v5 action.
 // Automatically label pull requests based on file patterns.
 type Labeler struct {
@@ -47,3 +49,25 @@ func (a Labeler) Inputs() map[string]any {
 
 	return with
 }
+
+// LabelerOutputs references the outputs of a actions/labeler step.
+type LabelerOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a Labeler) Outputs(stepID string) LabelerOutputs {
+	return LabelerOutputs{stepID: stepID}
+}
+
+// NewLabels returns the new-labels output.
+// Labels added by this run, comma-separated.
+func (o LabelerOutputs) NewLabels() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "new-labels")
+}
+
+// AllLabels returns the all-labels output.
+// All labels on the pull request, comma-separated.
+func (o LabelerOutputs) AllLabels() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "all-labels")
+}
@@ -186,3 +186,22 @@ func TestLabeler_ImplementsStepAction(t *testing.T) {
 	// Verify Labeler implements StepAction interface
 	var _ workflow.StepAction = l
 }
+
+func TestLabeler_Outputs(t *testing.T) {
+	o := Labeler{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"NewLabels", o.NewLabels().String(), "${{ steps.step.outputs.new-labels }}"},
+		{"AllLabels", o.AllLabels().String(), "${{ steps.step.outputs.all-labels }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
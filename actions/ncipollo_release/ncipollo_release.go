@@ -1,6 +1,8 @@
 // Package ncipollo_release provides a typed wrapper for ncipollo/release-action.
 package ncipollo_release
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // NcipolloRelease wraps the ncipollo/release-action@v1 action.
 // Create GitHub releases with ease.
 type NcipolloRelease struct {
@@ -180,3 +182,43 @@ func (a NcipolloRelease) Inputs() map[string]any {
 
 	return with
 }
+
+// NcipolloReleaseOutputs references the outputs of a ncipollo/release-action step.
+type NcipolloReleaseOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a NcipolloRelease) Outputs(stepID string) NcipolloReleaseOutputs {
+	return NcipolloReleaseOutputs{stepID: stepID}
+}
+
+// ID returns the id output.
+// Release ID.
+func (o NcipolloReleaseOutputs) ID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "id")
+}
+
+// HTMLURL returns the html_url output.
+// URL of the release page.
+func (o NcipolloReleaseOutputs) HTMLURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "html_url")
+}
+
+// UploadURL returns the upload_url output.
+// URL for uploading release assets.
+func (o NcipolloReleaseOutputs) UploadURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "upload_url")
+}
+
+// TarballURL returns the tarball_url output.
+// URL of the source tarball.
+func (o NcipolloReleaseOutputs) TarballURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "tarball_url")
+}
+
+// ZipballURL returns the zipball_url output.
+// URL of the source zipball.
+func (o NcipolloReleaseOutputs) ZipballURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "zipball_url")
+}
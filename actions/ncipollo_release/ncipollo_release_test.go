@@ -526,3 +526,25 @@ func TestNcipolloRelease_Inputs_CrossRepoRelease(t *testing.T) {
 		t.Errorf("inputs[repo] = %v, want other-repo", inputs["repo"])
 	}
 }
+
+func TestNcipolloRelease_Outputs(t *testing.T) {
+	o := NcipolloRelease{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ID", o.ID().String(), "${{ steps.step.outputs.id }}"},
+		{"HTMLURL", o.HTMLURL().String(), "${{ steps.step.outputs.html_url }}"},
+		{"UploadURL", o.UploadURL().String(), "${{ steps.step.outputs.upload_url }}"},
+		{"TarballURL", o.TarballURL().String(), "${{ steps.step.outputs.tarball_url }}"},
+		{"ZipballURL", o.ZipballURL().String(), "${{ steps.step.outputs.zipball_url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package setup_dotnet provides a typed wrapper for actions/setup-dotnet.
 package setup_dotnet
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupDotnet wraps the actions/setup-dotnet@v4 action.
 // Set up a specific version of the .NET SDK and add it to PATH.
 type SetupDotnet struct {
@@ -73,3 +75,25 @@ func (a SetupDotnet) Inputs() map[string]any {
 
 	return with
 }
+
+// SetupDotnetOutputs references the outputs of a actions/setup-dotnet step.
+type SetupDotnetOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupDotnet) Outputs(stepID string) SetupDotnetOutputs {
+	return SetupDotnetOutputs{stepID: stepID}
+}
+
+// CacheHit returns the cache-hit output.
+// Whether the NuGet cache was restored.
+func (o SetupDotnetOutputs) CacheHit() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cache-hit")
+}
+
+// DotnetVersion returns the dotnet-version output.
+// Installed .NET SDK version.
+func (o SetupDotnetOutputs) DotnetVersion() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "dotnet-version")
+}
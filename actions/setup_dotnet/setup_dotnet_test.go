@@ -167,3 +167,22 @@ func TestSetupDotnet_ImplementsStepAction(t *testing.T) {
 	// Verify SetupDotnet implements StepAction interface
 	var _ workflow.StepAction = a
 }
+
+func TestSetupDotnet_Outputs(t *testing.T) {
+	o := SetupDotnet{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"CacheHit", o.CacheHit().String(), "${{ steps.step.outputs.cache-hit }}"},
+		{"DotnetVersion", o.DotnetVersion().String(), "${{ steps.step.outputs.dotnet-version }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
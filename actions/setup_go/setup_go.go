@@ -1,6 +1,8 @@
 // Package setup_go provides a typed wrapper for actions/setup-go.
 package setup_go
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupGo wraps the actions/setup-go@v5 action.
 // Setup a Go environment and add it to PATH.
 type SetupGo struct {
@@ -59,3 +61,25 @@ func (a SetupGo) Inputs() map[string]any {
 
 	return with
 }
+
+// SetupGoOutputs references the outputs of a actions/setup-go step.
+type SetupGoOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupGo) Outputs(stepID string) SetupGoOutputs {
+	return SetupGoOutputs{stepID: stepID}
+}
+
+// GoVersion returns the go-version output.
+// Installed Go version.
+func (o SetupGoOutputs) GoVersion() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "go-version")
+}
+
+// CacheHit returns the cache-hit output.
+// Whether the module cache was restored.
+func (o SetupGoOutputs) CacheHit() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cache-hit")
+}
@@ -113,3 +113,22 @@ func TestSetupGo_ImplementsStepAction(t *testing.T) {
 	// Verify SetupGo implements StepAction interface
 	var _ workflow.StepAction = s
 }
+
+func TestSetupGo_Outputs(t *testing.T) {
+	o := SetupGo{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"GoVersion", o.GoVersion().String(), "${{ steps.step.outputs.go-version }}"},
+		{"CacheHit", o.CacheHit().String(), "${{ steps.step.outputs.cache-hit }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package setup_helm provides a typed wrapper for azure/setup-helm.
 package setup_helm
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupHelm wraps the azure/setup-helm@v4 action.
 // Install Helm CLI on a GitHub Actions runner.
 type SetupHelm struct {
@@ -35,3 +37,19 @@ func (a SetupHelm) Inputs() map[string]any {
 
 	return with
 }
+
+// SetupHelmOutputs references the outputs of a azure/setup-helm step.
+type SetupHelmOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupHelm) Outputs(stepID string) SetupHelmOutputs {
+	return SetupHelmOutputs{stepID: stepID}
+}
+
+// HelmPath returns the helm-path output.
+// Path of the Helm binary.
+func (o SetupHelmOutputs) HelmPath() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "helm-path")
+}
@@ -89,3 +89,21 @@ func TestSetupHelm_ImplementsStepAction(t *testing.T) {
 	// Verify SetupHelm implements StepAction interface
 	var _ workflow.StepAction = s
 }
+
+func TestSetupHelm_Outputs(t *testing.T) {
+	o := SetupHelm{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"HelmPath", o.HelmPath().String(), "${{ steps.step.outputs.helm-path }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package setup_java provides a typed wrapper for actions/setup-java.
 package setup_java
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupJava wraps the actions/setup-java@v4 action.
 // Set up a specific version of the Java JDK and add it to PATH.
 type SetupJava struct {
@@ -133,3 +135,37 @@ func (a SetupJava) Inputs() map[string]any {
 
 	return with
 }
+
+// SetupJavaOutputs references the outputs of a actions/setup-java step.
+type SetupJavaOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupJava) Outputs(stepID string) SetupJavaOutputs {
+	return SetupJavaOutputs{stepID: stepID}
+}
+
+// Distribution returns the distribution output.
+// Installed Java distribution.
+func (o SetupJavaOutputs) Distribution() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "distribution")
+}
+
+// Path returns the path output.
+// Path of the installed JDK.
+func (o SetupJavaOutputs) Path() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "path")
+}
+
+// Version returns the version output.
+// Installed Java version.
+func (o SetupJavaOutputs) Version() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "version")
+}
+
+// CacheHit returns the cache-hit output.
+// Whether the dependency cache was restored.
+func (o SetupJavaOutputs) CacheHit() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cache-hit")
+}
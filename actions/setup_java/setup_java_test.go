@@ -209,3 +209,24 @@ func TestSetupJava_ImplementsStepAction(t *testing.T) {
 	// Verify SetupJava implements StepAction interface
 	var _ workflow.StepAction = a
 }
+
+func TestSetupJava_Outputs(t *testing.T) {
+	o := SetupJava{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Distribution", o.Distribution().String(), "${{ steps.step.outputs.distribution }}"},
+		{"Path", o.Path().String(), "${{ steps.step.outputs.path }}"},
+		{"Version", o.Version().String(), "${{ steps.step.outputs.version }}"},
+		{"CacheHit", o.CacheHit().String(), "${{ steps.step.outputs.cache-hit }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
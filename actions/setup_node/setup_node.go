@@ -1,6 +1,8 @@
 // Package setup_node provides a typed wrapper for actions/setup-node.
 package setup_node
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupNode wraps the actions/setup-node@v4 action.
 // Setup a Node.js environment and add it to PATH.
 type SetupNode struct {
@@ -77,3 +79,25 @@ func (a SetupNode) Inputs() map[string]any {
 
 	return with
 }
+
+// SetupNodeOutputs references the outputs of a actions/setup-node step.
+type SetupNodeOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupNode) Outputs(stepID string) SetupNodeOutputs {
+	return SetupNodeOutputs{stepID: stepID}
+}
+
+// CacheHit returns the cache-hit output.
+// Whether the package manager cache was restored.
+func (o SetupNodeOutputs) CacheHit() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cache-hit")
+}
+
+// NodeVersion returns the node-version output.
+// Installed Node.js version.
+func (o SetupNodeOutputs) NodeVersion() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "node-version")
+}
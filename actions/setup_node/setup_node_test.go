@@ -100,3 +100,22 @@ func TestSetupNode_ImplementsStepAction(t *testing.T) {
 	// Verify SetupNode implements StepAction interface
 	var _ workflow.StepAction = a
 }
+
+func TestSetupNode_Outputs(t *testing.T) {
+	o := SetupNode{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"CacheHit", o.CacheHit().String(), "${{ steps.step.outputs.cache-hit }}"},
+		{"NodeVersion", o.NodeVersion().String(), "${{ steps.step.outputs.node-version }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package setup_python provides a typed wrapper for actions/setup-python.
 package setup_python
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupPython wraps the actions/setup-python@v5 action.
 // Setup a Python environment and add it to PATH.
 type SetupPython struct {
@@ -71,3 +73,31 @@ func (a SetupPython) Inputs() map[string]any {
 
 	return with
 }
+
+// SetupPythonOutputs references the outputs of a actions/setup-python step.
+type SetupPythonOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupPython) Outputs(stepID string) SetupPythonOutputs {
+	return SetupPythonOutputs{stepID: stepID}
+}
+
+// PythonVersion returns the python-version output.
+// Installed Python version.
+func (o SetupPythonOutputs) PythonVersion() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "python-version")
+}
+
+// CacheHit returns the cache-hit output.
+// Whether the dependency cache was restored.
+func (o SetupPythonOutputs) CacheHit() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cache-hit")
+}
+
+// PythonPath returns the python-path output.
+// Path of the Python executable.
+func (o SetupPythonOutputs) PythonPath() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "python-path")
+}
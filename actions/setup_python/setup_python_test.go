@@ -93,3 +93,23 @@ func TestSetupPython_Inputs_AllFields(t *testing.T) {
 func TestSetupPython_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = SetupPython{}
 }
+
+func TestSetupPython_Outputs(t *testing.T) {
+	o := SetupPython{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"PythonVersion", o.PythonVersion().String(), "${{ steps.step.outputs.python-version }}"},
+		{"CacheHit", o.CacheHit().String(), "${{ steps.step.outputs.cache-hit }}"},
+		{"PythonPath", o.PythonPath().String(), "${{ steps.step.outputs.python-path }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
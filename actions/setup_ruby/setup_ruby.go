@@ -1,6 +1,8 @@
 // Package setup_ruby provides a typed wrapper for ruby/setup-ruby.
 package setup_ruby
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupRuby wraps the ruby/setup-ruby@v1 action.
 // Set up a specific version of Ruby and add it to PATH.
 type SetupRuby struct {
@@ -73,3 +75,19 @@ func (a SetupRuby) Inputs() map[string]any {
 
 	return with
 }
+
+// SetupRubyOutputs references the outputs of a ruby/setup-ruby step.
+type SetupRubyOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupRuby) Outputs(stepID string) SetupRubyOutputs {
+	return SetupRubyOutputs{stepID: stepID}
+}
+
+// RubyPrefix returns the ruby-prefix output.
+// Prefix of the installed Ruby.
+func (o SetupRubyOutputs) RubyPrefix() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "ruby-prefix")
+}
@@ -167,3 +167,21 @@ func TestSetupRuby_Inputs_BooleanFalse(t *testing.T) {
 func TestSetupRuby_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = SetupRuby{}
 }
+
+func TestSetupRuby_Outputs(t *testing.T) {
+	o := SetupRuby{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"RubyPrefix", o.RubyPrefix().String(), "${{ steps.step.outputs.ruby-prefix }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
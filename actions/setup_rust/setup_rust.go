@@ -1,6 +1,8 @@
 // Package setup_rust provides a typed wrapper for dtolnay/rust-toolchain.
 package setup_rust
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // SetupRust wraps the dtolnay/rust-toolchain@stable action.
 // Install a Rust toolchain and add it to PATH.
 type SetupRust struct {
@@ -56,3 +58,25 @@ func Nightly() SetupRust {
 func Beta() SetupRust {
 	return SetupRust{Toolchain: "beta"}
 }
+
+// SetupRustOutputs references the outputs of a dtolnay/rust-toolchain step.
+type SetupRustOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a SetupRust) Outputs(stepID string) SetupRustOutputs {
+	return SetupRustOutputs{stepID: stepID}
+}
+
+// CacheKey returns the cachekey output.
+// Short hash of the installed rustc version.
+func (o SetupRustOutputs) CacheKey() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "cachekey")
+}
+
+// Name returns the name output.
+// Rustup's name for the installed toolchain.
+func (o SetupRustOutputs) Name() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "name")
+}
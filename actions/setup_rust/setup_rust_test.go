@@ -121,3 +121,22 @@ func TestSetupRust_Inputs_AllFields(t *testing.T) {
 func TestSetupRust_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = SetupRust{}
 }
+
+func TestSetupRust_Outputs(t *testing.T) {
+	o := SetupRust{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"CacheKey", o.CacheKey().String(), "${{ steps.step.outputs.cachekey }}"},
+		{"Name", o.Name().String(), "${{ steps.step.outputs.name }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package slack provides a typed wrapper for slackapi/slack-github-action.
 package slack
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // Slack wraps the slackapi/slack-github-action@v1 action.
 // Send notifications to Slack from your GitHub Actions workflow.
 type Slack struct {
@@ -59,3 +61,31 @@ func (a Slack) Inputs() map[string]any {
 
 	return with
 }
+
+// SlackOutputs references the outputs of a slackapi/slack-github-action step.
+type SlackOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a Slack) Outputs(stepID string) SlackOutputs {
+	return SlackOutputs{stepID: stepID}
+}
+
+// Time returns the time output.
+// Unix timestamp of the message.
+func (o SlackOutputs) Time() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "time")
+}
+
+// TS returns the ts output.
+// Timestamp ID of the posted message.
+func (o SlackOutputs) TS() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "ts")
+}
+
+// ThreadTS returns the thread_ts output.
+// Timestamp ID of the message thread.
+func (o SlackOutputs) ThreadTS() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "thread_ts")
+}
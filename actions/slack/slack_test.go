@@ -210,3 +210,23 @@ func TestSlack_Inputs_PayloadOnly(t *testing.T) {
 		t.Errorf("inputs has %d entries, want 1", len(inputs))
 	}
 }
+
+func TestSlack_Outputs(t *testing.T) {
+	o := Slack{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Time", o.Time().String(), "${{ steps.step.outputs.time }}"},
+		{"TS", o.TS().String(), "${{ steps.step.outputs.ts }}"},
+		{"ThreadTS", o.ThreadTS().String(), "${{ steps.step.outputs.thread_ts }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package stale provides a typed wrapper for actions/stale.
 package stale
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // Stale wraps the actions/stale@v9 action.
 // Marks issues and pull requests as stale and closes them after a period of inactivity.
 type Stale struct {
@@ -299,3 +301,25 @@ func (a Stale) Inputs() map[string]any {
 
 	return with
 }
+
+// StaleOutputs references the outputs of a actions/stale step.
+type StaleOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a Stale) Outputs(stepID string) StaleOutputs {
+	return StaleOutputs{stepID: stepID}
+}
+
+// StaledIssuesPRs returns the staled-issues-prs output.
+// JSON list of issues and pull requests marked stale.
+func (o StaleOutputs) StaledIssuesPRs() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "staled-issues-prs")
+}
+
+// ClosedIssuesPRs returns the closed-issues-prs output.
+// JSON list of issues and pull requests closed.
+func (o StaleOutputs) ClosedIssuesPRs() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "closed-issues-prs")
+}
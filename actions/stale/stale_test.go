@@ -386,3 +386,22 @@ func TestStale_Inputs_ComprehensiveCoverage(t *testing.T) {
 		t.Errorf("inputs[start-date] = %v, want %q", inputs["start-date"], "2024-01-01")
 	}
 }
+
+func TestStale_Outputs(t *testing.T) {
+	o := Stale{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"StaledIssuesPRs", o.StaledIssuesPRs().String(), "${{ steps.step.outputs.staled-issues-prs }}"},
+		{"ClosedIssuesPRs", o.ClosedIssuesPRs().String(), "${{ steps.step.outputs.closed-issues-prs }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package upload_artifact provides a typed wrapper for actions/upload-artifact.
 package upload_artifact
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // UploadArtifact wraps the actions/upload-artifact@v4 action.
 // Upload a build artifact for use in subsequent jobs.
 type UploadArtifact struct {
@@ -59,3 +61,25 @@ func (a UploadArtifact) Inputs() map[string]any {
 
 	return with
 }
+
+// UploadArtifactOutputs references the outputs of a actions/upload-artifact step.
+type UploadArtifactOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a UploadArtifact) Outputs(stepID string) UploadArtifactOutputs {
+	return UploadArtifactOutputs{stepID: stepID}
+}
+
+// ArtifactID returns the artifact-id output.
+// ID of the uploaded artifact.
+func (o UploadArtifactOutputs) ArtifactID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "artifact-id")
+}
+
+// ArtifactURL returns the artifact-url output.
+// URL to download the uploaded artifact.
+func (o UploadArtifactOutputs) ArtifactURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "artifact-url")
+}
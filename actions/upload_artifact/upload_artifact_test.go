@@ -88,3 +88,22 @@ func TestUploadArtifact_Inputs_AllFields(t *testing.T) {
 func TestUploadArtifact_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = UploadArtifact{}
 }
+
+func TestUploadArtifact_Outputs(t *testing.T) {
+	o := UploadArtifact{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ArtifactID", o.ArtifactID().String(), "${{ steps.step.outputs.artifact-id }}"},
+		{"ArtifactURL", o.ArtifactURL().String(), "${{ steps.step.outputs.artifact-url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
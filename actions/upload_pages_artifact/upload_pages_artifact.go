@@ -1,6 +1,8 @@
 // Package upload_pages_artifact provides a typed wrapper for actions/upload-pages-artifact.
 package upload_pages_artifact
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // UploadPagesArtifact wraps the actions/upload-pages-artifact@v3 action.
 // Uploads an artifact for GitHub Pages deployment.
 type UploadPagesArtifact struct {
@@ -35,3 +37,19 @@ func (a UploadPagesArtifact) Inputs() map[string]any {
 
 	return m
 }
+
+// UploadPagesArtifactOutputs references the outputs of a actions/upload-pages-artifact step.
+type UploadPagesArtifactOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a UploadPagesArtifact) Outputs(stepID string) UploadPagesArtifactOutputs {
+	return UploadPagesArtifactOutputs{stepID: stepID}
+}
+
+// ArtifactID returns the artifact_id output.
+// ID of the uploaded Pages artifact.
+func (o UploadPagesArtifactOutputs) ArtifactID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "artifact_id")
+}
@@ -152,3 +152,21 @@ func TestUploadPagesArtifact_CommonPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestUploadPagesArtifact_Outputs(t *testing.T) {
+	o := UploadPagesArtifact{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ArtifactID", o.ArtifactID().String(), "${{ steps.step.outputs.artifact_id }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
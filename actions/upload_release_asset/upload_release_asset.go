@@ -1,6 +1,8 @@
 // Package upload_release_asset provides a typed wrapper for actions/upload-release-asset.
 package upload_release_asset
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // UploadReleaseAsset wraps the actions/upload-release-asset@v1 action.
 // Upload a release asset to an existing release in your repository.
 //
@@ -47,3 +49,19 @@ func (a UploadReleaseAsset) Inputs() map[string]any {
 
 	return with
 }
+
+// UploadReleaseAssetOutputs references the outputs of a actions/upload-release-asset step.
+type UploadReleaseAssetOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a UploadReleaseAsset) Outputs(stepID string) UploadReleaseAssetOutputs {
+	return UploadReleaseAssetOutputs{stepID: stepID}
+}
+
+// BrowserDownloadURL returns the browser_download_url output.
+// URL users can download the asset from.
+func (o UploadReleaseAssetOutputs) BrowserDownloadURL() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "browser_download_url")
+}
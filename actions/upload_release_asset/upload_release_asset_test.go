@@ -188,3 +188,21 @@ func TestUploadReleaseAsset_Inputs_WithExpression(t *testing.T) {
 func TestUploadReleaseAsset_ImplementsStepAction(t *testing.T) {
 	var _ workflow.StepAction = UploadReleaseAsset{}
 }
+
+func TestUploadReleaseAsset_Outputs(t *testing.T) {
+	o := UploadReleaseAsset{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"BrowserDownloadURL", o.BrowserDownloadURL().String(), "${{ steps.step.outputs.browser_download_url }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package upload_sarif provides a typed wrapper for github/codeql-action/upload-sarif.
 package upload_sarif
 
+import "github.com/lex00/wetwire-github-go/workflow"
+
 // UploadSarif wraps the github/codeql-action/upload-sarif@v3 action.
 // Upload a SARIF file to GitHub Security to display alerts.
 type UploadSarif struct {
@@ -59,3 +61,19 @@ func (a UploadSarif) Inputs() map[string]any {
 
 	return with
 }
+
+// UploadSarifOutputs references the outputs of a github/codeql-action/upload-sarif step.
+type UploadSarifOutputs struct {
+	stepID string
+}
+
+// Outputs returns output references for the step with the given ID.
+func (a UploadSarif) Outputs(stepID string) UploadSarifOutputs {
+	return UploadSarifOutputs{stepID: stepID}
+}
+
+// SarifID returns the sarif-id output.
+// ID of the uploaded SARIF file.
+func (o UploadSarifOutputs) SarifID() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "sarif-id")
+}
@@ -140,3 +140,21 @@ func TestUploadSarif_ImplementsStepAction(t *testing.T) {
 	a := UploadSarif{}
 	var _ workflow.StepAction = a
 }
+
+func TestUploadSarif_Outputs(t *testing.T) {
+	o := UploadSarif{}.Outputs("step")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"SarifID", o.SarifID().String(), "${{ steps.step.outputs.sarif-id }}"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Outputs(\"step\").%s() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
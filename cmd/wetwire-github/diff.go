@@ -4,21 +4,51 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"sort"
+	"path/filepath"
+	"strings"
 
-	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/domain"
+	"github.com/lex00/wetwire-github-go/internal/differ"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var diffCmd = &cobra.Command{
-	Use:   "diff <path1> <path2>",
+	Use:   "diff <old> <new> | diff --rev <rev> [path]",
 	Short: "Compare two workflow configurations",
 	Long: `Semantically compare two workflow configurations and show differences.
 
-Shows added jobs, removed jobs, modified jobs, and dependency changes.
-
-By default, compares two Go packages. Use --yaml to compare YAML files.
+By default, both sides are Go modules: each is built in memory, without
+writing anything, and the generated files are compared. Use --yaml to
+compare YAML files or directories such as .github instead.
+
+With --rev, the old side is the same path at a git revision: it is
+checked out into a temporary git worktree and built there, so reviewers
+see how the generated files change without committing them. --base
+compares against the commit where HEAD branched from a branch such as
+origin/main, like a pull request does. The path defaults to the current
+directory.
+
+Files are matched by their path under .github and compared after
+normalization, so formatting, quoting, key order and equivalent shorthand
+(on: push, needs: build, a one-element runs-on list) don't show up as
+changes. Every field is compared, including triggers and their filters,
+permissions, env, concurrency, strategy matrices and runners. Steps are
+matched by ID, then by name, then by position, so inserting a step only
+reports the new step.
+
+Security-relevant changes to workflows are listed separately, by risk:
+  - high: a permission raised to write, a pull_request_target or
+    workflow_run trigger added, an action no longer pinned to a commit SHA,
+    persist-credentials enabled on actions/checkout
+  - medium: a new secrets.* reference, secrets: inherit, a new third-party
+    action, a permissions block removed
+  - low: persist-credentials disabled on actions/checkout
+
+Exit status:
+  0  no differences
+  1  differences found
+  2  differences found, at least one of them high risk
+  3  the comparison failed, e.g. a side could not be loaded or built
 
 Supported output formats:
   - text (default): Human-readable output
@@ -26,398 +56,367 @@ Supported output formats:
   - markdown: Markdown formatted diff report
 
 Examples:
-  # Compare two Go packages
+  # Compare two Go modules
   wetwire-github diff ./old-workflows ./new-workflows
 
   # Compare two YAML files
   wetwire-github diff old-ci.yml new-ci.yml --yaml
 
+  # Compare two .github directories
+  wetwire-github diff ../main/.github .github --yaml
+
+  # Compare the current module with the previous commit
+  wetwire-github diff --rev HEAD~1
+
+  # Compare with the branch point of a pull request
+  wetwire-github diff --base origin/main ./ci
+
   # JSON output for automation
   wetwire-github diff ./v1 ./v2 --format json
 
-  # Markdown report
+  # Markdown report with the security review, for a PR comment
   wetwire-github diff ./v1 ./v2 --format markdown`,
-	Args: cobra.ExactArgs(2),
+	Args: diffArgs,
 	RunE: runDiff,
 }
 
+// Exit statuses of the diff command.
+const (
+	diffExitChanges  = 1
+	diffExitHighRisk = 2
+	diffExitError    = 3
+)
+
 func init() {
-	diffCmd.Flags().Bool("yaml", false, "Compare YAML files instead of Go packages")
+	diffCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &exitError{code: diffExitError, err: err}
+	})
+	diffCmd.Flags().Bool("yaml", false, "Compare YAML files or directories instead of Go modules")
 	diffCmd.Flags().String("format", "text", "Output format: text, json, markdown")
+	diffCmd.Flags().String("rev", "", "Compare with the path at this git revision, e.g. HEAD~1")
+	diffCmd.Flags().String("base", "", "Compare with the path where HEAD branched from this revision, e.g. origin/main")
+	diffCmd.MarkFlagsMutuallyExclusive("rev", "base")
 }
 
-// jobInfo represents a discovered job with its dependencies.
-type jobInfo struct {
-	Name         string
-	Dependencies []string
-}
-
-// jobDiff represents changes to a single job.
-type jobDiff struct {
-	Name    string   `json:"name"`
-	Changes []string `json:"changes,omitempty"`
+// diffArgs accepts a path with --rev or --base, and two paths otherwise.
+func diffArgs(cmd *cobra.Command, args []string) error {
+	rev, _ := cmd.Flags().GetString("rev")
+	base, _ := cmd.Flags().GetString("base")
+	check := cobra.ExactArgs(2)
+	if rev != "" || base != "" {
+		check = cobra.MaximumNArgs(1)
+	}
+	if err := check(cmd, args); err != nil {
+		return &exitError{code: diffExitError, err: err}
+	}
+	return nil
 }
 
-// dependencyChange represents dependency changes for a job.
-type dependencyChange struct {
-	Job         string   `json:"job"`
-	AddedDeps   []string `json:"added_deps,omitempty"`
-	RemovedDeps []string `json:"removed_deps,omitempty"`
+// diffSummary counts the files that differ and their security findings.
+type diffSummary struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+	Changes  int `json:"changes"`
+	Findings int `json:"findings"`
+	HighRisk int `json:"high_risk"`
 }
 
 // diffResult holds the comparison results.
 type diffResult struct {
-	Success           bool               `json:"success"`
-	Message           string             `json:"message,omitempty"`
-	AddedJobs         []string           `json:"added_jobs,omitempty"`
-	RemovedJobs       []string           `json:"removed_jobs,omitempty"`
-	ModifiedJobs      []jobDiff          `json:"modified_jobs,omitempty"`
-	DependencyChanges []dependencyChange `json:"dependency_changes,omitempty"`
+	Success bool              `json:"success"`
+	Message string            `json:"message,omitempty"`
+	Rev     string            `json:"rev,omitempty"`
+	Files   []differ.FileDiff `json:"files,omitempty"`
+	Summary diffSummary       `json:"summary"`
 }
 
 func runDiff(cmd *cobra.Command, args []string) error {
 	yamlMode, _ := cmd.Flags().GetBool("yaml")
 	outputFormat, _ := cmd.Flags().GetString("format")
 
-	path1 := args[0]
-	path2 := args[1]
+	rev, _ := cmd.Flags().GetString("rev")
+	base, _ := cmd.Flags().GetString("base")
 
-	var oldJobs, newJobs []jobInfo
+	var oldFiles, newFiles []differ.File
+	var label string
 	var err error
-
-	if yamlMode {
-		oldJobs, err = parseYAMLWorkflowJobs(path1)
-		if err != nil {
-			return outputDiffError(cmd, outputFormat, fmt.Errorf("parse %s: %w", path1, err))
+	if rev != "" || base != "" {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
 		}
-
-		newJobs, err = parseYAMLWorkflowJobs(path2)
-		if err != nil {
-			return outputDiffError(cmd, outputFormat, fmt.Errorf("parse %s: %w", path2, err))
+		label = rev
+		if base != "" {
+			if rev, err = mergeBase(path, base); err != nil {
+				return outputDiffError(cmd, outputFormat, err)
+			}
+			label = "merge base with " + base
+		}
+		var commit string
+		if oldFiles, commit, err = loadRevisionSide(path, rev, yamlMode); err != nil {
+			return outputDiffError(cmd, outputFormat, err)
+		}
+		label = fmt.Sprintf("%s (%s)", label, commit)
+		if newFiles, err = loadDiffSide(path, yamlMode); err != nil {
+			return outputDiffError(cmd, outputFormat, err)
 		}
 	} else {
-		disc := discover.NewDiscoverer()
-
-		result1, err := disc.Discover(path1)
-		if err != nil {
-			return outputDiffError(cmd, outputFormat, fmt.Errorf("discover %s: %w", path1, err))
+		if oldFiles, err = loadDiffSide(args[0], yamlMode); err != nil {
+			return outputDiffError(cmd, outputFormat, err)
 		}
-		oldJobs = convertDiscoveredJobs(result1.Jobs)
-
-		result2, err := disc.Discover(path2)
-		if err != nil {
-			return outputDiffError(cmd, outputFormat, fmt.Errorf("discover %s: %w", path2, err))
+		if newFiles, err = loadDiffSide(args[1], yamlMode); err != nil {
+			return outputDiffError(cmd, outputFormat, err)
 		}
-		newJobs = convertDiscoveredJobs(result2.Jobs)
+		pairSingleFiles(args[0], args[1], oldFiles, newFiles)
 	}
 
-	result := compareWorkflows(oldJobs, newJobs)
-	result.Success = true
+	files, err := differ.DiffFiles(oldFiles, newFiles)
+	if err != nil {
+		return outputDiffError(cmd, outputFormat, err)
+	}
+	result := newDiffResult(files)
+	result.Rev = label
 
 	switch outputFormat {
 	case "json":
-		return outputDiffJSON(cmd, result)
+		if err := outputDiffJSON(cmd, result); err != nil {
+			return outputDiffError(cmd, "text", err)
+		}
 	case "markdown":
 		outputDiffMarkdown(cmd, result)
 	default:
 		outputDiffText(cmd, result)
 	}
 
-	return nil
-}
-
-func convertDiscoveredJobs(jobs []discover.DiscoveredJob) []jobInfo {
-	result := make([]jobInfo, len(jobs))
-	for i, job := range jobs {
-		result[i] = jobInfo{
-			Name:         job.Name,
-			Dependencies: job.Dependencies,
-		}
-	}
-	return result
-}
-
-func compareWorkflows(oldJobs, newJobs []jobInfo) diffResult {
-	result := diffResult{}
-
-	oldJobMap := make(map[string]jobInfo)
-	newJobMap := make(map[string]jobInfo)
-
-	for _, job := range oldJobs {
-		oldJobMap[job.Name] = job
+	code := 0
+	switch {
+	case result.Summary.HighRisk > 0:
+		code = diffExitHighRisk
+	case len(result.Files) > 0:
+		code = diffExitChanges
 	}
-	for _, job := range newJobs {
-		newJobMap[job.Name] = job
+	if code == 0 {
+		return nil
 	}
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &exitError{code: code}
+}
 
-	// Find added jobs
-	for _, newJob := range newJobs {
-		if _, exists := oldJobMap[newJob.Name]; !exists {
-			result.AddedJobs = append(result.AddedJobs, newJob.Name)
+// loadDiffSide returns the files of one side of a diff: the files in a
+// YAML file or directory, or the files a Go module builds, keyed by their
+// path under .github.
+func loadDiffSide(path string, yamlMode bool) ([]differ.File, error) {
+	if yamlMode {
+		files, err := differ.LoadFiles(path)
+		if err != nil {
+			return nil, fmt.Errorf("load %s: %w", path, err)
 		}
+		return files, nil
 	}
 
-	// Find removed jobs
-	for _, oldJob := range oldJobs {
-		if _, exists := newJobMap[oldJob.Name]; !exists {
-			result.RemovedJobs = append(result.RemovedJobs, oldJob.Name)
-		}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
 	}
+	built, err := domain.BuildFiles(absPath, domain.BuildOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("build %s: %w", path, err)
+	}
+	return builtDiffFiles(filepath.Join(absPath, ".github"), built), nil
+}
 
-	// Find modified jobs and dependency changes
-	for _, newJob := range newJobs {
-		oldJob, exists := oldJobMap[newJob.Name]
-		if !exists {
+// builtDiffFiles converts the files a build generates into diff files
+// keyed by their path under githubDir. Files outside it, such as action
+// wrappers, are skipped.
+func builtDiffFiles(githubDir string, built []domain.BuiltFile) []differ.File {
+	var files []differ.File
+	for _, f := range built {
+		rel, err := filepath.Rel(githubDir, f.Path)
+		if err != nil || strings.HasPrefix(rel, "..") {
 			continue
 		}
-
-		var changes []string
-
-		// Check dependency changes
-		oldDepsSet := make(map[string]bool)
-		newDepsSet := make(map[string]bool)
-		for _, dep := range oldJob.Dependencies {
-			oldDepsSet[dep] = true
-		}
-		for _, dep := range newJob.Dependencies {
-			newDepsSet[dep] = true
-		}
-
-		var addedDeps, removedDeps []string
-		for dep := range newDepsSet {
-			if !oldDepsSet[dep] {
-				addedDeps = append(addedDeps, dep)
-			}
-		}
-		for dep := range oldDepsSet {
-			if !newDepsSet[dep] {
-				removedDeps = append(removedDeps, dep)
-			}
-		}
-
-		if len(addedDeps) > 0 || len(removedDeps) > 0 {
-			sort.Strings(addedDeps)
-			sort.Strings(removedDeps)
-			result.DependencyChanges = append(result.DependencyChanges, dependencyChange{
-				Job:         newJob.Name,
-				AddedDeps:   addedDeps,
-				RemovedDeps: removedDeps,
-			})
-
-			if len(addedDeps) > 0 {
-				changes = append(changes, fmt.Sprintf("added dependencies: %v", addedDeps))
-			}
-			if len(removedDeps) > 0 {
-				changes = append(changes, fmt.Sprintf("removed dependencies: %v", removedDeps))
-			}
-		}
-
-		if len(changes) > 0 {
-			result.ModifiedJobs = append(result.ModifiedJobs, jobDiff{
-				Name:    newJob.Name,
-				Changes: changes,
-			})
-		}
+		files = append(files, differ.File{Path: filepath.ToSlash(rel), Kind: f.Kind, Content: f.Content})
 	}
-
-	sort.Strings(result.AddedJobs)
-	sort.Strings(result.RemovedJobs)
-
-	return result
+	return files
 }
 
-func parseYAMLWorkflowJobs(path string) ([]jobInfo, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	var workflow map[string]interface{}
-	if err := yaml.Unmarshal(data, &workflow); err != nil {
-		return nil, err
+// pairSingleFiles matches two single files with different names, as in
+// "diff old-ci.yml ci.yml", by giving the old one the new one's name.
+func pairSingleFiles(oldPath, newPath string, oldFiles, newFiles []differ.File) {
+	if len(oldFiles) != 1 || len(newFiles) != 1 || isDir(oldPath) || isDir(newPath) {
+		return
 	}
+	oldFiles[0].Path = newFiles[0].Path
+	oldFiles[0].Kind = newFiles[0].Kind
+}
 
-	jobs, ok := workflow["jobs"].(map[string]interface{})
-	if !ok {
-		return []jobInfo{}, nil
-	}
+// isDir reports whether path is a directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
 
-	var result []jobInfo
-	for name, value := range jobs {
-		jobMap, ok := value.(map[string]interface{})
-		if !ok {
-			continue
+// newDiffResult builds the result for the files that differ.
+func newDiffResult(files []differ.FileDiff) diffResult {
+	result := diffResult{Success: true, Files: files}
+	for _, f := range files {
+		switch f.Status {
+		case differ.StatusAdded:
+			result.Summary.Added++
+		case differ.StatusRemoved:
+			result.Summary.Removed++
+		case differ.StatusModified:
+			result.Summary.Modified++
+			result.Summary.Changes += len(f.Changes)
 		}
-
-		job := jobInfo{Name: name}
-
-		// Extract dependencies from needs
-		if needs, ok := jobMap["needs"]; ok {
-			switch n := needs.(type) {
-			case string:
-				job.Dependencies = []string{n}
-			case []interface{}:
-				for _, need := range n {
-					if s, ok := need.(string); ok {
-						job.Dependencies = append(job.Dependencies, s)
-					}
-				}
+		result.Summary.Findings += len(f.Findings)
+		for _, finding := range f.Findings {
+			if finding.Risk == differ.RiskHigh {
+				result.Summary.HighRisk++
 			}
 		}
-
-		result = append(result, job)
 	}
-
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Name < result[j].Name
-	})
-
-	return result, nil
+	return result
 }
 
+// outputDiffError reports a failed comparison, as a JSON result in JSON
+// output, and returns it with the error exit status.
 func outputDiffError(cmd *cobra.Command, outputFormat string, err error) error {
-	result := diffResult{
-		Success: false,
-		Message: err.Error(),
-	}
-
+	cmd.SilenceUsage = true
 	if outputFormat == "json" {
-		return outputDiffJSON(cmd, result)
+		result := diffResult{
+			Success: false,
+			Message: err.Error(),
+		}
+		if jsonErr := outputDiffJSON(cmd, result); jsonErr == nil {
+			cmd.SilenceErrors = true
+		}
 	}
+	return &exitError{code: diffExitError, err: err}
+}
 
-	fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
-	return nil
+// diffStatusMarks are the text output markers of each file status.
+var diffStatusMarks = map[string]string{
+	differ.StatusAdded:    "+",
+	differ.StatusRemoved:  "-",
+	differ.StatusModified: "~",
 }
 
 func outputDiffText(cmd *cobra.Command, result diffResult) {
-	hasChanges := len(result.AddedJobs) > 0 ||
-		len(result.RemovedJobs) > 0 ||
-		len(result.ModifiedJobs) > 0 ||
-		len(result.DependencyChanges) > 0
-
-	if !hasChanges {
-		fmt.Fprintln(cmd.OutOrStdout(), "No differences found.")
+	out := cmd.OutOrStdout()
+	if len(result.Files) == 0 {
+		fmt.Fprintln(out, "No differences found.")
 		return
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "Workflow Diff")
-	fmt.Fprintln(cmd.OutOrStdout(), "=============")
-	fmt.Fprintln(cmd.OutOrStdout())
-
-	if len(result.AddedJobs) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "Added Jobs:")
-		for _, job := range result.AddedJobs {
-			fmt.Fprintf(cmd.OutOrStdout(), "  + %s\n", job)
-		}
-		fmt.Fprintln(cmd.OutOrStdout())
+	title := "Workflow Diff"
+	if result.Rev != "" {
+		title += " against " + result.Rev
 	}
-
-	if len(result.RemovedJobs) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "Removed Jobs:")
-		for _, job := range result.RemovedJobs {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", job)
+	fmt.Fprintln(out, title)
+	fmt.Fprintln(out, strings.Repeat("=", len(title)))
+	fmt.Fprintln(out)
+
+	for _, f := range result.Files {
+		fmt.Fprintf(out, "%s %s\n", diffStatusMarks[f.Status], f.Path)
+		for _, c := range f.Changes {
+			fmt.Fprintf(out, "    %s\n", c)
 		}
-		fmt.Fprintln(cmd.OutOrStdout())
 	}
-
-	if len(result.ModifiedJobs) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "Modified Jobs:")
-		for _, job := range result.ModifiedJobs {
-			fmt.Fprintf(cmd.OutOrStdout(), "  ~ %s\n", job.Name)
-			for _, change := range job.Changes {
-				fmt.Fprintf(cmd.OutOrStdout(), "    - %s\n", change)
+	fmt.Fprintln(out)
+
+	if result.Summary.Findings > 0 {
+		fmt.Fprintln(out, "Security Review")
+		fmt.Fprintln(out, "---------------")
+		for _, f := range result.Files {
+			for _, finding := range f.Findings {
+				fmt.Fprintf(out, "  %-8s %s %s: %s\n", "["+finding.Risk+"]", f.Path, finding.Path, finding.Message)
 			}
 		}
-		fmt.Fprintln(cmd.OutOrStdout())
+		fmt.Fprintln(out)
 	}
 
-	if len(result.DependencyChanges) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "Dependency Changes:")
-		for _, dc := range result.DependencyChanges {
-			fmt.Fprintf(cmd.OutOrStdout(), "  %s:\n", dc.Job)
-			if len(dc.AddedDeps) > 0 {
-				fmt.Fprintf(cmd.OutOrStdout(), "    + %v\n", dc.AddedDeps)
-			}
-			if len(dc.RemovedDeps) > 0 {
-				fmt.Fprintf(cmd.OutOrStdout(), "    - %v\n", dc.RemovedDeps)
-			}
-		}
-		fmt.Fprintln(cmd.OutOrStdout())
+	fmt.Fprintln(out, diffSummaryLine(result.Summary))
+}
+
+// diffSummaryLine describes a summary on one line.
+func diffSummaryLine(s diffSummary) string {
+	line := fmt.Sprintf("%d added, %d removed, %d modified (%d change(s))", s.Added, s.Removed, s.Modified, s.Changes)
+	if s.Findings > 0 {
+		line += fmt.Sprintf(", %d security finding(s), %d high risk", s.Findings, s.HighRisk)
 	}
+	return line
 }
 
 func outputDiffJSON(cmd *cobra.Command, result diffResult) error {
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error marshaling JSON: %v\n", err)
-		return nil
+		return fmt.Errorf("marshaling JSON: %w", err)
 	}
 	fmt.Fprintln(cmd.OutOrStdout(), string(data))
 	return nil
 }
 
 func outputDiffMarkdown(cmd *cobra.Command, result diffResult) {
-	hasChanges := len(result.AddedJobs) > 0 ||
-		len(result.RemovedJobs) > 0 ||
-		len(result.ModifiedJobs) > 0 ||
-		len(result.DependencyChanges) > 0
-
-	fmt.Fprintln(cmd.OutOrStdout(), "## Workflow Diff")
-	fmt.Fprintln(cmd.OutOrStdout())
+	out := cmd.OutOrStdout()
+	if result.Rev != "" {
+		fmt.Fprintf(out, "## Workflow Diff against %s\n", result.Rev)
+	} else {
+		fmt.Fprintln(out, "## Workflow Diff")
+	}
+	fmt.Fprintln(out)
 
-	if !hasChanges {
-		fmt.Fprintln(cmd.OutOrStdout(), "No differences found.")
+	if len(result.Files) == 0 {
+		fmt.Fprintln(out, "No differences found.")
 		return
 	}
 
-	if len(result.AddedJobs) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "### Added Jobs")
-		fmt.Fprintln(cmd.OutOrStdout())
-		for _, job := range result.AddedJobs {
-			fmt.Fprintf(cmd.OutOrStdout(), "- `%s`\n", job)
+	fmt.Fprintln(out, diffSummaryLine(result.Summary))
+	fmt.Fprintln(out)
+
+	if result.Summary.Findings > 0 {
+		fmt.Fprintln(out, "### Security Review")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "| Risk | File | Path | Change |")
+		fmt.Fprintln(out, "|------|------|------|--------|")
+		for _, f := range result.Files {
+			for _, finding := range f.Findings {
+				risk := finding.Risk
+				if risk == differ.RiskHigh {
+					risk = "**high**"
+				}
+				fmt.Fprintf(out, "| %s | `%s` | `%s` | %s |\n", risk, markdownCell(f.Path), markdownCell(finding.Path), markdownCell(finding.Message))
+			}
 		}
-		fmt.Fprintln(cmd.OutOrStdout())
+		fmt.Fprintln(out)
 	}
 
-	if len(result.RemovedJobs) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "### Removed Jobs")
-		fmt.Fprintln(cmd.OutOrStdout())
-		for _, job := range result.RemovedJobs {
-			fmt.Fprintf(cmd.OutOrStdout(), "- `%s`\n", job)
+	for _, f := range result.Files {
+		fmt.Fprintf(out, "### `%s` (%s)\n\n", f.Path, f.Status)
+		if len(f.Changes) == 0 {
+			continue
 		}
-		fmt.Fprintln(cmd.OutOrStdout())
-	}
-
-	if len(result.ModifiedJobs) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "### Modified Jobs")
-		fmt.Fprintln(cmd.OutOrStdout())
-		for _, job := range result.ModifiedJobs {
-			fmt.Fprintf(cmd.OutOrStdout(), "#### `%s`\n\n", job.Name)
-			for _, change := range job.Changes {
-				fmt.Fprintf(cmd.OutOrStdout(), "- %s\n", change)
-			}
-			fmt.Fprintln(cmd.OutOrStdout())
+		fmt.Fprintln(out, "| Path | Old | New |")
+		fmt.Fprintln(out, "|------|-----|-----|")
+		for _, c := range f.Changes {
+			fmt.Fprintf(out, "| `%s` | %s | %s |\n", markdownCell(c.Path), markdownValue(c.Old), markdownValue(c.New))
 		}
+		fmt.Fprintln(out)
 	}
+}
 
-	if len(result.DependencyChanges) > 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "### Dependency Changes")
-		fmt.Fprintln(cmd.OutOrStdout())
-		for _, dc := range result.DependencyChanges {
-			fmt.Fprintf(cmd.OutOrStdout(), "#### `%s`\n\n", dc.Job)
-			if len(dc.AddedDeps) > 0 {
-				fmt.Fprintln(cmd.OutOrStdout(), "Added dependencies:")
-				for _, dep := range dc.AddedDeps {
-					fmt.Fprintf(cmd.OutOrStdout(), "- `%s`\n", dep)
-				}
-			}
-			if len(dc.RemovedDeps) > 0 {
-				fmt.Fprintln(cmd.OutOrStdout(), "Removed dependencies:")
-				for _, dep := range dc.RemovedDeps {
-					fmt.Fprintf(cmd.OutOrStdout(), "- `%s`\n", dep)
-				}
-			}
-			fmt.Fprintln(cmd.OutOrStdout())
-		}
+// markdownValue formats a changed value for a markdown table cell.
+func markdownValue(v any) string {
+	if v == nil {
+		return ""
 	}
+	return "`" + markdownCell(differ.FormatValue(v)) + "`"
+}
+
+// markdownCell escapes the characters that would break a table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
 }
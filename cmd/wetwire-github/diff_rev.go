@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lex00/wetwire-github-go/internal/differ"
+	"github.com/lex00/wetwire-github-go/internal/git"
+)
+
+// loadRevisionSide returns the files of path at a git revision. The
+// revision is checked out into a temporary worktree, which is removed
+// before returning, and path's counterpart there is loaded as by
+// loadDiffSide. A path that doesn't exist at the revision has no files.
+// It also returns the abbreviated commit the revision resolved to.
+func loadRevisionSide(path, rev string, yamlMode bool) ([]differ.File, string, error) {
+	abs, dir, err := resolvePath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	top, err := git.Run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, "", err
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil {
+		return nil, "", err
+	}
+	commit, err := git.Run(top, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return nil, "", fmt.Errorf("unknown revision %s", rev)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "wetwire-diff-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	worktree := filepath.Join(tmpDir, "worktree")
+	if _, err := git.Run(top, "worktree", "add", "--detach", "--quiet", worktree, commit); err != nil {
+		return nil, "", err
+	}
+	defer git.Run(top, "worktree", "remove", "--force", worktree)
+
+	short := commit
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	old := filepath.Join(worktree, rel)
+	if _, err := os.Stat(old); os.IsNotExist(err) {
+		return nil, short, nil
+	}
+	files, err := loadDiffSide(old, yamlMode)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s at %s: %w", path, rev, err)
+	}
+	return files, short, nil
+}
+
+// mergeBase returns the commit where HEAD of the repository holding path
+// branched from base.
+func mergeBase(path, base string) (string, error) {
+	_, dir, err := resolvePath(path)
+	if err != nil {
+		return "", err
+	}
+	return git.Run(dir, "merge-base", base, "HEAD")
+}
+
+// resolvePath returns the absolute path of an existing file or directory,
+// with symlinks resolved as git reports them, and the directory to run git
+// in: the path itself or the directory holding the file.
+func resolvePath(path string) (abs, dir string, err error) {
+	abs, err = filepath.Abs(path)
+	if err != nil {
+		return "", "", fmt.Errorf("resolve path: %w", err)
+	}
+	abs, err = filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", "", err
+	}
+	dir = abs
+	if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+		dir = filepath.Dir(abs)
+	}
+	return abs, dir, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lex00/wetwire-github-go/internal/git"
+)
+
+// initDiffRepo creates a git repository with a committed workflow and
+// returns its path.
+func initDiffRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	workflows := filepath.Join(dir, ".github", "workflows")
+	os.MkdirAll(workflows, 0755)
+	os.WriteFile(filepath.Join(workflows, "ci.yml"), []byte(diffOldWorkflow), 0644)
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"add", "-A"},
+		{"commit", "-q", "-m", "initial"},
+		{"branch", "-M", "main"},
+	} {
+		if _, err := git.Run(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadRevisionSide(t *testing.T) {
+	dir := initDiffRepo(t)
+	githubDir := filepath.Join(dir, ".github")
+	os.WriteFile(filepath.Join(githubDir, "workflows", "ci.yml"), []byte(diffNewWorkflow), 0644)
+
+	files, commit, err := loadRevisionSide(githubDir, "HEAD", true)
+	if err != nil {
+		t.Fatalf("loadRevisionSide() error = %v", err)
+	}
+	if len(commit) != 7 {
+		t.Errorf("commit = %q, want an abbreviated SHA", commit)
+	}
+	if len(files) != 1 || files[0].Path != "workflows/ci.yml" || string(files[0].Content) != diffOldWorkflow {
+		t.Errorf("loadRevisionSide() = %+v, want the committed workflow", files)
+	}
+
+	worktrees, err := git.Run(dir, "worktree", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(worktrees, "\n") != 0 {
+		t.Errorf("temporary worktree was not removed:\n%s", worktrees)
+	}
+}
+
+func TestLoadRevisionSide_SingleFile(t *testing.T) {
+	dir := initDiffRepo(t)
+
+	files, _, err := loadRevisionSide(filepath.Join(dir, ".github", "workflows", "ci.yml"), "HEAD", true)
+	if err != nil {
+		t.Fatalf("loadRevisionSide() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "ci.yml" {
+		t.Errorf("loadRevisionSide() = %+v, want ci.yml", files)
+	}
+}
+
+func TestLoadRevisionSide_NewPath(t *testing.T) {
+	dir := initDiffRepo(t)
+	newDir := filepath.Join(dir, "new")
+	os.MkdirAll(newDir, 0755)
+
+	files, _, err := loadRevisionSide(newDir, "HEAD", true)
+	if err != nil {
+		t.Fatalf("loadRevisionSide() error = %v", err)
+	}
+	if files != nil {
+		t.Errorf("loadRevisionSide() = %+v, want no files for a path the revision lacks", files)
+	}
+}
+
+func TestLoadRevisionSide_Errors(t *testing.T) {
+	dir := initDiffRepo(t)
+
+	if _, _, err := loadRevisionSide(dir, "no-such-rev", true); err == nil || !strings.Contains(err.Error(), "unknown revision") {
+		t.Errorf("loadRevisionSide() error = %v, want unknown revision", err)
+	}
+	if _, _, err := loadRevisionSide(t.TempDir(), "HEAD", true); err == nil {
+		t.Error("loadRevisionSide() should fail outside a git repository")
+	}
+}
+
+func TestMergeBase(t *testing.T) {
+	dir := initDiffRepo(t)
+	want, _ := git.Run(dir, "rev-parse", "HEAD")
+
+	git.Run(dir, "checkout", "-q", "-b", "feature")
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0644)
+	git.Run(dir, "add", "-A")
+	git.Run(dir, "commit", "-q", "-m", "feature")
+
+	got, err := mergeBase(dir, "main")
+	if err != nil {
+		t.Fatalf("mergeBase() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("mergeBase() = %q, want %q", got, want)
+	}
+}
+
+func TestDiffArgs(t *testing.T) {
+	tests := []struct {
+		flags   []string
+		args    []string
+		wantErr bool
+	}{
+		{nil, []string{"a", "b"}, false},
+		{nil, []string{"a"}, true},
+		{[]string{"--rev", "HEAD"}, nil, false},
+		{[]string{"--rev", "HEAD"}, []string{"a"}, false},
+		{[]string{"--base", "main"}, []string{"a", "b"}, true},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("rev", "", "")
+		cmd.Flags().String("base", "", "")
+		if err := cmd.ParseFlags(tt.flags); err != nil {
+			t.Fatal(err)
+		}
+		if err := diffArgs(cmd, tt.args); (err != nil) != tt.wantErr {
+			t.Errorf("diffArgs(%v, %v) error = %v, wantErr %v", tt.flags, tt.args, err, tt.wantErr)
+		}
+	}
+}
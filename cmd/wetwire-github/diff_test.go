@@ -1,340 +1,304 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
-)
 
-func TestDiffCmd_NoChanges(t *testing.T) {
-	dir := t.TempDir()
+	"github.com/lex00/wetwire-github-go/domain"
+	"github.com/lex00/wetwire-github-go/internal/differ"
+	"github.com/spf13/cobra"
+)
 
-	yaml1 := `name: CI
+const diffOldWorkflow = `name: CI
 on: push
 jobs:
   build:
     runs-on: ubuntu-latest
+    steps:
+      - id: test
+        run: make test
 `
-	path1 := filepath.Join(dir, "ci1.yml")
-	path2 := filepath.Join(dir, "ci2.yml")
-	os.WriteFile(path1, []byte(yaml1), 0644)
-	os.WriteFile(path2, []byte(yaml1), 0644)
-
-	oldJobs, _ := parseYAMLWorkflowJobs(path1)
-	newJobs, _ := parseYAMLWorkflowJobs(path2)
-
-	result := compareWorkflows(oldJobs, newJobs)
-
-	hasChanges := len(result.AddedJobs) > 0 ||
-		len(result.RemovedJobs) > 0 ||
-		len(result.ModifiedJobs) > 0 ||
-		len(result.DependencyChanges) > 0
-
-	if hasChanges {
-		t.Errorf("expected no changes, got: added=%v, removed=%v", result.AddedJobs, result.RemovedJobs)
-	}
-}
-
-func TestDiffCmd_AddedJob(t *testing.T) {
-	dir := t.TempDir()
 
-	yaml1 := `name: CI
-on: push
-jobs:
-  build:
-    runs-on: ubuntu-latest
-`
-	yaml2 := `name: CI
-on: push
+const diffNewWorkflow = `name: CI
+on:
+  push:
+    branches: [main]
 jobs:
   build:
     runs-on: ubuntu-latest
-  test:
-    runs-on: ubuntu-latest
+    steps:
+      - name: Setup
+        run: make setup
+      - id: test
+        run: make test | tee test.txt
 `
-	path1 := filepath.Join(dir, "ci1.yml")
-	path2 := filepath.Join(dir, "ci2.yml")
-	os.WriteFile(path1, []byte(yaml1), 0644)
-	os.WriteFile(path2, []byte(yaml2), 0644)
-
-	oldJobs, _ := parseYAMLWorkflowJobs(path1)
-	newJobs, _ := parseYAMLWorkflowJobs(path2)
-
-	result := compareWorkflows(oldJobs, newJobs)
-
-	if len(result.AddedJobs) != 1 || result.AddedJobs[0] != "test" {
-		t.Errorf("expected added job 'test', got: %v", result.AddedJobs)
-	}
-}
-
-func TestDiffCmd_RemovedJob(t *testing.T) {
-	dir := t.TempDir()
 
-	yaml1 := `name: CI
-on: push
+const diffRiskyWorkflow = `name: CI
+on: [push, pull_request_target]
 jobs:
   build:
     runs-on: ubuntu-latest
-  test:
-    runs-on: ubuntu-latest
+    steps:
+      - id: test
+        run: make test
+        env:
+          TOKEN: ${{ secrets.NPM_TOKEN }}
 `
-	yaml2 := `name: CI
-on: push
-jobs:
-  build:
-    runs-on: ubuntu-latest
-`
-	path1 := filepath.Join(dir, "ci1.yml")
-	path2 := filepath.Join(dir, "ci2.yml")
-	os.WriteFile(path1, []byte(yaml1), 0644)
-	os.WriteFile(path2, []byte(yaml2), 0644)
 
-	oldJobs, _ := parseYAMLWorkflowJobs(path1)
-	newJobs, _ := parseYAMLWorkflowJobs(path2)
-
-	result := compareWorkflows(oldJobs, newJobs)
-
-	if len(result.RemovedJobs) != 1 || result.RemovedJobs[0] != "test" {
-		t.Errorf("expected removed job 'test', got: %v", result.RemovedJobs)
+// diffTestResult compares the two test workflows.
+func diffTestResult(t *testing.T) diffResult {
+	t.Helper()
+	files, err := differ.DiffFiles(
+		[]differ.File{{Path: "workflows/ci.yml", Kind: differ.KindWorkflow, Content: []byte(diffOldWorkflow)}},
+		[]differ.File{
+			{Path: "workflows/ci.yml", Kind: differ.KindWorkflow, Content: []byte(diffNewWorkflow)},
+			{Path: "CODEOWNERS", Kind: differ.KindCodeowners, Content: []byte("* @a\n")},
+		},
+	)
+	if err != nil {
+		t.Fatalf("DiffFiles() error = %v", err)
 	}
+	return newDiffResult(files)
 }
 
-func TestDiffCmd_NeedsChanges(t *testing.T) {
+func TestLoadDiffSide_YAML(t *testing.T) {
 	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "workflows"), 0755)
+	os.WriteFile(filepath.Join(dir, "workflows", "ci.yml"), []byte(diffOldWorkflow), 0644)
+	os.WriteFile(filepath.Join(dir, "dependabot.yml"), []byte("version: 2\n"), 0644)
 
-	yaml1 := `name: CI
-on: push
-jobs:
-  build:
-    runs-on: ubuntu-latest
-  deploy:
-    runs-on: ubuntu-latest
-    needs: [build]
-`
-	yaml2 := `name: CI
-on: push
-jobs:
-  build:
-    runs-on: ubuntu-latest
-  test:
-    runs-on: ubuntu-latest
-  deploy:
-    runs-on: ubuntu-latest
-    needs: [build, test]
-`
-	path1 := filepath.Join(dir, "ci1.yml")
-	path2 := filepath.Join(dir, "ci2.yml")
-	os.WriteFile(path1, []byte(yaml1), 0644)
-	os.WriteFile(path2, []byte(yaml2), 0644)
-
-	oldJobs, _ := parseYAMLWorkflowJobs(path1)
-	newJobs, _ := parseYAMLWorkflowJobs(path2)
-
-	result := compareWorkflows(oldJobs, newJobs)
-
-	if len(result.DependencyChanges) != 1 {
-		t.Errorf("expected 1 dependency change, got: %d", len(result.DependencyChanges))
-		return
+	files, err := loadDiffSide(dir, true)
+	if err != nil {
+		t.Fatalf("loadDiffSide() error = %v", err)
 	}
-
-	dc := result.DependencyChanges[0]
-	if dc.Job != "deploy" {
-		t.Errorf("expected dependency change for 'deploy', got: %s", dc.Job)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
 	}
-
-	if len(dc.AddedDeps) != 1 || dc.AddedDeps[0] != "test" {
-		t.Errorf("expected added dependency 'test', got: %v", dc.AddedDeps)
+	if want := []string{"dependabot.yml", "workflows/ci.yml"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("loadDiffSide() paths = %v, want %v", paths, want)
 	}
-}
 
-func TestParseYAMLWorkflowJobs(t *testing.T) {
-	dir := t.TempDir()
-
-	yaml := `name: CI
-on: push
-jobs:
-  build:
-    runs-on: ubuntu-latest
-  test:
-    runs-on: ubuntu-latest
-    needs: build
-  deploy:
-    runs-on: ubuntu-latest
-    needs: [build, test]
-`
-	path := filepath.Join(dir, "ci.yml")
-	os.WriteFile(path, []byte(yaml), 0644)
-
-	jobs, err := parseYAMLWorkflowJobs(path)
-	if err != nil {
-		t.Fatalf("parseYAMLWorkflowJobs failed: %v", err)
+	if _, err := loadDiffSide(filepath.Join(dir, "missing"), true); err == nil {
+		t.Error("loadDiffSide() should fail for a missing path")
 	}
+}
 
-	if len(jobs) != 3 {
-		t.Errorf("expected 3 jobs, got: %d", len(jobs))
+func TestBuiltDiffFiles(t *testing.T) {
+	githubDir := filepath.Join("/project", ".github")
+	built := []domain.BuiltFile{
+		{Path: filepath.Join(githubDir, "workflows", "ci.yml"), Kind: "workflow"},
+		{Path: filepath.Join(githubDir, "CODEOWNERS"), Kind: "codeowners"},
+		{Path: filepath.Join("/project", "actions", "setup.go"), Kind: "action"},
 	}
 
-	// Check job names are present
-	jobNames := make(map[string]bool)
-	for _, job := range jobs {
-		jobNames[job.Name] = true
+	files := builtDiffFiles(githubDir, built)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
 	}
-
-	if !jobNames["build"] || !jobNames["test"] || !jobNames["deploy"] {
-		t.Errorf("expected jobs build, test, deploy, got: %v", jobNames)
+	if want := []string{"workflows/ci.yml", "CODEOWNERS"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("builtDiffFiles() paths = %v, want %v", paths, want)
 	}
 }
 
-func TestParseYAMLWorkflowJobs_NeedsString(t *testing.T) {
+func TestPairSingleFiles(t *testing.T) {
 	dir := t.TempDir()
+	oldFiles := []differ.File{{Path: "old-ci.yml", Kind: differ.KindWorkflow}}
+	newFiles := []differ.File{{Path: "ci.yml", Kind: differ.KindWorkflow}}
 
-	yaml := `name: CI
-on: push
-jobs:
-  build:
-    runs-on: ubuntu-latest
-  test:
-    runs-on: ubuntu-latest
-    needs: build
-`
-	path := filepath.Join(dir, "ci.yml")
-	os.WriteFile(path, []byte(yaml), 0644)
-
-	jobs, err := parseYAMLWorkflowJobs(path)
-	if err != nil {
-		t.Fatalf("parseYAMLWorkflowJobs failed: %v", err)
+	pairSingleFiles("old-ci.yml", "ci.yml", oldFiles, newFiles)
+	if oldFiles[0].Path != "ci.yml" {
+		t.Errorf("pairSingleFiles() old path = %q, want ci.yml", oldFiles[0].Path)
 	}
 
-	// Find test job
-	var testJob *jobInfo
-	for i := range jobs {
-		if jobs[i].Name == "test" {
-			testJob = &jobs[i]
-			break
-		}
+	oldFiles[0].Path = "old-ci.yml"
+	pairSingleFiles(dir, "ci.yml", oldFiles, newFiles)
+	if oldFiles[0].Path != "old-ci.yml" {
+		t.Error("pairSingleFiles() should not rename files loaded from a directory")
 	}
+}
 
-	if testJob == nil {
-		t.Fatal("test job not found")
-	}
+func TestNewDiffResult(t *testing.T) {
+	result := diffTestResult(t)
 
-	if len(testJob.Dependencies) != 1 || testJob.Dependencies[0] != "build" {
-		t.Errorf("expected test job to have dependency 'build', got: %v", testJob.Dependencies)
+	want := diffSummary{Added: 1, Modified: 1, Changes: 3}
+	if result.Summary != want {
+		t.Errorf("summary = %+v, want %+v", result.Summary, want)
 	}
 }
 
-func TestParseYAMLWorkflowJobs_InvalidPath(t *testing.T) {
-	_, err := parseYAMLWorkflowJobs("/nonexistent/path.yml")
-	if err == nil {
-		t.Error("expected error for invalid path")
+func TestOutputDiffText(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+
+	outputDiffText(cmd, diffTestResult(t))
+	out := buf.String()
+	for _, want := range []string{
+		"+ CODEOWNERS",
+		"~ workflows/ci.yml",
+		`jobs.build.steps[id=test].run: "make test" → "make test | tee test.txt"`,
+		`jobs.build.steps[name="Setup"]: added`,
+		"on.push.branches: added",
+		"1 added, 0 removed, 1 modified (3 change(s))",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
 	}
-}
-
-func TestParseYAMLWorkflowJobs_InvalidYAML(t *testing.T) {
-	dir := t.TempDir()
-	path := filepath.Join(dir, "invalid.yml")
-	os.WriteFile(path, []byte("invalid: yaml: content:"), 0644)
 
-	_, err := parseYAMLWorkflowJobs(path)
-	if err == nil {
-		t.Error("expected error for invalid YAML")
+	buf.Reset()
+	outputDiffText(cmd, newDiffResult(nil))
+	if !strings.Contains(buf.String(), "No differences found.") {
+		t.Errorf("output = %q, want no differences", buf.String())
 	}
 }
 
-func TestParseYAMLWorkflowJobs_NoJobs(t *testing.T) {
-	dir := t.TempDir()
-
-	yaml := `name: CI
-on: push
-`
-	path := filepath.Join(dir, "ci.yml")
-	os.WriteFile(path, []byte(yaml), 0644)
+func TestOutputDiffMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+
+	outputDiffMarkdown(cmd, diffTestResult(t))
+	out := buf.String()
+	for _, want := range []string{
+		"## Workflow Diff",
+		"### `workflows/ci.yml` (modified)",
+		"| Path | Old | New |",
+		"| `jobs.build.steps[id=test].run` | `\"make test\"` | `\"make test \\| tee test.txt\"` |",
+		"### `CODEOWNERS` (added)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
 
-	jobs, err := parseYAMLWorkflowJobs(path)
+func TestOutputDiffMarkdown_Security(t *testing.T) {
+	files, err := differ.DiffFiles(
+		[]differ.File{{Path: "workflows/ci.yml", Kind: differ.KindWorkflow, Content: []byte(diffOldWorkflow)}},
+		[]differ.File{{Path: "workflows/ci.yml", Kind: differ.KindWorkflow, Content: []byte(diffRiskyWorkflow)}},
+	)
 	if err != nil {
-		t.Fatalf("parseYAMLWorkflowJobs failed: %v", err)
+		t.Fatalf("DiffFiles() error = %v", err)
+	}
+	result := newDiffResult(files)
+	if result.Summary.Findings != 2 || result.Summary.HighRisk != 1 {
+		t.Errorf("summary = %+v, want 2 findings, 1 high risk", result.Summary)
 	}
 
-	if len(jobs) != 0 {
-		t.Errorf("expected 0 jobs, got: %d", len(jobs))
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	outputDiffMarkdown(cmd, result)
+	out := buf.String()
+	for _, want := range []string{
+		"### Security Review",
+		"| Risk | File | Path | Change |",
+		"| **high** | `workflows/ci.yml` | `on.pull_request_target` |",
+		"| medium | `workflows/ci.yml` | `jobs.build.steps[id=test].env.TOKEN` | references secrets.NPM_TOKEN |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "### Security Review") > strings.Index(out, "### `workflows/ci.yml`") {
+		t.Error("security review should come before the file changes")
 	}
 }
 
-func TestCompareWorkflows_Empty(t *testing.T) {
-	result := compareWorkflows([]jobInfo{}, []jobInfo{})
-
-	hasChanges := len(result.AddedJobs) > 0 ||
-		len(result.RemovedJobs) > 0 ||
-		len(result.ModifiedJobs) > 0 ||
-		len(result.DependencyChanges) > 0
+func TestOutputDiffJSON(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
 
-	if hasChanges {
-		t.Error("expected no changes for empty workflows")
+	if err := outputDiffJSON(cmd, diffTestResult(t)); err != nil {
+		t.Fatalf("outputDiffJSON() error = %v", err)
 	}
-}
 
-func TestCompareWorkflows_AllAdded(t *testing.T) {
-	oldJobs := []jobInfo{}
-	newJobs := []jobInfo{
-		{Name: "build"},
-		{Name: "test"},
+	var decoded struct {
+		Success bool `json:"success"`
+		Files   []struct {
+			Path    string `json:"path"`
+			Status  string `json:"status"`
+			Changes []struct {
+				Path string `json:"path"`
+			} `json:"changes"`
+		} `json:"files"`
+		Summary diffSummary `json:"summary"`
 	}
-
-	result := compareWorkflows(oldJobs, newJobs)
-
-	if len(result.AddedJobs) != 2 {
-		t.Errorf("expected 2 added jobs, got: %d", len(result.AddedJobs))
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if !decoded.Success || len(decoded.Files) != 2 || decoded.Summary.Changes != 3 {
+		t.Errorf("decoded = %+v", decoded)
+	}
+	if decoded.Files[1].Status != "modified" || len(decoded.Files[1].Changes) != 3 {
+		t.Errorf("workflow file = %+v, want 3 changes", decoded.Files[1])
 	}
 }
 
-func TestCompareWorkflows_AllRemoved(t *testing.T) {
-	oldJobs := []jobInfo{
-		{Name: "build"},
-		{Name: "test"},
+func TestDiffCmd_ExitStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
 	}
-	newJobs := []jobInfo{}
 
-	result := compareWorkflows(oldJobs, newJobs)
-
-	if len(result.RemovedJobs) != 2 {
-		t.Errorf("expected 2 removed jobs, got: %d", len(result.RemovedJobs))
+	// Build the binary
+	binaryPath := filepath.Join(t.TempDir(), "wetwire-github")
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, ".")
+	buildCmd.Dir = getModulePath() + "/cmd/wetwire-github"
+	if out, err := buildCmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, out)
 	}
-}
 
-func TestCompareWorkflows_RemovedDependency(t *testing.T) {
-	oldJobs := []jobInfo{
-		{Name: "build"},
-		{Name: "test"},
-		{Name: "deploy", Dependencies: []string{"build", "test"}},
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old-ci.yml")
+	newPath := filepath.Join(dir, "ci.yml")
+	os.WriteFile(oldPath, []byte(diffOldWorkflow), 0644)
+	os.WriteFile(newPath, []byte(diffNewWorkflow), 0644)
+
+	out, err := exec.Command(binaryPath, "diff", oldPath, newPath, "--yaml").CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("diff error = %v, want exit status 1\n%s", err, out)
 	}
-	newJobs := []jobInfo{
-		{Name: "build"},
-		{Name: "test"},
-		{Name: "deploy", Dependencies: []string{"build"}},
+	if !strings.Contains(string(out), "~ ci.yml") {
+		t.Errorf("output missing the changed file:\n%s", out)
 	}
 
-	result := compareWorkflows(oldJobs, newJobs)
-
-	if len(result.DependencyChanges) != 1 {
-		t.Errorf("expected 1 dependency change, got: %d", len(result.DependencyChanges))
-		return
+	riskyPath := filepath.Join(dir, "risky.yml")
+	os.WriteFile(riskyPath, []byte(diffRiskyWorkflow), 0644)
+	out, err = exec.Command(binaryPath, "diff", oldPath, riskyPath, "--yaml").CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Fatalf("diff error = %v, want exit status 2 for a high risk change\n%s", err, out)
 	}
-
-	dc := result.DependencyChanges[0]
-	if len(dc.RemovedDeps) != 1 || dc.RemovedDeps[0] != "test" {
-		t.Errorf("expected removed dependency 'test', got: %v", dc.RemovedDeps)
+	if !strings.Contains(string(out), "[high]") {
+		t.Errorf("output missing the high risk finding:\n%s", out)
 	}
-}
-
-func TestOutputDiffText_NoChanges(t *testing.T) {
-	result := diffResult{Success: true}
 
-	hasChanges := len(result.AddedJobs) > 0 ||
-		len(result.RemovedJobs) > 0 ||
-		len(result.ModifiedJobs) > 0 ||
-		len(result.DependencyChanges) > 0
+	out, err = exec.Command(binaryPath, "diff", newPath, newPath, "--yaml").CombinedOutput()
+	if err != nil {
+		t.Fatalf("diff of identical files error = %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "No differences found.") {
+		t.Errorf("output = %s, want no differences", out)
+	}
 
-	if hasChanges {
-		t.Error("expected no changes")
+	missingPath := filepath.Join(dir, "missing.yml")
+	for _, args := range [][]string{
+		{"diff", oldPath, missingPath, "--yaml"},
+		{"diff", oldPath, missingPath, "--yaml", "--format", "json"},
+		{"diff", oldPath},
+	} {
+		out, err = exec.Command(binaryPath, args...).CombinedOutput()
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 3 {
+			t.Errorf("%v error = %v, want exit status 3\n%s", args, err, out)
+		}
 	}
 }
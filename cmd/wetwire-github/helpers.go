@@ -8,6 +8,7 @@ import (
 	wetwire "github.com/lex00/wetwire-github-go"
 	"github.com/lex00/wetwire-github-go/internal/discover"
 	"github.com/lex00/wetwire-github-go/internal/lint"
+	"github.com/lex00/wetwire-github-go/internal/pin"
 	"github.com/lex00/wetwire-github-go/internal/runner"
 	"github.com/lex00/wetwire-github-go/internal/template"
 )
@@ -55,8 +56,21 @@ func runBuild(sourcePath, outputDir string, dryRun bool) wetwire.BuildResult {
 		return result
 	}
 
-	// Build templates
+	// Resolve output directory
+	absOutputDir := outputDir
+	if !filepath.IsAbs(outputDir) {
+		absOutputDir = filepath.Join(sourcePath, outputDir)
+	}
+
+	// Build templates, pinning actions if the project has a lock file
 	builder := template.NewBuilder()
+	lock, err := pin.Find(sourcePath)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("loading action lock file: %v", err))
+		return result
+	}
+	builder.Lock = lock
+	builder.WorkflowDir = template.RepoWorkflowDir(sourcePath, absOutputDir)
 	built, err := builder.Build(discovered, extracted)
 	if err != nil {
 		result.Errors = append(result.Errors, fmt.Sprintf("template build failed: %v", err))
@@ -65,12 +79,6 @@ func runBuild(sourcePath, outputDir string, dryRun bool) wetwire.BuildResult {
 
 	result.Errors = append(result.Errors, built.Errors...)
 
-	// Resolve output directory
-	absOutputDir := outputDir
-	if !filepath.IsAbs(outputDir) {
-		absOutputDir = filepath.Join(sourcePath, outputDir)
-	}
-
 	// Create output directory if needed
 	if !dryRun {
 		if err := os.MkdirAll(absOutputDir, 0755); err != nil {
@@ -81,7 +89,7 @@ func runBuild(sourcePath, outputDir string, dryRun bool) wetwire.BuildResult {
 
 	// Write workflow files
 	for _, wf := range built.Workflows {
-		filename := toFilename(wf.Name) + ".yml"
+		filename := template.ToFilename(wf.Name) + ".yml"
 		filePath := filepath.Join(absOutputDir, filename)
 
 		if dryRun {
@@ -120,7 +128,17 @@ func runLintPath(path string) LintPathResult {
 		return result
 	}
 
-	l := lint.DefaultLinter()
+	configDir := absPath
+	if !info.IsDir() {
+		configDir = filepath.Dir(absPath)
+	}
+	cfg, err := lint.FindConfig(configDir)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("loading lint config: %v", err))
+		return result
+	}
+
+	l := lint.NewLinterWithOptions(lint.LinterOptions{Config: cfg})
 
 	var lintResult *lint.LintResult
 	if info.IsDir() {
@@ -159,35 +177,6 @@ type LintPathResult struct {
 	Errors  []string
 }
 
-// toFilename converts a workflow name to a valid filename.
-// "CI" -> "ci", "MyWorkflow" -> "my-workflow"
-func toFilename(name string) string {
-	var result string
-	for i, r := range name {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result += "-"
-		}
-		result += string(r)
-	}
-	return toFilenameCase(result)
-}
-
-func toFilenameCase(s string) string {
-	return toLowerString(s)
-}
-
-func toLowerString(s string) string {
-	result := ""
-	for _, r := range s {
-		if r >= 'A' && r <= 'Z' {
-			result += string(r + 32)
-		} else {
-			result += string(r)
-		}
-	}
-	return result
-}
-
 // getModulePath returns the path to the module root.
 func getModulePath() string {
 	// Get the absolute path to the module root
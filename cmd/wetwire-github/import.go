@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	wetwire "github.com/lex00/wetwire-github-go"
+	"github.com/lex00/wetwire-github-go/domain"
+	"github.com/lex00/wetwire-github-go/internal/differ"
 	"github.com/lex00/wetwire-github-go/internal/importer"
 )
 
@@ -18,9 +21,11 @@ var importSingleFile bool
 var importNoScaffold bool
 var importType string
 var importFormat string
+var importAll bool
+var importVerify bool
 
 var importCmd = &cobra.Command{
-	Use:   "import <file>",
+	Use:   "import <file|dir>",
 	Short: "Convert existing config files to Go code",
 	Long: `Import converts existing GitHub configuration files to typed Go declarations.
 
@@ -31,11 +36,24 @@ Supported types:
   discussion-template Discussion template YAML files
   codeowners        CODEOWNERS file
 
+With --all, the argument is a .github directory (or a repository that has
+one). Every workflow, dependabot.yml, CODEOWNERS, issue form, discussion
+form and PR template in it is imported into one package, and a report
+lists what each file lost in the conversion.
+
+With --verify, the Go module holding the output is built after the import
+and each built file is compared with its original. Key order, quoting and
+the shorthand forms of on: don't count; every other difference is listed,
+and the command fails if one changes behaviour. Step names and run-name
+are reported as cosmetic.
+
 Example:
   wetwire-github import .github/workflows/ci.yml -o my-workflows/
   wetwire-github import ci.yml --single-file
   wetwire-github import ci.yml --no-scaffold
-  wetwire-github import .github/CODEOWNERS --type codeowners`,
+  wetwire-github import .github/CODEOWNERS --type codeowners
+  wetwire-github import --all .github/ -o my-workflows/
+  wetwire-github import --all --verify .github/ -o . --no-scaffold`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runImport(args[0])
@@ -48,13 +66,22 @@ func init() {
 	importCmd.Flags().BoolVar(&importNoScaffold, "no-scaffold", false, "skip generating go.mod, README, etc.")
 	importCmd.Flags().StringVar(&importType, "type", "workflow", "config type (workflow, dependabot, issue-template, discussion-template, codeowners)")
 	importCmd.Flags().StringVar(&importFormat, "format", "text", "output format (text, json)")
+	importCmd.Flags().BoolVar(&importAll, "all", false, "import every supported file of a .github directory")
+	importCmd.Flags().BoolVar(&importVerify, "verify", false, "build the imported code and compare it with the original files")
 }
 
 // runImport executes the import command.
 func runImport(path string) error {
+	if importAll {
+		return runImportAll(path)
+	}
+
 	// Dispatch based on type
 	switch importType {
 	case "codeowners":
+		if importVerify {
+			return fmt.Errorf("--verify supports workflow imports and --all")
+		}
 		return runImportCodeowners(path)
 	default:
 		return runImportWorkflow(path)
@@ -252,10 +279,335 @@ func runImportWorkflow(path string) error {
 	result.Jobs = code.Jobs
 	result.Steps = code.Steps
 
-	outputImportResult(result)
+	if !importVerify {
+		outputImportResult(result)
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		result.Success = false
+		result.Errors = append(result.Errors, fmt.Sprintf("reading %s: %v", path, err))
+		outputImportResult(result)
+		return nil
+	}
+	verifications, err := verifyImport(absOutput, []verifySource{
+		{path: path, kind: importer.FileWorkflow, source: code.WorkflowVar, content: content},
+	})
+	if err != nil {
+		result.Success = false
+		result.Errors = append(result.Errors, fmt.Sprintf("verifying: %v", err))
+		outputImportResult(result)
+		return nil
+	}
+	result.Errors = append(result.Errors, verifyErrors(verifications)...)
+	result.Success = len(result.Errors) == 0
+	outputVerifiedImportResult(result, verifications)
+	return nil
+}
+
+// runImportAll imports every supported file of a .github directory.
+func runImportAll(path string) error {
+	result := wetwire.ImportResult{
+		Success:   false,
+		OutputDir: importOutput,
+		Files:     []string{},
+		Errors:    []string{},
+	}
+
+	imp := importer.NewDirImporter()
+	imp.SingleFile = importSingleFile
+	dir, err := imp.Import(path)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("importing %s: %v", path, err))
+		outputImportResult(result)
+		return nil
+	}
+
+	// Resolve output directory
+	absOutput, err := filepath.Abs(importOutput)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("resolving output path: %v", err))
+		outputImportResult(result)
+		return nil
+	}
+	result.OutputDir = absOutput
+
+	// Create output directory and workflows subdirectory
+	workflowsDir := filepath.Join(absOutput, "workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("creating output directory: %v", err))
+		outputImportResult(result)
+		return nil
+	}
+
+	// Write generated code to workflows/ subdirectory
+	if err := importer.WriteGeneratedCode(workflowsDir, &importer.GeneratedCode{Files: dir.Files}); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("writing code: %v", err))
+		outputImportResult(result)
+		return nil
+	}
+
+	filenames := make([]string, 0, len(dir.Files))
+	for filename := range dir.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		result.Files = append(result.Files, filepath.Join(workflowsDir, filename))
+	}
+
+	// Generate scaffold files if not disabled
+	if !importNoScaffold {
+		projectName := filepath.Base(absOutput)
+		modulePath := "github.com/example/" + strings.ToLower(strings.ReplaceAll(projectName, " ", "-"))
+
+		scaffold := importer.NewScaffold(modulePath, projectName)
+		scaffoldFiles := scaffold.Generate()
+
+		if err := importer.WriteScaffold(absOutput, scaffoldFiles); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("writing scaffold: %v", err))
+			outputImportResult(result)
+			return nil
+		}
+
+		for filename := range scaffoldFiles.Files {
+			result.Files = append(result.Files, filepath.Join(absOutput, filename))
+		}
+	}
+
+	// Files of a supported type that failed to import fail the command,
+	// after the rest is written
+	for _, report := range dir.Reports {
+		if report.Type != "" && report.Error != "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", report.Path, report.Error))
+		}
+	}
+
+	result.Workflows = dir.Workflows
+	result.Jobs = dir.Jobs
+	result.Steps = dir.Steps
+
+	var verifications []verification
+	if importVerify {
+		var sources []verifySource
+		for _, report := range dir.Reports {
+			if report.Type == "" || report.Error != "" {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(dir.Dir, filepath.FromSlash(report.Path)))
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("reading %s: %v", report.Path, err))
+				continue
+			}
+			sources = append(sources, verifySource{path: report.Path, kind: report.Type, source: report.Declarations[0], content: content})
+		}
+		verifications, err = verifyImport(absOutput, sources)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("verifying: %v", err))
+		}
+		result.Errors = append(result.Errors, verifyErrors(verifications)...)
+	}
+
+	result.Success = len(result.Errors) == 0
+	outputImportAllResult(result, dir.Reports, verifications)
 	return nil
 }
 
+// verifySource is an imported file to compare with the file built from
+// its Go declaration.
+type verifySource struct {
+	path    string
+	kind    string
+	source  string
+	content []byte
+}
+
+// verification is the result of comparing one imported file with the
+// file built from its Go declaration.
+type verification struct {
+	// Path is the imported file
+	Path string `json:"path"`
+	// Built is the built file, relative to the module root
+	Built string `json:"built,omitempty"`
+	// Lost lists the differences that change behaviour
+	Lost []differ.Change `json:"lost,omitempty"`
+	// Cosmetic lists the differences that only change how runs display
+	Cosmetic []differ.Change `json:"cosmetic,omitempty"`
+	// Error says why the file could not be compared
+	Error string `json:"error,omitempty"`
+}
+
+// verifyImport builds the Go module holding dir without writing anything
+// and compares each source with the file built from its declaration.
+func verifyImport(dir string, sources []verifySource) ([]verification, error) {
+	root, err := moduleRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := domain.BuildFiles(root, domain.BuildOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("building %s: %w", root, err)
+	}
+
+	verifications := make([]verification, 0, len(sources))
+	for _, src := range sources {
+		v := verification{Path: src.path}
+		var built *domain.BuiltFile
+		for i := range files {
+			if files[i].Kind == src.kind && files[i].Source == src.source {
+				built = &files[i]
+				break
+			}
+		}
+		if built == nil {
+			v.Error = fmt.Sprintf("no file was built from %s", src.source)
+			verifications = append(verifications, v)
+			continue
+		}
+
+		v.Built, _ = filepath.Rel(root, built.Path)
+		v.Built = filepath.ToSlash(v.Built)
+		v.Lost, v.Cosmetic, err = importer.CompareBuilt(src.kind, src.content, built.Content)
+		if err != nil {
+			v.Error = err.Error()
+		}
+		verifications = append(verifications, v)
+	}
+	return verifications, nil
+}
+
+// verifyErrors returns an error message for each file that could not be
+// verified or lost behaviour in the round trip.
+func verifyErrors(verifications []verification) []string {
+	var errs []string
+	for _, v := range verifications {
+		switch {
+		case v.Error != "":
+			errs = append(errs, fmt.Sprintf("%s: verify: %s", v.Path, v.Error))
+		case len(v.Lost) > 0:
+			errs = append(errs, fmt.Sprintf("%s: %d difference(s) after the round trip", v.Path, len(v.Lost)))
+		}
+	}
+	return errs
+}
+
+// printVerifications prints the verification results as text.
+func printVerifications(verifications []verification) {
+	fmt.Println("Verify:")
+	for _, v := range verifications {
+		switch {
+		case v.Error != "":
+			fmt.Printf("  %s: error: %s\n", v.Path, v.Error)
+		case len(v.Lost) == 0:
+			fmt.Printf("  %s -> %s: ok\n", v.Path, v.Built)
+		default:
+			fmt.Printf("  %s -> %s: %d difference(s)\n", v.Path, v.Built, len(v.Lost))
+		}
+		for _, c := range v.Lost {
+			fmt.Printf("    lost: %s\n", c)
+		}
+		for _, c := range v.Cosmetic {
+			fmt.Printf("    cosmetic: %s\n", c)
+		}
+	}
+}
+
+// outputVerifiedImportResult outputs a single-file import result with its
+// verification.
+func outputVerifiedImportResult(result wetwire.ImportResult, verifications []verification) {
+	if importFormat == "json" {
+		type verifiedResult struct {
+			wetwire.ImportResult
+			Verify []verification `json:"verify"`
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(verifiedResult{ImportResult: result, Verify: verifications})
+		if !result.Success {
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("Imported %d workflow(s), %d job(s), %d step(s)\n",
+		result.Workflows, result.Jobs, result.Steps)
+	fmt.Printf("Output: %s\n", result.OutputDir)
+	for _, file := range result.Files {
+		relPath, _ := filepath.Rel(result.OutputDir, file)
+		fmt.Printf("  %s\n", relPath)
+	}
+	printVerifications(verifications)
+
+	if !result.Success {
+		for _, err := range result.Errors {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// outputImportAllResult outputs a directory import result with its
+// per-file report and, with --verify, its verification.
+func outputImportAllResult(result wetwire.ImportResult, reports []importer.FileReport, verifications []verification) {
+	if importFormat == "json" {
+		type dirResult struct {
+			wetwire.ImportResult
+			Reports []importer.FileReport `json:"reports"`
+			Verify  []verification        `json:"verify,omitempty"`
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(dirResult{ImportResult: result, Reports: reports, Verify: verifications})
+		if !result.Success {
+			os.Exit(1)
+		}
+		return
+	}
+
+	imported := 0
+	for _, report := range reports {
+		if report.Type != "" && report.Error == "" {
+			imported++
+		}
+	}
+	fmt.Printf("Imported %d file(s): %d workflow(s), %d job(s), %d step(s)\n",
+		imported, result.Workflows, result.Jobs, result.Steps)
+	fmt.Printf("Output: %s\n", result.OutputDir)
+	for _, file := range result.Files {
+		relPath, _ := filepath.Rel(result.OutputDir, file)
+		fmt.Printf("  %s\n", relPath)
+	}
+
+	fmt.Println("Report:")
+	for _, report := range reports {
+		switch {
+		case report.Type == "":
+			fmt.Printf("  %s: not imported: %s\n", report.Path, report.Error)
+		case report.Error != "":
+			fmt.Printf("  %s (%s): error: %s\n", report.Path, report.Type, report.Error)
+		default:
+			fmt.Printf("  %s (%s): %s\n", report.Path, report.Type, strings.Join(report.Declarations, ", "))
+		}
+		for _, lost := range report.Lost {
+			fmt.Printf("    lost: %s\n", lost)
+		}
+		for _, note := range report.Notes {
+			fmt.Printf("    note: %s\n", note)
+		}
+	}
+	if importVerify {
+		printVerifications(verifications)
+	}
+
+	if !result.Success {
+		for _, err := range result.Errors {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
 // outputImportResult outputs the import result in the appropriate format.
 func outputImportResult(result wetwire.ImportResult) {
 	if importFormat == "json" {
@@ -465,6 +465,190 @@ func TestImportCmd_CodeownersFile(t *testing.T) {
 	}
 }
 
+// TestImportCmd_All tests importing a whole .github directory.
+func TestImportCmd_All(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Build the binary
+	binaryPath := filepath.Join(t.TempDir(), "wetwire-github")
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, ".")
+	buildCmd.Dir = getModulePath() + "/cmd/wetwire-github"
+	if out, err := buildCmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, out)
+	}
+
+	// Create a .github directory with a workflow and a CODEOWNERS file
+	tmpDir := t.TempDir()
+	githubDir := filepath.Join(tmpDir, ".github")
+	if err := os.MkdirAll(filepath.Join(githubDir, "workflows"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	workflowYAML := `name: CI
+on: push
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`
+	if err := os.WriteFile(filepath.Join(githubDir, "workflows", "ci.yml"), []byte(workflowYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(githubDir, "CODEOWNERS"), []byte("* @org/team\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir := filepath.Join(tmpDir, "output")
+	cmd := exec.Command(binaryPath, "import", "--all", githubDir, "-o", outputDir, "--no-scaffold", "--format", "json")
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Import command failed: %v\nOutput: %s", err, stdout.String())
+	}
+
+	var result struct {
+		wetwire.ImportResult
+		Reports []struct {
+			Path string   `json:"path"`
+			Type string   `json:"type"`
+			Lost []string `json:"lost"`
+		} `json:"reports"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("Expected valid JSON output, got error: %v\nOutput: %s", err, stdout.String())
+	}
+	if !result.Success || result.Workflows != 1 {
+		t.Errorf("Expected one imported workflow, got: %+v", result.ImportResult)
+	}
+	if len(result.Reports) != 2 {
+		t.Fatalf("Expected 2 file reports, got: %+v", result.Reports)
+	}
+	for _, r := range result.Reports {
+		if r.Path == "workflows/ci.yml" && (len(r.Lost) != 1 || r.Lost[0] != "permissions") {
+			t.Errorf("Expected permissions to be reported lost, got: %v", r.Lost)
+		}
+	}
+
+	for _, name := range []string{"ci_workflows.go", "codeowners.go"} {
+		if _, err := os.Stat(filepath.Join(outputDir, "workflows", name)); err != nil {
+			t.Errorf("Expected %s to be generated: %v", name, err)
+		}
+	}
+}
+
+// TestImportCmd_Verify tests building an import and comparing it with
+// the original workflow.
+func TestImportCmd_Verify(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Build the binary
+	binaryPath := filepath.Join(t.TempDir(), "wetwire-github")
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, ".")
+	buildCmd.Dir = getModulePath() + "/cmd/wetwire-github"
+	if out, err := buildCmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, out)
+	}
+
+	// Import into a module that resolves this project
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "project")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	goMod := "module example.com/project\n\ngo 1.23\n\nrequire github.com/lex00/wetwire-github-go v0.0.0\n\nreplace github.com/lex00/wetwire-github-go => " + getModulePath() + "\n"
+	if err := os.WriteFile(filepath.Join(outputDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		workflow string
+		wantOK   bool
+		wantLost string
+	}{
+		{
+			name: "lossless",
+			workflow: `name: CI
+on: [push]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+  test:
+    needs: build
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`,
+			wantOK: true,
+		},
+		{
+			name: "lossy",
+			workflow: `name: CI
+on: push
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+			wantLost: "permissions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowPath := filepath.Join(tmpDir, "ci.yml")
+			if err := os.WriteFile(workflowPath, []byte(tt.workflow), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.RemoveAll(filepath.Join(outputDir, "workflows")); err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := exec.Command(binaryPath, "import", workflowPath, "-o", outputDir, "--no-scaffold", "--verify", "--format", "json")
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			err := cmd.Run()
+			if (err == nil) != tt.wantOK {
+				t.Fatalf("exit error = %v, want success %v\nstdout: %s\nstderr: %s", err, tt.wantOK, stdout.String(), stderr.String())
+			}
+
+			var result struct {
+				wetwire.ImportResult
+				Verify []struct {
+					Built string `json:"built"`
+					Lost  []struct {
+						Path string `json:"path"`
+					} `json:"lost"`
+				} `json:"verify"`
+			}
+			if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+				t.Fatalf("Expected valid JSON output, got error: %v\nOutput: %s", err, stdout.String())
+			}
+			if len(result.Verify) != 1 || result.Verify[0].Built != ".github/workflows/c-i.yml" {
+				t.Fatalf("Expected one verified workflow, got: %+v", result.Verify)
+			}
+			lost := result.Verify[0].Lost
+			if tt.wantLost == "" && len(lost) > 0 {
+				t.Errorf("Expected no lost fields, got: %+v", lost)
+			}
+			if tt.wantLost != "" && (len(lost) != 1 || lost[0].Path != tt.wantLost) {
+				t.Errorf("Expected %s to be lost, got: %+v", tt.wantLost, lost)
+			}
+		})
+	}
+}
+
 // TestImportCmd_InvalidYAML tests error handling for invalid YAML.
 func TestImportCmd_InvalidYAML(t *testing.T) {
 	if testing.Short() {
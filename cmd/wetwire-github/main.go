@@ -2,6 +2,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -17,11 +18,31 @@ var (
 
 func main() {
 	if err := run(); err != nil {
+		var exit *exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// exitError is returned by a command to exit with a status other than 1.
+// Any message it holds has already been printed by cobra, or silenced.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error { return e.err }
+
 func run() error {
 	// Set version in domain
 	domain.Version = version
@@ -30,6 +51,13 @@ func run() error {
 	d := &domain.GitHubDomain{}
 	root := domain.CreateRootCommand(d)
 
+	// The diff command replaces the generic one registered by the core
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == "diff" {
+			root.RemoveCommand(cmd)
+		}
+	}
+
 	// Add GitHub-specific commands
 	root.AddCommand(designCmd)
 	root.AddCommand(testCmd)
@@ -37,6 +65,9 @@ func run() error {
 	root.AddCommand(diffCmd)
 	root.AddCommand(watchCmd)
 	root.AddCommand(mcpCmd)
+	root.AddCommand(simulateCmd)
+	root.AddCommand(permissionsCmd)
+	root.AddCommand(pinCmd)
 
 	return root.Execute()
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/lex00/wetwire-github-go/permissions"
+	"github.com/spf13/cobra"
+)
+
+var permissionsCmd = &cobra.Command{
+	Use:   "permissions [path]",
+	Short: "Infer the least-privilege GITHUB_TOKEN permissions for each job",
+	Long: `Infer the GITHUB_TOKEN permissions each job needs and compare them with
+the permissions it is granted.
+
+Requirements come from a catalog of the typed action wrappers (for example
+create_pull_request needs contents: write and pull-requests: write) and from
+run steps that push with git. Jobs using actions outside the catalog, or
+scripts that use the token directly, are reported as incomplete.
+
+With --fix, the inferred permissions block is written into each job's Go
+declaration. Incomplete jobs are left unchanged.
+
+The command exits with an error when any job is over- or under-granted, or
+relies on the default permissions.
+
+Supported output formats:
+  - text (default): Human-readable output
+  - json: Machine-readable JSON format
+
+Examples:
+  # Report permissions for every job
+  wetwire-github permissions ./workflows
+
+  # Write the inferred permissions into the Go source
+  wetwire-github permissions ./workflows --fix`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPermissions,
+}
+
+func init() {
+	permissionsCmd.Flags().Bool("fix", false, "Write inferred permissions into the Go source")
+	permissionsCmd.Flags().String("format", "text", "Output format: text, json")
+}
+
+func runPermissions(cmd *cobra.Command, args []string) error {
+	fix, _ := cmd.Flags().GetBool("fix")
+	outputFormat, _ := cmd.Flags().GetString("format")
+
+	path := "."
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	reports, err := permissions.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		outputPermissionsText(cmd, reports)
+	}
+
+	if fix {
+		changed, err := permissions.Fix(reports)
+		for _, file := range changed {
+			fmt.Fprintf(cmd.ErrOrStderr(), "fixed %s\n", file)
+		}
+		return err
+	}
+
+	mismatched := 0
+	for _, r := range reports {
+		if !r.OK() {
+			mismatched++
+		}
+	}
+	if mismatched > 0 {
+		return fmt.Errorf("%d job(s) need permission changes", mismatched)
+	}
+	return nil
+}
+
+func outputPermissionsText(cmd *cobra.Command, reports []permissions.JobReport) {
+	out := cmd.OutOrStdout()
+
+	for _, r := range reports {
+		mark := "✓"
+		if !r.OK() {
+			mark = "✗"
+		}
+		location := ""
+		if r.File != "" {
+			location = fmt.Sprintf(" (%s:%d)", filepath.Base(r.File), r.Line)
+		}
+		fmt.Fprintf(out, "%s %s/%s%s\n", mark, r.Workflow, r.Job, location)
+
+		fmt.Fprintf(out, "    required: %s\n", scopesText(r.Required))
+		if r.Source == permissions.SourceDefault {
+			fmt.Fprintln(out, "    granted:  repository default (declare permissions explicitly)")
+		} else {
+			fmt.Fprintf(out, "    granted:  %s (%s)\n", scopesText(r.Granted), r.Source)
+		}
+		if len(r.Over) > 0 {
+			fmt.Fprintf(out, "    over-granted:  %s\n", r.Over)
+		}
+		if len(r.Under) > 0 {
+			fmt.Fprintf(out, "    under-granted: %s\n", r.Under)
+		}
+		for _, uses := range r.Unknown {
+			fmt.Fprintf(out, "    unknown action: %s\n", uses)
+		}
+		for _, step := range r.Uncertain {
+			fmt.Fprintf(out, "    uncertain: %s\n", step)
+		}
+	}
+}
+
+func scopesText(s permissions.Scopes) string {
+	if len(s) == 0 {
+		return "none"
+	}
+	return s.String()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/permissions"
+)
+
+func TestOutputPermissionsText(t *testing.T) {
+	reports := []permissions.JobReport{
+		{
+			Workflow: "CI",
+			Job:      "build",
+			File:     "/src/ci/jobs.go",
+			Line:     12,
+			Source:   permissions.SourceJob,
+			Granted:  permissions.Scopes{"contents": "read"},
+			Required: permissions.Scopes{"contents": "read"},
+		},
+		{
+			Workflow: "CI",
+			Job:      "release",
+			Source:   permissions.SourceWorkflow,
+			Granted:  permissions.Scopes{"contents": "read", "issues": "write"},
+			Required: permissions.Scopes{"contents": "write"},
+			Over:     permissions.Scopes{"issues": "write"},
+			Under:    permissions.Scopes{"contents": "write"},
+		},
+		{
+			Workflow:  "CI",
+			Job:       "lint",
+			Source:    permissions.SourceDefault,
+			Required:  permissions.Scopes{},
+			Unknown:   []string{"example/lint@v1"},
+			Uncertain: []string{"step 2 uses the token directly"},
+		},
+	}
+
+	var buf bytes.Buffer
+	permissionsCmd.SetOut(&buf)
+	outputPermissionsText(permissionsCmd, reports)
+
+	out := buf.String()
+	for _, want := range []string{
+		"✓ CI/build (jobs.go:12)",
+		"    granted:  contents: read (job)",
+		"✗ CI/release",
+		"    over-granted:  issues: write",
+		"    under-granted: contents: write",
+		"✗ CI/lint",
+		"    required: none",
+		"    granted:  repository default",
+		"    unknown action: example/lint@v1",
+		"    uncertain: step 2 uses the token directly",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/pin"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/internal/template"
+	"github.com/lex00/wetwire-github-go/workflow"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin [path]",
+	Short: "Pin action references to commit SHAs in wetwire-github.lock",
+	Long: `Resolve every action and reusable workflow referenced by the workflows and
+composite actions in a Go package to a commit SHA, and record the result in
+wetwire-github.lock next to go.mod.
+
+Refs are resolved offline from local sources:
+  --mirror DIR   git clones laid out as DIR/<owner>/<repo> (or <repo>.git)
+  --from FILE    vendored JSON mapping repositories to tags and SHAs:
+                 {"actions/checkout": {"v4": "<sha>", "v4.2.2": "<sha>"}}
+
+When both are given, the mirror is tried first. Existing entries are kept
+unless --update is set; entries no longer referenced are removed.
+
+Once the lock file exists, build emits pinned references such as
+  uses: actions/checkout@<sha> # v4.2.2
+and lint rule WAG022 reports references missing from the lock.
+
+Examples:
+  # Pin from a directory of mirrors
+  wetwire-github pin ./workflows --mirror ~/mirrors
+
+  # Re-resolve every reference from a vendored file
+  wetwire-github pin . --from action-refs.json --update`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPin,
+}
+
+func init() {
+	pinCmd.Flags().String("mirror", "", "Directory of git clones to resolve refs from")
+	pinCmd.Flags().String("from", "", "Vendored JSON file of refs and SHAs")
+	pinCmd.Flags().Bool("update", false, "Re-resolve references that are already locked")
+}
+
+func runPin(cmd *cobra.Command, args []string) error {
+	mirror, _ := cmd.Flags().GetString("mirror")
+	from, _ := cmd.Flags().GetString("from")
+	update, _ := cmd.Flags().GetBool("update")
+
+	path := "."
+	if len(args) > 0 {
+		path = args[0]
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolving path: %w", err)
+	}
+
+	var resolvers pin.Resolvers
+	if mirror != "" {
+		resolvers = append(resolvers, pin.Mirror{Dir: mirror})
+	}
+	if from != "" {
+		vendored, err := pin.LoadVendored(from)
+		if err != nil {
+			return fmt.Errorf("loading vendored refs: %w", err)
+		}
+		resolvers = append(resolvers, vendored)
+	}
+
+	lock, err := pin.Find(absPath)
+	if err != nil {
+		return fmt.Errorf("loading lock file: %w", err)
+	}
+	if lock == nil {
+		root, err := moduleRoot(absPath)
+		if err != nil {
+			return err
+		}
+		lock = &pin.Lock{Path: filepath.Join(root, pin.FileName)}
+	}
+
+	refs, err := collectActionRefs(absPath)
+	if err != nil {
+		return err
+	}
+
+	var pinnable []string
+	for _, ref := range refs {
+		if pin.Pinnable(ref) {
+			pinnable = append(pinnable, ref)
+		}
+	}
+	if len(resolvers) == 0 {
+		for _, ref := range pinnable {
+			if update || !lock.Has(ref) {
+				return fmt.Errorf("no source to resolve %s from: use --mirror or --from", ref)
+			}
+		}
+	}
+
+	changed, errs := lock.Update(pinnable, resolvers, update)
+	removed := lock.Prune(pinnable)
+
+	if err := lock.Save(lock.Path); err != nil {
+		return fmt.Errorf("writing lock file: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	for _, ref := range changed {
+		entry := lock.Actions[ref]
+		fmt.Fprintf(out, "pinned %s → %s %s\n", ref, entry.SHA, entry.Version)
+	}
+	for _, ref := range removed {
+		fmt.Fprintf(out, "removed %s\n", ref)
+	}
+	fmt.Fprintf(out, "%s: %d reference(s) locked\n", lock.Path, len(lock.Actions))
+
+	if len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, e := range errs {
+			msgs[i] = e.Error()
+		}
+		return fmt.Errorf("could not resolve %d reference(s): %s", len(errs), strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// moduleRoot returns the directory containing the go.mod for dir.
+func moduleRoot(dir string) (string, error) {
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d, nil
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+	}
+}
+
+// collectActionRefs builds the workflows and composite actions in dir and
+// returns every uses reference in sorted order.
+func collectActionRefs(dir string) ([]string, error) {
+	disc := discover.NewDiscoverer()
+	run := runner.NewRunner()
+	builder := template.NewBuilder()
+
+	seen := make(map[string]bool)
+	addSteps := func(steps []any) {
+		for _, s := range steps {
+			switch v := s.(type) {
+			case workflow.Step:
+				seen[v.Uses] = true
+			case workflow.StepAction:
+				seen[v.Action()] = true
+			}
+		}
+	}
+
+	discovered, err := disc.Discover(dir)
+	if err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	if len(discovered.Workflows) > 0 {
+		extracted, err := run.ExtractValues(dir, discovered)
+		if err != nil {
+			return nil, fmt.Errorf("extraction failed: %w", err)
+		}
+		if extracted.Error != "" {
+			return nil, fmt.Errorf("extraction failed: %s", extracted.Error)
+		}
+		built, err := builder.Build(discovered, extracted)
+		if err != nil {
+			return nil, fmt.Errorf("template build failed: %w", err)
+		}
+		if errs := append(discovered.Errors, built.Errors...); len(errs) > 0 {
+			return nil, fmt.Errorf("build failed: %s", strings.Join(errs, "; "))
+		}
+		for _, bw := range built.Workflows {
+			for _, job := range bw.Workflow.Jobs {
+				if uses, ok := job.Uses.(string); ok {
+					seen[uses] = true
+				}
+				addSteps(job.Steps)
+			}
+		}
+	}
+
+	actions, err := disc.DiscoverActions(dir)
+	if err != nil {
+		return nil, fmt.Errorf("action discovery failed: %w", err)
+	}
+	if len(actions.Actions) > 0 {
+		extracted, err := run.ExtractActions(dir, actions)
+		if err != nil {
+			return nil, fmt.Errorf("action extraction failed: %w", err)
+		}
+		if extracted.Error != "" {
+			return nil, fmt.Errorf("action extraction failed: %s", extracted.Error)
+		}
+		built, err := builder.BuildActions(actions, extracted)
+		if err != nil {
+			return nil, fmt.Errorf("action build failed: %w", err)
+		}
+		for _, a := range built.Actions {
+			addSteps(a.Action.Runs.Steps)
+		}
+	}
+
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		if ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
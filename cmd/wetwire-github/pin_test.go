@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunPin_InvalidVendoredFile(t *testing.T) {
+	cmd := pinCmd
+	if err := cmd.Flags().Set("from", "/nonexistent/refs.json"); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Flags().Set("from", "")
+
+	err := runPin(cmd, []string{t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "loading vendored refs") {
+		t.Errorf("runPin() error = %v, want vendored refs error", err)
+	}
+}
+
+func TestModuleRoot(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(dir, "workflows", "ci")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := moduleRoot(sub)
+	if err != nil || root != dir {
+		t.Errorf("moduleRoot() = %q, %v; want %q", root, err, dir)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/lex00/wetwire-github-go/simulate"
+	"github.com/spf13/cobra"
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate <event> [path]",
+	Short: "Predict which workflows and jobs an event would start",
+	Long: `Simulate a GitHub event against the workflows declared in a Go package.
+
+Each workflow's triggers are matched against the event: branch and tag
+filters, paths and paths-ignore, activity types and workflow_run chains.
+Job If conditions of triggered workflows are then evaluated, and jobs are
+listed in the order they would start.
+
+Changed files for path filters come from --changed, --git-range, or the
+commits of a push payload.
+
+Supported output formats:
+  - text (default): Human-readable output
+  - json: Machine-readable JSON format
+
+Examples:
+  # Would a push to main touching the API service run CI?
+  wetwire-github simulate push ./workflows --ref refs/heads/main --changed services/api/main.go
+
+  # Use the files changed on the current branch
+  wetwire-github simulate pull_request ./workflows --payload pr.json --git-range origin/main...HEAD
+
+  # JSON output for automation
+  wetwire-github simulate push . --payload push.json --format json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSimulate,
+}
+
+func init() {
+	simulateCmd.Flags().String("payload", "", "JSON file with the webhook payload")
+	simulateCmd.Flags().String("ref", "", "Git ref the event runs on (default: from the payload)")
+	simulateCmd.Flags().StringSlice("changed", nil, "Changed files for paths filters")
+	simulateCmd.Flags().String("git-range", "", "Git revision range to read changed files from, e.g. main..HEAD")
+	simulateCmd.Flags().String("format", "text", "Output format: text, json")
+}
+
+func runSimulate(cmd *cobra.Command, args []string) error {
+	payloadPath, _ := cmd.Flags().GetString("payload")
+	ref, _ := cmd.Flags().GetString("ref")
+	changed, _ := cmd.Flags().GetStringSlice("changed")
+	gitRange, _ := cmd.Flags().GetString("git-range")
+	outputFormat, _ := cmd.Flags().GetString("format")
+
+	path := "."
+	if len(args) > 1 {
+		path = args[1]
+	}
+
+	event := simulate.Event{Name: args[0], Ref: ref, ChangedFiles: changed}
+
+	if payloadPath != "" {
+		data, err := os.ReadFile(payloadPath)
+		if err != nil {
+			return fmt.Errorf("reading payload: %w", err)
+		}
+		if err := json.Unmarshal(data, &event.Payload); err != nil {
+			return fmt.Errorf("parsing payload %s: %w", payloadPath, err)
+		}
+	}
+
+	if gitRange != "" {
+		files, err := simulate.ChangedFilesFromGit(path, gitRange)
+		if err != nil {
+			return err
+		}
+		event.ChangedFiles = append(event.ChangedFiles, files...)
+	}
+
+	workflows, err := simulate.Load(path)
+	if err != nil {
+		return err
+	}
+
+	result, err := simulate.Simulate(workflows, event)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	outputSimulateText(cmd, result)
+	return nil
+}
+
+func outputSimulateText(cmd *cobra.Command, result *simulate.Result) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Event: %s (%s)\n\n", result.Event, result.Ref)
+
+	for _, wr := range result.Workflows {
+		mark := "✗"
+		if wr.Triggered {
+			mark = "✓"
+		}
+		fmt.Fprintf(out, "%s %s: %s\n", mark, wr.ID, wr.Reason)
+		if wr.TriggeredBy != "" {
+			fmt.Fprintf(out, "    triggered by %s\n", wr.TriggeredBy)
+		}
+
+		for i, job := range wr.Jobs {
+			line := fmt.Sprintf("    %d. %s [%s]", i+1, job.ID, job.Status)
+			if job.Reason != "" {
+				line += " " + job.Reason
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+}
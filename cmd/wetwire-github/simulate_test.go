@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/simulate"
+)
+
+func TestOutputSimulateText(t *testing.T) {
+	result := &simulate.Result{
+		Event: "push",
+		Ref:   "refs/heads/main",
+		Workflows: []simulate.WorkflowResult{
+			{
+				ID:        "CI",
+				Triggered: true,
+				Reason:    `push to branch "main"`,
+				Jobs: []simulate.JobResult{
+					{ID: "build", Status: simulate.JobRuns},
+					{ID: "deploy", Status: simulate.JobSkipped, Reason: "if: false is false"},
+				},
+			},
+			{
+				ID:          "Deploy",
+				Triggered:   true,
+				Reason:      `workflow_run after "CI" completed`,
+				TriggeredBy: "CI",
+			},
+			{ID: "Docs", Reason: "no changed file matches paths [docs/**]"},
+		},
+	}
+
+	var buf bytes.Buffer
+	simulateCmd.SetOut(&buf)
+	outputSimulateText(simulateCmd, result)
+
+	out := buf.String()
+	for _, want := range []string{
+		"Event: push (refs/heads/main)",
+		`✓ CI: push to branch "main"`,
+		"    1. build [run]",
+		"    2. deploy [skipped] if: false is false",
+		"    triggered by CI",
+		"✗ Docs: no changed file matches paths [docs/**]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunSimulate_InvalidPayload(t *testing.T) {
+	cmd := simulateCmd
+	if err := cmd.Flags().Set("payload", "/nonexistent/payload.json"); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Flags().Set("payload", "")
+
+	err := runSimulate(cmd, []string{"push", t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "reading payload") {
+		t.Errorf("runSimulate() error = %v, want payload read error", err)
+	}
+}
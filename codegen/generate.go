@@ -43,6 +43,10 @@ func NewGenerator() *Generator {
 const actionWrapperTemplate = `// Code generated by wetwire-github codegen. DO NOT EDIT.
 
 package {{.PackageName}}
+{{- if .Outputs}}
+
+import "github.com/lex00/wetwire-github-go/workflow"
+{{- end}}
 
 // {{.TypeName}} wraps the {{.ActionRef}} action.
 {{- if .Spec.Description}}
@@ -80,6 +84,28 @@ func (a {{.TypeName}}) Inputs() map[string]any {
 {{- end}}
 	return with
 }
+{{- if .Outputs}}
+
+// {{.TypeName}}Outputs references the outputs of a {{.ActionRef}} step.
+type {{.TypeName}}Outputs struct {
+	stepID string
+}
+
+// {{.OutputsMethod}} returns output references for the step with the given ID.
+func (a {{.TypeName}}) {{.OutputsMethod}}(stepID string) {{.TypeName}}Outputs {
+	return {{.TypeName}}Outputs{stepID: stepID}
+}
+{{- range .Outputs}}
+
+// {{.Name}} returns the {{.YAMLName}} output.
+{{- if .Description}}
+// {{.Description}}
+{{- end}}
+func (o {{$.TypeName}}Outputs) {{.Name}}() workflow.Expression {
+	return workflow.Steps.Get(o.stepID, "{{.YAMLName}}")
+}
+{{- end}}
+{{- end}}
 `
 
 // Field represents a field in the generated struct.
@@ -93,11 +119,13 @@ type Field struct {
 
 // templateData contains the data for the template.
 type templateData struct {
-	PackageName string
-	TypeName    string
-	ActionRef   string
-	Spec        *ActionSpec
-	Fields      []Field
+	PackageName   string
+	TypeName      string
+	ActionRef     string
+	Spec          *ActionSpec
+	Fields        []Field
+	Outputs       []Field
+	OutputsMethod string
 }
 
 // GenerateActionWrapper generates a Go wrapper for an action.
@@ -122,12 +150,36 @@ func (g *Generator) GenerateActionWrapper(config ActionWrapperConfig) (*Generate
 		return fields[i].Name < fields[j].Name
 	})
 
+	// Collect output accessors, sorted alphabetically
+	outputs := make([]Field, 0, len(config.Spec.Outputs))
+	for name, output := range config.Spec.Outputs {
+		outputs = append(outputs, Field{
+			Name:        GetGoFieldName(name),
+			YAMLName:    name,
+			Description: sanitizeDescription(output.Description),
+		})
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		return outputs[i].Name < outputs[j].Name
+	})
+
+	// The accessor is named Outputs unless an input field already uses that name
+	outputsMethod := "Outputs"
+	for _, f := range fields {
+		if f.Name == outputsMethod {
+			outputsMethod = "StepOutputs"
+			break
+		}
+	}
+
 	data := templateData{
-		PackageName: config.PackageName,
-		TypeName:    config.TypeName,
-		ActionRef:   config.ActionRef,
-		Spec:        config.Spec,
-		Fields:      fields,
+		PackageName:   config.PackageName,
+		TypeName:      config.TypeName,
+		ActionRef:     config.ActionRef,
+		Spec:          config.Spec,
+		Fields:        fields,
+		Outputs:       outputs,
+		OutputsMethod: outputsMethod,
 	}
 
 	tmpl, err := template.New("action").Parse(actionWrapperTemplate)
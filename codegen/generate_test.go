@@ -856,3 +856,96 @@ func TestGenerator_GenerateActionWrapper_MixedRequiredOptional(t *testing.T) {
 		t.Error("Optional fields should be alphabetically sorted")
 	}
 }
+
+func TestGenerator_GenerateActionWrapper_Outputs(t *testing.T) {
+	spec := &ActionSpec{
+		Name: "Docker Metadata",
+		Inputs: map[string]ActionInput{
+			"images": {Description: "List of Docker images"},
+		},
+		Outputs: map[string]ActionOutput{
+			"tags":      {Description: "Generated Docker tags"},
+			"bake-file": {Description: "Bake definition file"},
+		},
+	}
+
+	gen := NewGenerator()
+	code, err := gen.GenerateActionWrapper(ActionWrapperConfig{
+		ActionRef:   "docker/metadata-action@v5",
+		PackageName: "docker_metadata",
+		TypeName:    "DockerMetadata",
+		Spec:        spec,
+	})
+	if err != nil {
+		t.Fatalf("GenerateActionWrapper() error = %v", err)
+	}
+
+	codeStr := string(code.Code)
+	expectedStrings := []string{
+		`import "github.com/lex00/wetwire-github-go/workflow"`,
+		"type DockerMetadataOutputs struct",
+		"func (a DockerMetadata) Outputs(stepID string) DockerMetadataOutputs",
+		"func (o DockerMetadataOutputs) Tags() workflow.Expression",
+		`return workflow.Steps.Get(o.stepID, "tags")`,
+		"func (o DockerMetadataOutputs) BakeFile() workflow.Expression",
+		"// Generated Docker tags",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing %q\n\nGenerated:\n%s", expected, codeStr)
+		}
+	}
+}
+
+func TestGenerator_GenerateActionWrapper_OutputsNameCollision(t *testing.T) {
+	spec := &ActionSpec{
+		Name: "Build and push",
+		Inputs: map[string]ActionInput{
+			"outputs": {Description: "List of output destinations"},
+		},
+		Outputs: map[string]ActionOutput{
+			"digest": {Description: "Image digest"},
+		},
+	}
+
+	gen := NewGenerator()
+	code, err := gen.GenerateActionWrapper(ActionWrapperConfig{
+		ActionRef:   "docker/build-push-action@v6",
+		PackageName: "docker_build_push",
+		TypeName:    "DockerBuildPush",
+		Spec:        spec,
+	})
+	if err != nil {
+		t.Fatalf("GenerateActionWrapper() error = %v", err)
+	}
+
+	codeStr := string(code.Code)
+	if !strings.Contains(codeStr, "func (a DockerBuildPush) StepOutputs(stepID string) DockerBuildPushOutputs") {
+		t.Errorf("expected StepOutputs accessor when an input is named outputs\n\nGenerated:\n%s", codeStr)
+	}
+}
+
+func TestGenerator_GenerateActionWrapper_NoOutputs(t *testing.T) {
+	spec := &ActionSpec{
+		Name: "Checkout",
+		Inputs: map[string]ActionInput{
+			"ref": {Description: "The branch, tag or SHA to checkout"},
+		},
+	}
+
+	gen := NewGenerator()
+	code, err := gen.GenerateActionWrapper(ActionWrapperConfig{
+		ActionRef:   "actions/checkout@v4",
+		PackageName: "checkout",
+		TypeName:    "Checkout",
+		Spec:        spec,
+	})
+	if err != nil {
+		t.Fatalf("GenerateActionWrapper() error = %v", err)
+	}
+
+	codeStr := string(code.Code)
+	if strings.Contains(codeStr, "import") || strings.Contains(codeStr, "CheckoutOutputs") {
+		t.Errorf("wrapper without outputs should not import workflow or define outputs\n\nGenerated:\n%s", codeStr)
+	}
+}
@@ -0,0 +1,388 @@
+package domain
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/inventory"
+	"github.com/lex00/wetwire-github-go/internal/pin"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/internal/template"
+)
+
+// Resource type names accepted by BuildOpts.Type.
+// They match the ResourceType() values of the corresponding Go types.
+const (
+	resourceWorkflow           = "workflow"
+	resourceDependabot         = "dependabot"
+	resourceCodeowners         = "codeowners"
+	resourceIssueTemplate      = "issue-template"
+	resourceDiscussionTemplate = "discussion-template"
+	resourcePRTemplate         = "pr-template"
+	resourceAction             = "action"
+)
+
+// resourceTypes lists every resource type in build order.
+var resourceTypes = []string{
+	resourceWorkflow,
+	resourceDependabot,
+	resourceCodeowners,
+	resourceIssueTemplate,
+	resourceDiscussionTemplate,
+	resourcePRTemplate,
+	resourceAction,
+}
+
+// BuiltFile is a single file produced by the build pipeline.
+type BuiltFile struct {
+	// Path is the absolute output path
+	Path string
+
+	// Kind is the resource type that produced the file
+	Kind string
+
+	// Source is the Go variable name the file was built from
+	Source string
+
+	// Content is the file content
+	Content []byte
+}
+
+// buildOutput contains everything produced by a build run.
+type buildOutput struct {
+	Files  []BuiltFile
+	Errors []Error
+
+	// Found is the number of discovered declarations across all resource types
+	Found int
+}
+
+// BuildFiles runs the build for the Go module at path without writing
+// anything, and returns the files it would write. Build errors are
+// returned as a single error.
+func BuildFiles(path string, opts BuildOpts) ([]BuiltFile, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
+	}
+
+	out, err := collectOutputs(absPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	if out.Found == 0 {
+		return nil, fmt.Errorf("no resources found in %s", absPath)
+	}
+	if len(out.Errors) > 0 {
+		msgs := make([]string, len(out.Errors))
+		for i, e := range out.Errors {
+			msgs[i] = e.Message
+		}
+		return nil, fmt.Errorf("template build failed: %s", strings.Join(msgs, "; "))
+	}
+
+	return out.Files, nil
+}
+
+// outputDirs resolves the workflow output directory and the .github directory.
+// Workflows are written to the output directory (default ".github/workflows");
+// all other resources are written to the .github directory above it. An
+// output directory that is not a .github/workflows directory has no
+// .github directory, so githubDir is the output directory itself, where
+// only workflows and the manifest are written.
+func outputDirs(absPath, output string) (workflowDir, githubDir string) {
+	if output == "" {
+		output = filepath.Join(".github", "workflows")
+	}
+	workflowDir = output
+	if !filepath.IsAbs(workflowDir) {
+		workflowDir = filepath.Join(absPath, workflowDir)
+	}
+	workflowDir = filepath.Clean(workflowDir)
+	if template.RepoWorkflowDir(absPath, workflowDir) == ".github/workflows" {
+		return workflowDir, filepath.Dir(workflowDir)
+	}
+	return workflowDir, workflowDir
+}
+
+// collectOutputs runs discover, extract and build for every requested
+// resource type and returns the files that would be written.
+func collectOutputs(absPath string, opts BuildOpts) (*buildOutput, error) {
+	kinds := resourceTypes
+	if opts.Type != "" {
+		if !isResourceType(opts.Type) {
+			return nil, fmt.Errorf("unknown resource type %q (expected one of: %s)", opts.Type, strings.Join(resourceTypes, ", "))
+		}
+		kinds = []string{opts.Type}
+	}
+
+	workflowDir, githubDir := outputDirs(absPath, opts.Output)
+
+	runners, err := inventory.Find(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading runner inventory: %w", err)
+	}
+
+	lock, err := pin.Find(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading action lock file: %w", err)
+	}
+
+	out := &buildOutput{}
+	c := &collector{
+		absPath:       absPath,
+		workflowsOnly: workflowDir == githubDir,
+		disc:          discover.NewDiscoverer(),
+		run:           runner.NewRunner(),
+		builder:       template.NewBuilder(),
+		out:           out,
+	}
+	c.builder.Runners = runners
+	c.builder.Lock = lock
+	c.builder.WorkflowDir = template.RepoWorkflowDir(absPath, workflowDir)
+
+	stages := c.stages(workflowDir, githubDir)
+	for _, kind := range kinds {
+		if err := stages[kind].run(c, kind); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// isResourceType reports whether kind is a known resource type.
+func isResourceType(kind string) bool {
+	for _, k := range resourceTypes {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// collector holds the shared state for a single collectOutputs run.
+type collector struct {
+	absPath string
+
+	// workflowsOnly is set when the output directory is not a
+	// .github/workflows directory, which leaves no place for the other
+	// resource types
+	workflowsOnly bool
+
+	disc    *discover.Discoverer
+	run     *runner.Runner
+	builder *template.Builder
+	out     *buildOutput
+}
+
+// addErrors records non-fatal build errors.
+func (c *collector) addErrors(errs []string) {
+	for _, e := range errs {
+		c.out.Errors = append(c.out.Errors, Error{Path: c.absPath, Message: e})
+	}
+}
+
+// stage builds the declarations of one resource type.
+type stage interface {
+	run(c *collector, kind string) error
+}
+
+// pipeline is the discover, extract and build steps of a resource type,
+// given as the Discoverer, Runner and Builder methods for it. D, E and R
+// are its discovery, extraction and build results.
+type pipeline[D, E, R any] struct {
+	// label names the resource type in error messages
+	label string
+
+	// single is set for types GitHub reads only one file of
+	single bool
+
+	discover func(dir string) (D, error)
+	found    func(D) int
+	extract  func(dir string, discovered D) (E, error)
+	failure  func(E) string
+	build    func(discovered D, extracted E) (R, error)
+
+	// files returns the built files, without their Kind, and the build
+	// errors
+	files func(R) ([]BuiltFile, []string)
+}
+
+// run records the files and errors of building the type's declarations.
+func (p pipeline[D, E, R]) run(c *collector, kind string) error {
+	discovered, err := p.discover(c.absPath)
+	if err != nil {
+		return fmt.Errorf("%s discovery failed: %w", p.label, err)
+	}
+	n := p.found(discovered)
+	if n == 0 {
+		return nil
+	}
+	c.out.Found += n
+	if c.workflowsOnly && kind != resourceWorkflow {
+		return fmt.Errorf("%s declarations found, but the output directory is not a .github/workflows directory: only workflows can be built to it (use --type %s)", p.label, resourceWorkflow)
+	}
+
+	extracted, err := p.extract(c.absPath, discovered)
+	if err != nil {
+		return fmt.Errorf("%s extraction failed: %w", p.label, err)
+	}
+	if failure := p.failure(extracted); failure != "" {
+		c.addErrors([]string{failure})
+		return nil
+	}
+
+	built, err := p.build(discovered, extracted)
+	if err != nil {
+		return fmt.Errorf("%s build failed: %w", p.label, err)
+	}
+	files, errs := p.files(built)
+	c.addErrors(errs)
+
+	if p.single && len(files) > 1 {
+		names := make([]string, len(files))
+		for i, f := range files {
+			names[i] = f.Source
+		}
+		c.addErrors([]string{fmt.Sprintf("multiple %s configs found (%s); only one is allowed", p.label, strings.Join(names, ", "))})
+		return nil
+	}
+	for _, f := range files {
+		f.Kind = kind
+		c.out.Files = append(c.out.Files, f)
+	}
+	return nil
+}
+
+// stages returns the build stage of each resource type. Workflows are
+// written to workflowDir and everything else below githubDir.
+func (c *collector) stages(workflowDir, githubDir string) map[string]stage {
+	issueDir := filepath.Join(githubDir, "ISSUE_TEMPLATE")
+	discussionDir := filepath.Join(githubDir, "DISCUSSION_TEMPLATE")
+	actionDir := filepath.Join(githubDir, "actions")
+
+	return map[string]stage{
+		resourceWorkflow: pipeline[*discover.DiscoveryResult, *runner.ExtractionResult, *template.BuildResult]{
+			label:    "workflow",
+			discover: c.disc.Discover,
+			found:    func(d *discover.DiscoveryResult) int { return len(d.Workflows) },
+			extract:  c.run.ExtractValues,
+			failure:  func(e *runner.ExtractionResult) string { return e.Error },
+			build:    c.builder.Build,
+			files: func(r *template.BuildResult) ([]BuiltFile, []string) {
+				var files []BuiltFile
+				for _, wf := range r.Workflows {
+					files = append(files, BuiltFile{Source: wf.Name, Path: filepath.Join(workflowDir, template.ToFilename(wf.Name)+".yml"), Content: wf.YAML})
+				}
+				return files, r.Errors
+			},
+		},
+		// GitHub only reads a single dependabot.yml per repository
+		resourceDependabot: pipeline[*discover.DependabotDiscoveryResult, *runner.DependabotExtractionResult, *template.DependabotBuildResult]{
+			label:    "dependabot",
+			single:   true,
+			discover: c.disc.DiscoverDependabot,
+			found:    func(d *discover.DependabotDiscoveryResult) int { return len(d.Configs) },
+			extract:  c.run.ExtractDependabot,
+			failure:  func(e *runner.DependabotExtractionResult) string { return e.Error },
+			build:    c.builder.BuildDependabot,
+			files: func(r *template.DependabotBuildResult) ([]BuiltFile, []string) {
+				var files []BuiltFile
+				for _, cfg := range r.Configs {
+					files = append(files, BuiltFile{Source: cfg.Name, Path: filepath.Join(githubDir, "dependabot.yml"), Content: cfg.YAML})
+				}
+				return files, r.Errors
+			},
+		},
+		// GitHub only reads a single CODEOWNERS file per location
+		resourceCodeowners: pipeline[*discover.CodeownersDiscoveryResult, *runner.CodeownersExtractionResult, *template.CodeownersBuildResult]{
+			label:    "codeowners",
+			single:   true,
+			discover: c.disc.DiscoverCodeowners,
+			found:    func(d *discover.CodeownersDiscoveryResult) int { return len(d.Configs) },
+			extract:  c.run.ExtractCodeowners,
+			failure:  func(e *runner.CodeownersExtractionResult) string { return e.Error },
+			build:    c.builder.BuildCodeowners,
+			files: func(r *template.CodeownersBuildResult) ([]BuiltFile, []string) {
+				var files []BuiltFile
+				for _, cfg := range r.Configs {
+					files = append(files, BuiltFile{Source: cfg.Name, Path: filepath.Join(githubDir, "CODEOWNERS"), Content: cfg.Content})
+				}
+				return files, r.Errors
+			},
+		},
+		resourceIssueTemplate: pipeline[*discover.IssueTemplateDiscoveryResult, *runner.IssueTemplateExtractionResult, *template.IssueTemplateBuildResult]{
+			label:    "issue template",
+			discover: c.disc.DiscoverIssueTemplates,
+			found:    func(d *discover.IssueTemplateDiscoveryResult) int { return len(d.Templates) },
+			extract:  c.run.ExtractIssueTemplates,
+			failure:  func(e *runner.IssueTemplateExtractionResult) string { return e.Error },
+			build:    c.builder.BuildIssueTemplates,
+			files: func(r *template.IssueTemplateBuildResult) ([]BuiltFile, []string) {
+				var files []BuiltFile
+				for _, tmpl := range r.Templates {
+					files = append(files, BuiltFile{Source: tmpl.Name, Path: filepath.Join(issueDir, template.ToFilename(tmpl.Name)+".yml"), Content: tmpl.YAML})
+				}
+				return files, r.Errors
+			},
+		},
+		// The file name comes from the variable name, as for issue
+		// templates, so the variable must be named after the discussion
+		// category: Ideas builds DISCUSSION_TEMPLATE/ideas.yml
+		resourceDiscussionTemplate: pipeline[*discover.DiscussionTemplateDiscoveryResult, *runner.DiscussionTemplateExtractionResult, *template.DiscussionTemplateBuildResult]{
+			label:    "discussion template",
+			discover: c.disc.DiscoverDiscussionTemplates,
+			found:    func(d *discover.DiscussionTemplateDiscoveryResult) int { return len(d.Templates) },
+			extract:  c.run.ExtractDiscussionTemplates,
+			failure:  func(e *runner.DiscussionTemplateExtractionResult) string { return e.Error },
+			build:    c.builder.BuildDiscussionTemplates,
+			files: func(r *template.DiscussionTemplateBuildResult) ([]BuiltFile, []string) {
+				var files []BuiltFile
+				for _, tmpl := range r.Templates {
+					files = append(files, BuiltFile{Source: tmpl.Name, Path: filepath.Join(discussionDir, template.ToFilename(tmpl.Name)+".yml"), Content: tmpl.YAML})
+				}
+				return files, r.Errors
+			},
+		},
+		resourcePRTemplate: pipeline[*discover.PRTemplateDiscoveryResult, *runner.PRTemplateExtractionResult, *template.PRTemplateBuildResult]{
+			label:    "PR template",
+			discover: c.disc.DiscoverPRTemplates,
+			found:    func(d *discover.PRTemplateDiscoveryResult) int { return len(d.Templates) },
+			extract:  c.run.ExtractPRTemplates,
+			failure:  func(e *runner.PRTemplateExtractionResult) string { return e.Error },
+			build:    c.builder.BuildPRTemplates,
+			files: func(r *template.PRTemplateBuildResult) ([]BuiltFile, []string) {
+				var files []BuiltFile
+				for _, tmpl := range r.Templates {
+					files = append(files, BuiltFile{Source: tmpl.Name, Path: filepath.Join(githubDir, filepath.FromSlash(tmpl.Filename)), Content: tmpl.Content})
+				}
+				return files, r.Errors
+			},
+		},
+		// Each action is written to actions/<name>/action.yml and its typed
+		// wrapper to actions/<package> in the module, so workflows in the
+		// same module can import it
+		resourceAction: pipeline[*discover.ActionDiscoveryResult, *runner.ActionExtractionResult, *template.ActionBuildResult]{
+			label:    "action",
+			discover: c.disc.DiscoverActions,
+			found:    func(d *discover.ActionDiscoveryResult) int { return len(d.Actions) },
+			extract:  c.run.ExtractActions,
+			failure:  func(e *runner.ActionExtractionResult) string { return e.Error },
+			build:    c.builder.BuildActions,
+			files: func(r *template.ActionBuildResult) ([]BuiltFile, []string) {
+				var files []BuiltFile
+				for _, a := range r.Actions {
+					wrapperDir := filepath.Join(c.absPath, "actions", a.Wrapper.PackageName)
+					files = append(files,
+						BuiltFile{Source: a.Name, Path: filepath.Join(actionDir, a.Dir, "action.yml"), Content: a.YAML},
+						BuiltFile{Source: a.Name, Path: filepath.Join(wrapperDir, a.Wrapper.FileName), Content: a.Wrapper.Code},
+					)
+				}
+				return files, r.Errors
+			},
+		},
+	}
+}
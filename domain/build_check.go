@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lex00/wetwire-github-go/internal/differ"
+)
+
+// checkStatuses describe a file that differs from the build, by the status
+// of the committed file compared with the built one.
+var checkStatuses = map[string]string{
+	differ.StatusAdded:    "missing",
+	differ.StatusRemoved:  "orphaned",
+	differ.StatusModified: "stale",
+}
+
+// checkMarks are the text output markers of each status.
+var checkMarks = map[string]string{
+	differ.StatusAdded:    "+",
+	differ.StatusRemoved:  "-",
+	differ.StatusModified: "~",
+}
+
+// CheckBuild runs the build for the Go module at path without writing
+// anything, and compares the files it would write with the committed
+// files in the .github directory and the Go wrappers of actions. Wrapper
+// paths are relative to the .github directory too. The diffs read from committed to built:
+// a file the build would add is missing, one it would remove is orphaned
+// and one it would change is stale.
+//
+// Committed files count as generated if the build produces them or, for
+// the types being built, the manifest records them. Other files, such as
+// hand-written workflows next to generated ones, are ignored, so without a
+// manifest no file is reported as orphaned.
+func CheckBuild(path string, opts BuildOpts) ([]differ.FileDiff, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
+	}
+	built, err := BuildFiles(absPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	_, githubDir := outputDirs(absPath, opts.Output)
+	manifest, err := LoadManifest(githubDir)
+	if err != nil {
+		return nil, err
+	}
+	recorded := manifest.paths()
+
+	paths := make(map[string]bool)
+	var newFiles, newWrappers []differ.File
+	for _, f := range built {
+		rel, err := manifestPath(githubDir, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		paths[rel] = true
+		file := differ.File{Path: rel, Kind: f.Kind, Content: f.Content}
+		if isWrapperPath(rel) {
+			newWrappers = append(newWrappers, file)
+		} else {
+			newFiles = append(newFiles, file)
+		}
+	}
+
+	var oldFiles []differ.File
+	if _, err := os.Stat(githubDir); err == nil {
+		committed, err := differ.LoadFiles(githubDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range committed {
+			if !paths[f.Path] && (!recorded[f.Path] || (opts.Type != "" && f.Kind != opts.Type)) {
+				continue
+			}
+			oldFiles = append(oldFiles, f)
+		}
+	}
+
+	// Action wrappers are Go code outside the .github directory
+	var orphanWrappers []ManifestEntry
+	for _, e := range manifest.Files {
+		if isWrapperPath(e.Path) && !paths[e.Path] && (opts.Type == "" || e.Kind == opts.Type) {
+			orphanWrappers = append(orphanWrappers, e)
+		}
+	}
+	wrapperDiffs, err := diffWrappers(githubDir, newWrappers, orphanWrappers)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := differ.DiffFiles(oldFiles, newFiles)
+	if err != nil {
+		return nil, err
+	}
+	diffs = append(diffs, wrapperDiffs...)
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, nil
+}
+
+// diffWrappers compares the Go wrappers of actions in the module with the
+// built ones, and reports the recorded ones the build no longer produces
+// as orphaned. Go code has no normalized form, so a wrapper is stale when
+// its content differs at all.
+func diffWrappers(githubDir string, built []differ.File, orphans []ManifestEntry) ([]differ.FileDiff, error) {
+	var diffs []differ.FileDiff
+	for _, f := range built {
+		diff := differ.FileDiff{Path: f.Path, Kind: f.Kind}
+		content, err := os.ReadFile(filepath.Join(githubDir, filepath.FromSlash(f.Path)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			diff.Status = differ.StatusAdded
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(content, f.Content):
+			diff.Status = differ.StatusModified
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	for _, e := range orphans {
+		if _, err := os.Stat(filepath.Join(githubDir, filepath.FromSlash(e.Path))); err != nil {
+			continue
+		}
+		diffs = append(diffs, differ.FileDiff{Path: e.Path, Kind: e.Kind, Status: differ.StatusRemoved})
+	}
+	return diffs, nil
+}
+
+// addBuildCheck adds --check to the build command. It writes nothing and
+// fails with a semantic diff when the committed .github files differ from
+// what the build would write, so CI can enforce that they are generated.
+func addBuildCheck(root *cobra.Command) {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() != "build" {
+			continue
+		}
+		cmd.Flags().Bool("check", false, "Write nothing and fail if the committed files differ from the build")
+
+		runE := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			check, _ := cmd.Flags().GetBool("check")
+			if !check {
+				return runE(cmd, args)
+			}
+			// A failed check is not a usage error
+			cmd.SilenceUsage = true
+
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+			buildType, _ := cmd.Flags().GetString("type")
+			output, _ := cmd.Flags().GetString("output")
+			format, _ := cmd.Flags().GetString("format")
+
+			diffs, err := CheckBuild(path, BuildOpts{Type: buildType, Output: output})
+			if err != nil {
+				return fmt.Errorf("build failed: %w", err)
+			}
+			if format == "json" {
+				if err := writeCheckJSON(cmd, diffs); err != nil {
+					return err
+				}
+			} else {
+				writeCheckText(cmd, diffs)
+			}
+
+			if len(diffs) > 0 {
+				return fmt.Errorf("%d generated file(s) out of date", len(diffs))
+			}
+			return nil
+		}
+	}
+}
+
+// writeCheckText prints the files that differ from the build and their
+// changes.
+func writeCheckText(cmd *cobra.Command, diffs []differ.FileDiff) {
+	out := cmd.OutOrStdout()
+	if len(diffs) == 0 {
+		fmt.Fprintln(out, "Generated files are up to date.")
+		return
+	}
+
+	fmt.Fprintln(out, "Generated files are out of date:")
+	fmt.Fprintln(out)
+	var outdated, orphaned bool
+	for _, d := range diffs {
+		fmt.Fprintf(out, "%s %s (%s)\n", checkMarks[d.Status], d.Path, checkStatuses[d.Status])
+		for _, c := range d.Changes {
+			fmt.Fprintf(out, "    %s\n", c)
+		}
+		if d.Status == differ.StatusRemoved {
+			orphaned = true
+		} else {
+			outdated = true
+		}
+	}
+	fmt.Fprintln(out)
+	if outdated {
+		fmt.Fprintln(out, "Run wetwire-github build to regenerate the missing and stale files.")
+	}
+	if orphaned {
+		// Pruning skips orphans edited since they were generated
+		fmt.Fprintln(out, "Run wetwire-github build --prune to remove the orphaned files; delete any edited since they were generated by hand.")
+	}
+}
+
+// writeCheckJSON prints the check result as JSON.
+func writeCheckJSON(cmd *cobra.Command, diffs []differ.FileDiff) error {
+	type checkFile struct {
+		differ.FileDiff
+		State string `json:"state"`
+	}
+	files := make([]checkFile, len(diffs))
+	for i, d := range diffs {
+		files[i] = checkFile{FileDiff: d, State: checkStatuses[d.Status]}
+	}
+
+	data, err := json.MarshalIndent(struct {
+		Success bool        `json:"success"`
+		Files   []checkFile `json:"files,omitempty"`
+	}{len(diffs) == 0, files}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// checkStates returns the state and path of each file CheckBuild reports.
+func checkStates(t *testing.T, dir string, opts BuildOpts) []string {
+	t.Helper()
+	diffs, err := CheckBuild(dir, opts)
+	if err != nil {
+		t.Fatalf("CheckBuild() error = %v", err)
+	}
+	var states []string
+	for _, d := range diffs {
+		states = append(states, checkStatuses[d.Status]+" "+d.Path)
+	}
+	return states
+}
+
+func TestCheckBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{"workflows.go": testWorkflowSource})
+	workflows := filepath.Join(dir, ".github", "workflows")
+
+	if got, want := checkStates(t, dir, BuildOpts{}), []string{"missing workflows/tests.yml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("before build = %v, want %v", got, want)
+	}
+	if _, err := os.Stat(workflows); !os.IsNotExist(err) {
+		t.Fatal("CheckBuild() should not write anything")
+	}
+
+	// Commit the build, reformatted, with hand-written files alongside
+	files, err := BuildFiles(dir, BuildOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(workflows, 0755)
+	os.WriteFile(files[0].Path, append([]byte("# Reformatted\n"), files[0].Content...), 0644)
+	os.MkdirAll(filepath.Join(dir, ".github", "ISSUE_TEMPLATE"), 0755)
+	os.WriteFile(filepath.Join(dir, ".github", "ISSUE_TEMPLATE", "bug.yml"), []byte("name: Bug\n"), 0644)
+	os.WriteFile(filepath.Join(dir, ".github", "labeler.yml"), []byte("docs: ['**/*.md']\n"), 0644)
+
+	if got := checkStates(t, dir, BuildOpts{}); got != nil {
+		t.Errorf("after build = %v, want no differences", got)
+	}
+
+	// Hand edit the workflow and add a hand-written one next to it
+	edited := strings.Replace(string(files[0].Content), "go test ./...", "go test -short ./...", 1)
+	os.WriteFile(files[0].Path, []byte(edited), 0644)
+	os.WriteFile(filepath.Join(workflows, "old.yml"), []byte("on: push\n"), 0644)
+
+	diffs, err := CheckBuild(dir, BuildOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range diffs {
+		got = append(got, checkStatuses[d.Status]+" "+d.Path)
+	}
+	if want := []string{"stale workflows/tests.yml"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after edits = %v, want %v", got, want)
+	}
+	if c := diffs[0].Changes; len(c) != 1 || c[0].Old != "go test -short ./..." || c[0].New != "go test ./..." {
+		t.Errorf("stale changes = %v, want the hand edit reverted", c)
+	}
+}
+
+func TestCreateRootCommand_BuildCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{"workflows.go": testWorkflowSource})
+
+	run := func(args ...string) (string, error) {
+		root := CreateRootCommand(&GitHubDomain{})
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, dir))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("build", "--check")
+	if err == nil {
+		t.Error("build --check should fail when files are missing")
+	}
+	for _, want := range []string{"+ workflows/tests.yml (missing)", "Run wetwire-github build"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := run("build"); err != nil {
+		t.Fatalf("build error = %v", err)
+	}
+	out, err = run("build", "--check")
+	if err != nil {
+		t.Errorf("build --check error = %v after build\n%s", err, out)
+	}
+	if !strings.Contains(out, "up to date") {
+		t.Errorf("output = %q, want up to date", out)
+	}
+
+	out, _ = run("build", "--check", "--format", "json")
+	if !strings.Contains(out, `"success": true`) {
+		t.Errorf("JSON output = %s", out)
+	}
+}
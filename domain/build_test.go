@@ -0,0 +1,648 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestModule creates a temporary Go module that depends on this
+// project via a replace directive and writes the given source files into it.
+func writeTestModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	// Find the project root (where go.mod is)
+	projectRoot := wd
+	for {
+		if _, err := os.Stat(filepath.Join(projectRoot, "go.mod")); err == nil {
+			break
+		}
+		parent := filepath.Dir(projectRoot)
+		if parent == projectRoot {
+			t.Skip("Could not find project root")
+		}
+		projectRoot = parent
+	}
+
+	tmpDir := t.TempDir()
+
+	goMod := fmt.Sprintf(`module testproject
+
+go 1.23
+
+require github.com/lex00/wetwire-github-go v0.0.0
+
+replace github.com/lex00/wetwire-github-go => %s
+`, projectRoot)
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return tmpDir
+}
+
+const testWorkflowSource = `package testproject
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Tests = workflow.Workflow{
+	Name: "CI",
+	On: workflow.Triggers{
+		Push: &workflow.PushTrigger{Branches: []string{"main"}},
+	},
+	Jobs: map[string]workflow.Job{"build": Build},
+}
+
+var Build = workflow.Job{
+	Name:   "build",
+	RunsOn: "ubuntu-latest",
+	Steps:  []any{workflow.Step{Run: "go test ./..."}},
+}
+`
+
+const testRepoSource = `package testproject
+
+import (
+	"github.com/lex00/wetwire-github-go/codeowners"
+	"github.com/lex00/wetwire-github-go/dependabot"
+	"github.com/lex00/wetwire-github-go/templates"
+)
+
+var Deps = dependabot.Dependabot{
+	Version: 2,
+	Updates: []dependabot.Update{
+		{
+			PackageEcosystem: "gomod",
+			Directory:        "/",
+			Schedule:         dependabot.Schedule{Interval: "weekly"},
+		},
+	},
+}
+
+var Owners = codeowners.Owners{
+	Rules: []codeowners.Rule{
+		{Pattern: "*", Owners: []string{"@org/maintainers"}},
+	},
+}
+
+var BugReport = templates.IssueTemplate{
+	Name:        "Bug Report",
+	Description: "Report a bug",
+	Body: []templates.FormElement{
+		templates.Textarea{ID: "what", Label: "What happened?"},
+	},
+}
+
+var Ideas = templates.DiscussionTemplate{
+	Title:       "Ideas",
+	Description: "Share an idea",
+	Body: []templates.FormElement{
+		templates.Textarea{ID: "idea", Label: "Your idea"},
+	},
+}
+
+var DefaultPR = templates.PRTemplate{
+	Content: "## Summary\n",
+}
+
+var FeaturePR = templates.PRTemplate{
+	Name:    "feature",
+	Content: "## Feature\n",
+}
+`
+
+func TestGitHubBuilder_Build_AllResources(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"workflows.go": testWorkflowSource,
+		"repo.go":      testRepoSource,
+	})
+
+	builder := &githubBuilder{}
+	result, err := builder.Build(&Context{}, dir, BuildOpts{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Build() failed: %s %v", result.Message, result.Errors)
+	}
+
+	githubDir := filepath.Join(dir, ".github")
+	want := map[string]string{
+		"workflows/tests.yml":              "go test ./...",
+		"dependabot.yml":                   "gomod",
+		"CODEOWNERS":                       "@org/maintainers",
+		"ISSUE_TEMPLATE/bug-report.yml":    "Bug Report",
+		"DISCUSSION_TEMPLATE/ideas.yml":    "Share an idea",
+		"PULL_REQUEST_TEMPLATE.md":         "## Summary",
+		"PULL_REQUEST_TEMPLATE/feature.md": "## Feature",
+	}
+	for rel, contains := range want {
+		content, err := os.ReadFile(filepath.Join(githubDir, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Errorf("expected %s to be written: %v", rel, err)
+			continue
+		}
+		if !strings.Contains(string(content), contains) {
+			t.Errorf("%s does not contain %q:\n%s", rel, contains, content)
+		}
+	}
+}
+
+func TestGitHubBuilder_Build_TypeFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"workflows.go": testWorkflowSource,
+		"repo.go":      testRepoSource,
+	})
+
+	builder := &githubBuilder{}
+	result, err := builder.Build(&Context{}, dir, BuildOpts{Type: "dependabot"})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Build() failed: %s %v", result.Message, result.Errors)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".github", "dependabot.yml")); err != nil {
+		t.Errorf("dependabot.yml not written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".github", "workflows")); !os.IsNotExist(err) {
+		t.Errorf("workflows should not be built with --type dependabot")
+	}
+}
+
+func TestBuildFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"workflows.go": testWorkflowSource,
+		"repo.go":      testRepoSource,
+	})
+
+	files, err := BuildFiles(dir, BuildOpts{Type: "workflow"})
+	if err != nil {
+		t.Fatalf("BuildFiles() error = %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("BuildFiles() returned %d files, want 1", len(files))
+	}
+	f := files[0]
+	if f.Kind != "workflow" || f.Source != "Tests" || f.Path != filepath.Join(dir, ".github", "workflows", "tests.yml") {
+		t.Errorf("file = %s %s %s", f.Kind, f.Source, f.Path)
+	}
+	if !strings.Contains(string(f.Content), "go test ./...") {
+		t.Errorf("content = %s", f.Content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".github")); !os.IsNotExist(err) {
+		t.Error("BuildFiles() should not write anything")
+	}
+}
+
+func TestBuildFiles_SingleConfig(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"repo.go": testRepoSource,
+		"more.go": `package testproject
+
+import (
+	"github.com/lex00/wetwire-github-go/codeowners"
+	"github.com/lex00/wetwire-github-go/dependabot"
+)
+
+var MoreDeps = dependabot.Dependabot{Version: 2}
+
+var MoreOwners = codeowners.Owners{}
+`,
+	})
+
+	_, err := BuildFiles(dir, BuildOpts{})
+	if err == nil {
+		t.Fatal("BuildFiles() should reject a second dependabot and CODEOWNERS config")
+	}
+	for _, want := range []string{
+		"multiple dependabot configs found (MoreDeps, Deps)",
+		"multiple codeowners configs found (MoreOwners, Owners)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want %q", err, want)
+		}
+	}
+}
+
+func TestBuildFiles_CustomOutput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"workflows.go": testWorkflowSource,
+		"repo.go":      testRepoSource,
+	})
+
+	files, err := BuildFiles(dir, BuildOpts{Output: filepath.Join("site", ".github", "workflows")})
+	if err != nil {
+		t.Fatalf("BuildFiles() error = %v", err)
+	}
+	paths := make(map[string]bool)
+	for _, f := range files {
+		paths[f.Path] = true
+	}
+	githubDir := filepath.Join(dir, "site", ".github")
+	for _, want := range []string{
+		filepath.Join(githubDir, "workflows", "tests.yml"),
+		filepath.Join(githubDir, "dependabot.yml"),
+		filepath.Join(githubDir, "CODEOWNERS"),
+	} {
+		if !paths[want] {
+			t.Errorf("BuildFiles() paths = %v, want %s", paths, want)
+		}
+	}
+
+	// Other resources have no place next to a plain output directory
+	_, err = BuildFiles(dir, BuildOpts{Output: "out"})
+	if err == nil || !strings.Contains(err.Error(), "only workflows can be built to it") {
+		t.Errorf("BuildFiles(-o out) error = %v, want the other resources rejected", err)
+	}
+
+	files, err = BuildFiles(dir, BuildOpts{Output: "out", Type: "workflow"})
+	if err != nil {
+		t.Fatalf("BuildFiles(-o out --type workflow) error = %v", err)
+	}
+	if len(files) != 1 || files[0].Path != filepath.Join(dir, "out", "tests.yml") {
+		t.Errorf("BuildFiles(-o out --type workflow) = %v", files)
+	}
+}
+
+func TestGitHubBuilder_Build_UnknownType(t *testing.T) {
+	builder := &githubBuilder{}
+	_, err := builder.Build(&Context{}, t.TempDir(), BuildOpts{Type: "bogus"})
+	if err == nil {
+		t.Fatal("Build() with unknown type should return an error")
+	}
+	if !strings.Contains(err.Error(), "unknown resource type") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGitHubBuilder_Build_NoResources(t *testing.T) {
+	builder := &githubBuilder{}
+	result, err := builder.Build(&Context{}, t.TempDir(), BuildOpts{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if result.Success {
+		t.Error("Build() with no declarations should fail")
+	}
+}
+
+func TestOutputDirs(t *testing.T) {
+	tests := []struct {
+		output       string
+		wantWorkflow string
+		wantGitHub   string
+	}{
+		{"", "/repo/.github/workflows", "/repo/.github"},
+		{"out/.github/workflows/", "/repo/out/.github/workflows", "/repo/out/.github"},
+		{"/abs/.github/workflows", "/abs/.github/workflows", "/abs/.github"},
+		{"out", "/repo/out", "/repo/out"},
+		{"out/workflows", "/repo/out/workflows", "/repo/out/workflows"},
+	}
+
+	for _, tt := range tests {
+		wf, gh := outputDirs("/repo", tt.output)
+		if wf != tt.wantWorkflow || gh != tt.wantGitHub {
+			t.Errorf("outputDirs(%q) = (%q, %q), want (%q, %q)", tt.output, wf, gh, tt.wantWorkflow, tt.wantGitHub)
+		}
+	}
+}
+
+const testReusableSource = `package testproject
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var SharedDeploy = workflow.Workflow{
+	Name: "Shared Deploy",
+	On: workflow.Triggers{
+		WorkflowCall: &workflow.WorkflowCallTrigger{
+			Inputs: map[string]workflow.WorkflowInput{
+				"environment": {Type: "string", Required: true},
+			},
+			Secrets: map[string]workflow.WorkflowSecret{
+				"token": {Required: true},
+			},
+		},
+	},
+	Jobs: map[string]workflow.Job{"deploy": DeployStep},
+}
+
+var DeployStep = workflow.Job{
+	Name:   "deploy",
+	RunsOn: "ubuntu-latest",
+	Steps:  []any{workflow.Step{Run: "./deploy.sh"}},
+}
+
+var Release = workflow.Workflow{
+	Name: "Release",
+	On:   workflow.Triggers{Push: &workflow.PushTrigger{Tags: []string{"v*"}}},
+	Jobs: map[string]workflow.Job{"call": CallDeploy},
+}
+
+var CallDeploy = workflow.Job{
+	Name:    "call",
+	Uses:    SharedDeploy,
+	With:    map[string]any{%s},
+	Secrets: workflow.SecretsInherit,
+}
+`
+
+func TestGitHubBuilder_Build_ReusableWorkflow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	t.Run("valid call", func(t *testing.T) {
+		dir := writeTestModule(t, map[string]string{
+			"workflows.go": fmt.Sprintf(testReusableSource, `"environment": "prod"`),
+		})
+
+		result, err := (&githubBuilder{}).Build(&Context{}, dir, BuildOpts{})
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		if !result.Success {
+			t.Fatalf("Build() failed: %s %v", result.Message, result.Errors)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, ".github", "workflows", "release.yml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{
+			"uses: ./.github/workflows/shared-deploy.yml",
+			"environment: prod",
+			"secrets: inherit",
+		} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("release.yml missing %q:\n%s", want, content)
+			}
+		}
+	})
+
+	t.Run("custom output", func(t *testing.T) {
+		dir := writeTestModule(t, map[string]string{
+			"workflows.go": fmt.Sprintf(testReusableSource, `"environment": "prod"`),
+		})
+
+		files, err := BuildFiles(dir, BuildOpts{Output: "out", Type: "workflow"})
+		if err != nil {
+			t.Fatalf("BuildFiles() error = %v", err)
+		}
+		var release []byte
+		for _, f := range files {
+			if f.Source == "Release" {
+				release = f.Content
+			}
+		}
+		if !strings.Contains(string(release), "uses: ./out/shared-deploy.yml") {
+			t.Errorf("release.yml should call the workflow in the output directory:\n%s", release)
+		}
+	})
+
+	t.Run("missing required input", func(t *testing.T) {
+		dir := writeTestModule(t, map[string]string{
+			"workflows.go": fmt.Sprintf(testReusableSource, ""),
+		})
+
+		result, err := (&githubBuilder{}).Build(&Context{}, dir, BuildOpts{})
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		if result.Success {
+			t.Fatal("Build() should fail when a required input is missing")
+		}
+		found := false
+		for _, e := range result.Errors {
+			if strings.Contains(e.Message, `missing required input "environment"`) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected missing input error, got %v", result.Errors)
+		}
+	})
+}
+
+func TestGitHubBuilder_Build_ActionOutputs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"workflows.go": `package testproject
+
+import (
+	"github.com/lex00/wetwire-github-go/actions/checkout"
+	"github.com/lex00/wetwire-github-go/actions/docker_build_push"
+	"github.com/lex00/wetwire-github-go/actions/docker_metadata"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+var Image = workflow.Workflow{
+	Name: "Image",
+	On:   workflow.Triggers{Push: &workflow.PushTrigger{}},
+	Jobs: map[string]workflow.Job{"build": BuildImage},
+}
+
+var meta = docker_metadata.DockerMetadata{Images: "ghcr.io/org/app"}
+
+var BuildImage = workflow.Job{
+	Name:   "build",
+	RunsOn: "ubuntu-latest",
+	If:     workflow.Branch("main"),
+	Env:    map[string]any{"TOKEN": workflow.Secrets.Get("TOKEN")},
+	Steps: []any{
+		checkout.Checkout{},
+		workflow.ToStepWithID(meta, "meta"),
+		docker_build_push.DockerBuildPush{
+			Push: true,
+			Tags: meta.Outputs("meta").Tags().String(),
+		},
+	},
+}
+`,
+	})
+
+	result, err := (&githubBuilder{}).Build(&Context{}, dir, BuildOpts{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Build() failed: %s %v", result.Message, result.Errors)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".github", "workflows", "image.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"uses: actions/checkout@v4",
+		"id: meta",
+		"uses: docker/metadata-action@v5",
+		"tags: ${{ steps.meta.outputs.tags }}",
+		"TOKEN: ${{ secrets.TOKEN }}",
+		"if: github.ref == 'refs/heads/main'",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("image.yml missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestGitHubBuilder_Build_JobOutputs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"workflows.go": `package testproject
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Pipeline = workflow.Workflow{
+	Name: "CI",
+	On:   workflow.Triggers{Push: &workflow.PushTrigger{}},
+	Jobs: map[string]workflow.Job{"build": Build, "deploy": Deploy},
+}
+
+var Build = workflow.Job{
+	Name:    "build",
+	RunsOn:  "ubuntu-latest",
+	Outputs: map[string]any{"version": workflow.Steps.Get("v", "version")},
+	Steps:   []any{workflow.Step{ID: "v", Run: "echo version=1 >> $GITHUB_OUTPUT"}},
+}
+
+var Deploy = workflow.Job{
+	Name:   "deploy",
+	RunsOn: "ubuntu-latest",
+	Needs:  []any{Build},
+	If:     Build.Output("version").Raw() + " != ''",
+	Steps:  []any{workflow.Step{Run: "echo " + Build.Output("version").String()}},
+}
+`,
+	})
+
+	result, err := (&githubBuilder{}).Build(&Context{}, dir, BuildOpts{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Build() failed: %s %v", result.Message, result.Errors)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".github", "workflows", "pipeline.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"if: needs.build.outputs.version != ''",
+		"run: echo ${{ needs.build.outputs.version }}",
+		"- build",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("pipeline.yml missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestGitHubBuilder_Build_Action(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"actions.go": `package testproject
+
+import (
+	"github.com/lex00/wetwire-github-go/action"
+	"github.com/lex00/wetwire-github-go/actions/checkout"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+var SetupTools = action.Action{
+	Name:        "Setup tools",
+	Description: "Check out and prepare the workspace",
+	Inputs: map[string]action.Input{
+		"target": {Description: "Build target", Required: true},
+	},
+	Outputs: map[string]action.Output{
+		"path": {Description: "Artifact path", Value: workflow.Steps.Get("build", "path")},
+	},
+	Runs: action.Composite(
+		checkout.Checkout{FetchDepth: 1},
+		workflow.Step{ID: "build", Run: "make ${{ inputs.target }}", Shell: "bash"},
+	),
+}
+`,
+	})
+
+	result, err := (&githubBuilder{}).Build(&Context{}, dir, BuildOpts{Type: "action"})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Build() failed: %s %v", result.Message, result.Errors)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".github", "actions", "setup-tools", "action.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"using: composite",
+		"uses: actions/checkout@v4",
+		"fetch-depth: 1",
+		"value: ${{ steps.build.outputs.path }}",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("action.yml missing %q:\n%s", want, content)
+		}
+	}
+
+	wrapper, err := os.ReadFile(filepath.Join(dir, "actions", "setup_tools", "setup_tools.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(wrapper), `"./.github/actions/setup-tools"`) {
+		t.Errorf("wrapper does not reference the local action:\n%s", wrapper)
+	}
+}
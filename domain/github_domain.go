@@ -12,8 +12,6 @@ import (
 	"github.com/lex00/wetwire-github-go/internal/differ"
 	"github.com/lex00/wetwire-github-go/internal/discover"
 	"github.com/lex00/wetwire-github-go/internal/lint"
-	"github.com/lex00/wetwire-github-go/internal/runner"
-	"github.com/lex00/wetwire-github-go/internal/template"
 	"github.com/lex00/wetwire-github-go/internal/validation"
 	"github.com/spf13/cobra"
 )
@@ -99,157 +97,252 @@ func (d *GitHubDomain) Differ() coredomain.Differ {
 
 // CreateRootCommand creates the root command using the domain interface.
 func CreateRootCommand(d coredomain.Domain) *cobra.Command {
-	return coredomain.Run(d)
+	root := coredomain.Run(d)
+	addReportFormats(root)
+	addFixPreview(root)
+	addBuildCheck(root)
+	addBuildPrune(root)
+	return root
 }
 
 // githubBuilder implements domain.Builder
 type githubBuilder struct{}
 
 func (b *githubBuilder) Build(ctx *Context, path string, opts BuildOpts) (*Result, error) {
+	return build(path, opts, false)
+}
+
+// build writes the generated files for the Go module at path and records
+// them in the manifest. With prune, it also removes the files it generated
+// before but no longer does.
+func build(path string, opts BuildOpts, prune bool) (*Result, error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return nil, fmt.Errorf("resolve path: %w", err)
 	}
 
-	// Default output directory for GitHub workflows
-	outputDir := opts.Output
-	if outputDir == "" {
-		outputDir = ".github/workflows"
-	}
-
-	// Discover workflows and jobs
-	disc := discover.NewDiscoverer()
-	discovered, err := disc.Discover(absPath)
+	// Discover, extract and build every resource type
+	out, err := collectOutputs(absPath, opts)
 	if err != nil {
-		return nil, fmt.Errorf("discovery failed: %w", err)
+		return nil, err
 	}
 
-	if len(discovered.Workflows) == 0 {
-		return NewErrorResult("no workflows found", Error{
+	if out.Found == 0 {
+		return NewErrorResult("no resources found", Error{
 			Path:    absPath,
-			Message: "no workflows found",
+			Message: "no workflows, dependabot, codeowners, template or action declarations found",
 		}), nil
 	}
 
-	// Extract values using runner
-	run := runner.NewRunner()
-	extracted, err := run.ExtractValues(absPath, discovered)
-	if err != nil {
-		return nil, fmt.Errorf("extraction failed: %w", err)
-	}
-
-	if extracted.Error != "" {
-		return NewErrorResult("extraction failed", Error{
-			Path:    absPath,
-			Message: extracted.Error,
-		}), nil
+	if len(out.Errors) > 0 {
+		return NewErrorResultMultiple("template build failed", out.Errors), nil
 	}
 
-	// Build templates
-	builder := template.NewBuilder()
-	built, err := builder.Build(discovered, extracted)
-	if err != nil {
-		return nil, fmt.Errorf("template build failed: %w", err)
-	}
-
-	if len(built.Errors) > 0 {
-		errs := make([]Error, len(built.Errors))
-		for i, e := range built.Errors {
-			errs[i] = Error{
-				Path:    absPath,
-				Message: e,
+	// Write generated files
+	var files []string
+	for _, f := range out.Files {
+		if !opts.DryRun {
+			if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+				return nil, fmt.Errorf("creating output directory: %w", err)
+			}
+			if err := os.WriteFile(f.Path, f.Content, 0644); err != nil {
+				return nil, fmt.Errorf("writing %s: %w", f.Path, err)
 			}
 		}
-		return NewErrorResultMultiple("template build failed", errs), nil
+		files = append(files, f.Path)
 	}
 
-	// Resolve output directory
-	absOutputDir := outputDir
-	if !filepath.IsAbs(outputDir) {
-		absOutputDir = filepath.Join(absPath, outputDir)
-	}
-
-	// Create output directory if needed
+	_, githubDir := outputDirs(absPath, opts.Output)
+	message := fmt.Sprintf("Built %d file(s) to %s", len(files), githubDir)
 	if !opts.DryRun {
-		if err := os.MkdirAll(absOutputDir, 0755); err != nil {
-			return nil, fmt.Errorf("creating output directory: %w", err)
+		kinds := resourceTypes
+		if opts.Type != "" {
+			kinds = []string{opts.Type}
 		}
-	}
-
-	// Write workflow files
-	var files []string
-	for _, wf := range built.Workflows {
-		filename := toFilename(wf.Name) + ".yml"
-		filePath := filepath.Join(absOutputDir, filename)
-
-		if !opts.DryRun {
-			if err := os.WriteFile(filePath, wf.YAML, 0644); err != nil {
-				return nil, fmt.Errorf("writing %s: %w", filename, err)
-			}
+		orphans, err := updateManifest(githubDir, out.Files, kinds, prune)
+		if err != nil {
+			return nil, err
+		}
+		if s := orphans.String(); s != "" {
+			message += "; " + s
 		}
-		files = append(files, filePath)
 	}
-
-	return NewResult(fmt.Sprintf("Built %d workflow(s) to %s", len(files), absOutputDir)), nil
+	return NewResultWithData(message, files), nil
 }
 
 // githubLinter implements domain.Linter
 type githubLinter struct{}
 
+// ruleSemantic is the code of the issue reporting that the semantic lint
+// rules could not run.
+const ruleSemantic = "semantic"
+
 func (l *githubLinter) Lint(ctx *Context, path string, opts LintOpts) (*Result, error) {
-	absPath, err := filepath.Abs(path)
+	_, issues, err := runLint(path, opts)
 	if err != nil {
-		return nil, fmt.Errorf("resolve path: %w", err)
+		return nil, err
 	}
 
-	// Check if path exists
-	info, err := os.Stat(absPath)
+	if len(issues) == 0 {
+		return NewResult("No lint issues found"), nil
+	}
+
+	// Convert to domain errors
+	errs := make([]Error, 0, len(issues))
+	for _, issue := range issues {
+		errs = append(errs, Error{
+			Path:     issue.File,
+			Line:     issue.Line,
+			Severity: issue.Severity.String(),
+			Message:  issue.Message,
+			Code:     issue.Rule,
+		})
+	}
+
+	// If Fix mode is enabled, the remaining issues could not be fixed
+	if opts.Fix {
+		return NewErrorResultMultiple("lint issues found (no auto-fix available for these issues)", errs), nil
+	}
+
+	return NewErrorResultMultiple("lint issues found", errs), nil
+}
+
+// runLint lints a file or directory, applying auto-fixes first in Fix
+// mode, and returns the linter used and the remaining issues.
+func runLint(path string, opts LintOpts) (*lint.Linter, []lint.LintIssue, error) {
+	target, err := newLintTarget(path, opts)
 	if err != nil {
-		return nil, fmt.Errorf("accessing path: %w", err)
+		return nil, nil, err
 	}
 
-	// Build lint options from LintOpts
-	lintOpts := lint.LinterOptions{
-		DisabledRules: opts.Disable,
-		Fix:           opts.Fix,
+	// Apply auto-fixes first, then report what remains
+	if opts.Fix {
+		if _, err := target.fix(true); err != nil {
+			return nil, nil, err
+		}
 	}
 
+	// Build the workflows so model rules can check the values the Go code
+	// computes; if the package does not build, only syntax rules run
+	lintOpts := target.options
+	issues := target.loadModel(&lintOpts)
+
 	// Create linter with options (respects disabled rules)
 	lntr := lint.NewLinterWithOptions(lintOpts)
 
 	var lintResult *lint.LintResult
-	if info.IsDir() {
-		lintResult, err = lntr.LintDir(absPath)
+	if target.isDir {
+		lintResult, err = lntr.LintDir(target.path)
 	} else {
-		lintResult, err = lntr.LintFile(absPath)
+		lintResult, err = lntr.LintFile(target.path)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("linting failed: %w", err)
+		return nil, nil, fmt.Errorf("linting failed: %w", err)
 	}
 
-	if len(lintResult.Issues) == 0 {
-		return NewResult("No lint issues found"), nil
+	return lntr, append(issues, lintResult.Issues...), nil
+}
+
+// FixLint applies the auto-fixes for a file or directory and returns the
+// changes as a unified diff. With write false the files are left unchanged
+// and the diff previews the fixes.
+func FixLint(path string, opts LintOpts, write bool) (string, error) {
+	target, err := newLintTarget(path, opts)
+	if err != nil {
+		return "", err
 	}
+	return target.fix(write)
+}
 
-	// Convert to domain errors
-	errs := make([]Error, 0, len(lintResult.Issues))
-	for _, issue := range lintResult.Issues {
-		errs = append(errs, Error{
-			Path:     issue.File,
-			Line:     issue.Line,
-			Severity: issue.Severity.String(),
-			Message:  issue.Message,
-			Code:     issue.Rule,
-		})
+// lintTarget is a file or directory to lint, with the linter options from
+// the command line and the project configuration.
+type lintTarget struct {
+	path      string
+	isDir     bool
+	configDir string
+	config    *lint.Config
+	options   lint.LinterOptions
+}
+
+func newLintTarget(path string, opts LintOpts) (*lintTarget, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
 	}
 
-	// If Fix mode is enabled, add a note about auto-fixing
-	if opts.Fix {
-		return NewErrorResultMultiple("lint issues found (Fix mode requested but auto-fix not yet implemented for these issues)", errs), nil
+	// Check if path exists
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("accessing path: %w", err)
 	}
 
-	return NewErrorResultMultiple("lint issues found", errs), nil
+	// Load the project configuration from .wetwire.yaml, if any
+	configDir := absPath
+	if !info.IsDir() {
+		configDir = filepath.Dir(absPath)
+	}
+	cfg, err := lint.FindConfig(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading lint config: %w", err)
+	}
+
+	return &lintTarget{
+		path:      absPath,
+		isDir:     info.IsDir(),
+		configDir: configDir,
+		config:    cfg,
+		options: lint.LinterOptions{
+			DisabledRules: opts.Disable,
+			Fix:           opts.Fix,
+			Config:        cfg,
+		},
+	}, nil
+}
+
+// loadModel sets the model checked by the semantic rules in opts, if they
+// are enabled. If the package does not build it returns an issue saying
+// the rules were skipped.
+func (t *lintTarget) loadModel(opts *lint.LinterOptions) []lint.LintIssue {
+	if !t.config.SemanticEnabled() {
+		return nil
+	}
+	model, err := lint.LoadModel(t.configDir)
+	if err != nil {
+		return []lint.LintIssue{{
+			File:     t.configDir,
+			Severity: lint.SeverityWarning,
+			Message:  fmt.Sprintf("semantic rules skipped: %v", err),
+			Rule:     ruleSemantic,
+		}}
+	}
+	opts.Model = model
+	return nil
+}
+
+// fix applies the auto-fixes and returns the changes as a unified diff.
+// The model lets fixes such as WAG017's use what the build infers.
+func (t *lintTarget) fix(write bool) (string, error) {
+	opts := t.options
+	opts.DryRun = !write
+	t.loadModel(&opts)
+	fixer := lint.NewLinterWithOptions(opts)
+
+	var diff string
+	if t.isDir {
+		result, err := fixer.FixDir(t.path)
+		if err != nil {
+			return "", fmt.Errorf("fixing failed: %w", err)
+		}
+		diff = result.Diff
+	} else {
+		result, err := fixer.FixFile(t.path)
+		if err != nil {
+			return "", fmt.Errorf("fixing failed: %w", err)
+		}
+		diff = result.Diff
+	}
+	return diff, nil
 }
 
 // githubInitializer implements domain.Initializer
@@ -583,19 +676,6 @@ func (g *githubGrapher) Graph(ctx *Context, path string, opts GraphOpts) (*Resul
 
 // Helper functions
 
-// toFilename converts a workflow name to a valid filename.
-// "CI" -> "ci", "MyWorkflow" -> "my-workflow"
-func toFilename(name string) string {
-	var result strings.Builder
-	for i, r := range name {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteRune('-')
-		}
-		result.WriteRune(r)
-	}
-	return strings.ToLower(result.String())
-}
-
 // generateDOT generates DOT format output.
 func generateDOT(graph *discover.DependencyGraph) string {
 	var sb strings.Builder
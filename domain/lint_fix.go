@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// addFixPreview shows the changes of the lint command's auto-fixes as a
+// unified diff. With --diff the fixes are only previewed; with --fix they
+// are written and the diff is printed before the remaining issues, in
+// text output.
+func addFixPreview(root *cobra.Command) {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() != "lint" {
+			continue
+		}
+		cmd.Flags().Bool("diff", false, "Show the auto-fixes as a unified diff without applying them")
+
+		runE := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			preview, _ := cmd.Flags().GetBool("diff")
+			fix, _ := cmd.Flags().GetBool("fix")
+			format, _ := cmd.Flags().GetString("format")
+			if !preview && !(fix && format == "text") {
+				return runE(cmd, args)
+			}
+
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+			disable, _ := cmd.Flags().GetStringSlice("disable")
+			diff, err := FixLint(path, LintOpts{Disable: disable}, !preview)
+			if err != nil {
+				return fmt.Errorf("lint failed: %w", err)
+			}
+			fmt.Fprint(cmd.OutOrStdout(), diff)
+			if preview {
+				return nil
+			}
+
+			// The fixes are applied; report the issues that remain
+			if err := cmd.Flags().Set("fix", "false"); err != nil {
+				return err
+			}
+			return runE(cmd, args)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/permissions"
+)
+
+const timeoutCode = `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+// Build compiles the code.
+var Build = workflow.Job{
+	RunsOn: "ubuntu-latest", // hosted
+}
+`
+
+func TestCreateRootCommand_LintFixDiff(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "jobs.go")
+	if err := os.WriteFile(path, []byte(timeoutCode), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		root := CreateRootCommand(&GitHubDomain{})
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, "--disable", "WAG008", tmpDir))
+		_ = root.Execute()
+		return out.String()
+	}
+
+	const added = "+\tTimeoutMinutes: 30,"
+	if out := run("lint", "--diff"); !strings.Contains(out, added) {
+		t.Errorf("--diff output missing %q:\n%s", added, out)
+	}
+	if content, _ := os.ReadFile(path); string(content) != timeoutCode {
+		t.Error("--diff should not modify the file")
+	}
+
+	if out := run("lint", "--fix"); !strings.Contains(out, added) {
+		t.Errorf("--fix output missing %q:\n%s", added, out)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "RunsOn:         \"ubuntu-latest\", // hosted\n\tTimeoutMinutes: 30,") {
+		t.Errorf("--fix wrote:\n%s", content)
+	}
+}
+
+const permissionsCode = `package ci
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var CI = workflow.Workflow{
+	Name: "CI",
+	On:   workflow.Triggers{Push: &workflow.PushTrigger{}},
+	Jobs: map[string]workflow.Job{"build": Build, "test": Test},
+}
+
+var Build = workflow.Job{
+	RunsOn:         "ubuntu-latest",
+	TimeoutMinutes: 10,
+	Steps:          []any{workflow.Step{Uses: "actions/checkout@v4"}},
+}
+
+var Test = workflow.Job{
+	RunsOn:         "ubuntu-latest",
+	TimeoutMinutes: 10,
+	Steps:          []any{workflow.Step{Run: "make test"}},
+}
+`
+
+func TestCreateRootCommand_LintFixPermissions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	dir := writeTestModule(t, map[string]string{"ci.go": permissionsCode})
+
+	root := CreateRootCommand(&GitHubDomain{})
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"lint", "--fix", dir})
+	_ = root.Execute()
+
+	content, _ := os.ReadFile(filepath.Join(dir, "ci.go"))
+	if !strings.Contains(string(content), "Permissions: &workflow.Permissions{},") {
+		t.Fatalf("--fix wrote:\n%s", content)
+	}
+
+	reports, err := permissions.Load(dir)
+	if err != nil {
+		t.Fatalf("permissions.Load() error = %v", err)
+	}
+	for _, r := range reports {
+		if !r.OK() {
+			t.Errorf("%s/%s after lint --fix: over %v, under %v", r.Workflow, r.Job, r.Over, r.Under)
+		}
+	}
+}
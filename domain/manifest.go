@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	coredomain "github.com/lex00/wetwire-core-go/domain"
+)
+
+// manifestName is the name of the manifest file in the .github directory.
+const manifestName = ".wetwire-manifest.json"
+
+// manifestVersion is the version of the manifest format.
+const manifestVersion = 1
+
+// Manifest records the files a build generated in the .github directory, so
+// later builds can tell them apart from hand-written files.
+type Manifest struct {
+	// Version is the manifest format version
+	Version int `json:"version"`
+
+	// Files are the generated files, sorted by path
+	Files []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry is a single generated file.
+type ManifestEntry struct {
+	// Path is the file path relative to the .github directory, with forward
+	// slashes. Action wrappers, which are Go code in the module, start with ../
+	Path string `json:"path"`
+
+	// Kind is the resource type that produced the file
+	Kind string `json:"kind"`
+
+	// Source is the Go variable name the file was built from
+	Source string `json:"source"`
+
+	// SHA256 is the hex digest of the generated content
+	SHA256 string `json:"sha256"`
+}
+
+// Orphans are the files a build no longer generates, by what happened to them.
+type Orphans struct {
+	// Removed were deleted by --prune
+	Removed []string
+
+	// Kept were left in place because --prune was not given
+	Kept []string
+
+	// Modified were left in place because they changed since they were generated
+	Modified []string
+}
+
+// LoadManifest reads the manifest from githubDir. A missing manifest is
+// returned as an empty one.
+func LoadManifest(githubDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(githubDir, manifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Manifest{Version: manifestVersion}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", manifestName, err)
+	}
+	if m.Version > manifestVersion {
+		return nil, fmt.Errorf("%s has version %d, this wetwire-github supports up to %d", manifestName, m.Version, manifestVersion)
+	}
+	return &m, nil
+}
+
+// write saves the manifest to githubDir.
+func (m *Manifest) write(githubDir string) error {
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(githubDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(githubDir, manifestName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
+}
+
+// paths returns the set of file paths the manifest records.
+func (m *Manifest) paths() map[string]bool {
+	paths := make(map[string]bool, len(m.Files))
+	for _, e := range m.Files {
+		paths[e.Path] = true
+	}
+	return paths
+}
+
+// manifestPath returns the manifest path of a generated file.
+func manifestPath(githubDir, path string) (string, error) {
+	rel, err := filepath.Rel(githubDir, path)
+	if err != nil {
+		return "", fmt.Errorf("recording %s: %w", path, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// isWrapperPath reports whether a manifest path is outside the .github
+// directory, as the Go wrappers of actions are.
+func isWrapperPath(path string) bool {
+	return strings.HasPrefix(path, "../")
+}
+
+// contentHash returns the hex SHA-256 digest of content.
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// updateManifest records the files written by a build of the given kinds in
+// the manifest in githubDir, and returns the files it recorded before that
+// the build no longer generates.
+//
+// Orphans stay in the manifest until they are deleted, so a later build can
+// still prune them. With prune, orphans whose content still matches the
+// recorded hash are deleted; edited ones are left in place. Files the
+// manifest does not record are never touched, and entries of kinds that
+// were not built are kept as they are.
+func updateManifest(githubDir string, files []BuiltFile, kinds []string, prune bool) (*Orphans, error) {
+	old, err := LoadManifest(githubDir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Version: manifestVersion}
+	built := make(map[string]bool)
+	for _, f := range files {
+		rel, err := manifestPath(githubDir, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		built[rel] = true
+		m.Files = append(m.Files, ManifestEntry{Path: rel, Kind: f.Kind, Source: f.Source, SHA256: contentHash(f.Content)})
+	}
+
+	builtKinds := make(map[string]bool)
+	for _, k := range kinds {
+		builtKinds[k] = true
+	}
+
+	orphans := &Orphans{}
+	for _, e := range old.Files {
+		if built[e.Path] {
+			continue
+		}
+		if !builtKinds[e.Kind] {
+			m.Files = append(m.Files, e)
+			continue
+		}
+
+		path := filepath.Join(githubDir, filepath.FromSlash(e.Path))
+		content, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		switch {
+		case contentHash(content) != e.SHA256:
+			orphans.Modified = append(orphans.Modified, e.Path)
+		case prune:
+			if err := os.Remove(path); err != nil {
+				return nil, fmt.Errorf("removing %s: %w", path, err)
+			}
+			orphans.Removed = append(orphans.Removed, e.Path)
+			stop := githubDir
+			if isWrapperPath(e.Path) {
+				stop = filepath.Dir(githubDir)
+			}
+			removeEmptyDirs(filepath.Dir(path), stop)
+			continue
+		default:
+			orphans.Kept = append(orphans.Kept, e.Path)
+		}
+		m.Files = append(m.Files, e)
+	}
+
+	if err := m.write(githubDir); err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
+
+// removeEmptyDirs removes dir and its parents up to, but not including,
+// stop while they are empty.
+func removeEmptyDirs(dir, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// String describes the orphans for the build result message.
+func (o *Orphans) String() string {
+	var parts []string
+	if n := len(o.Removed); n > 0 {
+		parts = append(parts, fmt.Sprintf("removed %d orphaned file(s): %s", n, strings.Join(o.Removed, ", ")))
+	}
+	if n := len(o.Kept); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d orphaned file(s) no longer generated, run build --prune to remove them: %s", n, strings.Join(o.Kept, ", ")))
+	}
+	if n := len(o.Modified); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d orphaned file(s) edited since they were generated, not removed: %s", n, strings.Join(o.Modified, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// addBuildPrune adds --prune to the build command. Every build records the
+// files it writes in the manifest; with --prune it also deletes the
+// recorded files it no longer generates, such as the YAML of a renamed
+// workflow.
+func addBuildPrune(root *cobra.Command) {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() != "build" {
+			continue
+		}
+		cmd.Flags().Bool("prune", false, "Remove previously generated files the build no longer produces")
+		cmd.MarkFlagsMutuallyExclusive("prune", "dry-run")
+		if cmd.Flags().Lookup("check") != nil {
+			cmd.MarkFlagsMutuallyExclusive("prune", "check")
+		}
+
+		runE := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			prune, _ := cmd.Flags().GetBool("prune")
+			if !prune {
+				return runE(cmd, args)
+			}
+
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+			buildType, _ := cmd.Flags().GetString("type")
+			output, _ := cmd.Flags().GetString("output")
+			format, _ := cmd.Flags().GetString("format")
+
+			result, err := build(path, BuildOpts{Type: buildType, Output: output}, true)
+			if err != nil {
+				return err
+			}
+			text, err := coredomain.FormatResult(result, format)
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Fprint(cmd.OutOrStdout(), text)
+			if !result.Success {
+				return fmt.Errorf("operation failed")
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package domain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// manifestPaths returns the path and source of each manifest entry.
+func manifestPaths(t *testing.T, githubDir string) []string {
+	t.Helper()
+	m, err := LoadManifest(githubDir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	var paths []string
+	for _, e := range m.Files {
+		paths = append(paths, e.Path+" "+e.Source)
+	}
+	return paths
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if m.Version != manifestVersion || len(m.Files) != 0 {
+		t.Errorf("missing manifest = %+v, want an empty one", m)
+	}
+
+	os.WriteFile(filepath.Join(dir, manifestName), []byte(`{"version": 99, "files": []}`), 0644)
+	if _, err := LoadManifest(dir); err == nil {
+		t.Error("LoadManifest() should reject a newer manifest version")
+	}
+
+	os.WriteFile(filepath.Join(dir, manifestName), []byte(`{`), 0644)
+	if _, err := LoadManifest(dir); err == nil {
+		t.Error("LoadManifest() should reject invalid JSON")
+	}
+}
+
+func TestBuild_Prune(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{"workflows.go": testWorkflowSource})
+	githubDir := filepath.Join(dir, ".github")
+	workflows := filepath.Join(githubDir, "workflows")
+
+	if _, err := build(dir, BuildOpts{}, false); err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if got, want := manifestPaths(t, githubDir), []string{"workflows/tests.yml Tests"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest = %v, want %v", got, want)
+	}
+	m, _ := LoadManifest(githubDir)
+	content, _ := os.ReadFile(filepath.Join(workflows, "tests.yml"))
+	if m.Files[0].SHA256 != contentHash(content) || m.Files[0].Kind != resourceWorkflow {
+		t.Errorf("manifest entry = %+v, want the workflow and its content hash", m.Files[0])
+	}
+
+	// Rename the workflow, alongside a hand-written one
+	renamed := strings.Replace(testWorkflowSource, "var Tests", "var Checks", 1)
+	os.WriteFile(filepath.Join(dir, "workflows.go"), []byte(renamed), 0644)
+	os.WriteFile(filepath.Join(workflows, "hand.yml"), []byte("on: push\n"), 0644)
+
+	result, err := build(dir, BuildOpts{}, false)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if !strings.Contains(result.Message, "run build --prune to remove them: workflows/tests.yml") {
+		t.Errorf("message = %q, want the orphan reported", result.Message)
+	}
+	if _, err := os.Stat(filepath.Join(workflows, "tests.yml")); err != nil {
+		t.Error("build without prune should keep the orphan")
+	}
+	if got, want := checkStates(t, dir, BuildOpts{}), []string{"orphaned workflows/tests.yml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("check = %v, want %v", got, want)
+	}
+
+	result, err = build(dir, BuildOpts{}, true)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if !strings.Contains(result.Message, "removed 1 orphaned file(s): workflows/tests.yml") {
+		t.Errorf("message = %q, want the orphan removed", result.Message)
+	}
+	if _, err := os.Stat(filepath.Join(workflows, "tests.yml")); !os.IsNotExist(err) {
+		t.Error("build with prune should remove the orphan")
+	}
+	if _, err := os.Stat(filepath.Join(workflows, "hand.yml")); err != nil {
+		t.Error("build with prune should never remove a hand-written file")
+	}
+	if got, want := manifestPaths(t, githubDir), []string{"workflows/checks.yml Checks"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest = %v, want %v", got, want)
+	}
+	if got := checkStates(t, dir, BuildOpts{}); got != nil {
+		t.Errorf("check = %v, want no differences", got)
+	}
+}
+
+func TestBuild_PruneKeepsEditedFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{"workflows.go": testWorkflowSource})
+	githubDir := filepath.Join(dir, ".github")
+	tests := filepath.Join(githubDir, "workflows", "tests.yml")
+
+	if _, err := build(dir, BuildOpts{}, false); err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+
+	renamed := strings.Replace(testWorkflowSource, "var Tests", "var Checks", 1)
+	os.WriteFile(filepath.Join(dir, "workflows.go"), []byte(renamed), 0644)
+	os.WriteFile(tests, []byte("# Edited by hand\non: push\n"), 0644)
+
+	result, err := build(dir, BuildOpts{}, true)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if !strings.Contains(result.Message, "edited since they were generated, not removed: workflows/tests.yml") {
+		t.Errorf("message = %q, want the edited orphan reported", result.Message)
+	}
+	if _, err := os.Stat(tests); err != nil {
+		t.Error("build with prune should keep an edited orphan")
+	}
+
+	// Once the file is deleted by hand it leaves the manifest
+	os.Remove(tests)
+	if _, err := build(dir, BuildOpts{}, false); err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if got, want := manifestPaths(t, githubDir), []string{"workflows/checks.yml Checks"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest = %v, want %v", got, want)
+	}
+}
+
+func TestBuild_PruneOtherTypes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{
+		"workflows.go": testWorkflowSource,
+		"repo.go":      testRepoSource,
+	})
+	githubDir := filepath.Join(dir, ".github")
+
+	if _, err := build(dir, BuildOpts{}, false); err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	before := manifestPaths(t, githubDir)
+
+	// Building one type keeps the entries of the others
+	os.Remove(filepath.Join(dir, "repo.go"))
+	if _, err := build(dir, BuildOpts{Type: resourceWorkflow}, true); err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if got := manifestPaths(t, githubDir); !reflect.DeepEqual(got, before) {
+		t.Errorf("manifest = %v, want %v", got, before)
+	}
+	if _, err := os.Stat(filepath.Join(githubDir, "dependabot.yml")); err != nil {
+		t.Error("build --type workflow should not prune other types")
+	}
+}
+
+func TestBuild_PruneActionWrappers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	source := `package testproject
+
+import (
+	"github.com/lex00/wetwire-github-go/action"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+var SetupTools = action.Action{
+	Name:        "Setup tools",
+	Description: "Prepare the workspace",
+	Runs:        action.Composite(workflow.Step{Run: "make tools", Shell: "bash"}),
+}
+`
+	dir := writeTestModule(t, map[string]string{"actions.go": source})
+	githubDir := filepath.Join(dir, ".github")
+	wrapper := filepath.Join(dir, "actions", "setup_tools", "setup_tools.go")
+
+	if _, err := build(dir, BuildOpts{}, false); err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	want := []string{
+		"../actions/setup_tools/setup_tools.go SetupTools",
+		"actions/setup-tools/action.yml SetupTools",
+	}
+	if got := manifestPaths(t, githubDir); !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest = %v, want %v", got, want)
+	}
+
+	// An edited wrapper is stale
+	content, _ := os.ReadFile(wrapper)
+	os.WriteFile(wrapper, append(content, "// Edited\n"...), 0644)
+	if got, want := checkStates(t, dir, BuildOpts{}), []string{"stale ../actions/setup_tools/setup_tools.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("check = %v, want %v", got, want)
+	}
+	os.WriteFile(wrapper, content, 0644)
+
+	// Renaming the action orphans the old wrapper package
+	renamed := strings.Replace(source, "var SetupTools", "var Toolchain", 1)
+	os.WriteFile(filepath.Join(dir, "actions.go"), []byte(renamed), 0644)
+	want = []string{
+		"orphaned ../actions/setup_tools/setup_tools.go",
+		"missing ../actions/toolchain/toolchain.go",
+		"orphaned actions/setup-tools/action.yml",
+		"missing actions/toolchain/action.yml",
+	}
+	if got := checkStates(t, dir, BuildOpts{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("check = %v, want %v", got, want)
+	}
+
+	if _, err := build(dir, BuildOpts{}, true); err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(wrapper)); !os.IsNotExist(err) {
+		t.Error("build with prune should remove the old wrapper package")
+	}
+	if got := checkStates(t, dir, BuildOpts{}); got != nil {
+		t.Errorf("check = %v, want no differences", got)
+	}
+}
+
+func TestCreateRootCommand_BuildPrune(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	dir := writeTestModule(t, map[string]string{"workflows.go": testWorkflowSource})
+	tests := filepath.Join(dir, ".github", "workflows", "tests.yml")
+
+	run := func(args ...string) (string, error) {
+		root := CreateRootCommand(&GitHubDomain{})
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, dir))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("build", "--prune"); err != nil {
+		t.Fatalf("build --prune error = %v", err)
+	}
+	renamed := strings.Replace(testWorkflowSource, "var Tests", "var Checks", 1)
+	os.WriteFile(filepath.Join(dir, "workflows.go"), []byte(renamed), 0644)
+
+	out, _ := run("build", "--check")
+	if !strings.Contains(out, "- workflows/tests.yml (orphaned)") || !strings.Contains(out, "Run wetwire-github build --prune to remove the orphaned files") {
+		t.Errorf("build --check output = %q, want the orphan and a prune hint", out)
+	}
+
+	out, err := run("build", "--prune")
+	if err != nil {
+		t.Fatalf("build --prune error = %v", err)
+	}
+	if !strings.Contains(out, "removed 1 orphaned file(s)") {
+		t.Errorf("output = %q, want the orphan removed", out)
+	}
+	if _, err := os.Stat(tests); !os.IsNotExist(err) {
+		t.Error("build --prune should remove the renamed workflow's file")
+	}
+
+	if _, err := run("build", "--prune", "--dry-run"); err == nil {
+		t.Error("build --prune --dry-run should be rejected")
+	}
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/lint"
+	"github.com/lex00/wetwire-github-go/internal/report"
+	"github.com/lex00/wetwire-github-go/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Documentation linked from report metadata.
+const (
+	informationURI = "https://github.com/lex00/wetwire-github-go"
+	lintRulesURI   = "https://lex00.github.io/wetwire-github-go/lint-rules/"
+)
+
+// pseudoRules describes the issue codes that do not come from a lint rule.
+var pseudoRules = []report.Rule{
+	{ID: "parse-error", Description: "Go source files must parse"},
+	{ID: lint.RuleUnusedSuppression, Description: "Suppression comments must name known rules and suppress an issue", HelpURI: lintRulesURI},
+	{ID: ruleSemantic, Description: "Semantic lint rules need the package to build", HelpURI: lintRulesURI},
+}
+
+// LintReport lints a file or directory like the lint command and returns
+// the issues as a report, with rule metadata and the auto-fix of each
+// fixable issue as a suggested change.
+func LintReport(path string, opts LintOpts) (*report.Report, error) {
+	lntr, issues, err := runLint(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &report.Report{
+		Tool:           "wetwire-github",
+		Version:        Version,
+		InformationURI: informationURI,
+	}
+	for _, rule := range lntr.Rules() {
+		r.AddRule(report.Rule{ID: rule.ID(), Description: rule.Description(), HelpURI: lintRulesURI})
+	}
+	for _, rule := range pseudoRules {
+		r.AddRule(rule)
+	}
+
+	sources := make(map[string][]byte)
+	for _, issue := range issues {
+		finding := report.Finding{
+			File:     issue.File,
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Rule:     issue.Rule,
+			Message:  issue.Message,
+			Severity: issue.Severity.String(),
+		}
+		if issue.Fixable {
+			src, ok := sources[issue.File]
+			if !ok {
+				src, _ = os.ReadFile(issue.File)
+				sources[issue.File] = src
+			}
+			if fixed, err := lntr.FixIssue(issue.File, src, issue); err == nil && fixed != nil {
+				finding.Fix = report.NewFix(fmt.Sprintf("Apply the %s auto-fix", issue.Rule), src, fixed)
+			}
+		}
+		r.Findings = append(r.Findings, finding)
+	}
+	return r, nil
+}
+
+// ValidateReport validates a workflow file with actionlint like the
+// validate command and returns the issues as a report.
+func ValidateReport(path string) (*report.Report, error) {
+	validationResult, err := validation.NewActionlintValidator().ValidateFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	r := &report.Report{
+		Tool:           "wetwire-github",
+		Version:        Version,
+		InformationURI: informationURI,
+	}
+	for _, issue := range validationResult.Issues {
+		r.AddRule(report.Rule{
+			ID:          issue.RuleID,
+			Description: fmt.Sprintf("actionlint %s check", issue.RuleID),
+			HelpURI:     "https://github.com/rhysd/actionlint/blob/main/docs/checks.md",
+		})
+		r.Findings = append(r.Findings, report.Finding{
+			File:     issue.File,
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Rule:     issue.RuleID,
+			Message:  issue.Message,
+			Severity: "error",
+		})
+	}
+	return r, nil
+}
+
+// addReportFormats lets the lint and validate commands write SARIF and
+// GitHub workflow command output, which the generic result formatter
+// does not support. Other formats go to the original command.
+func addReportFormats(root *cobra.Command) {
+	if flag := root.PersistentFlags().Lookup("format"); flag != nil {
+		flag.Usage = fmt.Sprintf("Output format (text, json, yaml; %s for lint and validate)", strings.Join(report.Formats, ", "))
+	}
+
+	for _, cmd := range root.Commands() {
+		var render func(cmd *cobra.Command, path string) (*report.Report, error)
+		switch cmd.Name() {
+		case "lint":
+			render = func(cmd *cobra.Command, path string) (*report.Report, error) {
+				fix, _ := cmd.Flags().GetBool("fix")
+				disable, _ := cmd.Flags().GetStringSlice("disable")
+				return LintReport(path, LintOpts{Fix: fix, Disable: disable})
+			}
+		case "validate":
+			render = func(cmd *cobra.Command, path string) (*report.Report, error) {
+				return ValidateReport(path)
+			}
+		default:
+			continue
+		}
+
+		runE := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if !report.IsFormat(format) {
+				return runE(cmd, args)
+			}
+
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+			r, err := render(cmd, path)
+			if err != nil {
+				return fmt.Errorf("%s failed: %w", cmd.Name(), err)
+			}
+			output, err := r.Render(format)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), output)
+
+			if len(r.Findings) > 0 {
+				return fmt.Errorf("operation failed")
+			}
+			return nil
+		}
+	}
+}
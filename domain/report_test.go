@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const injectionCode = `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Greet = workflow.Step{
+	Run: "echo ${{ github.event.issue.title }}",
+}
+`
+
+func TestLintReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(injectionCode), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LintReport(tmpDir, LintOpts{})
+	if err != nil {
+		t.Fatalf("LintReport() error = %v", err)
+	}
+
+	described := make(map[string]string)
+	for _, rule := range r.Rules {
+		described[rule.ID] = rule.Description
+	}
+	if described["WAG023"] == "" || described["unused-suppression"] == "" {
+		t.Errorf("rule metadata missing: %v", described)
+	}
+
+	var found bool
+	for _, f := range r.Findings {
+		if f.Rule != "WAG023" {
+			continue
+		}
+		found = true
+		if f.Fix == nil || len(f.Fix.Replacements) != 1 {
+			t.Fatalf("WAG023 finding has no fix: %+v", f)
+		}
+		if !strings.Contains(f.Fix.Replacements[0].Text, "ISSUE_TITLE") {
+			t.Errorf("fix = %+v", f.Fix.Replacements[0])
+		}
+	}
+	if !found {
+		t.Errorf("Expected a WAG023 finding, got %+v", r.Findings)
+	}
+
+	// Suggesting a fix must not change the file
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "main.go"))
+	if string(content) != injectionCode {
+		t.Error("LintReport() modified the source file")
+	}
+}
+
+func TestCreateRootCommand_ReportFormats(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(injectionCode), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"github", "::error file="},
+		{"sarif", `"ruleId": "WAG023"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			root := CreateRootCommand(&GitHubDomain{})
+			var out bytes.Buffer
+			root.SetOut(&out)
+			root.SetErr(&bytes.Buffer{})
+			root.SetArgs([]string{"lint", "--format", tt.format, "--disable", "WAG008", tmpDir})
+
+			if err := root.Execute(); err == nil {
+				t.Error("Execute() should fail when issues are found")
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, out.String())
+			}
+		})
+	}
+}
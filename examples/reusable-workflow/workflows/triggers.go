@@ -29,11 +29,11 @@ var ReusableInputs = map[string]workflow.WorkflowInput{
 var ReusableOutputs = map[string]workflow.WorkflowOutput{
 	"artifact_name": {
 		Description: "Name of the built artifact",
-		Value:       "${{ jobs.build.outputs.artifact }}",
+		Value:       "jobs.build.outputs.artifact",
 	},
 	"build_version": {
 		Description: "Version of the build",
-		Value:       "${{ jobs.build.outputs.version }}",
+		Value:       "jobs.build.outputs.version",
 	},
 }
 
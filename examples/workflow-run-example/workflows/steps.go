@@ -15,7 +15,10 @@ var DeploySteps = []any{
 echo "Workflow run ID: ${{ github.event.workflow_run.id }}"
 echo "Conclusion: ${{ github.event.workflow_run.conclusion }}"
 echo "Head SHA: ${{ github.event.workflow_run.head_sha }}"
-echo "Head branch: ${{ github.event.workflow_run.head_branch }}"`,
+echo "Head branch: ${WORKFLOW_RUN_HEAD_BRANCH}"`,
+		Env: workflow.Env{
+			"WORKFLOW_RUN_HEAD_BRANCH": workflow.GitHub.Event("workflow_run.head_branch"),
+		},
 	},
 	// Checkout the commit that triggered the CI workflow
 	checkout.Checkout{
@@ -38,8 +41,11 @@ echo "Head branch: ${{ github.event.workflow_run.head_branch }}"`,
 	workflow.Step{
 		Name: "Deploy to Production",
 		Run: `echo "Deploying commit ${{ github.event.workflow_run.head_sha }}"
-echo "From branch: ${{ github.event.workflow_run.head_branch }}"
+echo "From branch: ${WORKFLOW_RUN_HEAD_BRANCH}"
 echo "Deployment successful!"`,
+		Env: workflow.Env{
+			"WORKFLOW_RUN_HEAD_BRANCH": workflow.GitHub.Event("workflow_run.head_branch"),
+		},
 	},
 }
 
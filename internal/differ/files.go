@@ -0,0 +1,446 @@
+package differ
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of generated file. They match the resource types of the build.
+const (
+	KindWorkflow           = "workflow"
+	KindDependabot         = "dependabot"
+	KindCodeowners         = "codeowners"
+	KindIssueTemplate      = "issue-template"
+	KindDiscussionTemplate = "discussion-template"
+	KindPRTemplate         = "pr-template"
+	KindAction             = "action"
+)
+
+// Statuses of a FileDiff.
+const (
+	StatusAdded    = "added"
+	StatusRemoved  = "removed"
+	StatusModified = "modified"
+)
+
+// File is a generated file to compare.
+type File struct {
+	// Path is the file's path relative to the .github directory, with
+	// forward slashes
+	Path string
+
+	// Kind is the file's kind, one of the Kind constants
+	Kind string
+
+	// Content is the file content
+	Content []byte
+}
+
+// FileDiff describes how a file differs between two sets of files.
+type FileDiff struct {
+	// Path is the file's path relative to the .github directory
+	Path string `json:"path"`
+
+	// Kind is the file's kind
+	Kind string `json:"kind"`
+
+	// Status is added, removed or modified
+	Status string `json:"status"`
+
+	// Changes lists the changed values of a modified file
+	Changes []Change `json:"changes,omitempty"`
+
+	// Findings lists the security-relevant changes, most severe first
+	Findings []Finding `json:"findings,omitempty"`
+
+	// Old and New are the normalized documents, nil for a missing file
+	Old any `json:"-"`
+	New any `json:"-"`
+}
+
+// KindOf returns the kind of a file from its path relative to the .github
+// directory, or "" for files the build doesn't generate. Other YAML files
+// are taken to be workflows, so a workflows directory can be loaded on its
+// own.
+func KindOf(rel string) string {
+	rel = filepath.ToSlash(rel)
+	dir, name := path.Split(rel)
+	ext := strings.ToLower(path.Ext(name))
+	stem := strings.TrimSuffix(name, path.Ext(name))
+
+	switch {
+	case name == "CODEOWNERS":
+		return KindCodeowners
+	case ext == ".md":
+		if strings.EqualFold(name, "pull_request_template.md") || strings.EqualFold(path.Base(dir), "PULL_REQUEST_TEMPLATE") {
+			return KindPRTemplate
+		}
+		return ""
+	case ext != ".yml" && ext != ".yaml":
+		return ""
+	case stem == "dependabot":
+		return KindDependabot
+	case stem == "action":
+		return KindAction
+	case path.Base(dir) == "ISSUE_TEMPLATE":
+		if stem == "config" {
+			return ""
+		}
+		return KindIssueTemplate
+	case path.Base(dir) == "DISCUSSION_TEMPLATE":
+		return KindDiscussionTemplate
+	}
+	return KindWorkflow
+}
+
+// LoadFiles reads the generated files of a directory, such as .github or
+// .github/workflows, or a single file. Files are keyed by their path
+// relative to the directory, or by their base name for a single file.
+func LoadFiles(root string) ([]File, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		content, err := os.ReadFile(root)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(root)
+		kind := KindOf(name)
+		if kind == "" {
+			kind = KindWorkflow
+		}
+		return []File{{Path: name, Kind: kind, Content: content}}, nil
+	}
+
+	var files []File
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		kind := KindOf(rel)
+		if kind == "" {
+			return nil
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: rel, Kind: kind, Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// DiffFiles compares two sets of files matched by path and returns the
+// files that differ, in path order, with their security findings.
+func DiffFiles(oldFiles, newFiles []File) ([]FileDiff, error) {
+	olds := make(map[string]File, len(oldFiles))
+	for _, f := range oldFiles {
+		olds[f.Path] = f
+	}
+	news := make(map[string]File, len(newFiles))
+	for _, f := range newFiles {
+		news[f.Path] = f
+	}
+
+	paths := make([]string, 0, len(olds)+len(news))
+	for p := range olds {
+		paths = append(paths, p)
+	}
+	for p := range news {
+		if _, ok := olds[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var diffs []FileDiff
+	for _, p := range paths {
+		oldFile, inOld := olds[p]
+		newFile, inNew := news[p]
+		kind := newFile.Kind
+		if !inNew {
+			kind = oldFile.Kind
+		}
+
+		diff := FileDiff{Path: p, Kind: kind}
+		var err error
+		if inOld {
+			if diff.Old, err = Normalize(kind, oldFile.Content); err != nil {
+				return nil, fmt.Errorf("%s: %w", p, err)
+			}
+		}
+		if inNew {
+			if diff.New, err = Normalize(kind, newFile.Content); err != nil {
+				return nil, fmt.Errorf("%s: %w", p, err)
+			}
+		}
+
+		switch {
+		case !inOld:
+			diff.Status = StatusAdded
+		case !inNew:
+			diff.Status = StatusRemoved
+		default:
+			diff.Changes = CompareDocuments(kind, diff.Old, diff.New)
+			if len(diff.Changes) == 0 {
+				continue
+			}
+			diff.Status = StatusModified
+		}
+		diff.Findings = Classify(diff)
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// Normalize parses a file of the given kind and returns it in the
+// canonical form its Normalize function produces. CODEOWNERS files and
+// PR templates, which aren't YAML, become a mapping with their rules or
+// their trimmed content.
+func Normalize(kind string, content []byte) (any, error) {
+	switch kind {
+	case KindCodeowners:
+		return NormalizeCodeowners(content), nil
+	case KindPRTemplate:
+		return map[string]any{"content": strings.TrimSpace(string(content))}, nil
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+	switch kind {
+	case KindWorkflow:
+		return NormalizeWorkflow(doc), nil
+	case KindDependabot:
+		return NormalizeDependabot(doc), nil
+	case KindIssueTemplate, KindDiscussionTemplate:
+		return NormalizeForm(doc), nil
+	case KindAction:
+		return NormalizeDocument(doc), nil
+	}
+	return nil, fmt.Errorf("unknown file kind %q", kind)
+}
+
+// CompareFile compares two versions of a file of the given kind.
+func CompareFile(kind string, oldContent, newContent []byte) ([]Change, error) {
+	a, err := Normalize(kind, oldContent)
+	if err != nil {
+		return nil, err
+	}
+	b, err := Normalize(kind, newContent)
+	if err != nil {
+		return nil, err
+	}
+	return CompareDocuments(kind, a, b), nil
+}
+
+// CompareDocuments compares two normalized documents of the given kind.
+// The steps of a job present in both workflows are matched by ID, name,
+// action or script, then by position, and labeled steps[id=...],
+// steps[name="..."] or steps[N] in change paths.
+func CompareDocuments(kind string, a, b any) []Change {
+	if kind != KindWorkflow {
+		return Compare(a, b)
+	}
+
+	a, b, aSteps, bSteps := splitSteps(a, b)
+	changes := Compare(a, b)
+	for _, id := range sortedKeys(aSteps) {
+		changes = append(changes, compareSteps("jobs."+id+".steps", aSteps[id], bSteps[id])...)
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// splitSteps returns copies of two workflows without the steps of the
+// jobs both have, so the rest of the jobs can be compared on their own,
+// and the steps removed, by job.
+func splitSteps(a, b any) (aOut, bOut any, aSteps, bSteps map[string][]any) {
+	aWf, aOK := a.(map[string]any)
+	bWf, bOK := b.(map[string]any)
+	aJobs, _ := aWf["jobs"].(map[string]any)
+	bJobs, _ := bWf["jobs"].(map[string]any)
+	if !aOK || !bOK || aJobs == nil || bJobs == nil {
+		return a, b, nil, nil
+	}
+
+	aJobs, bJobs = maps.Clone(aJobs), maps.Clone(bJobs)
+	aSteps = make(map[string][]any)
+	bSteps = make(map[string][]any)
+	for id, aj := range aJobs {
+		aJob, ok := aj.(map[string]any)
+		if !ok {
+			continue
+		}
+		bJob, ok := bJobs[id].(map[string]any)
+		if !ok {
+			continue
+		}
+		aSteps[id], _ = aJob["steps"].([]any)
+		bSteps[id], _ = bJob["steps"].([]any)
+		aJobs[id] = withoutKey(aJob, "steps")
+		bJobs[id] = withoutKey(bJob, "steps")
+	}
+
+	aWf, bWf = maps.Clone(aWf), maps.Clone(bWf)
+	aWf["jobs"], bWf["jobs"] = aJobs, bJobs
+	return aWf, bWf, aSteps, bSteps
+}
+
+// withoutKey returns a copy of m without key.
+func withoutKey(m map[string]any, key string) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if k != key {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// compareSteps compares two step lists. Steps are paired by matchSteps;
+// a change in the order of paired steps is reported on the list itself.
+func compareSteps(path string, a, b []any) []Change {
+	pairs := matchSteps(a, b)
+	paired := make(map[int]bool, len(pairs))
+	for _, j := range pairs {
+		paired[j] = true
+	}
+
+	var changes []Change
+	for i, as := range a {
+		label := fmt.Sprintf("%s[%s]", path, stepLabel(as, i))
+		if j, ok := pairs[i]; ok {
+			changes = append(changes, compareValues(as, b[j], label)...)
+		} else {
+			changes = append(changes, Change{Path: label, Old: as})
+		}
+	}
+	for j, bs := range b {
+		if !paired[j] {
+			changes = append(changes, Change{Path: fmt.Sprintf("%s[%s]", path, stepLabel(bs, j)), New: bs})
+		}
+	}
+
+	// Paired steps listed in their old order must also be in new order
+	var oldOrder, newOrder []int
+	for i := range a {
+		if j, ok := pairs[i]; ok {
+			oldOrder = append(oldOrder, i)
+			newOrder = append(newOrder, j)
+		}
+	}
+	if !sort.IntsAreSorted(newOrder) {
+		byNew := append([]int(nil), oldOrder...)
+		sort.Slice(byNew, func(x, y int) bool { return pairs[byNew[x]] < pairs[byNew[y]] })
+		old := make([]any, len(oldOrder))
+		reordered := make([]any, len(byNew))
+		for k := range oldOrder {
+			old[k] = stepLabel(a[oldOrder[k]], oldOrder[k])
+			reordered[k] = stepLabel(a[byNew[k]], byNew[k])
+		}
+		changes = append(changes, Change{Path: path, Old: old, New: reordered})
+	}
+	return changes
+}
+
+// stepKeys identify a step, in the order matchSteps tries them: its ID,
+// its name, the action it uses whatever the version, and its script.
+var stepKeys = []func(step any) string{
+	func(step any) string { return stepField(step, "id") },
+	func(step any) string { return stepField(step, "name") },
+	func(step any) string {
+		action, _, _ := strings.Cut(stepField(step, "uses"), "@")
+		return action
+	},
+	func(step any) string { return stepField(step, "run") },
+}
+
+// matchSteps pairs the steps of two lists by each of stepKeys in turn,
+// and the rest by their order. It maps indexes in a to indexes in b.
+func matchSteps(a, b []any) map[int]int {
+	pairs := make(map[int]int)
+	paired := make(map[int]bool)
+	for _, key := range stepKeys {
+		for i, as := range a {
+			if _, ok := pairs[i]; ok {
+				continue
+			}
+			v := key(as)
+			if v == "" {
+				continue
+			}
+			for j, bs := range b {
+				if !paired[j] && key(bs) == v {
+					pairs[i], paired[j] = j, true
+					break
+				}
+			}
+		}
+	}
+	j := 0
+	for i := range a {
+		if _, ok := pairs[i]; ok {
+			continue
+		}
+		for j < len(b) && paired[j] {
+			j++
+		}
+		if j == len(b) {
+			break
+		}
+		pairs[i], paired[j] = j, true
+	}
+	return pairs
+}
+
+// stepField returns a string field of a decoded step.
+func stepField(step any, key string) string {
+	m, _ := step.(map[string]any)
+	s, _ := m[key].(string)
+	return s
+}
+
+// stepLabel identifies a step in change paths.
+func stepLabel(step any, index int) string {
+	if id := stepField(step, "id"); id != "" {
+		return "id=" + id
+	}
+	if name := stepField(step, "name"); name != "" {
+		return fmt.Sprintf("name=%q", name)
+	}
+	return fmt.Sprint(index)
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,298 @@
+package differ
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	coredomain "github.com/lex00/wetwire-core-go/domain"
+)
+
+// changePaths returns the paths of changes.
+func changePaths(changes []Change) []string {
+	var paths []string
+	for _, c := range changes {
+		paths = append(paths, c.Path)
+	}
+	return paths
+}
+
+func TestKindOf(t *testing.T) {
+	tests := map[string]string{
+		"workflows/ci.yml":                 KindWorkflow,
+		"ci.yaml":                          KindWorkflow,
+		"dependabot.yml":                   KindDependabot,
+		"CODEOWNERS":                       KindCodeowners,
+		"ISSUE_TEMPLATE/bug.yml":           KindIssueTemplate,
+		"ISSUE_TEMPLATE/config.yml":        "",
+		"ISSUE_TEMPLATE/bug.md":            "",
+		"DISCUSSION_TEMPLATE/ideas.yml":    KindDiscussionTemplate,
+		"PULL_REQUEST_TEMPLATE.md":         KindPRTemplate,
+		"PULL_REQUEST_TEMPLATE/feature.md": KindPRTemplate,
+		"actions/setup/action.yml":         KindAction,
+		"workflows/README.md":              "",
+		"actions/setup/setup.sh":           "",
+	}
+	for path, want := range tests {
+		if got := KindOf(path); got != want {
+			t.Errorf("KindOf(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestCompareFile_Workflow(t *testing.T) {
+	old := `on:
+  push:
+    branches: main
+permissions:
+  contents: read
+concurrency:
+  group: ci
+jobs:
+  build:
+    runs-on: [ubuntu-latest]
+    strategy:
+      matrix:
+        go: ["1.22"]
+    env:
+      CGO_ENABLED: "0"
+    steps:
+      - uses: actions/checkout@v4
+      - id: test
+        run: make test
+`
+	changed := `on:
+  push:
+    branches: [main]
+  pull_request:
+permissions:
+  contents: write
+concurrency:
+  group: ci
+  cancel-in-progress: true
+jobs:
+  build:
+    runs-on: ubuntu-24.04
+    strategy:
+      matrix:
+        go: ["1.22", "1.23"]
+    env:
+      CGO_ENABLED: "1"
+    steps:
+      - uses: actions/checkout@v4
+      - id: test
+        run: make check
+`
+	changes, err := CompareFile(KindWorkflow, []byte(old), []byte(changed))
+	if err != nil {
+		t.Fatalf("CompareFile() error = %v", err)
+	}
+	want := []string{
+		"concurrency.cancel-in-progress",
+		"jobs.build.env.CGO_ENABLED",
+		"jobs.build.runs-on",
+		"jobs.build.steps[id=test].run",
+		"jobs.build.strategy.matrix.go[1]",
+		"on.pull_request",
+		"permissions.contents",
+	}
+	if got := changePaths(changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareFile() paths = %v, want %v", got, want)
+	}
+}
+
+func TestCompareFile_StepMatching(t *testing.T) {
+	old := `jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Lint
+        run: make lint
+      - id: test
+        run: make test
+`
+	tests := []struct {
+		name  string
+		steps string
+		want  []string
+	}{
+		{
+			name: "inserted step",
+			steps: `      - uses: actions/checkout@v4
+      - name: Setup
+        run: make setup
+      - name: Lint
+        run: make lint
+      - id: test
+        run: make test
+`,
+			want: []string{`jobs.build.steps[name="Setup"]`},
+		},
+		{
+			name: "removed step",
+			steps: `      - uses: actions/checkout@v4
+      - id: test
+        run: make test
+`,
+			want: []string{`jobs.build.steps[name="Lint"]`},
+		},
+		{
+			name: "reordered steps",
+			steps: `      - uses: actions/checkout@v4
+      - id: test
+        run: make test
+      - name: Lint
+        run: make lint
+`,
+			want: []string{"jobs.build.steps"},
+		},
+		{
+			name: "changed step",
+			steps: `      - uses: actions/checkout@v5
+      - name: Lint
+        run: make lint
+      - id: test
+        run: make test
+        env:
+          CI: "true"
+`,
+			want: []string{"jobs.build.steps[0].uses", "jobs.build.steps[id=test].env"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := "jobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n" + tt.steps
+			changes, err := CompareFile(KindWorkflow, []byte(old), []byte(changed))
+			if err != nil {
+				t.Fatalf("CompareFile() error = %v", err)
+			}
+			if got := changePaths(changes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareFile() paths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareDocuments_KeepsSteps(t *testing.T) {
+	a, _ := Normalize(KindWorkflow, []byte("jobs:\n  build:\n    steps:\n      - run: a\n"))
+	b, _ := Normalize(KindWorkflow, []byte("jobs:\n  build:\n    steps:\n      - run: b\n"))
+	CompareDocuments(KindWorkflow, a, b)
+
+	job := a.(map[string]any)["jobs"].(map[string]any)["build"].(map[string]any)
+	if _, ok := job["steps"]; !ok {
+		t.Error("CompareDocuments() should not modify its documents")
+	}
+}
+
+func TestDiffFiles(t *testing.T) {
+	oldFiles := []File{
+		{Path: "workflows/ci.yml", Kind: KindWorkflow, Content: []byte("on: push\njobs:\n  a:\n    runs-on: x\n")},
+		{Path: "workflows/old.yml", Kind: KindWorkflow, Content: []byte("on: push\n")},
+		{Path: "CODEOWNERS", Kind: KindCodeowners, Content: []byte("* @a\n")},
+	}
+	newFiles := []File{
+		{Path: "workflows/ci.yml", Kind: KindWorkflow, Content: []byte("on: [push]\njobs:\n  a:\n    runs-on: y\n")},
+		{Path: "workflows/new.yml", Kind: KindWorkflow, Content: []byte("on: push\n")},
+		{Path: "CODEOWNERS", Kind: KindCodeowners, Content: []byte("# Owners\n*   @a\n")},
+	}
+
+	diffs, err := DiffFiles(oldFiles, newFiles)
+	if err != nil {
+		t.Fatalf("DiffFiles() error = %v", err)
+	}
+	var got []string
+	for _, d := range diffs {
+		got = append(got, d.Status+" "+d.Path)
+	}
+	want := []string{"modified workflows/ci.yml", "added workflows/new.yml", "removed workflows/old.yml"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffFiles() = %v, want %v", got, want)
+	}
+	if len(diffs[0].Changes) != 1 || diffs[0].Changes[0].Path != "jobs.a.runs-on" {
+		t.Errorf("DiffFiles() changes = %v, want jobs.a.runs-on", diffs[0].Changes)
+	}
+}
+
+func TestDiffFiles_InvalidYAML(t *testing.T) {
+	files := []File{{Path: "ci.yml", Kind: KindWorkflow, Content: []byte("on: [push")}}
+	if _, err := DiffFiles(files, nil); err == nil {
+		t.Error("DiffFiles() should fail for invalid YAML")
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"workflows/ci.yml":          "on: push\n",
+		"workflows/notes.txt":       "notes",
+		"ISSUE_TEMPLATE/bug.yml":    "name: Bug\n",
+		"ISSUE_TEMPLATE/config.yml": "blank_issues_enabled: false\n",
+	} {
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := LoadFiles(dir)
+	if err != nil {
+		t.Fatalf("LoadFiles() error = %v", err)
+	}
+	var got []string
+	for _, f := range files {
+		got = append(got, f.Kind+" "+f.Path)
+	}
+	want := []string{"issue-template ISSUE_TEMPLATE/bug.yml", "workflow workflows/ci.yml"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadFiles() = %v, want %v", got, want)
+	}
+
+	files, err = LoadFiles(filepath.Join(dir, "workflows", "ci.yml"))
+	if err != nil {
+		t.Fatalf("LoadFiles() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "ci.yml" {
+		t.Errorf("LoadFiles() = %v, want ci.yml", files)
+	}
+
+	if _, err := LoadFiles(filepath.Join(dir, "missing")); err == nil {
+		t.Error("LoadFiles() should fail for a missing path")
+	}
+}
+
+func TestWorkflowDiffer_Diff(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "old.yml")
+	changed := filepath.Join(dir, "new.yml")
+	os.WriteFile(old, []byte("name: CI\non: push\njobs:\n  build:\n    runs-on: a\n  lint:\n    runs-on: a\n"), 0644)
+	os.WriteFile(changed, []byte("name: CI\non: pull_request\njobs:\n  build:\n    runs-on: b\n  test:\n    runs-on: a\n"), 0644)
+
+	result, err := New().Diff(nil, old, changed, coredomain.DiffOpts{})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	var got []string
+	for _, e := range result.Entries {
+		got = append(got, e.Action+" "+e.Resource)
+	}
+	want := []string{"modified job:build", "removed job:lint", "added job:test", "modified workflow"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() entries = %v, want %v", got, want)
+	}
+	if result.Entries[0].Changes[0] != `runs-on: "a" → "b"` {
+		t.Errorf("Diff() job change = %q", result.Entries[0].Changes[0])
+	}
+	if result.Summary.Total != 4 || result.Summary.Added != 1 || result.Summary.Removed != 1 {
+		t.Errorf("Diff() summary = %+v", result.Summary)
+	}
+
+	if _, err := New().Diff(nil, filepath.Join(dir, "missing.yml"), changed, coredomain.DiffOpts{}); err == nil {
+		t.Error("Diff() should fail for a missing file")
+	}
+}
@@ -0,0 +1,415 @@
+package differ
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/pin"
+)
+
+// Risk levels of a Finding, from most to least severe.
+const (
+	RiskHigh   = "high"
+	RiskMedium = "medium"
+	RiskLow    = "low"
+)
+
+// Rules of a Finding.
+const (
+	RulePermissionRaised   = "permission-raised"
+	RulePermissionsRemoved = "permissions-removed"
+	RuleNewSecret          = "new-secret"
+	RuleDangerousTrigger   = "dangerous-trigger"
+	RulePinRemoved         = "pin-removed"
+	RuleThirdPartyAction   = "third-party-action"
+	RulePersistCredentials = "persist-credentials"
+	RuleSecretsInherited   = "secrets-inherit"
+)
+
+// Finding is a change to a workflow that affects its security.
+type Finding struct {
+	// Risk is high, medium or low
+	Risk string `json:"risk"`
+
+	// Rule identifies the kind of change, one of the Rule constants
+	Rule string `json:"rule"`
+
+	// Path is the YAML path of the change
+	Path string `json:"path"`
+
+	// Message describes the change
+	Message string `json:"message"`
+}
+
+// riskOrder sorts findings from most to least severe.
+var riskOrder = map[string]int{RiskHigh: 0, RiskMedium: 1, RiskLow: 2}
+
+// dangerousTriggers run with a write token and secrets on behalf of code
+// from forks, and say how.
+var dangerousTriggers = map[string]string{
+	"pull_request_target": "runs with secrets and a write token for pull requests from forks",
+	"workflow_run":        "runs with secrets and a write token after workflows that forks can trigger",
+}
+
+// firstPartyOwners publish the actions GitHub maintains.
+var firstPartyOwners = map[string]bool{"actions": true, "github": true}
+
+// secretRef matches the secrets a string references, as secrets.NAME or
+// secrets['NAME'].
+var secretRef = regexp.MustCompile(`secrets(?:\.([A-Za-z_][A-Za-z0-9_-]*)|\[\s*['"]([^'"]+)['"]\s*\])`)
+
+// permissionLevels ranks the access levels of a permission.
+var permissionLevels = map[string]int{"none": 0, "read": 1, "write": 2}
+
+// Classify returns the security-relevant changes of a workflow diff, most
+// severe first:
+//
+//   - high: a permission raised to write, a pull_request_target or
+//     workflow_run trigger added, an action no longer pinned to a commit
+//     SHA, persist-credentials enabled on actions/checkout, including by
+//     a new checkout step that leaves it at its default
+//   - medium: a new secret reference, secrets: inherit, a new third-party
+//     action, a permissions block removed
+//   - low: persist-credentials disabled on actions/checkout
+//
+// Other kinds of file have no findings.
+func Classify(d FileDiff) []Finding {
+	if d.Kind != KindWorkflow || d.New == nil {
+		return nil
+	}
+	oldWf, _ := d.Old.(map[string]any)
+	newWf, _ := d.New.(map[string]any)
+
+	var findings []Finding
+	findings = append(findings, permissionFindings("permissions", oldWf["permissions"], newWf["permissions"])...)
+	findings = append(findings, triggerFindings(oldWf, newWf)...)
+	findings = append(findings, secretFindings(d.Old, d.New)...)
+	findings = append(findings, actionFindings(oldWf, newWf)...)
+
+	oldJobs, _ := oldWf["jobs"].(map[string]any)
+	newJobs, _ := newWf["jobs"].(map[string]any)
+	for _, id := range sortedKeys(newJobs) {
+		newJob, _ := newJobs[id].(map[string]any)
+		oldJob, _ := oldJobs[id].(map[string]any)
+		path := "jobs." + id
+		findings = append(findings, permissionFindings(path+".permissions", oldJob["permissions"], newJob["permissions"])...)
+		if newJob["secrets"] == "inherit" && oldJob["secrets"] != "inherit" {
+			findings = append(findings, Finding{
+				Risk:    RiskMedium,
+				Rule:    RuleSecretsInherited,
+				Path:    path + ".secrets",
+				Message: "passes every secret to the called workflow",
+			})
+		}
+		findings = append(findings, checkoutFindings(path+".steps", oldJob["steps"], newJob["steps"])...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if riskOrder[findings[i].Risk] != riskOrder[findings[j].Risk] {
+			return riskOrder[findings[i].Risk] < riskOrder[findings[j].Risk]
+		}
+		return findings[i].Path < findings[j].Path
+	})
+	return findings
+}
+
+// permissionFindings reports the scopes of a permissions block raised to
+// write, and the removal of a block, which leaves the token with the
+// repository's default permissions.
+func permissionFindings(path string, oldPerms, newPerms any) []Finding {
+	if newPerms == nil {
+		if oldPerms == nil {
+			return nil
+		}
+		return []Finding{{
+			Risk:    RiskMedium,
+			Rule:    RulePermissionsRemoved,
+			Path:    path,
+			Message: "permissions removed; the token gets the repository's default permissions",
+		}}
+	}
+
+	oldScopes, oldAll := scopeLevels(oldPerms)
+	newScopes, newAll := scopeLevels(newPerms)
+	if newAll == permissionLevels["write"] && oldAll < newAll {
+		return []Finding{{
+			Risk:    RiskHigh,
+			Rule:    RulePermissionRaised,
+			Path:    path,
+			Message: "write-all granted",
+		}}
+	}
+
+	var findings []Finding
+	for _, scope := range sortedKeys(newScopes) {
+		oldLevel, ok := oldScopes[scope]
+		if !ok {
+			oldLevel = oldAll
+		}
+		if newScopes[scope] != permissionLevels["write"] || oldLevel >= newScopes[scope] {
+			continue
+		}
+		message := scope + " raised to write"
+		if oldPerms != nil {
+			message = fmt.Sprintf("%s raised from %s to write", scope, levelName(oldLevel))
+		}
+		findings = append(findings, Finding{
+			Risk:    RiskHigh,
+			Rule:    RulePermissionRaised,
+			Path:    path + "." + scope,
+			Message: message,
+		})
+	}
+	return findings
+}
+
+// scopeLevels returns the level of each scope of a permissions block, and
+// the level of the scopes it doesn't list.
+func scopeLevels(perms any) (map[string]int, int) {
+	switch p := perms.(type) {
+	case string:
+		switch p {
+		case "write-all":
+			return nil, permissionLevels["write"]
+		case "read-all":
+			return nil, permissionLevels["read"]
+		}
+	case map[string]any:
+		scopes := make(map[string]int, len(p))
+		for scope, level := range p {
+			scopes[scope] = permissionLevels[fmt.Sprint(level)]
+		}
+		return scopes, permissionLevels["none"]
+	}
+	return nil, permissionLevels["none"]
+}
+
+// levelName returns the name of a permission level.
+func levelName(level int) string {
+	for name, l := range permissionLevels {
+		if l == level {
+			return name
+		}
+	}
+	return "none"
+}
+
+// triggerFindings reports triggers added that run privileged on behalf of
+// forks.
+func triggerFindings(oldWf, newWf map[string]any) []Finding {
+	oldOn, _ := oldWf["on"].(map[string]any)
+	newOn, _ := newWf["on"].(map[string]any)
+
+	var findings []Finding
+	for _, trigger := range sortedKeys(dangerousTriggers) {
+		_, had := oldOn[trigger]
+		if _, has := newOn[trigger]; has && !had {
+			findings = append(findings, Finding{
+				Risk:    RiskHigh,
+				Rule:    RuleDangerousTrigger,
+				Path:    "on." + trigger,
+				Message: trigger + " trigger added; it " + dangerousTriggers[trigger],
+			})
+		}
+	}
+	return findings
+}
+
+// secretFindings reports the secrets the new workflow references that the
+// old one doesn't.
+func secretFindings(oldDoc, newDoc any) []Finding {
+	old := make(map[string]bool)
+	walkStrings(oldDoc, "", func(_, s string) {
+		for _, name := range secretNames(s) {
+			old[name] = true
+		}
+	})
+
+	var findings []Finding
+	seen := make(map[string]bool)
+	walkStrings(newDoc, "", func(path, s string) {
+		for _, name := range secretNames(s) {
+			if old[name] || seen[name] {
+				continue
+			}
+			seen[name] = true
+			findings = append(findings, Finding{
+				Risk:    RiskMedium,
+				Rule:    RuleNewSecret,
+				Path:    path,
+				Message: "references secrets." + name,
+			})
+		}
+	})
+	return findings
+}
+
+// secretNames returns the names of the secrets a string references.
+func secretNames(s string) []string {
+	var names []string
+	for _, m := range secretRef.FindAllStringSubmatch(s, -1) {
+		if m[1] != "" {
+			names = append(names, m[1])
+		} else {
+			names = append(names, m[2])
+		}
+	}
+	return names
+}
+
+// actionFindings reports actions and reusable workflows that lost their
+// SHA pin, and third-party ones the old workflow didn't use.
+func actionFindings(oldWf, newWf map[string]any) []Finding {
+	oldPinned := make(map[string]bool)
+	oldUsed := make(map[string]bool)
+	for _, u := range actionUses(oldWf) {
+		repo, ref, ok := pin.Split(u.ref)
+		if !ok {
+			continue
+		}
+		oldUsed[repo] = true
+		if pin.IsSHA(ref) {
+			oldPinned[repo] = true
+		}
+	}
+
+	var findings []Finding
+	reported := make(map[string]bool)
+	for _, u := range actionUses(newWf) {
+		repo, ref, ok := pin.Split(u.ref)
+		if !ok {
+			continue
+		}
+		switch {
+		case oldPinned[repo] && !pin.IsSHA(ref):
+			findings = append(findings, Finding{
+				Risk:    RiskHigh,
+				Rule:    RulePinRemoved,
+				Path:    u.path,
+				Message: fmt.Sprintf("%s is no longer pinned to a commit SHA", u.ref),
+			})
+		case !oldUsed[repo] && !reported[repo] && !firstPartyOwners[strings.ToLower(strings.Split(repo, "/")[0])]:
+			reported[repo] = true
+			message := "new third-party action " + u.ref
+			if !pin.IsSHA(ref) {
+				message += ", not pinned to a commit SHA"
+			}
+			findings = append(findings, Finding{
+				Risk:    RiskMedium,
+				Rule:    RuleThirdPartyAction,
+				Path:    u.path,
+				Message: message,
+			})
+		}
+	}
+	return findings
+}
+
+// actionUse is a uses reference of a workflow.
+type actionUse struct {
+	path string
+	ref  string
+}
+
+// actionUses returns the uses references of a workflow's jobs and steps,
+// in path order.
+func actionUses(wf map[string]any) []actionUse {
+	jobs, _ := wf["jobs"].(map[string]any)
+	var uses []actionUse
+	for _, id := range sortedKeys(jobs) {
+		job, _ := jobs[id].(map[string]any)
+		if ref, ok := job["uses"].(string); ok {
+			uses = append(uses, actionUse{path: "jobs." + id + ".uses", ref: ref})
+		}
+		steps, _ := job["steps"].([]any)
+		for i, step := range steps {
+			if ref := stepField(step, "uses"); ref != "" {
+				uses = append(uses, actionUse{
+					path: fmt.Sprintf("jobs.%s.steps[%s].uses", id, stepLabel(step, i)),
+					ref:  ref,
+				})
+			}
+		}
+	}
+	return uses
+}
+
+// checkoutFindings reports persist-credentials changes on the
+// actions/checkout steps of a job, matched as by CompareDocuments. The
+// input defaults to true, so a new checkout step, including one in a new
+// job, enables it unless it sets it to false.
+func checkoutFindings(path string, oldSteps, newSteps any) []Finding {
+	a, _ := oldSteps.([]any)
+	b, _ := newSteps.([]any)
+
+	matched := make(map[int]int)
+	for i, j := range matchSteps(a, b) {
+		matched[j] = i
+	}
+
+	var findings []Finding
+	for j, step := range b {
+		if !isCheckout(step) {
+			continue
+		}
+		before := false
+		if i, ok := matched[j]; ok && isCheckout(a[i]) {
+			before = persistsCredentials(a[i])
+		}
+		after := persistsCredentials(step)
+		if before == after {
+			continue
+		}
+		f := Finding{
+			Risk:    RiskHigh,
+			Rule:    RulePersistCredentials,
+			Path:    fmt.Sprintf("%s[%s].with.persist-credentials", path, stepLabel(step, j)),
+			Message: "persist-credentials enabled; the token stays in .git/config for later steps",
+		}
+		if !after {
+			f.Risk = RiskLow
+			f.Message = "persist-credentials disabled"
+		}
+		findings = append(findings, f)
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Path < findings[j].Path })
+	return findings
+}
+
+// isCheckout reports whether a step uses actions/checkout.
+func isCheckout(step any) bool {
+	repo, _, ok := pin.Split(stepField(step, "uses"))
+	return ok && strings.EqualFold(repo, "actions/checkout")
+}
+
+// persistsCredentials reports whether a checkout step leaves the token in
+// the repository's git config.
+func persistsCredentials(step any) bool {
+	m, _ := step.(map[string]any)
+	with, _ := m["with"].(map[string]any)
+	v, ok := with["persist-credentials"]
+	return !ok || fmt.Sprint(v) != "false"
+}
+
+// walkStrings calls fn with the path of every string in a decoded
+// document. Steps are labeled as in CompareDocuments.
+func walkStrings(v any, path string, fn func(path, s string)) {
+	switch val := v.(type) {
+	case string:
+		fn(path, val)
+	case map[string]any:
+		for _, k := range sortedKeys(val) {
+			walkStrings(val[k], joinPath(path, k), fn)
+		}
+	case []any:
+		for i, item := range val {
+			label := fmt.Sprint(i)
+			if strings.HasSuffix(path, ".steps") {
+				label = stepLabel(item, i)
+			}
+			walkStrings(item, fmt.Sprintf("%s[%s]", path, label), fn)
+		}
+	}
+}
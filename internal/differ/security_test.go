@@ -0,0 +1,186 @@
+package differ
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// classify diffs two workflows and returns the rule and path of each
+// finding.
+func classify(t *testing.T, old, changed string) []string {
+	t.Helper()
+	var oldFiles []File
+	if old != "" {
+		oldFiles = []File{{Path: "ci.yml", Kind: KindWorkflow, Content: []byte(old)}}
+	}
+	newFiles := []File{{Path: "ci.yml", Kind: KindWorkflow, Content: []byte(changed)}}
+
+	diffs, err := DiffFiles(oldFiles, newFiles)
+	if err != nil {
+		t.Fatalf("DiffFiles() error = %v", err)
+	}
+	var got []string
+	for _, d := range diffs {
+		for _, f := range d.Findings {
+			got = append(got, f.Risk+" "+f.Rule+" "+f.Path)
+		}
+	}
+	return got
+}
+
+func TestClassify(t *testing.T) {
+	base := `on: push
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11
+        with:
+          persist-credentials: false
+      - run: make
+        env:
+          TOKEN: ${{ secrets.NPM_TOKEN }}
+`
+	tests := []struct {
+		name    string
+		changed string
+		want    []string
+	}{
+		{
+			name:    "permission raised",
+			changed: replace(base, "contents: read", "contents: write"),
+			want:    []string{"high permission-raised permissions.contents"},
+		},
+		{
+			name:    "write-all",
+			changed: replace(base, "permissions:\n  contents: read", "permissions: write-all"),
+			want:    []string{"high permission-raised permissions"},
+		},
+		{
+			name:    "permissions removed",
+			changed: replace(base, "permissions:\n  contents: read\n", ""),
+			want:    []string{"medium permissions-removed permissions"},
+		},
+		{
+			name:    "permission lowered",
+			changed: replace(base, "contents: read", "contents: none"),
+		},
+		{
+			name:    "job permission added",
+			changed: replace(base, "    runs-on: ubuntu-latest\n", "    runs-on: ubuntu-latest\n    permissions:\n      packages: write\n"),
+			want:    []string{"high permission-raised jobs.build.permissions.packages"},
+		},
+		{
+			name:    "pull_request_target",
+			changed: replace(base, "on: push", "on: [push, pull_request_target, workflow_run]"),
+			want:    []string{"high dangerous-trigger on.pull_request_target", "high dangerous-trigger on.workflow_run"},
+		},
+		{
+			name:    "pin removed",
+			changed: replace(base, "@b4ffde65f46336ab88eb53be808477a3936bae11", "@v4"),
+			want:    []string{"high pin-removed jobs.build.steps[0].uses"},
+		},
+		{
+			name:    "persist-credentials enabled",
+			changed: replace(base, "        with:\n          persist-credentials: false\n", ""),
+			want:    []string{"high persist-credentials jobs.build.steps[0].with.persist-credentials"},
+		},
+		{
+			name:    "new secret",
+			changed: replace(base, "NPM_TOKEN }}", "NPM_TOKEN }}\n          KEY: ${{ secrets['DEPLOY_KEY'] }}"),
+			want:    []string{"medium new-secret jobs.build.steps[1].env.KEY"},
+		},
+		{
+			name:    "third-party action",
+			changed: replace(base, "      - run: make\n", "      - uses: octo/lint@v1\n      - uses: github/codeql-action/init@v3\n      - run: make\n"),
+			want:    []string{"medium third-party-action jobs.build.steps[1].uses"},
+		},
+		{
+			name:    "secrets inherit",
+			changed: base + "  deploy:\n    uses: ./.github/workflows/deploy.yml\n    secrets: inherit\n",
+			want:    []string{"medium secrets-inherit jobs.deploy.secrets"},
+		},
+		{
+			name:    "unrelated change",
+			changed: replace(base, "run: make", "run: make all"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classify(t, base, tt.changed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassify_PersistCredentialsDisabled(t *testing.T) {
+	old := "jobs:\n  a:\n    steps:\n      - uses: actions/checkout@v4\n"
+	changed := "jobs:\n  a:\n    steps:\n      - uses: actions/checkout@v4\n        with:\n          persist-credentials: false\n"
+	want := []string{"low persist-credentials jobs.a.steps[0].with.persist-credentials"}
+	if got := classify(t, old, changed); !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestClassify_NewCheckoutSteps(t *testing.T) {
+	old := "jobs:\n  a:\n    steps:\n      - run: make\n"
+	changed := `jobs:
+  a:
+    steps:
+      - uses: actions/checkout@v4
+      - run: make
+      - uses: actions/checkout@v4
+        with:
+          persist-credentials: false
+  b:
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+`
+	want := []string{
+		"high persist-credentials jobs.a.steps[0].with.persist-credentials",
+		`high persist-credentials jobs.b.steps[name="Checkout"].with.persist-credentials`,
+	}
+	if got := classify(t, old, changed); !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestClassify_AddedWorkflow(t *testing.T) {
+	changed := `on: pull_request_target
+permissions:
+  pull-requests: write
+jobs:
+  a:
+    steps:
+      - uses: actions/checkout@v4
+`
+	want := []string{
+		"high persist-credentials jobs.a.steps[0].with.persist-credentials",
+		"high dangerous-trigger on.pull_request_target",
+		"high permission-raised permissions.pull-requests",
+	}
+	if got := classify(t, "", changed); !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestClassify_OtherKinds(t *testing.T) {
+	d := FileDiff{Kind: KindDependabot, New: map[string]any{"permissions": "write-all"}}
+	if findings := Classify(d); findings != nil {
+		t.Errorf("Classify() = %v, want none for dependabot", findings)
+	}
+}
+
+// replace replaces old with new in s, and panics if s lacks old so a
+// broken test case doesn't pass unchanged.
+func replace(s, old, new string) string {
+	if !strings.Contains(s, old) {
+		panic("replace: " + old + " not found")
+	}
+	return strings.Replace(s, old, new, 1)
+}
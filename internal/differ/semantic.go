@@ -0,0 +1,413 @@
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// Change is a single difference between two decoded YAML documents.
+type Change struct {
+	// Path is the dotted path of the value, e.g. jobs.build.steps[0].run
+	Path string `json:"path"`
+
+	// Old is the value in the first document, nil if it was added
+	Old any `json:"old,omitempty"`
+
+	// New is the value in the second document, nil if it was removed
+	New any `json:"new,omitempty"`
+}
+
+// String describes the change on one line.
+func (c Change) String() string {
+	switch {
+	case c.New == nil:
+		return fmt.Sprintf("%s: removed %s", c.Path, FormatValue(c.Old))
+	case c.Old == nil:
+		return fmt.Sprintf("%s: added %s", c.Path, FormatValue(c.New))
+	}
+	return fmt.Sprintf("%s: %s → %s", c.Path, FormatValue(c.Old), FormatValue(c.New))
+}
+
+// FormatValue formats a decoded YAML value compactly, as JSON for
+// mappings and lists. Long values are truncated.
+func FormatValue(v any) string {
+	var s string
+	switch v.(type) {
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		s = string(data)
+	case string:
+		s = fmt.Sprintf("%q", v)
+	default:
+		s = fmt.Sprint(v)
+	}
+	if len(s) > 80 {
+		s = s[:77] + "..."
+	}
+	return s
+}
+
+// Compare returns the differences between two documents normalized with
+// one of the Normalize functions, in path order. Scalars are compared by
+// their string form, so quoting doesn't matter.
+func Compare(a, b any) []Change {
+	return compareValues(a, b, "")
+}
+
+func compareValues(a, b any, path string) []Change {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		var changes []Change
+		for _, k := range keys {
+			changes = append(changes, compareValues(av[k], bv[k], joinPath(path, k))...)
+		}
+		return changes
+
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		var changes []Change
+		for i := 0; i < len(av) || i < len(bv); i++ {
+			var ai, bi any
+			if i < len(av) {
+				ai = av[i]
+			}
+			if i < len(bv) {
+				bi = bv[i]
+			}
+			changes = append(changes, compareValues(ai, bi, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return changes
+	}
+
+	if a == nil && b == nil {
+		return nil
+	}
+	if a != nil && b != nil && isScalar(a) && isScalar(b) && fmt.Sprint(a) == fmt.Sprint(b) {
+		return nil
+	}
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	return []Change{{Path: path, Old: a, New: b}}
+}
+
+// isScalar reports whether v is a decoded YAML scalar.
+func isScalar(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	return true
+}
+
+// joinPath appends a key to a dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// triggerFilters are the trigger settings that take a list but also
+// accept a single string.
+var triggerFilters = map[string]bool{
+	"branches":        true,
+	"branches-ignore": true,
+	"tags":            true,
+	"tags-ignore":     true,
+	"paths":           true,
+	"paths-ignore":    true,
+	"types":           true,
+	"workflows":       true,
+}
+
+// NormalizeWorkflow returns a decoded workflow in a canonical form:
+// on: is a mapping of events, filters and needs: are lists, a single
+// runs-on label is a string, a job name equal to its ID and settings that
+// are false by default are dropped, expressions are reprinted, and empty
+// values are removed.
+func NormalizeWorkflow(doc any) any {
+	wf, ok := doc.(map[string]any)
+	if !ok {
+		return NormalizeDocument(doc)
+	}
+
+	on := wf["on"]
+	rest := make(map[string]any, len(wf))
+	for k, v := range wf {
+		if k != "on" {
+			rest[k] = v
+		}
+	}
+	out, _ := NormalizeDocument(rest).(map[string]any)
+	if out == nil {
+		out = make(map[string]any)
+	}
+	if on != nil {
+		out["on"] = normalizeTriggers(on)
+	}
+
+	// permissions: {} revokes every permission, so unlike other empty
+	// mappings it is kept
+	keepEmptyPermissions(wf, out)
+	if concurrency, ok := out["concurrency"].(map[string]any); ok {
+		dropFalse(concurrency, "cancel-in-progress")
+	}
+
+	origJobs, _ := wf["jobs"].(map[string]any)
+	jobs, _ := out["jobs"].(map[string]any)
+	for id, j := range jobs {
+		job, ok := j.(map[string]any)
+		if !ok {
+			continue
+		}
+		if origJob, ok := origJobs[id].(map[string]any); ok {
+			keepEmptyPermissions(origJob, job)
+		}
+		if name, ok := job["name"].(string); ok && name == id {
+			delete(job, "name")
+		}
+		dropFalse(job, "continue-on-error")
+		if concurrency, ok := job["concurrency"].(map[string]any); ok {
+			dropFalse(concurrency, "cancel-in-progress")
+		}
+		if needs, ok := job["needs"]; ok && isScalar(needs) {
+			job["needs"] = []any{needs}
+		}
+		if labels, ok := job["runs-on"].([]any); ok && len(labels) == 1 {
+			job["runs-on"] = labels[0]
+		}
+		if cond, ok := job["if"]; ok {
+			job["if"] = normalizeCondition(cond)
+		}
+		steps, _ := job["steps"].([]any)
+		for _, s := range steps {
+			if step, ok := s.(map[string]any); ok {
+				dropFalse(step, "continue-on-error")
+				if cond, ok := step["if"]; ok {
+					step["if"] = normalizeCondition(cond)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// keepEmptyPermissions restores an empty permissions: mapping of orig
+// that normalizing removed from out.
+func keepEmptyPermissions(orig, out map[string]any) {
+	if perms, ok := orig["permissions"].(map[string]any); ok && len(perms) == 0 {
+		out["permissions"] = map[string]any{}
+	}
+}
+
+// normalizeTriggers converts the string and list forms of on: to a
+// mapping. An event without settings maps to an empty mapping.
+func normalizeTriggers(on any) any {
+	events := make(map[string]any)
+	switch v := on.(type) {
+	case string:
+		events[v] = map[string]any{}
+	case []any:
+		for _, e := range v {
+			events[fmt.Sprint(e)] = map[string]any{}
+		}
+	case map[string]any:
+		for event, cfg := range v {
+			normalized := NormalizeDocument(cfg)
+			if normalized == nil {
+				normalized = map[string]any{}
+			}
+			settings, ok := normalized.(map[string]any)
+			if !ok {
+				events[event] = normalized
+				continue
+			}
+			for k, filter := range settings {
+				if triggerFilters[k] && isScalar(filter) {
+					settings[k] = []any{filter}
+				}
+			}
+			events[event] = settings
+		}
+	default:
+		return on
+	}
+	return events
+}
+
+// normalizeCondition removes the optional ${{ }} around an if: condition
+// and reprints it.
+func normalizeCondition(cond any) any {
+	s, ok := cond.(string)
+	if !ok {
+		return cond
+	}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "${{") && strings.HasSuffix(s, "}}") && strings.Count(s, "${{") == 1 {
+		s = strings.TrimSpace(s[3 : len(s)-2])
+	}
+	return canonicalExpression(s)
+}
+
+// NormalizeForm returns a decoded issue or discussion form in a canonical
+// form: comma-separated labels, assignees and projects are lists, and
+// empty and false values are removed.
+func NormalizeForm(doc any) any {
+	form, ok := normalizeSettings(doc).(map[string]any)
+	if !ok {
+		return doc
+	}
+	for _, key := range []string{"labels", "assignees", "projects"} {
+		s, ok := form[key].(string)
+		if !ok {
+			continue
+		}
+		var list []any
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		form[key] = list
+	}
+	return form
+}
+
+// NormalizeDependabot returns a decoded dependabot.yml without empty
+// values and without false ones, which all its settings default to.
+func NormalizeDependabot(doc any) any {
+	return normalizeSettings(doc)
+}
+
+// normalizeSettings normalizes a document whose settings all default to
+// false.
+func normalizeSettings(doc any) any {
+	return NormalizeDocument(withoutFalse(doc))
+}
+
+// withoutFalse returns a copy of doc without the mapping entries that
+// are false.
+func withoutFalse(doc any) any {
+	switch v := doc.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			if item != false {
+				out[k] = withoutFalse(item)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = withoutFalse(item)
+		}
+		return out
+	}
+	return doc
+}
+
+// dropFalse deletes key from m if it is false.
+func dropFalse(m map[string]any, key string) {
+	if m[key] == false {
+		delete(m, key)
+	}
+}
+
+// NormalizeDocument returns a copy of a decoded YAML document without
+// null, empty string and empty collection values, and with the
+// expressions in its strings reprinted.
+func NormalizeDocument(doc any) any {
+	switch v := doc.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			if n := NormalizeDocument(item); n != nil {
+				out[k] = n
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = NormalizeDocument(item)
+		}
+		return out
+	case string:
+		if v == "" {
+			return nil
+		}
+		return normalizeExpressions(v)
+	}
+	return doc
+}
+
+// embeddedExpression matches a ${{ }} expression inside a string.
+var embeddedExpression = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+
+// normalizeExpressions reprints the ${{ }} expressions in s.
+func normalizeExpressions(s string) string {
+	if !strings.Contains(s, "${{") {
+		return s
+	}
+	return embeddedExpression.ReplaceAllStringFunc(s, func(match string) string {
+		inner := strings.TrimSpace(match[3 : len(match)-2])
+		return "${{ " + canonicalExpression(inner) + " }}"
+	})
+}
+
+// canonicalExpression reprints an expression, or returns it unchanged if
+// it doesn't parse.
+func canonicalExpression(expr string) string {
+	node, err := workflow.ParseExpression(expr)
+	if err != nil {
+		return expr
+	}
+	return node.String()
+}
+
+// NormalizeCodeowners returns the rules of a CODEOWNERS file as a list of
+// lines, without comments, blank lines and repeated whitespace.
+func NormalizeCodeowners(content []byte) any {
+	var rules []any
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			rules = append(rules, strings.Join(fields, " "))
+		}
+	}
+	return map[string]any{"rules": rules}
+}
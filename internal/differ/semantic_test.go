@@ -0,0 +1,135 @@
+package differ
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decode decodes a YAML document for the tests.
+func decode(t *testing.T, content string) any {
+	t.Helper()
+	var doc any
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestNormalizeWorkflow_Equivalent(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{
+			name: "on string",
+			a:    "on: push\n",
+			b:    "on:\n  push:\n",
+		},
+		{
+			name: "on list",
+			a:    "on: [push, pull_request]\n",
+			b:    "on:\n  pull_request: {}\n  push: {}\n",
+		},
+		{
+			name: "single filter",
+			a:    "on:\n  push:\n    branches: main\n",
+			b:    "on:\n  push:\n    branches: [main]\n",
+		},
+		{
+			name: "quoting",
+			a:    "env:\n  N: 1\n  B: true\n",
+			b:    "env:\n  N: \"1\"\n  B: 'true'\n",
+		},
+		{
+			name: "needs and runs-on",
+			a:    "jobs:\n  b:\n    needs: a\n    runs-on: [ubuntu-latest]\n",
+			b:    "jobs:\n  b:\n    needs: [a]\n    runs-on: ubuntu-latest\n",
+		},
+		{
+			name: "conditions and expressions",
+			a:    "jobs:\n  b:\n    if: ${{ github.ref=='refs/heads/main' }}\n    steps:\n      - run: echo ${{github.sha}}\n",
+			b:    "jobs:\n  b:\n    if: github.ref == 'refs/heads/main'\n    steps:\n      - run: echo ${{ github.sha }}\n",
+		},
+		{
+			name: "defaults",
+			a:    "jobs:\n  build:\n    name: build\n    continue-on-error: false\n    steps:\n      - run: make\n        continue-on-error: false\n        env: {}\n",
+			b:    "jobs:\n  build:\n    steps:\n      - run: make\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NormalizeWorkflow(decode(t, tt.a))
+			b := NormalizeWorkflow(decode(t, tt.b))
+			if changes := Compare(a, b); len(changes) > 0 {
+				t.Errorf("Compare() = %v, want no changes", changes)
+			}
+		})
+	}
+}
+
+func TestNormalizeWorkflow_EmptyPermissions(t *testing.T) {
+	a := NormalizeWorkflow(decode(t, "permissions: {}\njobs:\n  b:\n    permissions: {}\n    runs-on: x\n"))
+	b := NormalizeWorkflow(decode(t, "jobs:\n  b:\n    runs-on: x\n"))
+
+	var paths []string
+	for _, c := range Compare(a, b) {
+		paths = append(paths, c.Path)
+	}
+	if want := []string{"jobs.b.permissions", "permissions"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("changed paths = %v, want %v", paths, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := map[string]any{
+		"env":   map[string]any{"A": "1", "B": "2"},
+		"steps": []any{"x", "y"},
+	}
+	b := map[string]any{
+		"env":   map[string]any{"A": "1", "C": "3"},
+		"steps": []any{"x", "z", "w"},
+	}
+	want := []Change{
+		{Path: "env.B", Old: "2"},
+		{Path: "env.C", New: "3"},
+		{Path: "steps[1]", Old: "y", New: "z"},
+		{Path: "steps[2]", New: "w"},
+	}
+	if got := Compare(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %v, want %v", got, want)
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	tests := []struct {
+		change Change
+		want   string
+	}{
+		{Change{Path: "a", Old: "x"}, `a: removed "x"`},
+		{Change{Path: "a", New: map[string]any{"k": "v"}}, `a: added {"k":"v"}`},
+		{Change{Path: "a", Old: 1, New: 2}, "a: 1 → 2"},
+	}
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeForm(t *testing.T) {
+	a := NormalizeForm(decode(t, "labels: bug, triage\nbody:\n  - type: input\n    validations:\n      required: false\n"))
+	b := NormalizeForm(decode(t, "labels: [bug, triage]\nbody:\n  - type: input\n"))
+	if changes := Compare(a, b); len(changes) > 0 {
+		t.Errorf("Compare() = %v, want no changes", changes)
+	}
+}
+
+func TestNormalizeCodeowners(t *testing.T) {
+	a := NormalizeCodeowners([]byte("# Owners\n*   @org/team # everyone\n\n/docs/ @org/docs\n"))
+	b := NormalizeCodeowners([]byte("* @org/team\n/docs/ @org/docs\n"))
+	if changes := Compare(a, b); len(changes) > 0 {
+		t.Errorf("Compare() = %v, want no changes", changes)
+	}
+}
@@ -0,0 +1,135 @@
+package discover
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DiscoveredAction represents an action declaration found by AST parsing.
+type DiscoveredAction struct {
+	Name string // Variable name
+	File string // Source file path
+	Line int    // Line number
+}
+
+// ActionDiscoveryResult contains discovered action declarations.
+type ActionDiscoveryResult struct {
+	Actions []DiscoveredAction
+	Errors  []string
+}
+
+// DiscoverActions finds all action declarations in the given directory.
+func (d *Discoverer) DiscoverActions(dir string) (*ActionDiscoveryResult, error) {
+	result := &ActionDiscoveryResult{
+		Actions: []DiscoveredAction{},
+		Errors:  []string{},
+	}
+
+	// Walk the directory tree
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip hidden directories and vendor
+		if info.IsDir() {
+			name := info.Name()
+			if strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Only process .go files
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		// Skip test files
+		if strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		// Parse the file
+		file, err := parser.ParseFile(d.fset, path, nil, parser.ParseComments)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			return nil
+		}
+
+		// Find action variables
+		d.processActionFile(file, path, result)
+
+		return nil
+	})
+
+	return result, err
+}
+
+// processActionFile processes a single Go file to find action declarations.
+func (d *Discoverer) processActionFile(file *ast.File, path string, result *ActionDiscoveryResult) {
+	// Check if this file imports the action package
+	if !d.hasActionImport(file) {
+		return
+	}
+
+	// Look for package-level variable declarations
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			for i, name := range valueSpec.Names {
+				// Check if this is an action.Action
+				if valueSpec.Type != nil {
+					typeName := d.getTypeName(valueSpec.Type)
+					if typeName == "action.Action" || typeName == "Action" {
+						pos := d.fset.Position(name.Pos())
+						result.Actions = append(result.Actions, DiscoveredAction{
+							Name: name.Name,
+							File: path,
+							Line: pos.Line,
+						})
+					}
+				}
+
+				// If no explicit type, check the value
+				if valueSpec.Type == nil && len(valueSpec.Values) > i {
+					typeName := d.inferTypeFromValue(valueSpec.Values[i])
+					if typeName == "action.Action" || typeName == "Action" {
+						pos := d.fset.Position(name.Pos())
+						result.Actions = append(result.Actions, DiscoveredAction{
+							Name: name.Name,
+							File: path,
+							Line: pos.Line,
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+// hasActionImport checks if the file imports the action package.
+func (d *Discoverer) hasActionImport(file *ast.File) bool {
+	for _, imp := range file.Imports {
+		if imp.Path != nil {
+			path := strings.Trim(imp.Path.Value, `"`)
+			if strings.HasSuffix(path, "/action") || path == "action" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverer_DiscoverActions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "actions.go")
+	content := `package main
+
+import (
+	"github.com/lex00/wetwire-github-go/action"
+	"github.com/lex00/wetwire-github-go/actions/checkout"
+)
+
+var SetupToolchain = action.Action{
+	Name: "Setup toolchain",
+	Runs: action.Composite(checkout.Checkout{}),
+}
+
+var Lint action.Action
+
+var Other = checkout.Checkout{}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	d := NewDiscoverer()
+	result, err := d.DiscoverActions(tmpDir)
+	if err != nil {
+		t.Fatalf("DiscoverActions() error = %v", err)
+	}
+
+	if len(result.Actions) != 2 {
+		t.Fatalf("len(result.Actions) = %d, want 2", len(result.Actions))
+	}
+	if result.Actions[0].Name != "SetupToolchain" || result.Actions[1].Name != "Lint" {
+		t.Errorf("Actions = %+v, want SetupToolchain and Lint", result.Actions)
+	}
+}
+
+func TestDiscoverer_DiscoverActions_IgnoresActionWrappers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Importing an actions/* wrapper package is not an action declaration
+	testFile := filepath.Join(tmpDir, "steps.go")
+	content := `package main
+
+import "github.com/lex00/wetwire-github-go/actions/checkout"
+
+var Action = checkout.Checkout{}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	result, err := NewDiscoverer().DiscoverActions(tmpDir)
+	if err != nil {
+		t.Fatalf("DiscoverActions() error = %v", err)
+	}
+	if len(result.Actions) != 0 {
+		t.Errorf("len(result.Actions) = %d, want 0", len(result.Actions))
+	}
+}
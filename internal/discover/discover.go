@@ -24,6 +24,7 @@ type DiscoveredJob struct {
 	File         string   // Source file path
 	Line         int      // Line number
 	Dependencies []string // Referenced job names (Needs field)
+	Uses         string   // Referenced reusable workflow variable (Uses field)
 }
 
 // DiscoveryResult contains all discovered resources.
@@ -128,6 +129,7 @@ func (d *Discoverer) processFile(file *ast.File, path string, result *DiscoveryR
 						// Try to extract dependencies from the value
 						if i < len(valueSpec.Values) {
 							job.Dependencies = d.extractDependencies(valueSpec.Values[i])
+							job.Uses = d.extractUses(valueSpec.Values[i])
 						}
 						result.Jobs = append(result.Jobs, job)
 					}
@@ -155,6 +157,7 @@ func (d *Discoverer) processFile(file *ast.File, path string, result *DiscoveryR
 							File:         path,
 							Line:         pos.Line,
 							Dependencies: d.extractDependencies(value),
+							Uses:         d.extractUses(value),
 						}
 						result.Jobs = append(result.Jobs, job)
 					}
@@ -251,6 +254,42 @@ func (d *Discoverer) extractDependencies(expr ast.Expr) []string {
 	return deps
 }
 
+// extractUses extracts the variable referenced by a job's Uses field.
+// Returns "" when Uses is absent or is not an identifier, optionally
+// qualified by a package name.
+func (d *Discoverer) extractUses(expr ast.Expr) string {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != "Uses" {
+			continue
+		}
+
+		value := kv.Value
+		if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			value = unary.X
+		}
+		switch v := value.(type) {
+		case *ast.Ident:
+			return v.Name
+		case *ast.SelectorExpr:
+			// A workflow declared in another package of the module
+			return v.Sel.Name
+		}
+	}
+
+	return ""
+}
+
 // extractIdentifiers extracts all identifiers from an expression.
 func (d *Discoverer) extractIdentifiers(expr ast.Expr) []string {
 	var ids []string
@@ -643,6 +643,61 @@ var Build = workflow.Job{
 	}
 }
 
+func TestDiscoverer_JobUsesWorkflow(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "workflows.go")
+	content := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Shared = workflow.Workflow{
+	Name: "Shared",
+	On:   workflow.Triggers{WorkflowCall: &workflow.WorkflowCallTrigger{}},
+}
+
+var CallShared = workflow.Job{
+	Uses: Shared,
+}
+
+var CallPointer = workflow.Job{
+	Uses: &Shared,
+}
+
+var CallRemote = workflow.Job{
+	Uses: "org/repo/.github/workflows/ci.yml@v1",
+}
+
+var CallOther = workflow.Job{
+	Uses: deploy.Release,
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	d := NewDiscoverer()
+	result, err := d.Discover(tmpDir)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	want := map[string]string{
+		"CallShared":  "Shared",
+		"CallPointer": "Shared",
+		"CallRemote":  "",
+		"CallOther":   "Release",
+	}
+	if len(result.Jobs) != len(want) {
+		t.Fatalf("len(result.Jobs) = %d, want %d", len(result.Jobs), len(want))
+	}
+	for _, job := range result.Jobs {
+		if job.Uses != want[job.Name] {
+			t.Errorf("job %s: Uses = %q, want %q", job.Name, job.Uses, want[job.Name])
+		}
+	}
+}
+
 func TestDiscoverer_NestedCompositeLiteral(t *testing.T) {
 	tmpDir := t.TempDir()
 
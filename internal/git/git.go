@@ -0,0 +1,26 @@
+// Package git runs the git commands used to read local repositories, such
+// as the revisions compared by diff --rev and the clones pin resolves refs
+// from.
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Run runs git with args in dir and returns its output with surrounding
+// whitespace trimmed. When git fails, the error holds its stderr, or the
+// exit status if it printed nothing.
+func Run(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
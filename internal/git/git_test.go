@@ -0,0 +1,33 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+
+	if _, err := Run(dir, "init", "-q"); err != nil {
+		t.Fatalf("Run(init) error = %v", err)
+	}
+	out, err := Run(dir, "rev-parse", "--is-inside-work-tree")
+	if err != nil {
+		t.Fatalf("Run(rev-parse) error = %v", err)
+	}
+	if out != "true" {
+		t.Errorf("Run(rev-parse) = %q, want trimmed output", out)
+	}
+
+	_, err = Run(dir, "rev-parse", "--verify", "no-such-ref")
+	if err == nil {
+		t.Fatal("Run() should fail for an unknown ref")
+	}
+	if !strings.HasPrefix(err.Error(), "git rev-parse: ") || !strings.Contains(err.Error(), "fatal") {
+		t.Errorf("error = %v, want git's stderr", err)
+	}
+}
@@ -0,0 +1,104 @@
+// Package glob matches names against GitHub Actions filter patterns, as
+// used by branch, tag and path filters and by the linter's ignore list.
+package glob
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MatchPattern reports whether name matches a GitHub Actions filter pattern.
+//
+// Patterns use GitHub's filter syntax:
+//   - "*" matches zero or more characters except "/"
+//   - "**" matches zero or more of any character
+//   - "?" and "+" match zero-or-one and one-or-more of the preceding character;
+//     at the start of a pattern or after a wildcard they match themselves
+//   - "[]" matches one character in a set or range
+//   - "\" escapes the next character
+func MatchPattern(pattern, name string) bool {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(name)
+}
+
+// MatchFilters reports whether name is selected by an ordered list of
+// patterns. Patterns prefixed with "!" exclude names, and the last matching
+// pattern wins.
+func MatchFilters(patterns []string, name string) bool {
+	matched := false
+	for _, p := range patterns {
+		if strings.HasPrefix(p, "!") {
+			if MatchPattern(p[1:], name) {
+				matched = false
+			}
+			continue
+		}
+		if MatchPattern(p, name) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// compilePattern converts a filter pattern to an anchored regular expression.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	var re strings.Builder
+	re.WriteString("^")
+
+	// repeatable is whether the last element written is a single character
+	// or set that "?" and "+" can apply to
+	repeatable := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		repeated := repeatable
+		repeatable = true
+		switch c {
+		case '\\':
+			if i+1 < len(pattern) {
+				_, size := utf8.DecodeRuneInString(pattern[i+1:])
+				re.WriteString(regexp.QuoteMeta(pattern[i+1 : i+1+size]))
+				i += size
+			}
+		case '*':
+			repeatable = false
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "**/" also matches no directories at all
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					re.WriteString("(?:.*/)?")
+				} else {
+					re.WriteString(".*")
+				}
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?', '+':
+			if repeated {
+				re.WriteByte(c)
+				repeatable = false
+			} else {
+				re.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				re.WriteString(`\[`)
+				continue
+			}
+			re.WriteString("[" + pattern[i+1:i+1+end] + "]")
+			i += end + 1
+		default:
+			_, size := utf8.DecodeRuneInString(pattern[i:])
+			re.WriteString(regexp.QuoteMeta(pattern[i : i+size]))
+			i += size - 1
+		}
+	}
+
+	re.WriteString("$")
+	return regexp.Compile(re.String())
+}
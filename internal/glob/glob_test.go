@@ -0,0 +1,66 @@
+package glob
+
+import "testing"
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "main", name: "main", want: true},
+		{pattern: "main", name: "main2", want: false},
+		{pattern: "feature/*", name: "feature/login", want: true},
+		{pattern: "feature/*", name: "feature/login/ui", want: false},
+		{pattern: "feature/**", name: "feature/login/ui", want: true},
+		{pattern: "releases/**-alpha", name: "releases/beta/3-alpha", want: true},
+		{pattern: "v[12].*", name: "v2.0", want: true},
+		{pattern: "v[12].*", name: "v3.0", want: false},
+		{pattern: "v2*", name: "v2.0", want: true},
+		{pattern: "v1.?", name: "v1.", want: true},
+		{pattern: "ab+c", name: "abbbc", want: true},
+		{pattern: "ab+c", name: "ac", want: false},
+		{pattern: "docs/**", name: "docs/a/b.md", want: true},
+		{pattern: "**.js", name: "src/app.js", want: true},
+		{pattern: "**/*.go", name: "main.go", want: true},
+		{pattern: "**/*.go", name: "cmd/tool/main.go", want: true},
+		{pattern: "*.md", name: "docs/readme.md", want: false},
+		{pattern: `\*literal`, name: "*literal", want: true},
+		{pattern: "?.txt", name: "?.txt", want: true},
+		{pattern: "+x", name: "+x", want: true},
+		{pattern: "**/+config", name: "a/+config", want: true},
+		{pattern: "*?", name: "file?", want: true},
+		{pattern: "docs/café.md", name: "docs/café.md", want: true},
+		{pattern: `docs/\é.md`, name: "docs/é.md", want: true},
+		{pattern: "caf[eé]", name: "café", want: true},
+		{pattern: "naï+ve", name: "naïïve", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.name, func(t *testing.T) {
+			if got := MatchPattern(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchFilters(t *testing.T) {
+	patterns := []string{"releases/**", "!releases/**-alpha", "releases/special-alpha"}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "releases/v1", want: true},
+		{name: "releases/v1-alpha", want: false},
+		{name: "releases/special-alpha", want: true},
+		{name: "main", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchFilters(patterns, tt.name); got != tt.want {
+			t.Errorf("MatchFilters(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
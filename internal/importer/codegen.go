@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/lex00/wetwire-github-go/workflow"
 )
 
 // CodeGenerator generates Go code from parsed YAML.
@@ -13,6 +15,17 @@ type CodeGenerator struct {
 	PackageName string
 	// SingleFile puts all code in one file when true
 	SingleFile bool
+	// Names holds the declaration names already used in the package.
+	// Generate renames declarations that would collide with them and adds
+	// its own, so several workflows can share one package.
+	Names map[string]bool
+
+	// workflowVar and jobVars are the names Generate chose
+	workflowVar string
+	jobVars     map[string]string
+
+	// written is the workflow document the generated code represents
+	written map[string]any
 }
 
 // NewCodeGenerator creates a new CodeGenerator.
@@ -32,6 +45,14 @@ type GeneratedCode struct {
 	Jobs int
 	// Steps is the count of generated steps
 	Steps int
+	// Renamed describes declarations that got a different name to avoid
+	// a collision, such as "job build is ReleaseBuild"
+	Renamed []string
+	// WorkflowVar is the name of the workflow variable
+	WorkflowVar string
+	// Written is the workflow document the generated code represents.
+	// The fields of the original workflow missing from it are lost.
+	Written map[string]any
 }
 
 // Generate generates Go code from a parsed workflow.
@@ -39,6 +60,9 @@ func (g *CodeGenerator) Generate(workflow *IRWorkflow, workflowName string) (*Ge
 	result := &GeneratedCode{
 		Files: make(map[string]string),
 	}
+	result.Renamed = g.declareNames(workflow, workflowName)
+	result.WorkflowVar = g.workflowVar
+	g.written = make(map[string]any)
 
 	if g.SingleFile {
 		code := g.generateSingleFile(workflow, workflowName)
@@ -51,6 +75,8 @@ func (g *CodeGenerator) Generate(workflow *IRWorkflow, workflowName string) (*Ge
 		result.Files["steps.go"] = g.generateStepsFile(workflow)
 	}
 
+	result.Written = g.written
+
 	// Count resources
 	result.Workflows = 1
 	result.Jobs = len(workflow.Jobs)
@@ -61,17 +87,91 @@ func (g *CodeGenerator) Generate(workflow *IRWorkflow, workflowName string) (*Ge
 	return result, nil
 }
 
+// declareNames picks the variable names of the workflow and its jobs and
+// returns the renames. A name that is in g.Names, or that another of the
+// workflow's declarations uses, gets the workflow's name as a prefix or a
+// number suffix.
+func (g *CodeGenerator) declareNames(workflow *IRWorkflow, workflowName string) []string {
+	taken := g.Names
+	if taken == nil {
+		taken = make(map[string]bool)
+	}
+	var renamed []string
+
+	name := toVarName(workflowName)
+	g.workflowVar = claimName(taken, name, "", "", "Triggers", "Push", "PullRequest")
+	if g.workflowVar != name {
+		renamed = append(renamed, fmt.Sprintf("workflow %s is %s", workflowName, g.workflowVar))
+	}
+
+	jobIDs := make([]string, 0, len(workflow.Jobs))
+	for id := range workflow.Jobs {
+		jobIDs = append(jobIDs, id)
+	}
+	sort.Strings(jobIDs)
+
+	g.jobVars = make(map[string]string, len(jobIDs))
+	for _, id := range jobIDs {
+		name := toVarName(id)
+		g.jobVars[id] = claimName(taken, name, g.workflowVar, "", "Steps")
+		if g.jobVars[id] != name {
+			renamed = append(renamed, fmt.Sprintf("job %s is %s", id, g.jobVars[id]))
+		}
+	}
+	return renamed
+}
+
+// workflowVarName returns the workflow's variable name.
+func (g *CodeGenerator) workflowVarName(workflowName string) string {
+	if g.workflowVar != "" {
+		return g.workflowVar
+	}
+	return toVarName(workflowName)
+}
+
+// jobVarName returns the variable name of a job.
+func (g *CodeGenerator) jobVarName(jobID string) string {
+	if name, ok := g.jobVars[jobID]; ok {
+		return name
+	}
+	return toVarName(jobID)
+}
+
+// claimName returns the first of name, prefix+name, name2, name3 and so
+// on for which name+suffix is free for every suffix, and marks those
+// names taken.
+func claimName(taken map[string]bool, name, prefix string, suffixes ...string) string {
+	free := func(n string) bool {
+		for _, s := range suffixes {
+			if taken[n+s] {
+				return false
+			}
+		}
+		return true
+	}
+
+	candidate := name
+	if !free(candidate) && prefix != "" {
+		candidate = prefix + name
+	}
+	for i := 2; !free(candidate); i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	for _, s := range suffixes {
+		taken[candidate+s] = true
+	}
+	return candidate
+}
+
 // generateSingleFile generates all code in a single file.
 func (g *CodeGenerator) generateSingleFile(workflow *IRWorkflow, workflowName string) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("package %s\n\n", g.PackageName))
-	sb.WriteString("import (\n")
-	sb.WriteString("\t\"github.com/lex00/wetwire-github-go/workflow\"\n")
-	sb.WriteString(")\n\n")
+	writeImports(&sb, append(wrapperImports(workflow), workflowImport))
 
 	// Generate workflow
-	varName := toVarName(workflowName)
+	varName := g.workflowVarName(workflowName)
 	sb.WriteString(g.generateWorkflow(workflow, varName))
 	sb.WriteString("\n")
 
@@ -97,10 +197,12 @@ func (g *CodeGenerator) generateSingleFile(workflow *IRWorkflow, workflowName st
 	for _, jobID := range jobIDs {
 		job := workflow.Jobs[jobID]
 		if len(job.Steps) > 0 {
-			jobVarName := toVarName(jobID)
+			jobVarName := g.jobVarName(jobID)
 			stepsVarName := jobVarName + "Steps"
-			sb.WriteString(g.generateSteps(stepsVarName, job.Steps))
+			code, written := g.generateSteps(stepsVarName, job.Steps)
+			sb.WriteString(code)
 			sb.WriteString("\n")
+			g.record(written, "jobs", jobID, "steps")
 		}
 	}
 
@@ -116,7 +218,7 @@ func (g *CodeGenerator) generateWorkflowFile(workflow *IRWorkflow, workflowName
 	sb.WriteString("\t\"github.com/lex00/wetwire-github-go/workflow\"\n")
 	sb.WriteString(")\n\n")
 
-	varName := toVarName(workflowName)
+	varName := g.workflowVarName(workflowName)
 	sb.WriteString(g.generateWorkflow(workflow, varName))
 
 	return sb.String()
@@ -131,7 +233,7 @@ func (g *CodeGenerator) generateTriggersFile(workflow *IRWorkflow, workflowName
 	sb.WriteString("\t\"github.com/lex00/wetwire-github-go/workflow\"\n")
 	sb.WriteString(")\n\n")
 
-	varName := toVarName(workflowName)
+	varName := g.workflowVarName(workflowName)
 	sb.WriteString(g.generateTriggers(workflow, varName))
 
 	return sb.String()
@@ -167,9 +269,11 @@ func (g *CodeGenerator) generateStepsFile(workflow *IRWorkflow) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("package %s\n\n", g.PackageName))
-	sb.WriteString("import (\n")
-	sb.WriteString("\t\"github.com/lex00/wetwire-github-go/workflow\"\n")
-	sb.WriteString(")\n\n")
+	imports := wrapperImports(workflow)
+	if len(imports) == 0 || needsWorkflowStep(workflow) {
+		imports = append(imports, workflowImport)
+	}
+	writeImports(&sb, imports)
 
 	// Sort job IDs for deterministic output
 	jobIDs := make([]string, 0, len(workflow.Jobs))
@@ -181,15 +285,60 @@ func (g *CodeGenerator) generateStepsFile(workflow *IRWorkflow) string {
 	for _, jobID := range jobIDs {
 		job := workflow.Jobs[jobID]
 		if len(job.Steps) > 0 {
-			varName := toVarName(jobID) + "Steps"
-			sb.WriteString(g.generateSteps(varName, job.Steps))
+			varName := g.jobVarName(jobID) + "Steps"
+			code, written := g.generateSteps(varName, job.Steps)
+			sb.WriteString(code)
 			sb.WriteString("\n")
+			g.record(written, "jobs", jobID, "steps")
 		}
 	}
 
 	return sb.String()
 }
 
+// workflowImport is the import path of the workflow package.
+const workflowImport = "github.com/lex00/wetwire-github-go/workflow"
+
+// writeImports writes an import block for paths in sorted order.
+func writeImports(sb *strings.Builder, paths []string) {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+	sb.WriteString("import (\n")
+	for _, p := range sorted {
+		sb.WriteString(fmt.Sprintf("\t%q\n", p))
+	}
+	sb.WriteString(")\n\n")
+}
+
+// wrapperImports returns the packages of the action wrappers the
+// workflow's steps use.
+func wrapperImports(workflow *IRWorkflow) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, job := range workflow.Jobs {
+		for _, step := range job.Steps {
+			if ws, ok := newWrapperStep(step); ok && !seen[ws.Wrapper.ImportPath] {
+				seen[ws.Wrapper.ImportPath] = true
+				paths = append(paths, ws.Wrapper.ImportPath)
+			}
+		}
+	}
+	return paths
+}
+
+// needsWorkflowStep reports whether any of the workflow's steps is
+// generated with the workflow package, rather than as a bare wrapper.
+func needsWorkflowStep(workflow *IRWorkflow) bool {
+	for _, job := range workflow.Jobs {
+		for _, step := range job.Steps {
+			if ws, ok := newWrapperStep(step); !ok || !ws.Bare() || ws.UsesWorkflow {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // generateWorkflow generates the workflow variable.
 func (g *CodeGenerator) generateWorkflow(workflow *IRWorkflow, varName string) string {
 	var sb strings.Builder
@@ -198,6 +347,7 @@ func (g *CodeGenerator) generateWorkflow(workflow *IRWorkflow, varName string) s
 
 	if workflow.Name != "" {
 		sb.WriteString(fmt.Sprintf("\tName: %q,\n", workflow.Name))
+		g.record(workflow.Name, "name")
 	}
 
 	sb.WriteString(fmt.Sprintf("\tOn:   %sTriggers,\n", varName))
@@ -211,7 +361,7 @@ func (g *CodeGenerator) generateWorkflow(workflow *IRWorkflow, varName string) s
 		}
 		sort.Strings(jobIDs)
 		for _, id := range jobIDs {
-			sb.WriteString(fmt.Sprintf("\t\t%q: %s,\n", id, toVarName(id)))
+			sb.WriteString(fmt.Sprintf("\t\t%q: %s,\n", id, g.jobVarName(id)))
 		}
 		sb.WriteString("\t},\n")
 	}
@@ -245,16 +395,21 @@ func (g *CodeGenerator) generateTriggers(workflow *IRWorkflow, varName string) s
 	}
 	if workflow.On.WorkflowDispatch != nil {
 		sb.WriteString("\tWorkflowDispatch: &workflow.WorkflowDispatchTrigger{},\n")
+		g.record(map[string]any{}, "on", "workflow_dispatch")
 	}
 	if workflow.On.WorkflowCall != nil {
 		sb.WriteString("\tWorkflowCall: &workflow.WorkflowCallTrigger{},\n")
+		g.record(map[string]any{}, "on", "workflow_call")
 	}
 	if len(workflow.On.Schedule) > 0 {
 		sb.WriteString("\tSchedule: []workflow.ScheduleTrigger{\n")
+		var schedule []any
 		for _, s := range workflow.On.Schedule {
 			sb.WriteString(fmt.Sprintf("\t\t{Cron: %q},\n", s.Cron))
+			schedule = append(schedule, map[string]any{"cron": s.Cron})
 		}
 		sb.WriteString("\t},\n")
+		g.record(schedule, "on", "schedule")
 	}
 
 	sb.WriteString("}\n")
@@ -265,8 +420,10 @@ func (g *CodeGenerator) generateTriggers(workflow *IRWorkflow, varName string) s
 func (g *CodeGenerator) generatePushTriggerVar(push *IRPushTrigger, varName string) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("var %sPush = workflow.PushTrigger{\n", varName))
+	g.record(map[string]any{}, "on", "push")
 
 	if len(push.Branches) > 0 {
+		g.record(push.Branches, "on", "push", "branches")
 		sb.WriteString("\tBranches: []string{")
 		for i, b := range push.Branches {
 			if i > 0 {
@@ -278,6 +435,7 @@ func (g *CodeGenerator) generatePushTriggerVar(push *IRPushTrigger, varName stri
 	}
 
 	if len(push.BranchesIgnore) > 0 {
+		g.record(push.BranchesIgnore, "on", "push", "branches-ignore")
 		sb.WriteString("\tBranchesIgnore: []string{")
 		for i, b := range push.BranchesIgnore {
 			if i > 0 {
@@ -289,6 +447,7 @@ func (g *CodeGenerator) generatePushTriggerVar(push *IRPushTrigger, varName stri
 	}
 
 	if len(push.Tags) > 0 {
+		g.record(push.Tags, "on", "push", "tags")
 		sb.WriteString("\tTags: []string{")
 		for i, t := range push.Tags {
 			if i > 0 {
@@ -300,6 +459,7 @@ func (g *CodeGenerator) generatePushTriggerVar(push *IRPushTrigger, varName stri
 	}
 
 	if len(push.Paths) > 0 {
+		g.record(push.Paths, "on", "push", "paths")
 		sb.WriteString("\tPaths: []string{")
 		for i, p := range push.Paths {
 			if i > 0 {
@@ -318,8 +478,10 @@ func (g *CodeGenerator) generatePushTriggerVar(push *IRPushTrigger, varName stri
 func (g *CodeGenerator) generatePullRequestTriggerVar(pr *IRPullRequestTrigger, varName string) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("var %sPullRequest = workflow.PullRequestTrigger{\n", varName))
+	g.record(map[string]any{}, "on", "pull_request")
 
 	if len(pr.Branches) > 0 {
+		g.record(pr.Branches, "on", "pull_request", "branches")
 		sb.WriteString("\tBranches: []string{")
 		for i, b := range pr.Branches {
 			if i > 0 {
@@ -331,6 +493,7 @@ func (g *CodeGenerator) generatePullRequestTriggerVar(pr *IRPullRequestTrigger,
 	}
 
 	if len(pr.BranchesIgnore) > 0 {
+		g.record(pr.BranchesIgnore, "on", "pull_request", "branches-ignore")
 		sb.WriteString("\tBranchesIgnore: []string{")
 		for i, b := range pr.BranchesIgnore {
 			if i > 0 {
@@ -342,6 +505,7 @@ func (g *CodeGenerator) generatePullRequestTriggerVar(pr *IRPullRequestTrigger,
 	}
 
 	if len(pr.Types) > 0 {
+		g.record(pr.Types, "on", "pull_request", "types")
 		sb.WriteString("\tTypes: []string{")
 		for i, t := range pr.Types {
 			if i > 0 {
@@ -353,6 +517,7 @@ func (g *CodeGenerator) generatePullRequestTriggerVar(pr *IRPullRequestTrigger,
 	}
 
 	if len(pr.Paths) > 0 {
+		g.record(pr.Paths, "on", "pull_request", "paths")
 		sb.WriteString("\tPaths: []string{")
 		for i, p := range pr.Paths {
 			if i > 0 {
@@ -370,19 +535,24 @@ func (g *CodeGenerator) generatePullRequestTriggerVar(pr *IRPullRequestTrigger,
 // generateJob generates a job variable.
 func (g *CodeGenerator) generateJob(jobID string, job *IRJob) string {
 	var sb strings.Builder
-	varName := toVarName(jobID)
+	varName := g.jobVarName(jobID)
 
 	sb.WriteString(fmt.Sprintf("var %s = workflow.Job{\n", varName))
 
-	if job.Name != "" {
-		sb.WriteString(fmt.Sprintf("\tName: %q,\n", job.Name))
+	// Build writes a job under its Name, so a job without a display name
+	// gets its ID to keep needs references and expressions working
+	name := job.Name
+	if name == "" {
+		name = jobID
 	}
+	sb.WriteString(fmt.Sprintf("\tName: %q,\n", name))
+	g.record(job.Name, "jobs", jobID, "name")
 
 	// RunsOn
 	if job.RunsOn != nil {
-		runsOn := job.GetRunsOn()
-		if runsOn != "" {
-			sb.WriteString(fmt.Sprintf("\tRunsOn: %q,\n", runsOn))
+		if runsOn := runsOnCode(job.RunsOn); runsOn != "" {
+			sb.WriteString(fmt.Sprintf("\tRunsOn: %s,\n", runsOn))
+			g.record(job.RunsOn, "jobs", jobID, "runs-on")
 		}
 	}
 
@@ -397,16 +567,52 @@ func (g *CodeGenerator) generateJob(jobID string, job *IRJob) string {
 			sb.WriteString(fmt.Sprintf("%q", n))
 		}
 		sb.WriteString("},\n")
+		g.record(needs, "jobs", jobID, "needs")
 	}
 
 	// If condition
 	if job.If != "" {
-		sb.WriteString(fmt.Sprintf("\tIf: %q,\n", job.If))
+		sb.WriteString(fmt.Sprintf("\tIf: %s,\n", ifCode(job.If)))
+		g.record(job.If, "jobs", jobID, "if")
+	}
+
+	// Reusable workflow call
+	if job.Uses != "" {
+		sb.WriteString(fmt.Sprintf("\tUses: %q,\n", job.Uses))
+		g.record(job.Uses, "jobs", jobID, "uses")
+	}
+	if len(job.With) > 0 {
+		writeMapField(&sb, "\t", "With", job.With)
+		g.record(job.With, "jobs", jobID, "with")
+	}
+	switch secrets := job.Secrets.(type) {
+	case string:
+		if secrets == "inherit" {
+			sb.WriteString("\tSecrets: workflow.SecretsInherit,\n")
+			g.record(secrets, "jobs", jobID, "secrets")
+		}
+	case map[string]any:
+		writeMapField(&sb, "\t", "Secrets", secrets)
+		g.record(secrets, "jobs", jobID, "secrets")
+	}
+
+	if len(job.Outputs) > 0 {
+		outputs := make(map[string]any, len(job.Outputs))
+		for k, v := range job.Outputs {
+			outputs[k] = v
+		}
+		writeMapField(&sb, "\t", "Outputs", outputs)
+		g.record(outputs, "jobs", jobID, "outputs")
+	}
+	if len(job.Env) > 0 {
+		writeMapField(&sb, "\t", "Env", job.Env)
+		g.record(job.Env, "jobs", jobID, "env")
 	}
 
 	// TimeoutMinutes
 	if job.TimeoutMinutes > 0 {
 		sb.WriteString(fmt.Sprintf("\tTimeoutMinutes: %d,\n", job.TimeoutMinutes))
+		g.record(job.TimeoutMinutes, "jobs", jobID, "timeout-minutes")
 	}
 
 	// Steps reference
@@ -418,80 +624,171 @@ func (g *CodeGenerator) generateJob(jobID string, job *IRJob) string {
 	return sb.String()
 }
 
-// generateSteps generates a steps slice variable.
-func (g *CodeGenerator) generateSteps(varName string, steps []IRStep) string {
+// ifCode formats an if: condition as a typed expression, or as the raw
+// string if no part of it has a builder.
+func ifCode(cond string) string {
+	if code, ok := conditionCode(cond); ok {
+		return code
+	}
+	return fmt.Sprintf("%q", cond)
+}
+
+// writeMapField writes a map[string]any struct field with sorted keys.
+func writeMapField(sb *strings.Builder, indent, field string, m map[string]any) {
+	sb.WriteString(fmt.Sprintf("%s%s: map[string]any{\n", indent, field))
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("%s\t%q: %s,\n", indent, k, valueCode(m[k])))
+	}
+	sb.WriteString(indent + "},\n")
+}
+
+// generateSteps generates a steps slice variable. It also returns the
+// steps the code represents.
+func (g *CodeGenerator) generateSteps(varName string, steps []IRStep) (string, []any) {
 	var sb strings.Builder
+	var written []any
 
 	sb.WriteString(fmt.Sprintf("var %s = []any{\n", varName))
 
 	for _, step := range steps {
-		sb.WriteString("\tworkflow.Step{\n")
-
-		if step.ID != "" {
-			sb.WriteString(fmt.Sprintf("\t\tID: %q,\n", step.ID))
-		}
-		if step.Name != "" {
-			sb.WriteString(fmt.Sprintf("\t\tName: %q,\n", step.Name))
-		}
-		if step.Uses != "" {
-			sb.WriteString(fmt.Sprintf("\t\tUses: %q,\n", step.Uses))
-		}
-		if step.Run != "" {
-			// Handle multiline strings
-			if strings.Contains(step.Run, "\n") {
-				sb.WriteString(fmt.Sprintf("\t\tRun: `%s`,\n", step.Run))
+		if ws, ok := newWrapperStep(step); ok {
+			if ws.Note != "" {
+				sb.WriteString(fmt.Sprintf("\t// %s\n", ws.Note))
+			}
+			// The wrapper sets uses: and takes every with: input, as a
+			// field or in the workflow.Step next to it
+			var fields map[string]any
+			if ws.Bare() {
+				sb.WriteString(fmt.Sprintf("\t%s,\n", ws.Literal("\t")))
+				fields = make(map[string]any)
 			} else {
-				sb.WriteString(fmt.Sprintf("\t\tRun: %q,\n", step.Run))
+				// Other settings go in a workflow.Step next to the wrapper
+				sb.WriteString(fmt.Sprintf("\tworkflow.ActionStep(%s, workflow.Step{\n", ws.Literal("\t")))
+				fields = writeStepFields(&sb, ws.Rest)
+				sb.WriteString("\t}),\n")
 			}
+			fields["uses"] = step.Uses
+			if len(step.With) > 0 {
+				fields["with"] = step.With
+			}
+			written = append(written, fields)
+			continue
 		}
-		if step.Shell != "" {
-			sb.WriteString(fmt.Sprintf("\t\tShell: %q,\n", step.Shell))
-		}
-		if step.If != "" {
-			sb.WriteString(fmt.Sprintf("\t\tIf: %q,\n", step.If))
+
+		sb.WriteString("\tworkflow.Step{\n")
+		written = append(written, writeStepFields(&sb, step))
+		sb.WriteString("\t},\n")
+	}
+
+	sb.WriteString("}\n")
+	return sb.String(), written
+}
+
+// writeStepFields writes the fields of a workflow.Step literal and returns
+// the step fields they represent.
+func writeStepFields(sb *strings.Builder, step IRStep) map[string]any {
+	fields := make(map[string]any)
+	if step.ID != "" {
+		sb.WriteString(fmt.Sprintf("\t\tID: %q,\n", step.ID))
+		fields["id"] = step.ID
+	}
+	if step.Name != "" {
+		sb.WriteString(fmt.Sprintf("\t\tName: %q,\n", step.Name))
+		fields["name"] = step.Name
+	}
+	if step.Uses != "" {
+		sb.WriteString(fmt.Sprintf("\t\tUses: %q,\n", step.Uses))
+		fields["uses"] = step.Uses
+	}
+	if step.Run != "" {
+		// Handle multiline strings
+		if strings.Contains(step.Run, "\n") {
+			sb.WriteString(fmt.Sprintf("\t\tRun: `%s`,\n", step.Run))
+		} else {
+			sb.WriteString(fmt.Sprintf("\t\tRun: %q,\n", step.Run))
 		}
-		if step.WorkingDirectory != "" {
-			sb.WriteString(fmt.Sprintf("\t\tWorkingDirectory: %q,\n", step.WorkingDirectory))
+		fields["run"] = step.Run
+	}
+	if step.Shell != "" {
+		sb.WriteString(fmt.Sprintf("\t\tShell: %q,\n", step.Shell))
+		fields["shell"] = step.Shell
+	}
+	if step.If != "" {
+		sb.WriteString(fmt.Sprintf("\t\tIf: %s,\n", ifCode(step.If)))
+		fields["if"] = step.If
+	}
+	if step.WorkingDirectory != "" {
+		sb.WriteString(fmt.Sprintf("\t\tWorkingDirectory: %q,\n", step.WorkingDirectory))
+		fields["working-directory"] = step.WorkingDirectory
+	}
+	if step.TimeoutMinutes > 0 {
+		sb.WriteString(fmt.Sprintf("\t\tTimeoutMinutes: %d,\n", step.TimeoutMinutes))
+		fields["timeout-minutes"] = step.TimeoutMinutes
+	}
+
+	// With map
+	if len(step.With) > 0 {
+		sb.WriteString("\t\tWith: map[string]any{\n")
+		keys := make([]string, 0, len(step.With))
+		for k := range step.With {
+			keys = append(keys, k)
 		}
-		if step.TimeoutMinutes > 0 {
-			sb.WriteString(fmt.Sprintf("\t\tTimeoutMinutes: %d,\n", step.TimeoutMinutes))
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := step.With[k]
+			sb.WriteString(fmt.Sprintf("\t\t\t%q: %s,\n", k, valueCode(v)))
 		}
+		sb.WriteString("\t\t},\n")
+		fields["with"] = step.With
+	}
 
-		// With map
-		if len(step.With) > 0 {
-			sb.WriteString("\t\tWith: map[string]any{\n")
-			keys := make([]string, 0, len(step.With))
-			for k := range step.With {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-			for _, k := range keys {
-				v := step.With[k]
-				sb.WriteString(fmt.Sprintf("\t\t\t%q: %s,\n", k, formatValue(v)))
-			}
-			sb.WriteString("\t\t},\n")
+	// Env map
+	if len(step.Env) > 0 {
+		sb.WriteString("\t\tEnv: map[string]any{\n")
+		keys := make([]string, 0, len(step.Env))
+		for k := range step.Env {
+			keys = append(keys, k)
 		}
-
-		// Env map
-		if len(step.Env) > 0 {
-			sb.WriteString("\t\tEnv: map[string]any{\n")
-			keys := make([]string, 0, len(step.Env))
-			for k := range step.Env {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-			for _, k := range keys {
-				v := step.Env[k]
-				sb.WriteString(fmt.Sprintf("\t\t\t%q: %s,\n", k, formatValue(v)))
-			}
-			sb.WriteString("\t\t},\n")
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := step.Env[k]
+			sb.WriteString(fmt.Sprintf("\t\t\t%q: %s,\n", k, valueCode(v)))
 		}
+		sb.WriteString("\t\t},\n")
+		fields["env"] = step.Env
+	}
+	return fields
+}
 
-		sb.WriteString("\t},\n")
+// record notes that the generated code represents value at the given
+// path of the workflow document.
+func (g *CodeGenerator) record(value any, path ...string) {
+	if g.written == nil {
+		g.written = make(map[string]any)
+	}
+	m := g.written
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			m[key] = next
+		}
+		m = next
 	}
+	m[path[len(path)-1]] = value
+}
 
-	sb.WriteString("}\n")
-	return sb.String()
+// isEmptyStep reports whether a step sets none of the fields that
+// writeStepFields writes.
+func isEmptyStep(step IRStep) bool {
+	return step.ID == "" && step.Name == "" && step.Uses == "" && step.Run == "" &&
+		step.Shell == "" && step.If == "" && step.WorkingDirectory == "" &&
+		step.TimeoutMinutes == 0 && len(step.With) == 0 && len(step.Env) == 0
 }
 
 // toVarName converts a string to a valid Go variable name.
@@ -574,10 +871,48 @@ func formatValue(v any) string {
 	}
 }
 
-// toFilename converts a workflow name to a valid filename.
+// runsOnCode formats a runs-on value as Go source. Label lists and runner
+// groups use the typed workflow.RunsOn constructors.
+func runsOnCode(v any) string {
+	runsOn, ok := workflow.ParseRunsOn(v)
+	if !ok {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	args := make([]string, 0, len(runsOn.Labels)+1)
+	switch {
+	case runsOn.Group != "":
+		args = append(args, fmt.Sprintf("%q", runsOn.Group))
+		for _, l := range runsOn.Labels {
+			args = append(args, fmt.Sprintf("%q", l))
+		}
+		return "workflow.RunnerGroup(" + strings.Join(args, ", ") + ")"
+	case len(runsOn.Labels) > 0 && runsOn.Labels[0] == "self-hosted":
+		for _, l := range runsOn.Labels[1:] {
+			args = append(args, fmt.Sprintf("%q", l))
+		}
+		return "workflow.SelfHosted(" + strings.Join(args, ", ") + ")"
+	case len(runsOn.Labels) == 1:
+		return fmt.Sprintf("%q", runsOn.Labels[0])
+	default:
+		for _, l := range runsOn.Labels {
+			args = append(args, fmt.Sprintf("%q", l))
+		}
+		return "workflow.RunsOn{Labels: []string{" + strings.Join(args, ", ") + "}}"
+	}
+}
+
+// nonAlphanumericRE matches the runs of characters slugify replaces.
 var nonAlphanumericRE = regexp.MustCompile(`[^a-zA-Z0-9]+`)
 
-func toFilename(name string) string {
+// slugify converts a YAML file or display name to lowercase words joined
+// by hyphens, e.g. "Node.js CI" -> "node-js-ci". Unlike template.ToFilename,
+// which names generated files after Go variables, it splits on punctuation
+// and spaces rather than on capital letters.
+func slugify(name string) string {
 	name = nonAlphanumericRE.ReplaceAllString(name, "-")
 	name = strings.Trim(name, "-")
 	return strings.ToLower(name)
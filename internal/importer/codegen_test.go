@@ -310,7 +310,7 @@ func TestCodeGenerator_GenerateJob(t *testing.T) {
 	if !strings.Contains(code, `Needs: []any{"build"}`) {
 		t.Error("Missing needs")
 	}
-	if !strings.Contains(code, `If: "success()"`) {
+	if !strings.Contains(code, `If: workflow.Success()`) {
 		t.Error("Missing if condition")
 	}
 	if !strings.Contains(code, "TimeoutMinutes: 30") {
@@ -321,6 +321,134 @@ func TestCodeGenerator_GenerateJob(t *testing.T) {
 	}
 }
 
+func TestCodeGenerator_GenerateJob_NameDefaultsToID(t *testing.T) {
+	gen := &CodeGenerator{PackageName: "workflows"}
+
+	// Build writes jobs under their Name, so the ID must survive as one
+	code := gen.generateJob("build", &IRJob{RunsOn: "ubuntu-latest"})
+	if !strings.Contains(code, `Name: "build"`) {
+		t.Errorf("job without a name should be named after its ID:\n%s", code)
+	}
+}
+
+func TestCodeGenerator_GenerateJob_ReusableWorkflow(t *testing.T) {
+	gen := &CodeGenerator{PackageName: "workflows"}
+
+	tests := []struct {
+		name string
+		job  *IRJob
+		want []string
+	}{
+		{
+			name: "with and secrets map",
+			job: &IRJob{
+				Uses:    "org/repo/.github/workflows/deploy.yml@v1",
+				With:    map[string]any{"env": "prod"},
+				Secrets: map[string]any{"token": "${{ secrets.TOKEN }}"},
+			},
+			want: []string{
+				`Uses: "org/repo/.github/workflows/deploy.yml@v1"`,
+				`With: map[string]any{`,
+				`"env": "prod"`,
+				`Secrets: map[string]any{`,
+				`"token": workflow.Secrets.Get("TOKEN")`,
+			},
+		},
+		{
+			name: "inherit secrets",
+			job: &IRJob{
+				Uses:    "./.github/workflows/docs.yml",
+				Secrets: "inherit",
+			},
+			want: []string{
+				`Uses: "./.github/workflows/docs.yml"`,
+				"Secrets: workflow.SecretsInherit",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := gen.generateJob("call", tt.job)
+			for _, want := range tt.want {
+				if !strings.Contains(code, want) {
+					t.Errorf("missing %q in:\n%s", want, code)
+				}
+			}
+		})
+	}
+}
+
+func TestCodeGenerator_GenerateJob_Expressions(t *testing.T) {
+	gen := &CodeGenerator{PackageName: "workflows"}
+
+	code := gen.generateJob("release", &IRJob{
+		RunsOn: "ubuntu-latest",
+		If:     "${{ startsWith(github.ref, 'refs/tags/v') && github.event_name == 'push' }}",
+		Outputs: map[string]string{
+			"version": "${{ steps.version.outputs.value }}",
+			"label":   "release-${{ github.sha }}",
+		},
+		Env: map[string]any{
+			"TOKEN": "${{ secrets.RELEASE_TOKEN }}",
+		},
+	})
+
+	for _, want := range []string{
+		`If: workflow.TagPrefix("v").And(workflow.Push()),`,
+		"Outputs: map[string]any{",
+		`"version": workflow.Steps.Get("version", "value"),`,
+		`"label": "release-${{ github.sha }}",`,
+		"Env: map[string]any{",
+		`"TOKEN": workflow.Secrets.Get("RELEASE_TOKEN"),`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("missing %q in:\n%s", want, code)
+		}
+	}
+
+	code = gen.generateJob("docs", &IRJob{Needs: []any{"changes"}, If: "${{ needs.changes.outputs.docs == 'true' }}"})
+	if !strings.Contains(code, `If: workflow.Expression(workflow.Needs.Get("changes", "docs").Raw() + " == 'true'"),`) {
+		t.Errorf("Expected a typed needs output reference:\n%s", code)
+	}
+
+	code = gen.generateJob("check", &IRJob{If: "hashFiles('go.sum') != ''"})
+	if !strings.Contains(code, `If: "hashFiles('go.sum') != ''",`) {
+		t.Errorf("Expected a raw condition without a typed equivalent:\n%s", code)
+	}
+
+	code = gen.generateJob("broken", &IRJob{If: "needs.changes.outputs.docs =="})
+	if !strings.Contains(code, `If: "needs.changes.outputs.docs ==",`) {
+		t.Errorf("Expected a raw condition that does not parse:\n%s", code)
+	}
+}
+
+func TestCodeGenerator_GenerateJob_RunsOn(t *testing.T) {
+	gen := &CodeGenerator{PackageName: "workflows"}
+
+	tests := []struct {
+		name   string
+		runsOn any
+		want   string
+	}{
+		{"string", "ubuntu-latest", `RunsOn: "ubuntu-latest"`},
+		{"expression", "${{ matrix.os }}", `RunsOn: "${{ matrix.os }}"`},
+		{"self-hosted", []any{"self-hosted", "linux", "gpu"}, `RunsOn: workflow.SelfHosted("linux", "gpu")`},
+		{"label list", []any{"linux", "gpu"}, `RunsOn: workflow.RunsOn{Labels: []string{"linux", "gpu"}}`},
+		{"single label list", []any{"ubuntu-latest"}, `RunsOn: "ubuntu-latest"`},
+		{"group", map[string]any{"group": "fleet", "labels": []any{"linux"}}, `RunsOn: workflow.RunnerGroup("fleet", "linux")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := gen.generateJob("build", &IRJob{RunsOn: tt.runsOn})
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("missing %q in:\n%s", tt.want, code)
+			}
+		})
+	}
+}
+
 func TestCodeGenerator_GenerateSteps(t *testing.T) {
 	gen := &CodeGenerator{PackageName: "workflows"}
 
@@ -352,7 +480,7 @@ func TestCodeGenerator_GenerateSteps(t *testing.T) {
 		},
 	}
 
-	code := gen.generateSteps("TestSteps", steps)
+	code, _ := gen.generateSteps("TestSteps", steps)
 
 	if !strings.Contains(code, "var TestSteps = []any{") {
 		t.Error("Missing steps variable")
@@ -363,16 +491,16 @@ func TestCodeGenerator_GenerateSteps(t *testing.T) {
 	if !strings.Contains(code, `ID: "checkout"`) {
 		t.Error("Missing step ID")
 	}
-	if !strings.Contains(code, `Uses: "actions/checkout@v4"`) {
-		t.Error("Missing uses")
+	if !strings.Contains(code, `workflow.ActionStep(checkout.Checkout{Submodules: "recursive"}, workflow.Step{`) {
+		t.Error("Missing typed wrapper")
 	}
 	if !strings.Contains(code, `"fetch-depth": 0`) {
-		t.Error("Missing with parameter")
+		t.Error("Missing with parameter the wrapper can't express")
 	}
 	if !strings.Contains(code, `Env: map[string]any{`) {
 		t.Error("Missing env map")
 	}
-	if !strings.Contains(code, `If: "success()"`) {
+	if !strings.Contains(code, `If: workflow.Success()`) {
 		t.Error("Missing if condition")
 	}
 	if !strings.Contains(code, `WorkingDirectory: "./src"`) {
@@ -411,7 +539,7 @@ func TestFormatValue(t *testing.T) {
 	}
 }
 
-func TestToFilename(t *testing.T) {
+func TestSlugify(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected string
@@ -424,9 +552,9 @@ func TestToFilename(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := toFilename(tt.input)
+		result := slugify(tt.input)
 		if result != tt.expected {
-			t.Errorf("toFilename(%q) = %q, want %q", tt.input, result, tt.expected)
+			t.Errorf("slugify(%q) = %q, want %q", tt.input, result, tt.expected)
 		}
 	}
 }
@@ -519,6 +647,54 @@ func TestCodeGenerator_GenerateStepsFile(t *testing.T) {
 	}
 }
 
+func TestCodeGenerator_GenerateStepsFile_Wrappers(t *testing.T) {
+	gen := &CodeGenerator{PackageName: "workflows"}
+
+	wf := &IRWorkflow{
+		Jobs: map[string]*IRJob{
+			"build": {
+				Steps: []IRStep{
+					{Uses: "actions/checkout@v4", With: map[string]any{"fetch-depth": 1}},
+					{Uses: "actions/setup-go@v5", With: map[string]any{"go-version": "1.23", "cache": true}},
+				},
+			},
+		},
+	}
+
+	code := gen.generateStepsFile(wf)
+
+	wantImports := "import (\n" +
+		"\t\"github.com/lex00/wetwire-github-go/actions/checkout\"\n" +
+		"\t\"github.com/lex00/wetwire-github-go/actions/setup_go\"\n" +
+		")\n"
+	if !strings.Contains(code, wantImports) {
+		t.Errorf("Expected wrapper imports without the unused workflow package:\n%s", code)
+	}
+	if !strings.Contains(code, "\tcheckout.Checkout{FetchDepth: 1},\n") {
+		t.Errorf("Missing bare wrapper:\n%s", code)
+	}
+	if !strings.Contains(code, "\tsetup_go.SetupGo{\n\t\tGoVersion: \"1.23\",\n\t\tCache: true,\n\t},\n") {
+		t.Errorf("Missing multi-field wrapper:\n%s", code)
+	}
+
+	wf.Jobs["build"].Steps[0].With["token"] = "${{ secrets.CHECKOUT_TOKEN }}"
+	code = gen.generateStepsFile(wf)
+	if !strings.Contains(code, `checkout.Checkout{
+		Token: workflow.Secrets.Get("CHECKOUT_TOKEN").String(),`) {
+		t.Errorf("Expected a typed expression in a wrapper field:\n%s", code)
+	}
+	if !strings.Contains(code, "\t\"github.com/lex00/wetwire-github-go/workflow\"\n") {
+		t.Errorf("Missing workflow import for an expression:\n%s", code)
+	}
+	delete(wf.Jobs["build"].Steps[0].With, "token")
+
+	wf.Jobs["build"].Steps = append(wf.Jobs["build"].Steps, IRStep{Run: "go test ./..."})
+	code = gen.generateStepsFile(wf)
+	if !strings.Contains(code, "\t\"github.com/lex00/wetwire-github-go/workflow\"\n") {
+		t.Errorf("Missing workflow import for a run step:\n%s", code)
+	}
+}
+
 func TestCodeGenerator_EmptyJobs(t *testing.T) {
 	gen := &CodeGenerator{PackageName: "workflows"}
 
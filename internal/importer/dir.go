@@ -0,0 +1,422 @@
+package importer
+
+import (
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lex00/wetwire-github-go/internal/differ"
+	"github.com/lex00/wetwire-github-go/internal/template"
+)
+
+// File types of a directory import, as reported in FileReport.Type.
+const (
+	FileWorkflow           = "workflow"
+	FileDependabot         = "dependabot"
+	FileCodeowners         = "codeowners"
+	FileIssueTemplate      = "issue-template"
+	FileDiscussionTemplate = "discussion-template"
+	FilePRTemplate         = "pr-template"
+)
+
+// DirImporter imports every supported file of a .github directory into a
+// single Go package.
+type DirImporter struct {
+	// PackageName is the Go package name for generated code
+	PackageName string
+	// SingleFile generates each workflow in one file instead of four
+	SingleFile bool
+}
+
+// NewDirImporter creates a new DirImporter.
+func NewDirImporter() *DirImporter {
+	return &DirImporter{
+		PackageName: "workflows",
+	}
+}
+
+// DirImport is the result of importing a directory.
+type DirImport struct {
+	// Dir is the .github directory that was imported
+	Dir string
+	// Files maps Go file names to their content
+	Files map[string]string
+	// Reports describes each file found in the directory, in path order
+	Reports []FileReport
+	// Workflows is the count of generated workflows
+	Workflows int
+	// Jobs is the count of generated jobs
+	Jobs int
+	// Steps is the count of generated steps
+	Steps int
+}
+
+// FileReport describes how one file of the directory was imported.
+type FileReport struct {
+	// Path is the file's path relative to the imported directory
+	Path string `json:"path"`
+	// Type is the file type, or empty if the file was not imported
+	Type string `json:"type,omitempty"`
+	// GoFiles lists the generated files holding the file's declarations
+	GoFiles []string `json:"go_files,omitempty"`
+	// Declarations lists the Go variables declared for the file
+	Declarations []string `json:"declarations,omitempty"`
+	// Lost lists the fields the Go declarations don't represent
+	Lost []string `json:"lost,omitempty"`
+	// Notes describes renames and other differences in the output
+	Notes []string `json:"notes,omitempty"`
+	// Error says why the file was not imported
+	Error string `json:"error,omitempty"`
+}
+
+// Files for the declarations that are not workflows, and the package
+// each imports.
+const (
+	dependabotFile = "dependabot.go"
+	templatesFile  = "templates.go"
+
+	dependabotImport = "github.com/lex00/wetwire-github-go/dependabot"
+	templatesImport  = "github.com/lex00/wetwire-github-go/templates"
+)
+
+// dirImport holds the state of one Import call.
+type dirImport struct {
+	*DirImporter
+	result *DirImport
+	// names holds the declared variable names of the package
+	names map[string]bool
+	// bases holds the file name prefixes of imported workflows
+	bases map[string]bool
+	// decls holds the declarations of dependabotFile and templatesFile
+	decls map[string][]string
+}
+
+// Import imports the files of dir. If dir contains a .github directory,
+// that directory is imported.
+func (d *DirImporter) Import(dir string) (*DirImport, error) {
+	if info, err := os.Stat(filepath.Join(dir, ".github")); err == nil && info.IsDir() {
+		dir = filepath.Join(dir, ".github")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	imp := &dirImport{
+		DirImporter: d,
+		result:      &DirImport{Dir: dir, Files: make(map[string]string)},
+		names:       make(map[string]bool),
+		bases:       make(map[string]bool),
+		decls:       make(map[string][]string),
+	}
+
+	err = filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if report, ok := imp.importFile(p, filepath.ToSlash(rel)); ok {
+			imp.result.Reports = append(imp.result.Reports, report)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	imp.writeResourceFile(dependabotFile, dependabotImport)
+	imp.writeResourceFile(templatesFile, templatesImport)
+	for name, src := range imp.result.Files {
+		if formatted, err := format.Source([]byte(src)); err == nil {
+			imp.result.Files[name] = string(formatted)
+		}
+	}
+	return imp.result, nil
+}
+
+// classifyFile returns the type of a file by its path relative to the
+// .github directory, and the template name for PR templates.
+func classifyFile(rel string) (string, string) {
+	dir, name := path.Split(rel)
+	ext := strings.ToLower(path.Ext(name))
+	isYAML := ext == ".yml" || ext == ".yaml"
+
+	switch {
+	case dir == "workflows/" && isYAML:
+		return FileWorkflow, ""
+	case dir == "" && (name == "dependabot.yml" || name == "dependabot.yaml"):
+		return FileDependabot, ""
+	case dir == "" && name == "CODEOWNERS":
+		return FileCodeowners, ""
+	case dir == "ISSUE_TEMPLATE/" && isYAML && !strings.HasPrefix(name, "config."):
+		return FileIssueTemplate, ""
+	case dir == "DISCUSSION_TEMPLATE/" && isYAML:
+		return FileDiscussionTemplate, ""
+	case dir == "" && strings.EqualFold(name, "pull_request_template.md"):
+		return FilePRTemplate, ""
+	case strings.EqualFold(dir, "PULL_REQUEST_TEMPLATE/") && ext == ".md":
+		return FilePRTemplate, strings.TrimSuffix(name, path.Ext(name))
+	}
+	return "", ""
+}
+
+// unsupportedReason says why a file is not imported, or returns false for
+// files that are not configuration at all, such as scripts.
+func unsupportedReason(rel string) (string, bool) {
+	dir, name := path.Split(rel)
+	ext := strings.ToLower(path.Ext(name))
+	switch {
+	case dir == "ISSUE_TEMPLATE/" && strings.HasPrefix(name, "config."):
+		return "the issue template chooser config has no Go type", true
+	case dir == "ISSUE_TEMPLATE/" && ext == ".md":
+		return "Markdown issue templates are not supported, only issue forms", true
+	case ext == ".yml" || ext == ".yaml" || ext == ".md":
+		return "not a supported file type", true
+	}
+	return "", false
+}
+
+// importFile imports one file and returns its report, or false if the
+// file is not reported.
+func (imp *dirImport) importFile(p, rel string) (FileReport, bool) {
+	report := FileReport{Path: rel}
+	kind, name := classifyFile(rel)
+	if kind == "" {
+		reason, ok := unsupportedReason(rel)
+		report.Error = reason
+		return report, ok
+	}
+	report.Type = kind
+
+	content, err := os.ReadFile(p)
+	if err == nil {
+		switch kind {
+		case FileWorkflow:
+			err = imp.workflow(&report, content)
+		case FileDependabot:
+			err = imp.dependabot(&report, content)
+		case FileCodeowners:
+			err = imp.codeowners(&report, content)
+		case FileIssueTemplate:
+			err = imp.issueTemplate(&report, content)
+		case FileDiscussionTemplate:
+			err = imp.discussionTemplate(&report, content)
+		case FilePRTemplate:
+			err = imp.prTemplate(&report, name, content)
+		}
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	return report, true
+}
+
+func (imp *dirImport) workflow(report *FileReport, content []byte) error {
+	workflow, err := NewParser().Parse(content)
+	if err != nil {
+		return err
+	}
+
+	workflowName := strings.TrimSuffix(path.Base(report.Path), path.Ext(report.Path))
+	base := claimName(imp.bases, strings.ReplaceAll(slugify(workflowName), "-", "_"), "", "")
+	if workflow.Name != "" {
+		workflowName = workflow.Name
+	}
+
+	gen := &CodeGenerator{PackageName: imp.PackageName, SingleFile: imp.SingleFile, Names: imp.names}
+	code, err := gen.Generate(workflow, workflowName)
+	if err != nil {
+		return err
+	}
+
+	filenames := make([]string, 0, len(code.Files))
+	for filename := range code.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		// A jobs or steps file with nothing to declare would not compile
+		if !strings.Contains(code.Files[filename], "\nvar ") {
+			continue
+		}
+		goFile := base + "_" + filename
+		imp.result.Files[goFile] = code.Files[filename]
+		report.GoFiles = append(report.GoFiles, goFile)
+	}
+
+	report.Declarations = append(report.Declarations, gen.workflowVar)
+	for _, id := range sortedKeys(gen.jobVars) {
+		report.Declarations = append(report.Declarations, gen.jobVars[id])
+	}
+	if report.Lost, err = lostWorkflowFields(content, code.Written); err != nil {
+		return err
+	}
+	report.Notes = code.Renamed
+
+	imp.result.Workflows += code.Workflows
+	imp.result.Jobs += code.Jobs
+	imp.result.Steps += code.Steps
+	return nil
+}
+
+// lostWorkflowFields returns the paths of the fields of an imported
+// workflow that the generated code, which represents written, leaves out.
+// Both are normalized as for CompareBuilt, so differences in form alone
+// are not reported.
+func lostWorkflowFields(orig []byte, written map[string]any) ([]string, error) {
+	data, err := yaml.Marshal(written)
+	if err != nil {
+		return nil, err
+	}
+	o, err := differ.Normalize(FileWorkflow, orig)
+	if err != nil {
+		return nil, err
+	}
+	w, err := differ.Normalize(FileWorkflow, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var lost []string
+	for _, c := range differ.CompareDocuments(FileWorkflow, o, w) {
+		lost = append(lost, c.Path)
+	}
+	return lost, nil
+}
+
+func (imp *dirImport) dependabot(report *FileReport, content []byte) error {
+	cfg, lost, err := ParseDependabotContent(content)
+	if err != nil {
+		return err
+	}
+	varName := claimName(imp.names, "Dependabot", "", "")
+	imp.declare(report, dependabotFile, varName, fmt.Sprintf("var %s = %s\n", varName, literalCode(*cfg)))
+	report.Lost = lost
+	return nil
+}
+
+func (imp *dirImport) codeowners(report *FileReport, content []byte) error {
+	ir, err := ParseCodeownersContent(string(content))
+	if err != nil {
+		return err
+	}
+	gen := &CodeownersCodeGenerator{PackageName: imp.PackageName}
+	code, err := gen.Generate(ir)
+	if err != nil {
+		return err
+	}
+
+	varName := claimName(imp.names, "Codeowners", "", "")
+	for filename, src := range code.Files {
+		imp.result.Files[filename] = strings.Replace(src, "var Codeowners =", "var "+varName+" =", 1)
+		report.GoFiles = append(report.GoFiles, filename)
+	}
+	report.Declarations = []string{varName}
+	return nil
+}
+
+func (imp *dirImport) issueTemplate(report *FileReport, content []byte) error {
+	tmpl, lost, err := ParseIssueFormContent(content)
+	if err != nil {
+		return err
+	}
+	varName := imp.templateName(report, "Issue", "ISSUE_TEMPLATE/")
+	imp.declare(report, templatesFile, varName, fmt.Sprintf("var %s = %s\n", varName, literalCode(*tmpl)))
+	report.Lost = lost
+	return nil
+}
+
+func (imp *dirImport) discussionTemplate(report *FileReport, content []byte) error {
+	tmpl, lost, err := ParseDiscussionFormContent(content)
+	if err != nil {
+		return err
+	}
+	varName := imp.templateName(report, "Discussion", "DISCUSSION_TEMPLATE/")
+	imp.declare(report, templatesFile, varName, fmt.Sprintf("var %s = %s\n", varName, literalCode(*tmpl)))
+	report.Lost = lost
+	return nil
+}
+
+func (imp *dirImport) prTemplate(report *FileReport, name string, content []byte) error {
+	tmpl, err := ParsePRTemplateContent(name, string(content))
+	if err != nil {
+		return err
+	}
+	varName := claimName(imp.names, toVarName(name)+"PRTemplate", "", "")
+	gen := &PRTemplateCodeGenerator{PackageName: imp.PackageName}
+	imp.declare(report, templatesFile, varName, gen.generatePRTemplate(varName, tmpl))
+
+	built := "PULL_REQUEST_TEMPLATE.md"
+	if name != "" {
+		built = "PULL_REQUEST_TEMPLATE/" + name + ".md"
+	}
+	if built != report.Path {
+		report.Notes = append(report.Notes, "builds as "+built)
+	}
+	return nil
+}
+
+// templateName declares the variable name of an issue or discussion
+// form. Build names the form's file after the variable, so a name that
+// differs from the original file is noted.
+func (imp *dirImport) templateName(report *FileReport, prefix, dir string) string {
+	stem := strings.TrimSuffix(path.Base(report.Path), path.Ext(report.Path))
+	varName := claimName(imp.names, toVarName(stem), prefix, "")
+	if built := dir + template.ToFilename(varName) + ".yml"; built != report.Path {
+		report.Notes = append(report.Notes, "builds as "+built)
+	}
+	return varName
+}
+
+// declare adds a declaration to one of the shared resource files.
+func (imp *dirImport) declare(report *FileReport, file, varName, decl string) {
+	imp.decls[file] = append(imp.decls[file], decl)
+	report.GoFiles = []string{file}
+	report.Declarations = []string{varName}
+}
+
+// writeResourceFile writes the declarations collected for file.
+func (imp *dirImport) writeResourceFile(file, importPath string) {
+	decls := imp.decls[file]
+	if len(decls) == 0 {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("package %s\n\n", imp.PackageName))
+	writeImports(&sb, []string{importPath})
+	sb.WriteString(strings.Join(decls, "\n"))
+	imp.result.Files[file] = sb.String()
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedAnyKeys returns the keys of a decoded YAML mapping in sorted order.
+func sortedAnyKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
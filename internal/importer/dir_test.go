@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files under dir from a map of slash-separated paths.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDirImporter_Import(t *testing.T) {
+	repo := t.TempDir()
+	writeFiles(t, filepath.Join(repo, ".github"), map[string]string{
+		"workflows/ci.yml": `name: CI
+on: push
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+		"workflows/release.yml": `name: Release
+on: [push, release]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make dist
+`,
+		"workflows/broken.yml": "jobs: [",
+		"dependabot.yml": `version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: /
+    schedule:
+      interval: weekly
+`,
+		"CODEOWNERS":                    "* @org/team\n",
+		"ISSUE_TEMPLATE/bug_report.yml": "name: Bug\ndescription: File a bug\nbody:\n  - type: markdown\n    attributes:\n      value: Thanks\n",
+		"ISSUE_TEMPLATE/config.yml":     "blank_issues_enabled: false\n",
+		"DISCUSSION_TEMPLATE/ideas.yml": "title: Idea\nbody:\n  - type: input\n    attributes:\n      label: Summary\n",
+		"pull_request_template.md":      "## Summary\n",
+		"scripts/release.sh":            "echo release\n",
+	})
+
+	result, err := NewDirImporter().Import(repo)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Dir != filepath.Join(repo, ".github") {
+		t.Errorf("Dir = %q, want the .github directory", result.Dir)
+	}
+	if result.Workflows != 2 || result.Jobs != 2 || result.Steps != 2 {
+		t.Errorf("counts = %d/%d/%d, want 2/2/2", result.Workflows, result.Jobs, result.Steps)
+	}
+
+	for _, name := range []string{"ci_workflows.go", "ci_jobs.go", "release_jobs.go", "dependabot.go", "codeowners.go", "templates.go"} {
+		if result.Files[name] == "" {
+			t.Errorf("missing generated file %s; got %v", name, reflect.ValueOf(result.Files).MapKeys())
+		}
+	}
+	if !strings.Contains(result.Files["release_jobs.go"], "var ReleaseBuild = workflow.Job{") {
+		t.Errorf("release build job not renamed:\n%s", result.Files["release_jobs.go"])
+	}
+	if !strings.Contains(result.Files["release_workflows.go"], `"build": ReleaseBuild,`) {
+		t.Errorf("release workflow doesn't use the renamed job:\n%s", result.Files["release_workflows.go"])
+	}
+	for _, want := range []string{"var Dependabot = dependabot.Dependabot{", "var BugReport = templates.IssueTemplate{", "var Ideas = templates.DiscussionTemplate{", "var PRTemplate = templates.PRTemplate{"} {
+		found := false
+		for _, src := range result.Files {
+			found = found || strings.Contains(src, want)
+		}
+		if !found {
+			t.Errorf("no generated file contains %q", want)
+		}
+	}
+
+	reports := make(map[string]FileReport)
+	for _, r := range result.Reports {
+		reports[r.Path] = r
+	}
+	if _, ok := reports["scripts/release.sh"]; ok {
+		t.Error("scripts should not be reported")
+	}
+
+	tests := []struct {
+		path  string
+		typ   string
+		lost  []string
+		notes []string
+		err   bool
+	}{
+		{path: "workflows/ci.yml", typ: FileWorkflow, lost: []string{"permissions"}},
+		{path: "workflows/release.yml", typ: FileWorkflow, lost: []string{"on.release"}, notes: []string{"job build is ReleaseBuild"}},
+		{path: "workflows/broken.yml", typ: FileWorkflow, err: true},
+		{path: "dependabot.yml", typ: FileDependabot},
+		{path: "CODEOWNERS", typ: FileCodeowners},
+		{path: "ISSUE_TEMPLATE/bug_report.yml", typ: FileIssueTemplate, notes: []string{"builds as ISSUE_TEMPLATE/bug-report.yml"}},
+		{path: "ISSUE_TEMPLATE/config.yml", err: true},
+		{path: "DISCUSSION_TEMPLATE/ideas.yml", typ: FileDiscussionTemplate},
+		{path: "pull_request_template.md", typ: FilePRTemplate, notes: []string{"builds as PULL_REQUEST_TEMPLATE.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			r, ok := reports[tt.path]
+			if !ok {
+				t.Fatalf("no report; got %+v", result.Reports)
+			}
+			if r.Type != tt.typ {
+				t.Errorf("Type = %q, want %q", r.Type, tt.typ)
+			}
+			if (r.Error != "") != tt.err {
+				t.Errorf("Error = %q", r.Error)
+			}
+			if !reflect.DeepEqual(r.Lost, tt.lost) {
+				t.Errorf("Lost = %v, want %v", r.Lost, tt.lost)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.notes) {
+				t.Errorf("Notes = %v, want %v", r.Notes, tt.notes)
+			}
+		})
+	}
+}
+
+func TestDirImporter_ImportNotADirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ci.yml")
+	if err := os.WriteFile(path, []byte("on: push\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewDirImporter().Import(path); err == nil {
+		t.Error("Import() should fail for a file")
+	}
+}
+
+func TestLostWorkflowFields(t *testing.T) {
+	content := []byte(`name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+  issues:
+permissions:
+  contents: read
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    needs: lint
+    services:
+      db:
+        image: postgres
+    steps:
+      - uses: actions/checkout@v4
+      - run: make
+        continue-on-error: true
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+`)
+	wf, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := (&CodeGenerator{PackageName: "workflows"}).Generate(wf, "CI")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := lostWorkflowFields(content, code.Written)
+	if err != nil {
+		t.Fatalf("lostWorkflowFields() error = %v", err)
+	}
+	want := []string{"jobs.test.services", "jobs.test.steps[1].continue-on-error", "on.issues", "on.pull_request", "permissions"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lostWorkflowFields() = %v, want %v", got, want)
+	}
+}
+
+func TestClaimName(t *testing.T) {
+	taken := map[string]bool{"Build": true, "CISteps": true}
+
+	if got := claimName(taken, "Test", "CI", "", "Steps"); got != "Test" {
+		t.Errorf("free name = %q, want Test", got)
+	}
+	if got := claimName(taken, "Build", "CI", "", "Steps"); got != "CIBuild" {
+		t.Errorf("taken name = %q, want CIBuild", got)
+	}
+	if got := claimName(taken, "Build", "", ""); got != "Build2" {
+		t.Errorf("taken name without prefix = %q, want Build2", got)
+	}
+	if !taken["TestSteps"] || !taken["CIBuildSteps"] {
+		t.Errorf("derived names not marked taken: %v", taken)
+	}
+}
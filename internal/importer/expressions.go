@@ -0,0 +1,343 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// Imported expressions are parsed and printed as the typed builders of the
+// workflow package, such as workflow.GitHub.Ref() or workflow.Branch("main").
+// Parts without a builder become workflow.Expression values inside a typed
+// expression; an expression with no typed part at all stays a raw string.
+
+// githubProperties maps github.* properties to their accessor methods.
+var githubProperties = map[string]string{
+	"ref":              "Ref",
+	"ref_name":         "RefName",
+	"ref_type":         "RefType",
+	"sha":              "SHA",
+	"actor":            "Actor",
+	"repository":       "Repository",
+	"repository_owner": "RepositoryOwner",
+	"event_name":       "EventName",
+	"workspace":        "Workspace",
+	"run_id":           "RunID",
+	"run_number":       "RunNumber",
+	"run_attempt":      "RunAttempt",
+	"job":              "Job",
+	"token":            "Token",
+	"server_url":       "ServerURL",
+	"api_url":          "APIURL",
+	"graphql_url":      "GraphQLURL",
+	"head_ref":         "HeadRef",
+	"base_ref":         "BaseRef",
+}
+
+// runnerProperties maps runner.* properties to their accessor methods.
+var runnerProperties = map[string]string{
+	"os":         "OS",
+	"arch":       "Arch",
+	"name":       "Name",
+	"temp":       "Temp",
+	"tool_cache": "ToolCache",
+}
+
+// nameContexts maps contexts accessed by a single name to their accessors.
+var nameContexts = map[string]string{
+	"secrets": "workflow.Secrets",
+	"matrix":  "workflow.MatrixContext",
+	"inputs":  "workflow.Inputs",
+	"vars":    "workflow.Vars",
+	"env":     "workflow.EnvContext",
+}
+
+// statusFunctions maps status check functions to their builders.
+var statusFunctions = map[string]string{
+	"success":   "workflow.Success()",
+	"failure":   "workflow.Failure()",
+	"always":    "workflow.Always()",
+	"cancelled": "workflow.Cancelled()",
+}
+
+// eventNames maps github.event_name values to their condition builders.
+var eventNames = map[string]string{
+	"push":         "workflow.Push()",
+	"pull_request": "workflow.PullRequest()",
+	"release":      "workflow.IsRelease()",
+}
+
+// conditionCode returns Go source for an if: condition, or false if no
+// part of it has a typed builder. The condition may be wrapped in ${{ }}.
+func conditionCode(cond string) (string, bool) {
+	expr := strings.TrimSpace(cond)
+	if inner, ok := placeholder(expr); ok {
+		expr = inner
+	}
+	node, err := workflow.ParseExpression(expr)
+	if err != nil {
+		return "", false
+	}
+	return exprCode(node)
+}
+
+// valueCode formats a value for Go source code. A string that is a single
+// ${{ }} expression with a typed builder becomes that builder.
+func valueCode(v any) string {
+	if s, ok := v.(string); ok {
+		if code, ok := expressionValueCode(s); ok {
+			return code
+		}
+	}
+	return formatValue(v)
+}
+
+// expressionValueCode returns Go source for a string that consists of one
+// ${{ }} expression, or false if it has other text or no typed builder.
+func expressionValueCode(s string) (string, bool) {
+	expr, ok := placeholder(s)
+	if !ok {
+		return "", false
+	}
+	node, err := workflow.ParseExpression(expr)
+	if err != nil {
+		return "", false
+	}
+	return exprCode(node)
+}
+
+// placeholder returns the expression inside s if s is exactly one ${{ }}
+// placeholder.
+func placeholder(s string) (string, bool) {
+	exprs, err := workflow.ExtractExpressions(s)
+	if err != nil || len(exprs) != 1 {
+		return "", false
+	}
+	if !strings.HasPrefix(s, "${{") || !strings.HasSuffix(s, "}}") {
+		return "", false
+	}
+	return exprs[0], true
+}
+
+// exprCode returns Go source for an expression node and whether any part
+// of it uses a typed builder. Parts without one are workflow.Expression
+// values.
+func exprCode(n workflow.ExprNode) (string, bool) {
+	if code, ok := builderCode(n); ok {
+		return code, true
+	}
+	return fmt.Sprintf("workflow.Expression(%q)", n.String()), false
+}
+
+// builderCode returns Go source for n if a typed builder produces it.
+func builderCode(n workflow.ExprNode) (string, bool) {
+	switch n := n.(type) {
+	case *workflow.PropertyNode:
+		return contextCode(n)
+	case *workflow.CallNode:
+		return callCode(n)
+	case *workflow.CompareNode:
+		if code, ok := compareCode(n); ok {
+			return code, true
+		}
+		return comparisonCode(n)
+	case *workflow.NotNode:
+		if operand, ok := builderCode(n.Operand); ok {
+			return operand + ".Not()", true
+		}
+	case *workflow.LogicalNode:
+		left, leftTyped := exprCode(n.Left)
+		right, rightTyped := exprCode(n.Right)
+		if !leftTyped && !rightTyped {
+			return "", false
+		}
+		method := "And"
+		if n.Op == "||" {
+			method = "Or"
+		}
+		return fmt.Sprintf("%s.%s(%s)", left, method, right), true
+	}
+	return "", false
+}
+
+// contextCode returns the accessor for a context property such as
+// github.ref, secrets.TOKEN or needs.build.outputs.version.
+func contextCode(n *workflow.PropertyNode) (string, bool) {
+	path, ok := propertyPath(n)
+	if !ok {
+		return "", false
+	}
+
+	switch ctx, rest := path[0], path[1:]; {
+	case ctx == "github" && len(rest) == 1 && githubProperties[rest[0]] != "":
+		return fmt.Sprintf("workflow.GitHub.%s()", githubProperties[rest[0]]), true
+	case ctx == "github" && len(rest) > 1 && rest[0] == "event":
+		return fmt.Sprintf("workflow.GitHub.Event(%q)", strings.Join(rest[1:], ".")), true
+	case ctx == "runner" && len(rest) == 1 && runnerProperties[rest[0]] != "":
+		return fmt.Sprintf("workflow.Runner.%s()", runnerProperties[rest[0]]), true
+	case ctx == "secrets" && len(rest) == 1 && rest[0] == "GITHUB_TOKEN":
+		return "workflow.Secrets.GITHUB_TOKEN()", true
+	case nameContexts[ctx] != "" && len(rest) == 1:
+		return fmt.Sprintf("%s.Get(%q)", nameContexts[ctx], rest[0]), true
+	case ctx == "steps" && len(rest) == 3 && rest[1] == "outputs":
+		return fmt.Sprintf("workflow.Steps.Get(%q, %q)", rest[0], rest[2]), true
+	case ctx == "steps" && len(rest) == 2 && rest[1] == "outcome":
+		return fmt.Sprintf("workflow.Steps.Outcome(%q)", rest[0]), true
+	case ctx == "steps" && len(rest) == 2 && rest[1] == "conclusion":
+		return fmt.Sprintf("workflow.Steps.Conclusion(%q)", rest[0]), true
+	case ctx == "needs" && len(rest) == 3 && rest[1] == "outputs":
+		return fmt.Sprintf("workflow.Needs.Get(%q, %q)", rest[0], rest[2]), true
+	case ctx == "needs" && len(rest) == 2 && rest[1] == "result":
+		return fmt.Sprintf("workflow.Needs.Result(%q)", rest[0]), true
+	}
+	return "", false
+}
+
+// propertyPath returns the names of a context property chain, such as
+// [github event pull_request number], or false if it has index or filter
+// access.
+func propertyPath(n workflow.ExprNode) ([]string, bool) {
+	switch n := n.(type) {
+	case *workflow.VariableNode:
+		return []string{n.Name}, true
+	case *workflow.PropertyNode:
+		path, ok := propertyPath(n.Receiver)
+		return append(path, n.Name), ok
+	}
+	return nil, false
+}
+
+// callCode returns the builder for a function call.
+func callCode(n *workflow.CallNode) (string, bool) {
+	if code, ok := statusFunctions[n.Name]; ok && len(n.Args) == 0 {
+		return code, true
+	}
+
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i], _ = exprCode(arg)
+	}
+	switch {
+	case n.Name == "startsWith" && len(n.Args) == 2 && isGitHubRef(n.Args[0]):
+		if prefix, ok := quotableString(n.Args[1]); ok && strings.HasPrefix(prefix, "refs/tags/") {
+			if prefix == "refs/tags/" {
+				return "workflow.IsTag()", true
+			}
+			return fmt.Sprintf("workflow.TagPrefix(%q)", strings.TrimPrefix(prefix, "refs/tags/")), true
+		}
+		fallthrough
+	case n.Name == "startsWith" && len(n.Args) == 2:
+		return fmt.Sprintf("workflow.StartsWith(%s, %s)", args[0], args[1]), true
+	case n.Name == "endsWith" && len(n.Args) == 2:
+		return fmt.Sprintf("workflow.EndsWith(%s, %s)", args[0], args[1]), true
+	case n.Name == "contains" && len(n.Args) == 2:
+		return fmt.Sprintf("workflow.Contains(%s, %s)", args[0], args[1]), true
+	case n.Name == "toJSON" && len(n.Args) == 1:
+		return fmt.Sprintf("workflow.ToJSON(%s)", args[0]), true
+	case n.Name == "fromJSON" && len(n.Args) == 1:
+		return fmt.Sprintf("workflow.FromJSON(%s)", args[0]), true
+	case n.Name == "join" && len(n.Args) == 2:
+		if sep, ok := quotableString(n.Args[1]); ok {
+			return fmt.Sprintf("workflow.Join(%s, %q)", args[0], sep), true
+		}
+	case n.Name == "format" && len(n.Args) > 1:
+		if format, ok := quotableString(n.Args[0]); ok {
+			return fmt.Sprintf("workflow.Format(%q, %s)", format, strings.Join(args[1:], ", ")), true
+		}
+	}
+	return "", false
+}
+
+// compareCode returns the condition builder for a comparison, such as
+// workflow.Branch("main") for github.ref == 'refs/heads/main'. != uses the
+// negated builder.
+func compareCode(n *workflow.CompareNode) (string, bool) {
+	if n.Op != "==" && n.Op != "!=" {
+		return "", false
+	}
+	code, ok := equalityCode(n.Left, n.Right)
+	if !ok {
+		return "", false
+	}
+	if n.Op == "!=" {
+		code += ".Not()"
+	}
+	return code, true
+}
+
+// equalityCode returns the condition builder for left == right.
+func equalityCode(left, right workflow.ExprNode) (string, bool) {
+	if isGitHubRef(left) && right.String() == "format('refs/heads/{0}', github.event.repository.default_branch)" {
+		return "workflow.OnDefaultBranch()", true
+	}
+	value, ok := quotableString(right)
+	if !ok {
+		return "", false
+	}
+
+	path, _ := propertyPath(left)
+	switch {
+	case isGitHubRef(left) && strings.HasPrefix(value, "refs/heads/"):
+		return fmt.Sprintf("workflow.Branch(%q)", strings.TrimPrefix(value, "refs/heads/")), true
+	case isGitHubRef(left) && strings.HasPrefix(value, "refs/tags/"):
+		return fmt.Sprintf("workflow.Tag(%q)", strings.TrimPrefix(value, "refs/tags/")), true
+	case left.String() == "github.event_name" && eventNames[value] != "":
+		return eventNames[value], true
+	case len(path) == 3 && path[0] == "needs" && path[2] == "result" && value == "failure":
+		return fmt.Sprintf("workflow.PreviousJobFailed(%q)", path[1]), true
+	}
+	return "", false
+}
+
+// comparisonCode returns Go source for a comparison without a condition
+// builder whose operands have typed accessors, such as
+// workflow.Expression(workflow.Needs.Get("build", "changed").Raw() + " == 'true'"),
+// or false if neither operand has one.
+func comparisonCode(n *workflow.CompareNode) (string, bool) {
+	var parts []string
+	var text strings.Builder
+	typed := false
+	for i, operand := range []workflow.ExprNode{n.Left, n.Right} {
+		if i == 1 {
+			text.WriteString(" " + n.Op + " ")
+		}
+		switch operand.(type) {
+		case *workflow.CompareNode, *workflow.LogicalNode:
+			return "", false
+		}
+		code, ok := builderCode(operand)
+		if !ok {
+			text.WriteString(operand.String())
+			continue
+		}
+		if text.Len() > 0 {
+			parts = append(parts, fmt.Sprintf("%q", text.String()))
+			text.Reset()
+		}
+		parts = append(parts, code+".Raw()")
+		typed = true
+	}
+	if !typed {
+		return "", false
+	}
+	if text.Len() > 0 {
+		parts = append(parts, fmt.Sprintf("%q", text.String()))
+	}
+	return "workflow.Expression(" + strings.Join(parts, " + ") + ")", true
+}
+
+// isGitHubRef reports whether n is github.ref.
+func isGitHubRef(n workflow.ExprNode) bool {
+	return n.String() == "github.ref"
+}
+
+// quotableString returns the value of a string literal that the builders
+// can quote: they don't escape single quotes.
+func quotableString(n workflow.ExprNode) (string, bool) {
+	s, ok := n.(*workflow.StringNode)
+	if !ok || strings.Contains(s.Value, "'") {
+		return "", false
+	}
+	return s.Value, true
+}
@@ -0,0 +1,75 @@
+package importer
+
+import "testing"
+
+func TestConditionCode(t *testing.T) {
+	tests := []struct {
+		cond string
+		want string
+		ok   bool
+	}{
+		{"github.ref == 'refs/heads/main'", `workflow.Branch("main")`, true},
+		{"${{ github.ref == 'refs/tags/v1.0.0' }}", `workflow.Tag("v1.0.0")`, true},
+		{"startsWith(github.ref, 'refs/tags/v')", `workflow.TagPrefix("v")`, true},
+		{"startsWith(github.ref, 'refs/tags/')", "workflow.IsTag()", true},
+		{"github.event_name == 'push' && github.ref == 'refs/heads/main'", `workflow.Push().And(workflow.Branch("main"))`, true},
+		{"github.event_name != 'pull_request'", "workflow.PullRequest().Not()", true},
+		{"always() || failure()", "workflow.Always().Or(workflow.Failure())", true},
+		{"!cancelled()", "workflow.Cancelled().Not()", true},
+		{"needs.build.result == 'failure'", `workflow.PreviousJobFailed("build")`, true},
+		{"github.ref == format('refs/heads/{0}', github.event.repository.default_branch)", "workflow.OnDefaultBranch()", true},
+		{"needs.build.result == 'success' && success()", `workflow.Expression(workflow.Needs.Result("build").Raw() + " == 'success'").And(workflow.Success())`, true},
+		{"contains(github.event.head_commit.message, '[skip ci]')", `workflow.Contains(workflow.GitHub.Event("head_commit.message"), workflow.Expression("'[skip ci]'"))`, true},
+		{"steps.check.outputs.changed == 'true'", `workflow.Expression(workflow.Steps.Get("check", "changed").Raw() + " == 'true'")`, true},
+		{"${{ needs.changes.outputs.docs != 'false' }}", `workflow.Expression(workflow.Needs.Get("changes", "docs").Raw() + " != 'false'")`, true},
+		{"needs.plan.outputs.count > 0 && !cancelled()", `workflow.Expression(workflow.Needs.Get("plan", "count").Raw() + " > 0").And(workflow.Cancelled().Not())`, true},
+		{"'true' == needs.changes.outputs.go", `workflow.Expression("'true' == " + workflow.Needs.Get("changes", "go").Raw())`, true},
+		{"needs.a.outputs.x == needs.b.outputs.x", `workflow.Expression(workflow.Needs.Get("a", "x").Raw() + " == " + workflow.Needs.Get("b", "x").Raw())`, true},
+		{"needs.changes.outputs.docs == (github.event_name == 'push')", "", false},
+		{"needs.changes.outputs.docs ==", "", false},
+		{"hashFiles('go.sum') != ''", "", false},
+		{"github.ref == 'refs/heads/it''s'", `workflow.Expression(workflow.GitHub.Ref().Raw() + " == 'refs/heads/it''s'")`, true},
+		{"github.ref ==", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := conditionCode(tt.cond)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("conditionCode(%q) = %s, %v; want %s, %v", tt.cond, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestValueCode(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"${{ secrets.DEPLOY_TOKEN }}", `workflow.Secrets.Get("DEPLOY_TOKEN")`},
+		{"${{ secrets.GITHUB_TOKEN }}", "workflow.Secrets.GITHUB_TOKEN()"},
+		{"${{github.sha}}", "workflow.GitHub.SHA()"},
+		{"${{ github.event.pull_request.number }}", `workflow.GitHub.Event("pull_request.number")`},
+		{"${{ runner.os }}", "workflow.Runner.OS()"},
+		{"${{ matrix.go }}", `workflow.MatrixContext.Get("go")`},
+		{"${{ inputs.environment }}", `workflow.Inputs.Get("environment")`},
+		{"${{ vars.REGION }}", `workflow.Vars.Get("REGION")`},
+		{"${{ env.APP }}", `workflow.EnvContext.Get("APP")`},
+		{"${{ steps.meta.outputs.tags }}", `workflow.Steps.Get("meta", "tags")`},
+		{"${{ steps.test.outcome }}", `workflow.Steps.Outcome("test")`},
+		{"${{ needs.build.outputs.version }}", `workflow.Needs.Get("build", "version")`},
+		{"${{ needs.build.result }}", `workflow.Needs.Result("build")`},
+		{"${{ toJSON(github.event) }}", `workflow.ToJSON(workflow.Expression("github.event"))`},
+		{"${{ format('{0}-{1}', runner.os, github.sha) }}", `workflow.Format("{0}-{1}", workflow.Runner.OS(), workflow.GitHub.SHA())`},
+		{"${{ join(matrix.targets, ',') }}", `workflow.Join(workflow.MatrixContext.Get("targets"), ",")`},
+		{"${{ hashFiles('go.sum') }}", `"${{ hashFiles('go.sum') }}"`},
+		{"${{ github.event.commits[0].message }}", `"${{ github.event.commits[0].message }}"`},
+		{"Bearer ${{ secrets.TOKEN }}", `"Bearer ${{ secrets.TOKEN }}"`},
+		{"${{ secrets.A }}${{ secrets.B }}", `"${{ secrets.A }}${{ secrets.B }}"`},
+		{"plain", `"plain"`},
+		{42, "42"},
+	}
+	for _, tt := range tests {
+		if got := valueCode(tt.value); got != tt.want {
+			t.Errorf("valueCode(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lex00/wetwire-github-go/dependabot"
+	"github.com/lex00/wetwire-github-go/internal/serialize"
+	"github.com/lex00/wetwire-github-go/templates"
+)
+
+// Dependabot configs decode straight into the dependabot package's types.
+// Issue and discussion forms are converted by hand, because their form
+// elements nest their settings under attributes: and validations:. Each
+// parser also returns the fields the Go value can't represent, found by
+// serializing it the way build does and comparing with the original.
+
+// ParseDependabotContent parses a dependabot.yml file. It returns the
+// config and the paths of the fields it doesn't represent.
+func ParseDependabotContent(content []byte) (*dependabot.Dependabot, []string, error) {
+	var cfg dependabot.Dependabot
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+	built, err := serialize.DependabotToYAML(&cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	lost, err := compareYAML(content, built)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cfg, lost, nil
+}
+
+// ParseIssueFormContent parses an issue form from ISSUE_TEMPLATE/. It
+// returns the template and the paths of the fields it doesn't represent.
+func ParseIssueFormContent(content []byte) (*templates.IssueTemplate, []string, error) {
+	raw, err := decodeForm(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := formElements(raw["body"])
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl := &templates.IssueTemplate{
+		Name:        scalarString(raw["name"]),
+		Description: scalarString(raw["description"]),
+		Title:       scalarString(raw["title"]),
+		Labels:      formList(raw, "labels"),
+		Projects:    formList(raw, "projects"),
+		Assignees:   formList(raw, "assignees"),
+		Body:        body,
+	}
+
+	built, err := serialize.IssueTemplateToYAML(tmpl)
+	if err != nil {
+		return nil, nil, err
+	}
+	lost, err := compareDecoded(raw, built)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tmpl, lost, nil
+}
+
+// ParseDiscussionFormContent parses a discussion category form from
+// DISCUSSION_TEMPLATE/. It returns the template and the paths of the
+// fields it doesn't represent.
+func ParseDiscussionFormContent(content []byte) (*templates.DiscussionTemplate, []string, error) {
+	raw, err := decodeForm(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := formElements(raw["body"])
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl := &templates.DiscussionTemplate{
+		Title:       scalarString(raw["title"]),
+		Description: scalarString(raw["description"]),
+		Labels:      formList(raw, "labels"),
+		Body:        body,
+	}
+
+	built, err := serialize.DiscussionTemplateToYAML(tmpl)
+	if err != nil {
+		return nil, nil, err
+	}
+	lost, err := compareDecoded(raw, built)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tmpl, lost, nil
+}
+
+// decodeForm decodes a form's YAML into a map.
+func decodeForm(content []byte) (map[string]any, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("empty form")
+	}
+	return raw, nil
+}
+
+// formElements converts the body: of a form.
+func formElements(v any) ([]templates.FormElement, error) {
+	items, _ := v.([]any)
+	elements := make([]templates.FormElement, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("body[%d]: expected a mapping", i)
+		}
+		attrs, _ := m["attributes"].(map[string]any)
+		validations, _ := m["validations"].(map[string]any)
+		id := scalarString(m["id"])
+		required := validations["required"] == true
+
+		switch typ := scalarString(m["type"]); typ {
+		case "markdown":
+			elements = append(elements, templates.Markdown{ID: id, Value: scalarString(attrs["value"])})
+		case "input":
+			elements = append(elements, templates.Input{
+				ID:          id,
+				Label:       scalarString(attrs["label"]),
+				Description: scalarString(attrs["description"]),
+				Placeholder: scalarString(attrs["placeholder"]),
+				Value:       scalarString(attrs["value"]),
+				Required:    required,
+			})
+		case "textarea":
+			elements = append(elements, templates.Textarea{
+				ID:          id,
+				Label:       scalarString(attrs["label"]),
+				Description: scalarString(attrs["description"]),
+				Placeholder: scalarString(attrs["placeholder"]),
+				Value:       scalarString(attrs["value"]),
+				Render:      scalarString(attrs["render"]),
+				Required:    required,
+			})
+		case "dropdown":
+			def, _ := attrs["default"].(int)
+			elements = append(elements, templates.Dropdown{
+				ID:          id,
+				Label:       scalarString(attrs["label"]),
+				Description: scalarString(attrs["description"]),
+				Options:     stringList(attrs["options"]),
+				Multiple:    attrs["multiple"] == true,
+				Default:     def,
+				Required:    required,
+			})
+		case "checkboxes":
+			options, _ := attrs["options"].([]any)
+			checkboxes := templates.Checkboxes{
+				ID:          id,
+				Label:       scalarString(attrs["label"]),
+				Description: scalarString(attrs["description"]),
+			}
+			for _, opt := range options {
+				o, _ := opt.(map[string]any)
+				checkboxes.Options = append(checkboxes.Options, templates.CheckboxOption{
+					Label:    scalarString(o["label"]),
+					Required: o["required"] == true,
+				})
+			}
+			elements = append(elements, checkboxes)
+		default:
+			return nil, fmt.Errorf("body[%d]: unknown form element type %q", i, typ)
+		}
+	}
+	return elements, nil
+}
+
+// formList reads a list field of a form, which may also be written as a
+// comma-separated string. The string form is replaced with the list in
+// raw, so it isn't reported as lost.
+func formList(raw map[string]any, key string) []string {
+	s, ok := raw[key].(string)
+	if !ok {
+		return stringList(raw[key])
+	}
+	var list []any
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+			out = append(out, item)
+		}
+	}
+	raw[key] = list
+	return out
+}
+
+// stringList converts a decoded YAML list to strings.
+func stringList(v any) []string {
+	items, _ := v.([]any)
+	var out []string
+	for _, item := range items {
+		out = append(out, scalarString(item))
+	}
+	return out
+}
+
+// scalarString formats a decoded YAML scalar as a string.
+func scalarString(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// compareYAML returns the fields of the original YAML that are missing from
+// or different in the built YAML.
+func compareYAML(orig, built []byte) ([]string, error) {
+	var o any
+	if err := yaml.Unmarshal(orig, &o); err != nil {
+		return nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+	return compareDecoded(o, built)
+}
+
+// compareDecoded is compareYAML for an original document already decoded.
+func compareDecoded(orig any, built []byte) ([]string, error) {
+	var b any
+	if err := yaml.Unmarshal(built, &b); err != nil {
+		return nil, fmt.Errorf("parsing built YAML: %w", err)
+	}
+	return lostFields(orig, b, ""), nil
+}
@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/dependabot"
+	"github.com/lex00/wetwire-github-go/templates"
+)
+
+func TestParseDependabotContent(t *testing.T) {
+	content := `version: 2
+updates:
+  - package-ecosystem: npm
+    directory: /
+    schedule:
+      interval: daily
+    cooldown:
+      default-days: 3
+    open-pull-requests-limit: 0
+`
+	cfg, lost, err := ParseDependabotContent([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseDependabotContent() error = %v", err)
+	}
+	if len(cfg.Updates) != 1 || cfg.Updates[0].Schedule.Interval != "daily" {
+		t.Errorf("Updates = %+v", cfg.Updates)
+	}
+	if want := []string{"updates[0].cooldown"}; !reflect.DeepEqual(lost, want) {
+		t.Errorf("lost = %v, want %v", lost, want)
+	}
+}
+
+func TestParseIssueFormContent(t *testing.T) {
+	content := `name: Bug
+description: File a bug
+labels: bug, triage
+type: Bug
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: false
+  - type: dropdown
+    attributes:
+      label: OS
+      options: [Linux, macOS]
+      default: 1
+    validations:
+      required: true
+  - type: checkboxes
+    attributes:
+      label: Terms
+      options:
+        - label: I agree
+          required: true
+`
+	tmpl, lost, err := ParseIssueFormContent([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseIssueFormContent() error = %v", err)
+	}
+
+	want := &templates.IssueTemplate{
+		Name:        "Bug",
+		Description: "File a bug",
+		Labels:      []string{"bug", "triage"},
+		Body: []templates.FormElement{
+			templates.Input{ID: "version", Label: "Version"},
+			templates.Dropdown{Label: "OS", Options: []string{"Linux", "macOS"}, Default: 1, Required: true},
+			templates.Checkboxes{Label: "Terms", Options: []templates.CheckboxOption{{Label: "I agree", Required: true}}},
+		},
+	}
+	if !reflect.DeepEqual(tmpl, want) {
+		t.Errorf("template = %+v, want %+v", tmpl, want)
+	}
+	if want := []string{"type"}; !reflect.DeepEqual(lost, want) {
+		t.Errorf("lost = %v, want %v", lost, want)
+	}
+}
+
+func TestParseDiscussionFormContent_UnknownElement(t *testing.T) {
+	_, _, err := ParseDiscussionFormContent([]byte("body:\n  - type: slider\n"))
+	if err == nil || !strings.Contains(err.Error(), "slider") {
+		t.Errorf("error = %v, want unknown element type", err)
+	}
+}
+
+func TestLiteralCode(t *testing.T) {
+	cfg := dependabot.Dependabot{
+		Version: 2,
+		Updates: []dependabot.Update{{
+			PackageEcosystem: "gomod",
+			Schedule:         dependabot.Schedule{Interval: "weekly"},
+			Labels:           []string{"deps"},
+			CommitMessage:    &dependabot.CommitMessage{Prefix: "ci"},
+			Groups:           map[string]dependabot.Group{"go": {Patterns: []string{"*"}}},
+		}},
+	}
+	want := `dependabot.Dependabot{
+	Version: 2,
+	Updates: []dependabot.Update{
+		{
+			PackageEcosystem: "gomod",
+			Schedule: dependabot.Schedule{
+				Interval: "weekly",
+			},
+			Labels: []string{"deps"},
+			Groups: map[string]dependabot.Group{
+				"go": {
+					Patterns: []string{"*"},
+				},
+			},
+			CommitMessage: &dependabot.CommitMessage{
+				Prefix: "ci",
+			},
+		},
+	},
+}`
+	if got := literalCode(cfg); got != want {
+		t.Errorf("literalCode() =\n%s\nwant\n%s", got, want)
+	}
+
+	md := templates.Markdown{Value: "line 1\nline 2\n"}
+	if got := literalCode(md); !strings.Contains(got, "Value: `line 1\nline 2\n`,") {
+		t.Errorf("multi-line string not raw:\n%s", got)
+	}
+}
@@ -0,0 +1,205 @@
+package importer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// literalCode returns a Go composite literal for v, a value of one of the
+// repository's config packages such as dependabot.Dependabot. Zero fields
+// are omitted, and the types of struct elements in slices and maps are
+// elided as gofmt -s would.
+func literalCode(v any) string {
+	return valueLiteral(reflect.ValueOf(v), "", false)
+}
+
+// valueLiteral returns the literal for v at the given indent. With elide,
+// the type of a struct literal is left out.
+func valueLiteral(v reflect.Value, indent string, elide bool) string {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return valueLiteral(v.Elem(), indent, false)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		return "&" + valueLiteral(v.Elem(), indent, false)
+
+	case reflect.Struct:
+		prefix := typeCode(v.Type())
+		if elide {
+			prefix = ""
+		}
+		var fields []string
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() || v.Field(i).IsZero() {
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s\t%s: %s,\n", indent, f.Name, valueLiteral(v.Field(i), indent+"\t", false)))
+		}
+		if len(fields) == 0 {
+			return prefix + "{}"
+		}
+		return prefix + "{\n" + strings.Join(fields, "") + indent + "}"
+
+	case reflect.Slice:
+		elem := v.Type().Elem()
+		if isScalarKind(elem.Kind()) {
+			items := make([]string, v.Len())
+			for i := range items {
+				items[i] = valueLiteral(v.Index(i), indent, false)
+			}
+			return typeCode(v.Type()) + "{" + strings.Join(items, ", ") + "}"
+		}
+		var sb strings.Builder
+		sb.WriteString(typeCode(v.Type()) + "{\n")
+		for i := 0; i < v.Len(); i++ {
+			sb.WriteString(fmt.Sprintf("%s\t%s,\n", indent, valueLiteral(v.Index(i), indent+"\t", elem.Kind() == reflect.Struct)))
+		}
+		sb.WriteString(indent + "}")
+		return sb.String()
+
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		elideElem := v.Type().Elem().Kind() == reflect.Struct
+		var sb strings.Builder
+		sb.WriteString(typeCode(v.Type()) + "{\n")
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("%s\t%s: %s,\n", indent, valueLiteral(k, indent+"\t", false), valueLiteral(v.MapIndex(k), indent+"\t", elideElem)))
+		}
+		sb.WriteString(indent + "}")
+		return sb.String()
+
+	case reflect.String:
+		return stringLiteral(v.String())
+
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	return fmt.Sprintf("%q", fmt.Sprint(v.Interface()))
+}
+
+// typeCode returns Go source for a type, with any for empty interfaces.
+func typeCode(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Slice:
+		return "[]" + typeCode(t.Elem())
+	case reflect.Map:
+		return "map[" + typeCode(t.Key()) + "]" + typeCode(t.Elem())
+	case reflect.Ptr:
+		return "*" + typeCode(t.Elem())
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any"
+		}
+	}
+	return t.String()
+}
+
+// isScalarKind reports whether values of kind k are written inline.
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16,
+		reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// stringLiteral quotes s, as a raw string if it spans several lines.
+func stringLiteral(s string) string {
+	if strings.Contains(s, "\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// lostFields returns the paths of the values in orig, a decoded YAML
+// document, that are missing from or different in built, the document the
+// generated Go code builds. Zero values count as missing in both.
+func lostFields(orig, built any, path string) []string {
+	switch o := orig.(type) {
+	case map[string]any:
+		b, ok := built.(map[string]any)
+		if !ok && built != nil {
+			return []string{path}
+		}
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var lost []string
+		for _, k := range keys {
+			child := joinPath(path, k)
+			bv, ok := b[k]
+			switch {
+			case isZeroYAML(o[k]) && isZeroYAML(bv):
+			case !ok:
+				lost = append(lost, child)
+			default:
+				lost = append(lost, lostFields(o[k], bv, child)...)
+			}
+		}
+		return lost
+
+	case []any:
+		b, ok := built.([]any)
+		if !ok || len(b) != len(o) {
+			return []string{path}
+		}
+		var lost []string
+		for i := range o {
+			lost = append(lost, lostFields(o[i], b[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return lost
+	}
+
+	if fmt.Sprint(orig) != fmt.Sprint(built) {
+		return []string{path}
+	}
+	return nil
+}
+
+// joinPath appends a key to a dotted YAML path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// isZeroYAML reports whether a decoded YAML value is empty, false or 0,
+// or a mapping of such values.
+func isZeroYAML(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		for _, item := range val {
+			if !isZeroYAML(item) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		return len(val) == 0
+	}
+	return reflect.ValueOf(v).IsZero()
+}
@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/lex00/wetwire-github-go/internal/differ"
+)
+
+// cosmeticPath matches the fields that only change how a run is
+// displayed: the run title and step names.
+var cosmeticPath = regexp.MustCompile(`^(run-name|jobs\.[^.]+\.steps\[.+\]\.name)$`)
+
+// CompareBuilt compares an imported file of the given type (one of the
+// File* constants) with the file build generated from its Go code. It
+// returns the differences that change behaviour and the cosmetic ones.
+func CompareBuilt(fileType string, orig, built []byte) (lost, cosmetic []differ.Change, err error) {
+	switch fileType {
+	case FileWorkflow, FileDependabot, FileCodeowners, FileIssueTemplate, FileDiscussionTemplate, FilePRTemplate:
+	default:
+		return nil, nil, fmt.Errorf("unknown file type %q", fileType)
+	}
+
+	o, err := differ.Normalize(fileType, orig)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := differ.Normalize(fileType, built)
+	if err != nil {
+		return nil, nil, fmt.Errorf("built file: %w", err)
+	}
+
+	var changes []differ.Change
+	if fileType == FileWorkflow {
+		changes = matchJobIDs(o, b)
+	}
+	changes = append(changes, differ.CompareDocuments(fileType, o, b)...)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	for _, c := range changes {
+		if cosmeticPath.MatchString(c.Path) {
+			cosmetic = append(cosmetic, c)
+		} else {
+			lost = append(lost, c)
+		}
+	}
+	return lost, cosmetic, nil
+}
+
+// matchJobIDs finds the jobs of the normalized original workflow that
+// were built under their display name instead of their ID. Each is moved
+// back to its ID in built, so its fields are compared, and reported as a
+// change of the job ID.
+func matchJobIDs(orig, built any) []differ.Change {
+	o, _ := orig.(map[string]any)
+	b, _ := built.(map[string]any)
+	origJobs, _ := o["jobs"].(map[string]any)
+	builtJobs, _ := b["jobs"].(map[string]any)
+
+	var changes []differ.Change
+	for _, id := range sortedAnyKeys(origJobs) {
+		if _, ok := builtJobs[id]; ok {
+			continue
+		}
+		job, _ := origJobs[id].(map[string]any)
+		name, _ := job["name"].(string)
+		builtJob, ok := builtJobs[name].(map[string]any)
+		if name == "" || !ok {
+			continue
+		}
+		if _, taken := origJobs[name]; taken {
+			continue
+		}
+		// The name was dropped as equal to the built job's ID
+		builtJob["name"] = name
+		builtJobs[id] = builtJob
+		delete(builtJobs, name)
+		changes = append(changes, differ.Change{Path: "jobs." + id, Old: id, New: name})
+	}
+	return changes
+}
@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareBuilt_Workflow(t *testing.T) {
+	orig := `name: CI
+on: [push]
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - name: Make
+        run: make
+  test:
+    name: Unit tests
+    needs: build
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`
+	built := `name: CI
+on:
+  push: {}
+jobs:
+  build:
+    name: build
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+  Unit tests:
+    name: Unit tests
+    needs: [build]
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+`
+	lost, cosmetic, err := CompareBuilt(FileWorkflow, []byte(orig), []byte(built))
+	if err != nil {
+		t.Fatalf("CompareBuilt() error = %v", err)
+	}
+
+	var paths []string
+	for _, c := range lost {
+		paths = append(paths, c.Path)
+	}
+	if want := []string{"jobs.test", "permissions"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("lost = %v, want paths %v", lost, want)
+	}
+	if lost[0].New != "Unit tests" {
+		t.Errorf("job ID change = %v, want the display name", lost[0])
+	}
+	if len(cosmetic) != 1 || cosmetic[0].Path != `jobs.build.steps[name="Make"].name` {
+		t.Errorf("cosmetic = %v, want the step name", cosmetic)
+	}
+}
+
+func TestCompareBuilt_OtherFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileType string
+		orig     string
+		built    string
+		lost     int
+	}{
+		{"dependabot", FileDependabot, "version: 2\nupdates:\n  - package-ecosystem: npm\n    vendor: false\n", "version: 2\nupdates:\n- package-ecosystem: \"npm\"\n", 0},
+		{"issue form", FileIssueTemplate, "name: Bug\nlabels: bug\n", "name: Bug\nlabels:\n  - bug\n", 0},
+		{"codeowners", FileCodeowners, "* @a\n/docs/ @b\n", "# Generated\n* @a\n", 1},
+		{"pr template", FilePRTemplate, "## Summary\n", "## Summary", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lost, _, err := CompareBuilt(tt.fileType, []byte(tt.orig), []byte(tt.built))
+			if err != nil {
+				t.Fatalf("CompareBuilt() error = %v", err)
+			}
+			if len(lost) != tt.lost {
+				t.Errorf("lost = %v, want %d", lost, tt.lost)
+			}
+		})
+	}
+}
+
+func TestCompareBuilt_UnknownType(t *testing.T) {
+	if _, _, err := CompareBuilt("action", []byte("a: 1"), []byte("a: 1")); err == nil {
+		t.Error("CompareBuilt() should fail for an unknown file type")
+	}
+}
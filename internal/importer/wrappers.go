@@ -0,0 +1,355 @@
+package importer
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lex00/wetwire-github-go/actions/actions_rs_toolchain"
+	"github.com/lex00/wetwire-github-go/actions/add_and_commit"
+	"github.com/lex00/wetwire-github-go/actions/add_to_project"
+	"github.com/lex00/wetwire-github-go/actions/attest_build_provenance"
+	"github.com/lex00/wetwire-github-go/actions/aws_configure_credentials"
+	"github.com/lex00/wetwire-github-go/actions/aws_ecr_login"
+	"github.com/lex00/wetwire-github-go/actions/azure_docker_login"
+	"github.com/lex00/wetwire-github-go/actions/azure_login"
+	"github.com/lex00/wetwire-github-go/actions/azure_webapps_deploy"
+	"github.com/lex00/wetwire-github-go/actions/cache"
+	"github.com/lex00/wetwire-github-go/actions/cargo"
+	"github.com/lex00/wetwire-github-go/actions/checkout"
+	"github.com/lex00/wetwire-github-go/actions/codecov"
+	"github.com/lex00/wetwire-github-go/actions/codeql_analyze"
+	"github.com/lex00/wetwire-github-go/actions/codeql_init"
+	"github.com/lex00/wetwire-github-go/actions/configure_pages"
+	"github.com/lex00/wetwire-github-go/actions/cosign_installer"
+	"github.com/lex00/wetwire-github-go/actions/create_github_app_token"
+	"github.com/lex00/wetwire-github-go/actions/create_pull_request"
+	"github.com/lex00/wetwire-github-go/actions/create_release"
+	"github.com/lex00/wetwire-github-go/actions/dawidd6_download_artifact"
+	"github.com/lex00/wetwire-github-go/actions/dependency_review"
+	"github.com/lex00/wetwire-github-go/actions/deploy_pages"
+	"github.com/lex00/wetwire-github-go/actions/docker_build_push"
+	"github.com/lex00/wetwire-github-go/actions/docker_login"
+	"github.com/lex00/wetwire-github-go/actions/docker_metadata"
+	"github.com/lex00/wetwire-github-go/actions/docker_setup_buildx"
+	"github.com/lex00/wetwire-github-go/actions/download_artifact"
+	"github.com/lex00/wetwire-github-go/actions/first_interaction"
+	"github.com/lex00/wetwire-github-go/actions/fossa"
+	"github.com/lex00/wetwire-github-go/actions/gcp_auth"
+	"github.com/lex00/wetwire-github-go/actions/gcp_deploy_cloudrun"
+	"github.com/lex00/wetwire-github-go/actions/gcp_setup_gcloud"
+	"github.com/lex00/wetwire-github-go/actions/gh_pages_deploy"
+	"github.com/lex00/wetwire-github-go/actions/gh_pages_peaceiris"
+	"github.com/lex00/wetwire-github-go/actions/gh_release"
+	"github.com/lex00/wetwire-github-go/actions/github_script"
+	"github.com/lex00/wetwire-github-go/actions/github_tag_action"
+	"github.com/lex00/wetwire-github-go/actions/golangci_lint"
+	"github.com/lex00/wetwire-github-go/actions/helm_chart_releaser"
+	"github.com/lex00/wetwire-github-go/actions/hugo"
+	"github.com/lex00/wetwire-github-go/actions/import_gpg"
+	"github.com/lex00/wetwire-github-go/actions/junit_report"
+	"github.com/lex00/wetwire-github-go/actions/k8s_set_context"
+	"github.com/lex00/wetwire-github-go/actions/kind"
+	"github.com/lex00/wetwire-github-go/actions/kustomize"
+	"github.com/lex00/wetwire-github-go/actions/labeler"
+	"github.com/lex00/wetwire-github-go/actions/ncipollo_release"
+	"github.com/lex00/wetwire-github-go/actions/pre_commit"
+	"github.com/lex00/wetwire-github-go/actions/pulumi"
+	"github.com/lex00/wetwire-github-go/actions/reviewdog"
+	"github.com/lex00/wetwire-github-go/actions/scorecard"
+	"github.com/lex00/wetwire-github-go/actions/setup_dotnet"
+	"github.com/lex00/wetwire-github-go/actions/setup_go"
+	"github.com/lex00/wetwire-github-go/actions/setup_helm"
+	"github.com/lex00/wetwire-github-go/actions/setup_java"
+	"github.com/lex00/wetwire-github-go/actions/setup_node"
+	"github.com/lex00/wetwire-github-go/actions/setup_python"
+	"github.com/lex00/wetwire-github-go/actions/setup_ruby"
+	"github.com/lex00/wetwire-github-go/actions/setup_rust"
+	"github.com/lex00/wetwire-github-go/actions/setup_terraform"
+	"github.com/lex00/wetwire-github-go/actions/slack"
+	"github.com/lex00/wetwire-github-go/actions/sonarcloud"
+	"github.com/lex00/wetwire-github-go/actions/stale"
+	"github.com/lex00/wetwire-github-go/actions/super_linter"
+	"github.com/lex00/wetwire-github-go/actions/trivy"
+	"github.com/lex00/wetwire-github-go/actions/upload_artifact"
+	"github.com/lex00/wetwire-github-go/actions/upload_pages_artifact"
+	"github.com/lex00/wetwire-github-go/actions/upload_release_asset"
+	"github.com/lex00/wetwire-github-go/actions/upload_sarif"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// actionWrappers lists every typed action wrapper under actions/, so that
+// imported steps can use them instead of raw uses: strings.
+var actionWrappers = []workflow.StepAction{
+	actions_rs_toolchain.Toolchain{},
+	add_and_commit.AddAndCommit{},
+	add_to_project.AddToProject{},
+	attest_build_provenance.AttestBuildProvenance{},
+	aws_configure_credentials.AWSConfigureCredentials{},
+	aws_ecr_login.AWSECRLogin{},
+	azure_docker_login.AzureDockerLogin{},
+	azure_login.AzureLogin{},
+	azure_webapps_deploy.AzureWebappsDeploy{},
+	cache.Cache{},
+	cargo.Cargo{},
+	checkout.Checkout{},
+	codecov.Codecov{},
+	codeql_analyze.CodeQLAnalyze{},
+	codeql_init.CodeQLInit{},
+	configure_pages.ConfigurePages{},
+	cosign_installer.CosignInstaller{},
+	create_github_app_token.CreateGithubAppToken{},
+	create_pull_request.CreatePullRequest{},
+	create_release.CreateRelease{},
+	dawidd6_download_artifact.DownloadArtifact{},
+	dependency_review.DependencyReview{},
+	deploy_pages.DeployPages{},
+	docker_build_push.DockerBuildPush{},
+	docker_login.DockerLogin{},
+	docker_metadata.DockerMetadata{},
+	docker_setup_buildx.DockerSetupBuildx{},
+	download_artifact.DownloadArtifact{},
+	first_interaction.FirstInteraction{},
+	fossa.Fossa{},
+	gcp_auth.GCPAuth{},
+	gcp_deploy_cloudrun.GCPDeployCloudRun{},
+	gcp_setup_gcloud.GCPSetupGcloud{},
+	gh_pages_deploy.GitHubPagesDeploy{},
+	gh_pages_peaceiris.GHPagesPeaceiris{},
+	gh_release.GHRelease{},
+	github_script.GithubScript{},
+	github_tag_action.GitHubTagAction{},
+	golangci_lint.GolangciLint{},
+	helm_chart_releaser.HelmChartReleaser{},
+	hugo.Hugo{},
+	import_gpg.ImportGPG{},
+	junit_report.JUnitReport{},
+	k8s_set_context.K8sSetContext{},
+	kind.Kind{},
+	kustomize.Kustomize{},
+	labeler.Labeler{},
+	ncipollo_release.NcipolloRelease{},
+	pre_commit.PreCommit{},
+	pulumi.Pulumi{},
+	reviewdog.Reviewdog{},
+	reviewdog.ReviewdogReporter{},
+	scorecard.Scorecard{},
+	setup_dotnet.SetupDotnet{},
+	setup_go.SetupGo{},
+	setup_helm.SetupHelm{},
+	setup_java.SetupJava{},
+	setup_node.SetupNode{},
+	setup_python.SetupPython{},
+	setup_ruby.SetupRuby{},
+	setup_rust.SetupRust{},
+	setup_terraform.SetupTerraform{},
+	slack.Slack{},
+	sonarcloud.SonarCloud{},
+	stale.Stale{},
+	super_linter.SuperLinter{},
+	trivy.Trivy{},
+	upload_artifact.UploadArtifact{},
+	upload_pages_artifact.UploadPagesArtifact{},
+	upload_release_asset.UploadReleaseAsset{},
+	upload_sarif.UploadSarif{},
+}
+
+// actionWrapper describes the typed wrapper for an action.
+type actionWrapper struct {
+	// ImportPath is the Go import path of the wrapper's package
+	ImportPath string
+	// Package is the package name, e.g. "checkout"
+	Package string
+	// Type is the wrapper type name, e.g. "Checkout"
+	Type string
+	// Version is the version the wrapper's Action method returns, e.g. "v4"
+	Version string
+	// Inputs maps action input names to the wrapper's fields
+	Inputs map[string]reflect.StructField
+}
+
+var (
+	wrapperIndexOnce sync.Once
+	wrapperIndex     map[string]*actionWrapper
+)
+
+// lookupWrapper returns the wrapper for an action reference such as
+// "actions/checkout@v3". The action name is matched case-insensitively;
+// the version is not matched.
+func lookupWrapper(uses string) (*actionWrapper, bool) {
+	wrapperIndexOnce.Do(func() {
+		wrapperIndex = make(map[string]*actionWrapper, len(actionWrappers))
+		for _, a := range actionWrappers {
+			name, version, _ := strings.Cut(a.Action(), "@")
+			t := reflect.TypeOf(a)
+			w := &actionWrapper{
+				ImportPath: t.PkgPath(),
+				Package:    t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:],
+				Type:       t.Name(),
+				Version:    version,
+				Inputs:     make(map[string]reflect.StructField, t.NumField()),
+			}
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				input, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+				if input != "" && input != "-" {
+					w.Inputs[input] = field
+				}
+			}
+			wrapperIndex[strings.ToLower(name)] = w
+		}
+	})
+
+	name, _, _ := strings.Cut(uses, "@")
+	w, ok := wrapperIndex[strings.ToLower(name)]
+	return w, ok
+}
+
+// wrapperStep is an imported step that uses a typed action wrapper.
+type wrapperStep struct {
+	Wrapper *actionWrapper
+	// Fields holds the wrapper fields set from with:, in field order
+	Fields []string
+	// UsesWorkflow is true if a field value uses the workflow package
+	UsesWorkflow bool
+	// Rest holds the step's other settings. Its With keeps the inputs
+	// the wrapper has no field for or whose value a field can't hold,
+	// such as zero values or expressions for bool and int fields.
+	Rest IRStep
+	// Note explains a difference between the imported reference and the
+	// wrapper's, or is empty
+	Note string
+}
+
+// newWrapperStep maps a step's uses: and with: to a typed wrapper. It
+// returns false if no wrapper exists for the action.
+func newWrapperStep(step IRStep) (*wrapperStep, bool) {
+	w, ok := lookupWrapper(step.Uses)
+	if !ok {
+		return nil, false
+	}
+
+	ws := &wrapperStep{Wrapper: w, Rest: step}
+	ws.Rest.Uses = ""
+	ws.Rest.With = nil
+	type field struct {
+		index int
+		code  string
+	}
+	var fields []field
+	for input, v := range step.With {
+		f, ok := w.Inputs[input]
+		if !ok {
+			ws.addExtra(input, v)
+			continue
+		}
+		value, ok := fieldValue(f.Type.Kind(), v)
+		if !ok {
+			ws.addExtra(input, v)
+			continue
+		}
+		if strings.HasPrefix(value, "workflow.") {
+			ws.UsesWorkflow = true
+		}
+		fields = append(fields, field{f.Index[0], fmt.Sprintf("%s: %s", f.Name, value)})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].index < fields[j].index })
+	for _, f := range fields {
+		ws.Fields = append(ws.Fields, f.code)
+	}
+
+	if _, version, found := strings.Cut(step.Uses, "@"); found && version != w.Version {
+		if shaRE.MatchString(version) {
+			ws.Note = fmt.Sprintf("pinned to %s in the imported workflow; the wrapper uses %s (see wetwire-github pin)", version, w.Version)
+		} else {
+			ws.Note = fmt.Sprintf("%s in the imported workflow; the wrapper uses %s", step.Uses, w.Version)
+		}
+	}
+	return ws, true
+}
+
+func (ws *wrapperStep) addExtra(input string, v any) {
+	if ws.Rest.With == nil {
+		ws.Rest.With = make(map[string]any)
+	}
+	ws.Rest.With[input] = v
+}
+
+// Bare reports whether the wrapper alone expresses the step.
+func (ws *wrapperStep) Bare() bool {
+	return isEmptyStep(ws.Rest)
+}
+
+// Literal returns the wrapper's composite literal.
+func (ws *wrapperStep) Literal(indent string) string {
+	typ := ws.Wrapper.Package + "." + ws.Wrapper.Type
+	if len(ws.Fields) <= 1 {
+		return typ + "{" + strings.Join(ws.Fields, "") + "}"
+	}
+	var sb strings.Builder
+	sb.WriteString(typ + "{\n")
+	for _, f := range ws.Fields {
+		sb.WriteString(indent + "\t" + f + ",\n")
+	}
+	sb.WriteString(indent + "}")
+	return sb.String()
+}
+
+var shaRE = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// fieldValue formats a with: value as Go source for a wrapper field of the
+// given kind. It returns false if the field can't hold the value: wrappers
+// leave zero values out of their inputs, and bool and int fields can't
+// hold expressions. Expressions in string fields use the typed builders.
+func fieldValue(kind reflect.Kind, v any) (string, bool) {
+	switch kind {
+	case reflect.String:
+		var s string
+		switch val := v.(type) {
+		case string:
+			if code, ok := expressionValueCode(val); ok {
+				return code + ".String()", true
+			}
+			s = val
+		case int, bool:
+			s = fmt.Sprint(val)
+		case float64:
+			s = strconv.FormatFloat(val, 'f', -1, 64)
+		default:
+			return "", false
+		}
+		return fmt.Sprintf("%q", s), s != ""
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if s, isString := v.(string); isString {
+			parsed, err := strconv.ParseBool(s)
+			b, ok = parsed, err == nil
+		}
+		return "true", ok && b
+	case reflect.Int:
+		var n int
+		switch val := v.(type) {
+		case int:
+			n = val
+		case float64:
+			if val != float64(int(val)) {
+				return "", false
+			}
+			n = int(val)
+		case string:
+			parsed, err := strconv.Atoi(val)
+			if err != nil {
+				return "", false
+			}
+			n = parsed
+		default:
+			return "", false
+		}
+		return strconv.Itoa(n), n != 0
+	}
+	return "", false
+}
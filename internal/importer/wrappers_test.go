@@ -0,0 +1,140 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestActionWrappers_MatchActions(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "actions", "*", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pattern := regexp.MustCompile(`func \(\w+ \*?(\w+)\) Action\(\) string \{\s*return "([^"]+)"`)
+	found := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg := filepath.Base(filepath.Dir(file))
+		for _, m := range pattern.FindAllStringSubmatch(string(src), -1) {
+			found++
+			w, ok := lookupWrapper(m[2])
+			if !ok || w.Package != pkg || w.Type != m[1] {
+				t.Errorf("lookupWrapper(%q) = %+v, want %s.%s", m[2], w, pkg, m[1])
+			}
+		}
+	}
+	if found != len(actionWrappers) {
+		t.Errorf("found %d wrappers, actionWrappers has %d", found, len(actionWrappers))
+	}
+}
+
+// TestActionWrappers_Inputs checks that every wrapper field's yaml tag is
+// the input name its Inputs method uses.
+func TestActionWrappers_Inputs(t *testing.T) {
+	for _, a := range actionWrappers {
+		w, _ := lookupWrapper(a.Action())
+		v := reflect.New(reflect.TypeOf(a)).Elem()
+		for input, f := range w.Inputs {
+			field := v.FieldByIndex(f.Index)
+			switch field.Kind() {
+			case reflect.String:
+				field.SetString("x")
+			case reflect.Bool:
+				field.SetBool(true)
+			case reflect.Int:
+				field.SetInt(1)
+			default:
+				t.Errorf("%s.%s: unsupported kind %s for %q", w.Package, w.Type, field.Kind(), input)
+			}
+		}
+
+		inputs := v.Interface().(interface{ Inputs() map[string]any }).Inputs()
+		for input := range w.Inputs {
+			if _, ok := inputs[input]; !ok {
+				t.Errorf("%s.%s: field for %q does not produce that input", w.Package, w.Type, input)
+			}
+		}
+		if len(inputs) != len(w.Inputs) {
+			t.Errorf("%s.%s: Inputs() has %d inputs, fields map %d", w.Package, w.Type, len(inputs), len(w.Inputs))
+		}
+	}
+}
+
+func TestNewWrapperStep(t *testing.T) {
+	ws, ok := newWrapperStep(IRStep{
+		Name: "Checkout",
+		Uses: "Actions/Checkout@v4",
+		With: map[string]any{
+			"fetch-depth":         2,
+			"lfs":                 "true",
+			"persist-credentials": false,
+			"clean":               "${{ inputs.clean }}",
+			"ref":                 "",
+			"submodules":          true,
+			"unknown":             "x",
+		},
+	})
+	if !ok {
+		t.Fatal("newWrapperStep() found no wrapper")
+	}
+
+	if got := strings.Join(ws.Fields, ", "); got != `FetchDepth: 2, LFS: true, Submodules: "true"` {
+		t.Errorf("Fields = %s", got)
+	}
+	wantRest := map[string]any{
+		"persist-credentials": false,
+		"clean":               "${{ inputs.clean }}",
+		"ref":                 "",
+		"unknown":             "x",
+	}
+	if !reflect.DeepEqual(ws.Rest.With, wantRest) {
+		t.Errorf("Rest.With = %v, want %v", ws.Rest.With, wantRest)
+	}
+	if ws.Rest.Name != "Checkout" || ws.Rest.Uses != "" || ws.Bare() {
+		t.Errorf("Rest = %+v", ws.Rest)
+	}
+	if ws.Note != "" {
+		t.Errorf("Note = %q, want none for a matching version", ws.Note)
+	}
+
+	if _, ok := newWrapperStep(IRStep{Uses: "./.github/actions/build"}); ok {
+		t.Error("local actions should not map to a wrapper")
+	}
+	if _, ok := newWrapperStep(IRStep{Uses: "some/unknown-action@v1"}); ok {
+		t.Error("unknown actions should not map to a wrapper")
+	}
+}
+
+func TestNewWrapperStep_VersionNote(t *testing.T) {
+	tests := []struct {
+		uses string
+		want string
+	}{
+		{"actions/checkout@v4", ""},
+		{"actions/checkout@v3", "actions/checkout@v3 in the imported workflow; the wrapper uses v4"},
+		{"actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab", "pinned to 8e5e7e5ab8b370d6c329ec480221332ada57f0ab in the imported workflow; the wrapper uses v4 (see wetwire-github pin)"},
+	}
+	for _, tt := range tests {
+		ws, ok := newWrapperStep(IRStep{Uses: tt.uses})
+		if !ok {
+			t.Fatalf("newWrapperStep(%q) found no wrapper", tt.uses)
+		}
+		if ws.Note != tt.want {
+			t.Errorf("newWrapperStep(%q).Note = %q, want %q", tt.uses, ws.Note, tt.want)
+		}
+		if !ws.Bare() {
+			t.Errorf("newWrapperStep(%q) should be bare", tt.uses)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Package inventory describes the runners available to a project and
+// checks runs-on labels against them.
+//
+// GitHub-hosted images and their retirements are built in. A project can
+// add its own self-hosted labels, larger runners and runner groups in a
+// wetwire-runners.yml file; once that file exists, labels it does not list
+// are reported as unknown.
+package inventory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// FileName is the name of the runner inventory file.
+const FileName = "wetwire-runners.yml"
+
+// Inventory lists the runners a project may use.
+type Inventory struct {
+	// Labels are self-hosted and larger runner labels in addition to the
+	// GitHub-hosted images.
+	Labels []string `yaml:"labels"`
+
+	// Groups are the runner groups available to the repository.
+	Groups []string `yaml:"groups"`
+
+	// Deprecated maps labels that still work but should be replaced to a
+	// migration hint.
+	Deprecated map[string]string `yaml:"deprecated"`
+
+	// Retired maps labels that no longer have runners to a migration hint.
+	Retired map[string]string `yaml:"retired"`
+
+	// Path is the file the inventory was loaded from.
+	Path string `yaml:"-"`
+}
+
+// Problem classifies a finding.
+type Problem string
+
+const (
+	// ProblemUnknown is a label or group missing from the inventory.
+	ProblemUnknown Problem = "unknown"
+
+	// ProblemDeprecated is a label that should be migrated away from.
+	ProblemDeprecated Problem = "deprecated"
+
+	// ProblemRetired is a label no runner will pick up.
+	ProblemRetired Problem = "retired"
+)
+
+// Finding is a problem with a runs-on label or group.
+type Finding struct {
+	// Label is the offending label or group name
+	Label string
+
+	// Problem classifies the finding
+	Problem Problem
+
+	// Message describes the problem
+	Message string
+}
+
+// hostedImages are the GitHub-hosted runner images.
+var hostedImages = map[string]bool{
+	"ubuntu-latest":       true,
+	"ubuntu-24.04":        true,
+	"ubuntu-22.04":        true,
+	"ubuntu-24.04-arm":    true,
+	"ubuntu-22.04-arm":    true,
+	"ubuntu-slim":         true,
+	"windows-latest":      true,
+	"windows-2025":        true,
+	"windows-2022":        true,
+	"windows-11-arm":      true,
+	"macos-latest":        true,
+	"macos-26":            true,
+	"macos-15":            true,
+	"macos-14":            true,
+	"macos-15-intel":      true,
+	"macos-latest-large":  true,
+	"macos-15-large":      true,
+	"macos-14-large":      true,
+	"macos-latest-xlarge": true,
+	"macos-26-xlarge":     true,
+	"macos-15-xlarge":     true,
+	"macos-14-xlarge":     true,
+}
+
+// retiredImages are GitHub-hosted images that have been removed, with the
+// suggested replacement.
+var retiredImages = map[string]string{
+	"ubuntu-16.04":    "use ubuntu-24.04",
+	"ubuntu-18.04":    "use ubuntu-24.04",
+	"ubuntu-20.04":    "use ubuntu-24.04",
+	"macos-10.15":     "use macos-15",
+	"macos-11":        "use macos-15",
+	"macos-12":        "use macos-15",
+	"macos-13":        "use macos-15 or macos-15-intel",
+	"macos-13-large":  "use macos-15-large",
+	"macos-13-xlarge": "use macos-15-xlarge",
+	"windows-2016":    "use windows-2025",
+	"windows-2019":    "use windows-2025",
+}
+
+// selfHostedLabels are the labels every self-hosted runner receives.
+var selfHostedLabels = map[string]bool{
+	"self-hosted": true,
+	"linux":       true,
+	"windows":     true,
+	"macos":       true,
+	"x64":         true,
+	"arm":         true,
+	"arm64":       true,
+}
+
+// Load reads an inventory file.
+func Load(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &Inventory{}
+	if err := yaml.Unmarshal(data, inv); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	inv.Path = path
+	return inv, nil
+}
+
+// Find looks for an inventory file in dir and its parents, stopping at the
+// directory containing go.mod. It returns nil if there is none.
+func Find(dir string) (*Inventory, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Check reports retired, deprecated and unknown labels in r. Unknown labels
+// and groups are only reported when inv is not nil. Runners chosen by an
+// expression are not checked.
+//
+// The built-in retired images only apply to GitHub-hosted runners: they are
+// not reported for self-hosted runners or for labels the inventory lists,
+// since those may reuse a retired image name.
+func (inv *Inventory) Check(r workflow.RunsOn) []Finding {
+	if r.Expr != "" {
+		return nil
+	}
+
+	selfHosted := false
+	for _, label := range r.Labels {
+		if strings.EqualFold(label, "self-hosted") {
+			selfHosted = true
+		}
+	}
+
+	var findings []Finding
+
+	if r.Group != "" && inv != nil && !contains(inv.Groups, r.Group) {
+		findings = append(findings, Finding{
+			Label:   r.Group,
+			Problem: ProblemUnknown,
+			Message: fmt.Sprintf("runner group %q is not listed in %s", r.Group, inv.fileName()),
+		})
+	}
+
+	for _, label := range r.Labels {
+		if strings.Contains(label, "${{") {
+			continue
+		}
+		if f, ok := inv.checkLabel(label, selfHosted); ok {
+			findings = append(findings, f)
+		}
+	}
+
+	return findings
+}
+
+// checkLabel reports the problem with a single label of a runner, if any.
+func (inv *Inventory) checkLabel(label string, selfHosted bool) (Finding, bool) {
+	listed := inv != nil && contains(inv.Labels, label)
+
+	if inv != nil {
+		if hint, ok := inv.Retired[label]; ok {
+			return retired(label, hint), true
+		}
+		if hint, ok := inv.Deprecated[label]; ok {
+			return Finding{
+				Label:   label,
+				Problem: ProblemDeprecated,
+				Message: withHint(fmt.Sprintf("runner label %q is deprecated", label), hint),
+			}, true
+		}
+	}
+
+	if hint, ok := retiredImages[label]; ok && !selfHosted && !listed {
+		return retired(label, hint), true
+	}
+
+	if inv == nil || hostedImages[label] || selfHostedLabels[strings.ToLower(label)] || listed {
+		return Finding{}, false
+	}
+
+	return Finding{
+		Label:   label,
+		Problem: ProblemUnknown,
+		Message: fmt.Sprintf("runner label %q is not a GitHub-hosted image and is not listed in %s", label, inv.fileName()),
+	}, true
+}
+
+func (inv *Inventory) fileName() string {
+	if inv.Path != "" {
+		return filepath.Base(inv.Path)
+	}
+	return FileName
+}
+
+func retired(label, hint string) Finding {
+	return Finding{
+		Label:   label,
+		Problem: ProblemRetired,
+		Message: withHint(fmt.Sprintf("runner label %q is retired and no runner will pick up the job", label), hint),
+	}
+}
+
+func withHint(msg, hint string) string {
+	if hint == "" {
+		return msg
+	}
+	return msg + " (" + hint + ")"
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
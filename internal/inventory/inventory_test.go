@@ -0,0 +1,137 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+func TestCheck_NoInventory(t *testing.T) {
+	var inv *Inventory
+
+	tests := []struct {
+		name    string
+		runsOn  workflow.RunsOn
+		problem Problem
+	}{
+		{"hosted image", workflow.Hosted("ubuntu-24.04"), ""},
+		{"custom label", workflow.SelfHosted("gpu"), ""},
+		{"group", workflow.RunnerGroup("fleet"), ""},
+		{"retired image", workflow.Hosted("ubuntu-20.04"), ProblemRetired},
+		{"self-hosted retired name", workflow.SelfHosted("ubuntu-20.04"), ""},
+		{"expression", workflow.RunsOnExpr("matrix.os"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := inv.Check(tt.runsOn)
+			if tt.problem == "" {
+				if len(findings) != 0 {
+					t.Errorf("Check() = %v, want no findings", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].Problem != tt.problem {
+				t.Errorf("Check() = %v, want one %s finding", findings, tt.problem)
+			}
+		})
+	}
+}
+
+func TestCheck_WithInventory(t *testing.T) {
+	inv := &Inventory{
+		Labels:     []string{"gpu", "ubuntu-latest-16-cores", "macos-12"},
+		Groups:     []string{"fleet"},
+		Deprecated: map[string]string{"ubuntu-22.04": "use ubuntu-24.04"},
+		Retired:    map[string]string{"old-box": ""},
+		Path:       "/repo/" + FileName,
+	}
+
+	tests := []struct {
+		name    string
+		runsOn  workflow.RunsOn
+		problem Problem
+	}{
+		{"hosted image", workflow.Hosted("ubuntu-latest"), ""},
+		{"larger runner", workflow.Hosted("ubuntu-latest-16-cores"), ""},
+		{"self-hosted labels", workflow.SelfHosted("Linux", "x64", "gpu"), ""},
+		{"known group", workflow.RunnerGroup("fleet", "gpu"), ""},
+		{"unknown label", workflow.SelfHosted("tpu"), ProblemUnknown},
+		{"unknown group", workflow.RunnerGroup("other"), ProblemUnknown},
+		{"deprecated", workflow.Hosted("ubuntu-22.04"), ProblemDeprecated},
+		{"project retired", workflow.SelfHosted("old-box"), ProblemRetired},
+		{"built-in retired", workflow.Hosted("ubuntu-20.04"), ProblemRetired},
+		{"listed retired name", workflow.Hosted("macos-12"), ""},
+		{"label expression", workflow.SelfHosted("${{ matrix.pool }}"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := inv.Check(tt.runsOn)
+			if tt.problem == "" {
+				if len(findings) != 0 {
+					t.Errorf("Check() = %v, want no findings", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].Problem != tt.problem {
+				t.Fatalf("Check() = %v, want one %s finding", findings, tt.problem)
+			}
+			if !strings.Contains(findings[0].Message, findings[0].Label) {
+				t.Errorf("Message %q does not name %q", findings[0].Message, findings[0].Label)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	pkg := filepath.Join(root, "ci", "workflows")
+	if err := os.MkdirAll(pkg, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err := Find(pkg)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if inv != nil {
+		t.Fatalf("Find() = %v, want nil without an inventory file", inv)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	content := "labels:\n  - gpu\ngroups:\n  - fleet\ndeprecated:\n  ubuntu-22.04: use ubuntu-24.04\n"
+	if err := os.WriteFile(filepath.Join(root, FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err = Find(pkg)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if inv == nil {
+		t.Fatal("Find() = nil, want the inventory in the module root")
+	}
+	if len(inv.Labels) != 1 || inv.Labels[0] != "gpu" {
+		t.Errorf("Labels = %v", inv.Labels)
+	}
+	if inv.Deprecated["ubuntu-22.04"] != "use ubuntu-24.04" {
+		t.Errorf("Deprecated = %v", inv.Deprecated)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("labels: {"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("Load() should fail on invalid YAML")
+	}
+}
@@ -0,0 +1,257 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lex00/wetwire-github-go/internal/glob"
+)
+
+// ConfigFileName is the name of the project lint configuration file.
+const ConfigFileName = ".wetwire.yaml"
+
+// Config is a project lint configuration, read from .wetwire.yaml:
+//
+//	disable: [WAG004]
+//	semantic: true
+//	ignore:
+//	  - "generated/**"
+//	rules:
+//	  WAG007:
+//	    max-jobs: 20
+//	  WAG008:
+//	    severity: off
+//	  WAG014:
+//	    severity: error
+//	    default-timeout: 15
+type Config struct {
+	// Disable lists rule IDs to turn off.
+	Disable []string `yaml:"disable"`
+
+	// Ignore lists files not to lint, as GitHub filter patterns relative to
+	// the directory of the configuration file. Patterns starting with "!"
+	// re-include files.
+	Ignore []string `yaml:"ignore"`
+
+	// Rules configures individual rules by ID.
+	Rules map[string]RuleConfig `yaml:"rules"`
+
+	// Semantic turns the model rules, which build the workflows before
+	// checking them, on or off. They are on unless set to false.
+	Semantic *bool `yaml:"semantic"`
+
+	// Path is the file the configuration was loaded from.
+	Path string `yaml:"-"`
+}
+
+// RuleConfig configures one rule.
+type RuleConfig struct {
+	// Severity overrides the rule's severity: error, warning, info or off.
+	Severity string `yaml:"severity"`
+
+	// Options are rule parameters, such as max-jobs for WAG007.
+	Options map[string]any `yaml:",inline"`
+}
+
+// Configurable is an optional interface for rules that take parameters
+// from the configuration file.
+type Configurable interface {
+	// Configure applies the rule's options, rejecting unknown ones.
+	Configure(options map[string]any) error
+}
+
+// LoadConfig reads and validates a configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	cfg.Path = path
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// FindConfig looks for a configuration file in dir and its parents,
+// stopping at the directory containing go.mod. It returns nil if there is
+// none.
+func FindConfig(dir string) (*Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		path := filepath.Join(dir, ConfigFileName)
+		if _, err := os.Stat(path); err == nil {
+			return LoadConfig(path)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// validate checks rule IDs, severities and rule options.
+func (c *Config) validate() error {
+	rules := make(map[string]Rule)
+	for _, rule := range defaultRules() {
+		rules[rule.ID()] = rule
+	}
+
+	for _, id := range c.Disable {
+		if _, ok := rules[strings.ToUpper(id)]; !ok {
+			return fmt.Errorf("disable: unknown rule %s", id)
+		}
+	}
+
+	// Rule IDs are case-insensitive, as on the command line.
+	normalized := make(map[string]RuleConfig, len(c.Rules))
+	for id, rc := range c.Rules {
+		if ruleIDPattern.MatchString(id) {
+			id = strings.ToUpper(id)
+		}
+		normalized[id] = rc
+	}
+	c.Rules = normalized
+
+	ids := make([]string, 0, len(c.Rules))
+	for id := range c.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		rc := c.Rules[id]
+		if rc.Severity != "" {
+			if _, _, err := parseSeverity(rc.Severity); err != nil {
+				return fmt.Errorf("rules.%s: %w", id, err)
+			}
+		}
+
+		if id == RuleUnusedSuppression {
+			if len(rc.Options) > 0 {
+				return fmt.Errorf("rules.%s: takes no options", id)
+			}
+			continue
+		}
+		rule, ok := rules[id]
+		if !ok {
+			return fmt.Errorf("rules: unknown rule %s", id)
+		}
+		if len(rc.Options) == 0 {
+			continue
+		}
+		configurable, ok := rule.(Configurable)
+		if !ok {
+			return fmt.Errorf("rules.%s: rule takes no options", id)
+		}
+		if err := configurable.Configure(rc.Options); err != nil {
+			return fmt.Errorf("rules.%s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// severityOverrides returns the configured severities by rule ID, and the
+// rules turned off.
+func (c *Config) severityOverrides() (map[string]Severity, map[string]bool) {
+	severities := make(map[string]Severity)
+	off := make(map[string]bool)
+	for id, rc := range c.Rules {
+		if rc.Severity == "" {
+			continue
+		}
+		// Severities were checked when the configuration was loaded.
+		severity, isOff, _ := parseSeverity(rc.Severity)
+		if isOff {
+			off[strings.ToUpper(id)] = true
+		} else {
+			severities[strings.ToUpper(id)] = severity
+		}
+	}
+	return severities, off
+}
+
+// SemanticEnabled reports whether model rules should run.
+func (c *Config) SemanticEnabled() bool {
+	return c == nil || c.Semantic == nil || *c.Semantic
+}
+
+// Ignored reports whether path is excluded by the Ignore patterns.
+func (c *Config) Ignored(path string) bool {
+	if c == nil || len(c.Ignore) == 0 {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(filepath.Dir(c.Path), abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	return glob.MatchFilters(c.Ignore, filepath.ToSlash(rel))
+}
+
+// parseSeverity parses a configured severity. off reports a rule turned
+// off.
+func parseSeverity(s string) (severity Severity, off bool, err error) {
+	switch strings.ToLower(s) {
+	case "error":
+		return SeverityError, false, nil
+	case "warning", "warn":
+		return SeverityWarning, false, nil
+	case "info":
+		return SeverityInfo, false, nil
+	case "off":
+		return 0, true, nil
+	}
+	return 0, false, fmt.Errorf("invalid severity %q: use error, warning, info or off", s)
+}
+
+// intOption reads a positive integer option.
+func intOption(options map[string]any, key string) (int, bool, error) {
+	v, ok := options[key]
+	if !ok {
+		return 0, false, nil
+	}
+	n, isInt := v.(int)
+	if !isInt || n <= 0 {
+		return 0, false, fmt.Errorf("%s must be a positive integer", key)
+	}
+	return n, true, nil
+}
+
+// checkOptions rejects options other than known.
+func checkOptions(options map[string]any, known ...string) error {
+	for key := range options {
+		found := false
+		for _, k := range known {
+			if key == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return nil
+}
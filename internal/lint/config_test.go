@@ -0,0 +1,192 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigProject(t *testing.T, config string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":       "module example\n",
+		ConfigFileName: config,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFindConfig(t *testing.T) {
+	dir := writeConfigProject(t, `
+disable: [wag004]
+ignore:
+  - "generated/**"
+rules:
+  wag007:
+    max-jobs: 20
+  WAG008:
+    severity: off
+  WAG014:
+    severity: error
+    default-timeout: 15
+`)
+	sub := filepath.Join(dir, "workflows")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := FindConfig(sub)
+	if err != nil {
+		t.Fatalf("FindConfig() error = %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("FindConfig() = nil, want the project configuration")
+	}
+	if cfg.Path != filepath.Join(dir, ConfigFileName) {
+		t.Errorf("Path = %q", cfg.Path)
+	}
+	if got := cfg.Rules["WAG007"].Options["max-jobs"]; got != 20 {
+		t.Errorf("WAG007 max-jobs = %v, want 20 (rule IDs are case-insensitive)", got)
+	}
+	if got := cfg.Rules["WAG014"].Severity; got != "error" {
+		t.Errorf("WAG014 severity = %q, want error", got)
+	}
+
+	if !cfg.Ignored(filepath.Join(dir, "generated", "jobs.go")) {
+		t.Error("generated/jobs.go should be ignored")
+	}
+	if cfg.Ignored(filepath.Join(sub, "jobs.go")) {
+		t.Error("workflows/jobs.go should not be ignored")
+	}
+}
+
+func TestFindConfig_None(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := FindConfig(dir)
+	if err != nil || cfg != nil {
+		t.Errorf("FindConfig() = %v, %v; want nil, nil", cfg, err)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"unknown rule", "rules:\n  WAG999:\n    severity: error\n", "unknown rule WAG999"},
+		{"unknown disabled rule", "disable: [WAG999]\n", "unknown rule WAG999"},
+		{"bad severity", "rules:\n  WAG008:\n    severity: fatal\n", `invalid severity "fatal"`},
+		{"unknown option", "rules:\n  WAG007:\n    max-files: 3\n", `unknown option "max-files"`},
+		{"bad option value", "rules:\n  WAG014:\n    default-timeout: soon\n", "default-timeout must be a positive integer"},
+		{"rule without options", "rules:\n  WAG008:\n    threshold: 3\n", "rule takes no options"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigProject(t, tt.config)
+			_, err := LoadConfig(filepath.Join(dir, ConfigFileName))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadConfig() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinter_Config(t *testing.T) {
+	dir := writeConfigProject(t, `
+ignore:
+  - "generated/**"
+rules:
+  WAG008:
+    severity: off
+  WAG014:
+    severity: error
+    default-timeout: 15
+`)
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = workflow.Job{
+	If: "${{ github.ref == 'refs/heads/main' }}",
+}
+`
+	for _, sub := range []string{"workflows", "generated"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, sub, "jobs.go"), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := FindConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := NewLinterWithOptions(LinterOptions{Config: cfg})
+	result, err := l.LintDir(dir)
+	if err != nil {
+		t.Fatalf("LintDir() error = %v", err)
+	}
+
+	var timeout *LintIssue
+	for i, issue := range result.Issues {
+		if strings.Contains(issue.File, "generated") {
+			t.Errorf("ignored file was linted: %v", issue)
+		}
+		if issue.Rule == "WAG008" {
+			t.Errorf("WAG008 is off but reported: %v", issue)
+		}
+		if issue.Rule == "WAG014" {
+			timeout = &result.Issues[i]
+		}
+	}
+	if timeout == nil {
+		t.Fatalf("Expected a WAG014 issue, got %v", result.Issues)
+	}
+	if timeout.Severity != SeverityError {
+		t.Errorf("WAG014 severity = %v, want error", timeout.Severity)
+	}
+	if !strings.Contains(timeout.Message, "15 minutes") {
+		t.Errorf("WAG014 message = %q, want the configured default timeout", timeout.Message)
+	}
+}
+
+func TestWAG007_Configure(t *testing.T) {
+	r := &WAG007{MaxJobs: 10}
+	if err := r.Configure(map[string]any{"max-jobs": 3}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if r.MaxJobs != 3 {
+		t.Errorf("MaxJobs = %d, want 3", r.MaxJobs)
+	}
+	if err := r.Configure(map[string]any{"max-jobs": -1}); err == nil {
+		t.Error("Configure() should reject a negative threshold")
+	}
+}
+
+func TestConfig_SemanticEnabled(t *testing.T) {
+	var none *Config
+	if !none.SemanticEnabled() {
+		t.Error("semantic rules should run without a configuration")
+	}
+	dir := writeConfigProject(t, "semantic: false\n")
+	cfg, err := LoadConfig(filepath.Join(dir, ConfigFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SemanticEnabled() {
+		t.Error("semantic: false should turn the semantic rules off")
+	}
+}
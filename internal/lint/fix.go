@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Auto-fixes edit the source text rather than printing a rewritten AST, so
+// comments and the layout of untouched code survive. The edited source is
+// then run through gofmt.
+
+// textEdit replaces src[start:end] with text.
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// applyEdits applies non-overlapping edits to src.
+func applyEdits(src []byte, edits []textEdit) []byte {
+	sorted := append([]textEdit(nil), edits...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start > sorted[j].start })
+
+	out := append([]byte(nil), src...)
+	for _, e := range sorted {
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+	return out
+}
+
+// replaceNode replaces the source of node with text.
+func replaceNode(fset *token.FileSet, node ast.Node, text string) textEdit {
+	file := fset.File(node.Pos())
+	return textEdit{start: file.Offset(node.Pos()), end: file.Offset(node.End()), text: text}
+}
+
+// appendElts adds elements to the end of a composite literal, keeping it
+// on one line or one element per line as it is written. In a multi-line
+// literal the elements go on their own lines before the closing brace, so
+// a comment after the last element stays with it.
+func appendElts(fset *token.FileSet, lit *ast.CompositeLit, elts []string) textEdit {
+	file := fset.File(lit.Pos())
+	rbraceLine := fset.Position(lit.Rbrace).Line
+	multiline := rbraceLine > fset.Position(lit.Lbrace).Line
+
+	if len(lit.Elts) == 0 {
+		if multiline {
+			at := file.Offset(file.LineStart(rbraceLine))
+			return textEdit{start: at, end: at, text: strings.Join(elts, ",\n") + ",\n"}
+		}
+		at := file.Offset(lit.Rbrace)
+		return textEdit{start: at, end: at, text: strings.Join(elts, ", ")}
+	}
+
+	last := lit.Elts[len(lit.Elts)-1]
+	if multiline && fset.Position(last.End()).Line < rbraceLine {
+		at := file.Offset(file.LineStart(rbraceLine))
+		return textEdit{start: at, end: at, text: strings.Join(elts, ",\n") + ",\n"}
+	}
+	at := file.Offset(last.End())
+	text := ", " + strings.Join(elts, ", ")
+	if multiline {
+		text = ",\n" + strings.Join(elts, ",\n")
+	}
+	return textEdit{start: at, end: at, text: text}
+}
+
+// insertAfter adds an element after elt in a composite literal. If the
+// next element or the closing brace is on a later line, the new element
+// gets a line of its own below elt's line.
+func insertAfter(fset *token.FileSet, lit *ast.CompositeLit, elt ast.Expr, text string) textEdit {
+	file := fset.File(lit.Pos())
+	next := lit.Rbrace
+	for i, e := range lit.Elts {
+		if e == elt && i+1 < len(lit.Elts) {
+			next = lit.Elts[i+1].Pos()
+		}
+	}
+
+	endLine := fset.Position(elt.End()).Line
+	if fset.Position(next).Line > endLine {
+		at := file.Offset(file.LineStart(endLine + 1))
+		return textEdit{start: at, end: at, text: text + ",\n"}
+	}
+	at := file.Offset(elt.End())
+	return textEdit{start: at, end: at, text: ", " + text}
+}
+
+// litAt returns the composite literal whose type passes match and that
+// starts at the issue's position. If earlier fixes moved it, the first
+// literal for which fallback returns true is used instead.
+func litAt(fset *token.FileSet, file *ast.File, issue LintIssue, match func(*ast.CompositeLit) bool, fallback func(*ast.CompositeLit) bool) *ast.CompositeLit {
+	var exact, first *ast.CompositeLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || exact != nil || !match(lit) {
+			return exact == nil
+		}
+		pos := fset.Position(lit.Pos())
+		if pos.Line == issue.Line && (issue.Column == 0 || pos.Column == issue.Column) {
+			exact = lit
+			return false
+		}
+		if first == nil && fallback(lit) {
+			first = lit
+		}
+		return true
+	})
+	if exact != nil {
+		return exact
+	}
+	return first
+}
+
+// UnifiedDiff returns a unified diff from before to after, or "" if they
+// are equal. The file is named by its path relative to the working
+// directory when it is inside it.
+func UnifiedDiff(path string, before, after []byte) string {
+	if string(before) == string(after) {
+		return ""
+	}
+	name := diffName(path)
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: "a/" + name,
+		ToFile:   "b/" + name,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// diffName returns path relative to the working directory, with forward
+// slashes, or path itself if it is outside it.
+func diffName(path string) string {
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
@@ -2,6 +2,7 @@
 package lint
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -49,18 +50,35 @@ type FixResult struct {
 	Content    []byte `json:"-"`
 	FixedCount int    `json:"fixed_count"`
 	Issues     []LintIssue `json:"issues,omitempty"` // Remaining unfixed issues
+	Diff       string      `json:"diff,omitempty"`   // Unified diff of the fixes
 }
 
 // FixDirResult contains the result of fixing a directory.
 type FixDirResult struct {
 	Files      []string `json:"files"`
 	TotalFixed int      `json:"total_fixed"`
+	Diff       string   `json:"diff,omitempty"`
 }
 
 // Linter runs rules against Go source code.
 type Linter struct {
 	rules []Rule
 	fset  *token.FileSet
+
+	// config is the project configuration, if any
+	config *Config
+
+	// severities overrides the severity of issues by rule ID
+	severities map[string]Severity
+
+	// disabled holds the rule IDs turned off, including unused-suppression
+	disabled map[string]bool
+
+	// dryRun keeps FixFile and FixDir from writing fixed files
+	dryRun bool
+
+	// model holds the issues found by model rules, by absolute file path
+	model map[string][]LintIssue
 }
 
 // LinterOptions contains options for configuring the linter.
@@ -69,6 +87,12 @@ type LinterOptions struct {
 	DisabledRules []string
 	// Fix automatically fixes fixable issues (reserved for future use).
 	Fix bool
+	// DryRun computes fixes without writing them back to the files.
+	DryRun bool
+	// Config is the project configuration from .wetwire.yaml, if any.
+	Config *Config
+	// Model is the built workflows checked by model rules, if any.
+	Model *Model
 }
 
 // NewLinter creates a new Linter with the specified rules.
@@ -89,47 +113,55 @@ func NewLinterWithOptions(opts LinterOptions) *Linter {
 		disabled[strings.ToUpper(id)] = true
 	}
 
-	// Get all default rules
-	allRules := []Rule{
-		&WAG001{},
-		&WAG002{},
-		&WAG003{},
-		&WAG004{},
-		&WAG005{},
-		&WAG006{},
-		&WAG007{MaxJobs: 10},
-		&WAG008{},
-		&WAG009{},
-		&WAG010{},
-		&WAG011{},
-		&WAG012{},
-		&WAG013{},
-		&WAG014{},
-		&WAG015{},
-		&WAG016{},
-		&WAG017{},
-		&WAG018{},
-		&WAG019{},
-		&WAG020{},
+	var severities map[string]Severity
+	if cfg := opts.Config; cfg != nil {
+		for _, id := range cfg.Disable {
+			disabled[strings.ToUpper(id)] = true
+		}
+		var off map[string]bool
+		severities, off = cfg.severityOverrides()
+		for id := range off {
+			disabled[id] = true
+		}
 	}
 
 	// Filter out disabled rules
 	var enabledRules []Rule
-	for _, rule := range allRules {
-		if !disabled[rule.ID()] {
-			enabledRules = append(enabledRules, rule)
+	for _, rule := range defaultRules() {
+		if disabled[rule.ID()] {
+			continue
 		}
+		if opts.Config != nil {
+			// Options were validated when the configuration was loaded.
+			if rc, ok := opts.Config.Rules[rule.ID()]; ok && len(rc.Options) > 0 {
+				if configurable, ok := rule.(Configurable); ok {
+					_ = configurable.Configure(rc.Options)
+				}
+			}
+		}
+		enabledRules = append(enabledRules, rule)
 	}
 
-	return &Linter{
-		rules: enabledRules,
-		fset:  token.NewFileSet(),
+	l := &Linter{
+		rules:      enabledRules,
+		fset:       token.NewFileSet(),
+		config:     opts.Config,
+		severities: severities,
+		disabled:   disabled,
+		dryRun:     opts.DryRun,
 	}
+	l.SetModel(opts.Model)
+	return l
 }
 
 // DefaultLinter creates a linter with all default rules enabled.
 func DefaultLinter() *Linter {
-	return NewLinter(
+	return NewLinter(defaultRules()...)
+}
+
+// defaultRules returns a new instance of every rule.
+func defaultRules() []Rule {
+	return []Rule{
 		&WAG001{},
 		&WAG002{},
 		&WAG003{},
@@ -150,7 +182,10 @@ func DefaultLinter() *Linter {
 		&WAG018{},
 		&WAG019{},
 		&WAG020{},
-	)
+		&WAG021{},
+		&WAG022{},
+		&WAG023{},
+	}
 }
 
 // LintResult contains the result of linting.
@@ -161,6 +196,10 @@ type LintResult struct {
 
 // LintFile lints a single Go file.
 func (l *Linter) LintFile(path string) (*LintResult, error) {
+	if l.config.Ignored(path) {
+		return &LintResult{Success: true, Issues: []LintIssue{}}, nil
+	}
+
 	file, err := parser.ParseFile(l.fset, path, nil, parser.ParseComments)
 	if err != nil {
 		return nil, err
@@ -171,6 +210,10 @@ func (l *Linter) LintFile(path string) (*LintResult, error) {
 
 // LintContent lints Go source code from memory.
 func (l *Linter) LintContent(path string, content []byte) (*LintResult, error) {
+	if l.config.Ignored(path) {
+		return &LintResult{Success: true, Issues: []LintIssue{}}, nil
+	}
+
 	file, err := parser.ParseFile(l.fset, path, content, parser.ParseComments)
 	if err != nil {
 		return nil, err
@@ -234,18 +277,43 @@ func (l *Linter) LintDir(dir string) (*LintResult, error) {
 func (l *Linter) lintAST(file *ast.File, path string) *LintResult {
 	result := &LintResult{
 		Success: true,
-		Issues:  []LintIssue{},
+		Issues:  l.check(file, path),
+	}
+	if len(result.Issues) > 0 {
+		result.Success = false
 	}
+	return result
+}
 
+// check runs all rules against a parsed AST, then applies inline
+// suppressions and configured severities.
+func (l *Linter) check(file *ast.File, path string) []LintIssue {
+	issues := []LintIssue{}
 	for _, rule := range l.rules {
-		issues := rule.Check(l.fset, file, path)
-		if len(issues) > 0 {
-			result.Success = false
-			result.Issues = append(result.Issues, issues...)
-		}
+		issues = append(issues, rule.Check(l.fset, file, path)...)
+	}
+	if len(l.model) > 0 {
+		issues = mergeModelIssues(issues, l.model[absPath(path)], path)
 	}
 
-	return result
+	sups, malformed := parseSuppressions(l.fset, file, path)
+	enabled := make(map[string]bool, len(l.rules))
+	for _, rule := range l.rules {
+		enabled[rule.ID()] = true
+	}
+	issues = append(applySuppressions(sups, issues, enabled, path), malformed...)
+
+	kept := issues[:0]
+	for _, issue := range issues {
+		if l.disabled[issue.Rule] {
+			continue
+		}
+		if severity, ok := l.severities[issue.Rule]; ok {
+			issue.Severity = severity
+		}
+		kept = append(kept, issue)
+	}
+	return kept
 }
 
 // AddRule adds a rule to the linter.
@@ -253,6 +321,13 @@ func (l *Linter) AddRule(rule Rule) {
 	l.rules = append(l.rules, rule)
 }
 
+// SetModel runs the model rules of the linter against m. Their issues are
+// reported with the issues of the files they point at, and go through the
+// same suppressions and severity overrides.
+func (l *Linter) SetModel(m *Model) {
+	l.model = modelIssues(l.rules, m)
+}
+
 // Rules returns the list of rules configured in the linter.
 func (l *Linter) Rules() []Rule {
 	return l.rules
@@ -260,6 +335,10 @@ func (l *Linter) Rules() []Rule {
 
 // Fix applies fixes to Go source code from memory.
 func (l *Linter) Fix(path string, content []byte) (*FixResult, error) {
+	if l.config.Ignored(path) {
+		return &FixResult{Content: content, Issues: []LintIssue{}}, nil
+	}
+
 	// Parse the source
 	file, err := parser.ParseFile(l.fset, path, content, parser.ParseComments)
 	if err != nil {
@@ -272,56 +351,71 @@ func (l *Linter) Fix(path string, content []byte) (*FixResult, error) {
 		Issues:     []LintIssue{},
 	}
 
-	// Collect all issues first
-	var allIssues []LintIssue
-	for _, rule := range l.rules {
-		issues := rule.Check(l.fset, file, path)
-		allIssues = append(allIssues, issues...)
-	}
-
-	// Try to fix each issue
+	// Fix one issue at a time, checking the fixed source again each time:
+	// a fix can move the code the remaining issues point at, for example by
+	// adding an import. Issues whose fix failed are not retried.
+	issues := l.check(file, path)
+	failed := make(map[string]bool)
 	currentContent := content
-	for _, issue := range allIssues {
-		if !issue.Fixable {
-			result.Issues = append(result.Issues, issue)
+	for attempts := len(issues); attempts > 0; attempts-- {
+		var next *LintIssue
+		for i := range issues {
+			if issues[i].Fixable && !failed[issueKey(issues[i])] {
+				next = &issues[i]
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+
+		fixed, err := l.FixIssue(path, currentContent, *next)
+		if err != nil || fixed == nil || string(fixed) == string(currentContent) {
+			failed[issueKey(*next)] = true
 			continue
 		}
+		currentContent = fixed
+		result.FixedCount++
 
-		// Find the rule that generated this issue
-		for _, rule := range l.rules {
-			if rule.ID() != issue.Rule {
-				continue
-			}
+		file, err = parser.ParseFile(l.fset, path, currentContent, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		issues = l.check(file, path)
+	}
+	result.Issues = append(result.Issues, issues...)
 
-			// Check if rule implements Fixer
-			fixer, ok := rule.(Fixer)
-			if !ok {
-				result.Issues = append(result.Issues, issue)
-				continue
-			}
+	result.Content = currentContent
+	result.Diff = UnifiedDiff(path, content, currentContent)
+	return result, nil
+}
 
-			// Re-parse with current content
-			currentFile, err := parser.ParseFile(l.fset, path, currentContent, parser.ParseComments)
-			if err != nil {
-				result.Issues = append(result.Issues, issue)
-				continue
-			}
+// FixIssue applies the auto-fix for a single issue to content and returns
+// the fixed source, or nil if the issue's rule cannot fix it.
+func (l *Linter) FixIssue(path string, content []byte, issue LintIssue) ([]byte, error) {
+	for _, rule := range l.rules {
+		if rule.ID() != issue.Rule {
+			continue
+		}
 
-			// Apply fix
-			fixed, err := fixer.Fix(l.fset, currentFile, path, currentContent, issue)
-			if err != nil || fixed == nil {
-				result.Issues = append(result.Issues, issue)
-				continue
-			}
+		// Check if rule implements Fixer
+		fixer, ok := rule.(Fixer)
+		if !ok {
+			return nil, nil
+		}
 
-			currentContent = fixed
-			result.FixedCount++
-			break
+		file, err := parser.ParseFile(l.fset, path, content, parser.ParseComments)
+		if err != nil {
+			return nil, err
 		}
+		return fixer.Fix(l.fset, file, path, content, issue)
 	}
+	return nil, nil
+}
 
-	result.Content = currentContent
-	return result, nil
+// issueKey identifies an issue within one version of a file.
+func issueKey(issue LintIssue) string {
+	return fmt.Sprintf("%s:%d:%d:%s", issue.Rule, issue.Line, issue.Column, issue.Message)
 }
 
 // FixFile applies fixes to a Go file and writes the result back.
@@ -337,7 +431,7 @@ func (l *Linter) FixFile(path string) (*FixResult, error) {
 	}
 
 	// Write back if changes were made
-	if result.FixedCount > 0 {
+	if result.FixedCount > 0 && !l.dryRun {
 		if err := os.WriteFile(path, result.Content, 0644); err != nil {
 			return nil, err
 		}
@@ -380,6 +474,7 @@ func (l *Linter) FixDir(dir string) (*FixDirResult, error) {
 		if fileResult.FixedCount > 0 {
 			result.Files = append(result.Files, path)
 			result.TotalFixed += fileResult.FixedCount
+			result.Diff += fileResult.Diff
 		}
 
 		return nil
@@ -21,8 +21,8 @@ func TestDefaultLinter(t *testing.T) {
 	if l == nil {
 		t.Error("DefaultLinter() returned nil")
 	}
-	if len(l.Rules()) != 20 {
-		t.Errorf("len(Rules()) = %d, want 20", len(l.Rules()))
+	if len(l.Rules()) != 23 {
+		t.Errorf("len(Rules()) = %d, want 23", len(l.Rules()))
 	}
 }
 
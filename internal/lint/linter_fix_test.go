@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
 )
 
 func TestLinter_Fix_WAG001(t *testing.T) {
@@ -340,3 +342,206 @@ var Step = workflow.Step{
 		}
 	}
 }
+
+func TestLinter_Fix_WAG014(t *testing.T) {
+	content := []byte(`package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+// Build compiles the project.
+var Build = workflow.Job{
+	RunsOn: "ubuntu-latest", // hosted runner
+}
+
+var Release = workflow.Job{Uses: "./.github/workflows/release.yml"}
+`)
+
+	l := NewLinter(&WAG014{DefaultTimeout: 15})
+	result, err := l.Fix("test.go", content)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result.FixedCount != 1 || len(result.Issues) != 1 {
+		t.Fatalf("FixedCount = %d, Issues = %v; want the reusable workflow call left", result.FixedCount, result.Issues)
+	}
+
+	want := `// Build compiles the project.
+var Build = workflow.Job{
+	RunsOn:         "ubuntu-latest", // hosted runner
+	TimeoutMinutes: 15,
+}
+`
+	if !strings.Contains(string(result.Content), want) {
+		t.Errorf("Fixed content:\n%s\nwant:\n%s", result.Content, want)
+	}
+}
+
+func TestLinter_Fix_WAG012(t *testing.T) {
+	content := []byte(`package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Checkout = workflow.Step{Uses: "actions/checkout@v2"} // pinned in 2021
+`)
+
+	l := NewLinter(&WAG012{})
+	result, err := l.Fix("test.go", content)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result.FixedCount != 1 {
+		t.Fatalf("FixedCount = %d, want 1", result.FixedCount)
+	}
+	if !strings.Contains(string(result.Content), `Uses: "actions/checkout@v4"} // pinned in 2021`) {
+		t.Errorf("Fixed content:\n%s", result.Content)
+	}
+}
+
+func TestLinter_Fix_WAG015(t *testing.T) {
+	content := []byte(`package main
+
+import (
+	"github.com/lex00/wetwire-github-go/actions/setup_go"
+	"github.com/lex00/wetwire-github-go/actions/setup_node"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+var BuildSteps = []any{
+	setup_go.SetupGo{GoVersion: "1.23"}, // toolchain
+	setup_node.SetupNode{NodeVersion: "20"},
+	workflow.Step{Run: "make"},
+}
+`)
+
+	l := NewLinter(&WAG015{})
+	result, err := l.Fix("test.go", content)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	// One cache step is enough for the list
+	if result.FixedCount != 1 {
+		t.Fatalf("FixedCount = %d, want 1", result.FixedCount)
+	}
+
+	fixed := string(result.Content)
+	for _, want := range []string{
+		"\t\"github.com/lex00/wetwire-github-go/actions/cache\"\n\t\"github.com/lex00/wetwire-github-go/actions/setup_go\"",
+		"setup_go.SetupGo{GoVersion: \"1.23\"}, // toolchain\n\tcache.Cache{\n\t\tPath:",
+		`Key:         "go-mod-${{ runner.os }}-${{ hashFiles('**/go.sum') }}",`,
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("Fixed content missing %q:\n%s", want, fixed)
+		}
+	}
+
+	if again, _ := l.LintContent("test.go", result.Content); !again.Success {
+		t.Errorf("Issues after fix: %v", again.Issues)
+	}
+}
+
+func TestLinter_Fix_WAG017(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "ci.go")
+	content := []byte(`package ci
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var CI = workflow.Workflow{
+	Name: "CI",
+}
+
+var Other = workflow.Workflow{Name: "Other"}
+
+var build = workflow.Job{
+	Steps: []any{workflow.Step{Uses: "actions/checkout@v4"}},
+}
+
+var lint = workflow.Job{Steps: []any{workflow.Step{Run: "make lint"}}}
+
+var admin = workflow.Job{Permissions: &workflow.Permissions{Issues: workflow.PermissionWrite}}
+`)
+
+	m := newModel(path, map[string]workflow.Job{
+		"build": {Steps: []any{workflow.Step{Uses: "actions/checkout@v4"}}},
+		"lint":  {Steps: []any{workflow.Step{Run: "make lint"}}},
+		"admin": {Permissions: &workflow.Permissions{Issues: workflow.PermissionWrite}},
+	}, "build", "lint", "admin")
+
+	l := NewLinter(&WAG017{})
+	l.SetModel(m)
+	result, err := l.Fix(path, content)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result.FixedCount != 1 || len(result.Issues) != 1 {
+		t.Fatalf("FixedCount = %d, Issues = %v; want Other left unfixed", result.FixedCount, result.Issues)
+	}
+	for _, want := range []string{
+		"Name:        \"CI\",\n\tPermissions: &workflow.Permissions{},",
+		"Permissions: &workflow.Permissions{Contents: workflow.PermissionRead},",
+		"var lint = workflow.Job{Steps: []any{workflow.Step{Run: \"make lint\"}},\n\tPermissions: &workflow.Permissions{}}",
+		"var admin = workflow.Job{Permissions: &workflow.Permissions{Issues: workflow.PermissionWrite}}",
+	} {
+		if !strings.Contains(string(result.Content), want) {
+			t.Errorf("Fixed content missing %q:\n%s", want, result.Content)
+		}
+	}
+}
+
+func TestJobScopes_NotFixable(t *testing.T) {
+	tests := map[string]struct {
+		job  workflow.Job
+		file string
+	}{
+		"unknown action": {job: workflow.Job{Steps: []any{workflow.Step{Uses: "someone/unknown@v1"}}}, file: "ci.go"},
+		"reusable":       {job: workflow.Job{Uses: "./.github/workflows/release.yml"}, file: "ci.go"},
+		"other file":     {job: workflow.Job{Steps: []any{workflow.Step{Run: "make"}}}, file: "jobs.go"},
+	}
+	for name, tt := range tests {
+		m := newModel("ci.go", map[string]workflow.Job{"job": tt.job}, "job")
+		m.Workflows[0].Jobs["job"] = Declaration{Variable: "Job", File: tt.file}
+		if _, ok := jobScopes(&m.Workflows[0]); ok {
+			t.Errorf("%s: jobScopes() should not infer permissions", name)
+		}
+	}
+}
+
+func TestLinter_FixFile_DryRunDiff(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "jobs.go")
+	content := []byte(`package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Checkout = workflow.Step{Uses: "actions/checkout@v3"}
+
+var Build = workflow.Job{RunsOn: "ubuntu-latest"}
+`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLinterWithOptions(LinterOptions{DryRun: true, DisabledRules: []string{"WAG001", "WAG006", "WAG008"}})
+	result, err := l.FixDir(tmpDir)
+	if err != nil {
+		t.Fatalf("FixDir() error = %v", err)
+	}
+	if result.TotalFixed != 2 {
+		t.Errorf("TotalFixed = %d, want 2", result.TotalFixed)
+	}
+
+	for _, want := range []string{
+		"+++ b/" + filepath.ToSlash(path),
+		`-var Checkout = workflow.Step{Uses: "actions/checkout@v3"}`,
+		`+var Checkout = workflow.Step{Uses: "actions/checkout@v4"}`,
+		`+var Build = workflow.Job{RunsOn: "ubuntu-latest", TimeoutMinutes: 30}`,
+	} {
+		if !strings.Contains(result.Diff, want) {
+			t.Errorf("Diff missing %q:\n%s", want, result.Diff)
+		}
+	}
+
+	if after, _ := os.ReadFile(path); string(after) != string(content) {
+		t.Error("DryRun should not write the fixed file")
+	}
+}
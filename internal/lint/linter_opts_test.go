@@ -53,6 +53,7 @@ func TestLinterOptions_DisableAllRules(t *testing.T) {
 		"WAG006", "WAG007", "WAG008", "WAG009", "WAG010",
 		"WAG011", "WAG012", "WAG013", "WAG014", "WAG015",
 		"WAG016", "WAG017", "WAG018", "WAG019", "WAG020",
+		"WAG021", "WAG022", "WAG023",
 	}
 
 	l := NewLinterWithOptions(LinterOptions{
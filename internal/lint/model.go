@@ -0,0 +1,272 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/internal/template"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// Model holds the workflows of a Go package as the build command assembles
+// them. Model rules check these values rather than Go syntax, so they see
+// steps returned by helper functions, matrices built in loops and
+// conditions composed through variables.
+type Model struct {
+	Workflows []ModelWorkflow
+}
+
+// ModelWorkflow is a built workflow and the Go declarations behind it.
+type ModelWorkflow struct {
+	// Declaration locates the workflow variable.
+	Declaration
+
+	// Workflow is the built workflow, with Needs resolved to job IDs.
+	Workflow *workflow.Workflow
+
+	// Jobs maps each job ID to the declaration of its variable.
+	Jobs map[string]Declaration
+}
+
+// Declaration locates a package-level variable.
+type Declaration struct {
+	Variable string
+	File     string
+	Line     int
+}
+
+// ModelRule is the interface for rules that check built workflows. Issues
+// point at the Go declaration of the workflow or job at fault. A rule can
+// implement both Rule and ModelRule; an issue found both ways, with the
+// same message on the same line, is reported once.
+type ModelRule interface {
+	// ID returns the unique identifier for this rule (e.g., "WAG014")
+	ID() string
+	// Description returns a human-readable description of the rule
+	Description() string
+	// CheckModel analyzes the built workflows and returns any issues found
+	CheckModel(m *Model) []LintIssue
+}
+
+// LoadModel builds the workflows declared in the Go package in dir. It
+// returns nil if dir declares no workflows.
+func LoadModel(dir string) (*Model, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving path: %w", err)
+	}
+
+	discovered, err := discover.NewDiscoverer().Discover(dir)
+	if err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	if len(discovered.Workflows) == 0 {
+		return nil, nil
+	}
+
+	extracted, err := runner.NewRunner().ExtractValues(dir, discovered)
+	if err != nil {
+		return nil, fmt.Errorf("extraction failed: %w", err)
+	}
+	if extracted.Error != "" {
+		return nil, fmt.Errorf("extraction failed: %s", extracted.Error)
+	}
+
+	built, err := template.NewBuilder().Build(discovered, extracted)
+	if err != nil {
+		return nil, fmt.Errorf("template build failed: %w", err)
+	}
+	if errs := append(discovered.Errors, built.Errors...); len(errs) > 0 {
+		return nil, fmt.Errorf("build failed: %s", strings.Join(errs, "; "))
+	}
+
+	workflows := make(map[string]discover.DiscoveredWorkflow, len(discovered.Workflows))
+	for _, dw := range discovered.Workflows {
+		workflows[dw.Name] = dw
+	}
+	jobs := make(map[string]discover.DiscoveredJob, len(discovered.Jobs))
+	for _, dj := range discovered.Jobs {
+		jobs[dj.Name] = dj
+	}
+
+	m := &Model{}
+	for _, bw := range built.Workflows {
+		dw := workflows[bw.Name]
+		mw := ModelWorkflow{
+			Declaration: Declaration{Variable: bw.Name, File: dw.File, Line: dw.Line},
+			Workflow:    bw.Workflow,
+			Jobs:        make(map[string]Declaration, len(bw.JobIDs)),
+		}
+		for name, id := range bw.JobIDs {
+			dj := jobs[name]
+			mw.Jobs[id] = Declaration{Variable: name, File: dj.File, Line: dj.Line}
+		}
+		m.Workflows = append(m.Workflows, mw)
+	}
+	return m, nil
+}
+
+// jobIDs returns the IDs of the workflow's jobs in sorted order.
+func (w *ModelWorkflow) jobIDs() []string {
+	ids := make([]string, 0, len(w.Workflow.Jobs))
+	for id := range w.Workflow.Jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// issue returns an issue at the declaration of a job, or of the workflow
+// if the job's declaration is unknown.
+func (w *ModelWorkflow) issue(jobID, rule string, severity Severity, message string) LintIssue {
+	decl, ok := w.Jobs[jobID]
+	if !ok || decl.File == "" {
+		decl = w.Declaration
+	}
+	return LintIssue{
+		File:     decl.File,
+		Line:     decl.Line,
+		Severity: severity,
+		Message:  message,
+		Rule:     rule,
+	}
+}
+
+// modelIssues runs the model rules among rules and groups their issues by
+// absolute file path, dropping issues reported twice for a job shared by
+// several workflows.
+func modelIssues(rules []Rule, m *Model) map[string][]LintIssue {
+	byFile := make(map[string][]LintIssue)
+	if m == nil {
+		return byFile
+	}
+	for _, rule := range rules {
+		mr, ok := rule.(ModelRule)
+		if !ok {
+			continue
+		}
+		for _, issue := range mr.CheckModel(m) {
+			path := absPath(issue.File)
+			if !containsIssue(byFile[path], issue) {
+				byFile[path] = append(byFile[path], issue)
+			}
+		}
+	}
+	return byFile
+}
+
+// mergeModelIssues adds the model issues for the file at path to the
+// issues found in its syntax, skipping those already reported.
+func mergeModelIssues(issues, model []LintIssue, path string) []LintIssue {
+	for _, issue := range model {
+		if containsIssue(issues, issue) {
+			continue
+		}
+		issue.File = path
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// containsIssue reports whether issues has one with the same rule, line
+// and message as issue.
+func containsIssue(issues []LintIssue, issue LintIssue) bool {
+	for _, other := range issues {
+		if other.Rule == issue.Rule && other.Line == issue.Line && other.Message == issue.Message {
+			return true
+		}
+	}
+	return false
+}
+
+// absPath returns path made absolute, or path itself if that fails.
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// conditionText returns an If condition as expression text.
+func conditionText(cond any) string {
+	switch c := cond.(type) {
+	case nil:
+		return ""
+	case workflow.Expression:
+		return c.Raw()
+	case fmt.Stringer:
+		return c.String()
+	default:
+		return fmt.Sprint(c)
+	}
+}
+
+// conditionNode parses an If condition, with or without the ${{ }}
+// wrapper. It returns nil for an empty condition or a string template.
+func conditionNode(cond any) workflow.ExprNode {
+	text := strings.TrimSpace(conditionText(cond))
+	if strings.HasPrefix(text, "${{") && strings.HasSuffix(text, "}}") {
+		text = strings.TrimSpace(text[3 : len(text)-2])
+	}
+	if text == "" || strings.Contains(text, "${{") {
+		return nil
+	}
+	node, err := workflow.ParseExpression(text)
+	if err != nil {
+		return nil
+	}
+	return node
+}
+
+// neverTrue reports whether an If condition reads no context and is false
+// whatever the status of the jobs it needs.
+func neverTrue(cond any) bool {
+	node := conditionNode(cond)
+	if node == nil {
+		return false
+	}
+
+	constant := true
+	workflow.WalkExpr(node, func(n workflow.ExprNode) bool {
+		if _, ok := n.(*workflow.VariableNode); ok {
+			constant = false
+		}
+		return constant
+	})
+	if !constant {
+		return false
+	}
+
+	for _, status := range []string{"success", "failure", "cancelled"} {
+		ok, err := workflow.EvalCondition(node.String(), &workflow.EvalContext{Status: status})
+		if err != nil || ok {
+			return false
+		}
+	}
+	return true
+}
+
+// runsAfterSkip reports whether an If condition calls always(), failure()
+// or cancelled(), so the job may run even when a job it needs is skipped.
+func runsAfterSkip(cond any) bool {
+	node := conditionNode(cond)
+	if node == nil {
+		return false
+	}
+
+	found := false
+	workflow.WalkExpr(node, func(n workflow.ExprNode) bool {
+		if call, ok := n.(*workflow.CallNode); ok {
+			switch strings.ToLower(call.Name) {
+			case "always", "failure", "cancelled":
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
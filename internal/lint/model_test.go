@@ -0,0 +1,173 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// newModel returns a model with one workflow whose jobs are all declared in
+// file, job N at line 10*N.
+func newModel(file string, jobs map[string]workflow.Job, order ...string) *Model {
+	mw := ModelWorkflow{
+		Declaration: Declaration{Variable: "CI", File: file, Line: 3},
+		Workflow:    &workflow.Workflow{Name: "CI", Jobs: jobs},
+		Jobs:        make(map[string]Declaration),
+	}
+	for i, id := range order {
+		mw.Jobs[id] = Declaration{Variable: id, File: file, Line: 10 * (i + 1)}
+	}
+	return &Model{Workflows: []ModelWorkflow{mw}}
+}
+
+func TestWAG014_CheckModel(t *testing.T) {
+	m := newModel("ci.go", map[string]workflow.Job{
+		"build":   {RunsOn: "ubuntu-latest", TimeoutMinutes: 10},
+		"test":    {RunsOn: "ubuntu-latest"},
+		"release": {Uses: "./.github/workflows/release.yml"},
+	}, "build", "test", "release")
+
+	issues := (&WAG014{DefaultTimeout: 20}).CheckModel(m)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	issue := issues[0]
+	if issue.File != "ci.go" || issue.Line != 20 {
+		t.Errorf("issue at %s:%d, want ci.go:20", issue.File, issue.Line)
+	}
+	if !strings.Contains(issue.Message, "20 minutes") {
+		t.Errorf("Message = %q", issue.Message)
+	}
+}
+
+func TestWAG011_CheckModel(t *testing.T) {
+	m := newModel("ci.go", map[string]workflow.Job{
+		"disabled": {If: "${{ false }}"},
+		"after":    {Needs: []any{"disabled"}},
+		"chained":  {Needs: []any{"after"}},
+		"cleanup":  {Needs: []any{"disabled"}, If: "always()"},
+		"deploy":   {Needs: []any{"publish"}},
+		"build":    {If: "github.ref == 'refs/heads/main'"},
+		"status":   {If: "failure() && false"},
+	}, "disabled", "after", "chained", "cleanup", "deploy", "build", "status")
+
+	var got []string
+	for _, issue := range (&WAG011{}).CheckModel(m) {
+		got = append(got, issue.Severity.String()+": "+issue.Message)
+	}
+	want := []string{
+		`error: Job "deploy" needs "publish", which is not a job in workflow CI`,
+		`warning: Job "after" never runs: it needs "disabled", which never runs`,
+		`warning: Job "chained" never runs: it needs "after", which never runs`,
+		`warning: Job "disabled" never runs: its condition "${{ false }}" is always false`,
+		`warning: Job "status" never runs: its condition "failure() && false" is always false`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLinter_Model(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jobs.go")
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = workflow.Job{
+	RunsOn: "ubuntu-latest",
+}
+
+var Test = newJob("test")
+
+//wetwire:ignore WAG014 the lint job is quick
+var Lint = newJob("lint")
+
+var Release workflow.Job = newJob("release")
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Model{Workflows: []ModelWorkflow{{
+		Declaration: Declaration{Variable: "CI", File: path, Line: 1},
+		Workflow: &workflow.Workflow{Jobs: map[string]workflow.Job{
+			"build":   {},
+			"test":    {},
+			"lint":    {},
+			"release": {TimeoutMinutes: 5},
+		}},
+		Jobs: map[string]Declaration{
+			"build":   {Variable: "Build", File: path, Line: 5},
+			"test":    {Variable: "Test", File: path, Line: 9},
+			"lint":    {Variable: "Lint", File: path, Line: 12},
+			"release": {Variable: "Release", File: path, Line: 14},
+		},
+	}}}
+
+	l := NewLinterWithOptions(LinterOptions{Model: m})
+	result, err := l.LintDir(dir)
+	if err != nil {
+		t.Fatalf("LintDir() error = %v", err)
+	}
+
+	var lines []int
+	for _, issue := range result.Issues {
+		if issue.Rule != "WAG014" {
+			continue
+		}
+		if issue.File != path {
+			t.Errorf("issue File = %q, want %q", issue.File, path)
+		}
+		lines = append(lines, issue.Line)
+	}
+	// Build is reported once although both checks find it, Lint is
+	// suppressed and Release has a timeout in the model
+	if len(lines) != 2 || lines[0] != 5 || lines[1] != 9 {
+		t.Errorf("WAG014 issues on lines %v, want [5 9]", lines)
+	}
+}
+
+func TestLinter_Model_Disabled(t *testing.T) {
+	m := newModel("ci.go", map[string]workflow.Job{"test": {}}, "test")
+	l := NewLinterWithOptions(LinterOptions{DisabledRules: []string{"WAG014"}, Model: m})
+	if len(l.model) != 0 {
+		t.Errorf("disabled model rule reported %v", l.model)
+	}
+}
+
+func TestLoadModel_NoWorkflows(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadModel(dir)
+	if err != nil || m != nil {
+		t.Errorf("LoadModel() = %v, %v; want nil, nil", m, err)
+	}
+}
+
+func TestNeverTrue(t *testing.T) {
+	tests := []struct {
+		cond any
+		want bool
+	}{
+		{nil, false},
+		{"false", true},
+		{"${{ false }}", true},
+		{"1 == 2", true},
+		{"always() && false", true},
+		{"always()", false},
+		{"github.event_name == 'push'", false},
+		{workflow.Expression("false"), true},
+		{"${{ false }} && ${{ true }}", false},
+	}
+	for _, tt := range tests {
+		if got := neverTrue(tt.cond); got != tt.want {
+			t.Errorf("neverTrue(%v) = %v, want %v", tt.cond, got, tt.want)
+		}
+	}
+}
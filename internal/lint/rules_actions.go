@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/format"
 	"go/token"
+	"strconv"
 	"strings"
 )
 
@@ -203,40 +204,83 @@ func (r *WAG012) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 		}
 
 		val := strings.Trim(bl.Value, `"'`)
-
-		if !strings.Contains(val, "/") || !strings.Contains(val, "@") {
+		actionName, version, recommended, ok := deprecatedRef(val)
+		if !ok {
 			return true
 		}
 
-		parts := strings.Split(val, "@")
-		if len(parts) != 2 {
+		pos := fset.Position(bl.Pos())
+		issues = append(issues, LintIssue{
+			File:     path,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("Action %s@%s is deprecated, use %s@%s", actionName, version, actionName, recommended),
+			Rule:     r.ID(),
+			Fixable:  true,
+		})
+		return true
+	})
+
+	return issues
+}
+
+// Fix replaces the deprecated version in the string the issue points at
+// with the recommended one, keeping the literal's quotes.
+func (r *WAG012) Fix(fset *token.FileSet, file *ast.File, path string, src []byte, issue LintIssue) ([]byte, error) {
+	var edit *textEdit
+	ast.Inspect(file, func(n ast.Node) bool {
+		bl, ok := n.(*ast.BasicLit)
+		if !ok || bl.Kind != token.STRING || edit != nil {
+			return edit == nil
+		}
+		pos := fset.Position(bl.Pos())
+		if pos.Line != issue.Line || pos.Column != issue.Column {
 			return true
 		}
-
-		actionName := parts[0]
-		version := parts[1]
-
-		if info, exists := deprecatedVersions[actionName]; exists {
-			for _, deprecated := range info.deprecated {
-				if version == deprecated {
-					pos := fset.Position(bl.Pos())
-					issues = append(issues, LintIssue{
-						File:     path,
-						Line:     pos.Line,
-						Column:   pos.Column,
-						Severity: SeverityWarning,
-						Message:  fmt.Sprintf("Action %s@%s is deprecated, use %s@%s", actionName, version, actionName, info.recommended),
-						Rule:     r.ID(),
-						Fixable:  false,
-					})
-					break
-				}
-			}
+		actionName, _, recommended, ok := deprecatedRef(strings.Trim(bl.Value, `"'`))
+		if !ok {
+			return true
 		}
-		return true
+		quote := bl.Value[:1]
+		e := replaceNode(fset, bl, quote+actionName+"@"+recommended+quote)
+		edit = &e
+		return false
 	})
+	if edit == nil {
+		return nil, fmt.Errorf("no deprecated action reference found")
+	}
 
-	return issues
+	return format.Source(applyEdits(src, []textEdit{*edit}))
+}
+
+var _ Fixer = (*WAG012)(nil)
+
+// deprecatedRef splits an owner/repo@version reference and reports
+// whether the version is deprecated, with the version to use instead.
+func deprecatedRef(ref string) (actionName, version, recommended string, ok bool) {
+	if !strings.Contains(ref, "/") || !strings.Contains(ref, "@") {
+		return "", "", "", false
+	}
+
+	parts := strings.Split(ref, "@")
+	if len(parts) != 2 {
+		return "", "", "", false
+	}
+
+	actionName = parts[0]
+	version = parts[1]
+
+	info, exists := deprecatedVersions[actionName]
+	if !exists {
+		return "", "", "", false
+	}
+	for _, deprecated := range info.deprecated {
+		if version == deprecated {
+			return actionName, version, info.recommended, true
+		}
+	}
+	return "", "", "", false
 }
 
 // WAG015 suggests caching for setup actions.
@@ -302,7 +346,7 @@ func (r *WAG015) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 					Severity: SeverityWarning,
 					Message:  fmt.Sprintf("Consider adding cache action for %s to improve build performance", displayName),
 					Rule:     r.ID(),
-					Fixable:  false,
+					Fixable:  true,
 				})
 			}
 		}
@@ -314,3 +358,70 @@ func (r *WAG015) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 
 	return issues
 }
+
+// setupCaches holds the cache step added for each setup action by the
+// WAG015 fix.
+var setupCaches = map[string]struct {
+	path   string
+	prefix string
+	files  string
+}{
+	"setup-go":     {"~/go/pkg/mod", "go-mod", "**/go.sum"},
+	"setup-node":   {"~/.npm", "npm", "**/package-lock.json"},
+	"setup-python": {"~/.cache/pip", "pip", "**/requirements*.txt"},
+}
+
+// Fix adds a cache.Cache step after the setup action the issue points at,
+// keyed on the runner OS and the hash of the ecosystem's lock files.
+func (r *WAG015) Fix(fset *token.FileSet, file *ast.File, path string, src []byte, issue LintIssue) ([]byte, error) {
+	var steps *ast.CompositeLit
+	var setup ast.Expr
+	ast.Inspect(file, func(n ast.Node) bool {
+		comp, ok := n.(*ast.CompositeLit)
+		if !ok || setup != nil {
+			return setup == nil
+		}
+		arrType, ok := comp.Type.(*ast.ArrayType)
+		if !ok {
+			return true
+		}
+		if elemIdent, ok := arrType.Elt.(*ast.Ident); !ok || elemIdent.Name != "any" {
+			return true
+		}
+		for _, elt := range comp.Elts {
+			e, ok := elt.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			if _, isSetup := setupActionsNeedingCache[getTypeName(e.Type)]; !isSetup {
+				continue
+			}
+			pos := fset.Position(e.Pos())
+			if pos.Line == issue.Line && pos.Column == issue.Column {
+				steps, setup = comp, e
+				return false
+			}
+		}
+		return true
+	})
+	if setup == nil {
+		return nil, fmt.Errorf("no setup action found")
+	}
+
+	c := setupCaches[setupActionsNeedingCache[getTypeName(setup.(*ast.CompositeLit).Type)]]
+	fields := []string{
+		"Path: " + strconv.Quote(c.path),
+		"Key: " + strconv.Quote(fmt.Sprintf("%s-${{ runner.os }}-${{ hashFiles('%s') }}", c.prefix, c.files)),
+		"RestoreKeys: " + strconv.Quote(c.prefix+"-${{ runner.os }}-"),
+	}
+	step := "cache.Cache{" + strings.Join(fields, ", ") + "}"
+	if fset.Position(steps.Rbrace).Line > fset.Position(steps.Lbrace).Line {
+		step = "cache.Cache{\n" + strings.Join(fields, ",\n") + ",\n}"
+	}
+
+	result := applyEdits(src, []textEdit{insertAfter(fset, steps, setup, step)})
+	result = addImportIfNeeded(result, "github.com/lex00/wetwire-github-go/actions/cache", "cache")
+	return format.Source(result)
+}
+
+var _ Fixer = (*WAG015)(nil)
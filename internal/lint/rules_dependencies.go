@@ -91,6 +91,64 @@ func (r *WAG011) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 	return issues
 }
 
+// CheckModel reports built jobs that need a job missing from their workflow,
+// and jobs that never run: their condition is always false, or they need a
+// job that never runs and do not call always(), failure() or cancelled().
+func (r *WAG011) CheckModel(m *Model) []LintIssue {
+	var issues []LintIssue
+
+	for i := range m.Workflows {
+		w := &m.Workflows[i]
+		ids := w.jobIDs()
+
+		never := make(map[string]string)
+		for _, id := range ids {
+			job := w.Workflow.Jobs[id]
+			for _, need := range job.Needs {
+				dep, ok := need.(string)
+				if !ok {
+					continue
+				}
+				if _, exists := w.Workflow.Jobs[dep]; !exists {
+					issues = append(issues, w.issue(id, r.ID(), SeverityError,
+						fmt.Sprintf("Job %q needs %q, which is not a job in workflow %s", id, dep, w.Variable)))
+				}
+			}
+			if neverTrue(job.If) {
+				never[id] = fmt.Sprintf("its condition %q is always false", conditionText(job.If))
+			}
+		}
+
+		// Skipped jobs skip the jobs that need them, transitively
+		for changed := true; changed; {
+			changed = false
+			for _, id := range ids {
+				job := w.Workflow.Jobs[id]
+				if _, ok := never[id]; ok || runsAfterSkip(job.If) {
+					continue
+				}
+				for _, need := range job.Needs {
+					dep, _ := need.(string)
+					if _, ok := never[dep]; ok {
+						never[id] = fmt.Sprintf("it needs %q, which never runs", dep)
+						changed = true
+						break
+					}
+				}
+			}
+		}
+
+		for _, id := range ids {
+			if reason, ok := never[id]; ok {
+				issues = append(issues, w.issue(id, r.ID(), SeverityWarning,
+					fmt.Sprintf("Job %q never runs: %s", id, reason)))
+			}
+		}
+	}
+
+	return issues
+}
+
 // WAG019 detects circular dependencies in job dependency graphs.
 type WAG019 struct{}
 
@@ -208,6 +208,10 @@ func addImportIfNeeded(src []byte, importPath, alias string) []byte {
 		// Find the closing paren
 		closeIdx := match[1] - 1
 		newImport := fmt.Sprintf("\n\t\"%s\"", importPath)
+		if strings.HasSuffix(srcStr[:closeIdx], "\n") {
+			// Keep the import in the last group rather than starting one
+			newImport = fmt.Sprintf("\t\"%s\"\n", importPath)
+		}
 		result := srcStr[:closeIdx] + newImport + srcStr[closeIdx:]
 		return []byte(result)
 	}
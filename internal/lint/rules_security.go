@@ -3,8 +3,14 @@ package lint
 import (
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/token"
+	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/pin"
+	"github.com/lex00/wetwire-github-go/permissions"
 )
 
 // WAG003 checks for hardcoded secrets instead of using the secrets context.
@@ -53,13 +59,19 @@ func (r *WAG003) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 }
 
 // WAG017 suggests adding explicit permissions scope to workflows.
-type WAG017 struct{}
+type WAG017 struct {
+	// inferred holds the permissions needed by the jobs of each workflow
+	// in the model that declares none, keyed by workflow variable and
+	// absolute file path, then by job variable
+	inferred map[Declaration]map[string]permissions.Scopes
+}
 
 func (r *WAG017) ID() string          { return "WAG017" }
 func (r *WAG017) Description() string { return "Suggest adding explicit permissions scope for security" }
 
 func (r *WAG017) Check(fset *token.FileSet, file *ast.File, path string) []LintIssue {
 	var issues []LintIssue
+	vars := literalVars(file)
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		lit, ok := n.(*ast.CompositeLit)
@@ -67,8 +79,7 @@ func (r *WAG017) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 			return true
 		}
 
-		typeName := getTypeName(lit.Type)
-		if typeName != "workflow.Workflow" && typeName != "Workflow" {
+		if !isWorkflowLit(lit) {
 			return true
 		}
 
@@ -89,15 +100,16 @@ func (r *WAG017) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 		}
 
 		if !hasPermissions {
+			_, fixable := r.jobs(vars[lit], path)
 			pos := fset.Position(lit.Pos())
 			issues = append(issues, LintIssue{
 				File:     path,
 				Line:     pos.Line,
 				Column:   pos.Column,
 				Severity: SeverityInfo,
-				Message:  "Consider adding explicit Permissions field for security best practices",
+				Message:  "Consider adding explicit Permissions field for security best practices (run `wetwire-github permissions --fix` to infer it)",
 				Rule:     r.ID(),
-				Fixable:  false,
+				Fixable:  fixable,
 			})
 		}
 
@@ -107,6 +119,133 @@ func (r *WAG017) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 	return issues
 }
 
+// CheckModel reports nothing itself. It infers the permissions each job of
+// a workflow needs, which makes the issue of a workflow without Permissions
+// fixable when every job's needs are known and the jobs without their own
+// Permissions are declared in the workflow's file.
+func (r *WAG017) CheckModel(m *Model) []LintIssue {
+	r.inferred = make(map[Declaration]map[string]permissions.Scopes)
+	for i := range m.Workflows {
+		w := &m.Workflows[i]
+		if w.Workflow.Permissions != nil || w.Variable == "" {
+			continue
+		}
+		if jobs, ok := jobScopes(w); ok {
+			r.inferred[Declaration{Variable: w.Variable, File: absPath(w.File)}] = jobs
+		}
+	}
+	return nil
+}
+
+// Fix grants the workflow no permissions and gives each job without its
+// own Permissions the scopes it was inferred to need, the same way
+// `wetwire-github permissions --fix` does. A workflow-level grant would
+// cover the needs of every job and over-grant most of them.
+func (r *WAG017) Fix(fset *token.FileSet, file *ast.File, path string, src []byte, issue LintIssue) ([]byte, error) {
+	vars := literalVars(file)
+	fixable := func(lit *ast.CompositeLit) bool {
+		_, ok := r.jobs(vars[lit], path)
+		return ok && fieldValue(lit, "Permissions") == nil
+	}
+	lit := litAt(fset, file, issue, isWorkflowLit, fixable)
+	if lit == nil || !fixable(lit) {
+		return nil, fmt.Errorf("no workflow with inferred permissions found")
+	}
+
+	jobs, _ := r.jobs(vars[lit], path)
+	declared := make(map[string]bool)
+	for _, name := range vars {
+		declared[name] = true
+	}
+	for name := range jobs {
+		if !declared[name] {
+			return nil, fmt.Errorf("job %s is not declared in %s", name, filepath.Base(path))
+		}
+	}
+
+	qualifier := workflowImport(file) + "."
+	if qualifier == ".." {
+		qualifier = ""
+	}
+	edit := appendElts(fset, lit, []string{"Permissions: " + permissions.Scopes{}.Literal(qualifier)})
+	fixed, err := format.Source(applyEdits(src, []textEdit{edit}))
+	if err != nil {
+		return nil, err
+	}
+	return permissions.FixSource(fixed, jobs)
+}
+
+// jobs returns the permissions inferred for the jobs of the workflow
+// variable in the file at path, keyed by job variable.
+func (r *WAG017) jobs(variable, path string) (map[string]permissions.Scopes, bool) {
+	if variable == "" {
+		return nil, false
+	}
+	jobs, ok := r.inferred[Declaration{Variable: variable, File: absPath(path)}]
+	return jobs, ok
+}
+
+var _ Fixer = (*WAG017)(nil)
+
+// jobScopes returns the permissions needed by each job of w that declares
+// none, keyed by job variable. It returns false if a job's needs are not
+// fully known, if such a job is not a variable declared in the workflow's
+// file, or if a job calls a reusable workflow, which would inherit the
+// workflow's permissions.
+func jobScopes(w *ModelWorkflow) (map[string]permissions.Scopes, bool) {
+	for _, job := range w.Workflow.Jobs {
+		if job.Uses != nil && job.Uses != "" {
+			return nil, false
+		}
+	}
+	jobs := make(map[string]permissions.Scopes)
+	for _, report := range permissions.Check(w.Variable, w.Workflow) {
+		if report.Source == permissions.SourceJob {
+			continue
+		}
+		decl := w.Jobs[report.Job]
+		if !report.Fixable() || decl.Variable == "" || absPath(decl.File) != absPath(w.File) {
+			return nil, false
+		}
+		jobs[decl.Variable] = report.Required
+	}
+	return jobs, true
+}
+
+// isWorkflowLit reports whether lit is a workflow.Workflow literal.
+func isWorkflowLit(lit *ast.CompositeLit) bool {
+	typeName := getTypeName(lit.Type)
+	return typeName == "workflow.Workflow" || typeName == "Workflow"
+}
+
+// literalVars maps the composite literals assigned to package-level
+// variables in file, directly or by address, to the variable names.
+func literalVars(file *ast.File) map[*ast.CompositeLit]string {
+	vars := make(map[*ast.CompositeLit]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					continue
+				}
+				value := vs.Values[i]
+				if u, ok := value.(*ast.UnaryExpr); ok && u.Op == token.AND {
+					value = u.X
+				}
+				if lit, ok := value.(*ast.CompositeLit); ok {
+					vars[lit] = name.Name
+				}
+			}
+		}
+	}
+	return vars
+}
+
 // WAG018 detects dangerous pull_request_target patterns.
 type WAG018 struct{}
 
@@ -250,3 +389,203 @@ func (r *WAG020) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 
 	return issues
 }
+
+// WAG022 requires every action reference to be pinned in the project's lock
+// file. It only applies once the project has a wetwire-github.lock.
+type WAG022 struct {
+	// locks caches the lock file found for each directory
+	locks map[string]*pin.Lock
+}
+
+func (r *WAG022) ID() string { return "WAG022" }
+func (r *WAG022) Description() string {
+	return "Require action references to be pinned in the lock file"
+}
+
+func (r *WAG022) Check(fset *token.FileSet, file *ast.File, path string) []LintIssue {
+	lock := r.lock(path)
+	if lock == nil {
+		return nil
+	}
+
+	wrappers := wrapperImports(file)
+	var issues []LintIssue
+
+	report := func(node ast.Node, uses string) {
+		if !pin.Pinnable(uses) || lock.Has(uses) {
+			return
+		}
+		pos := fset.Position(node.Pos())
+		issues = append(issues, LintIssue{
+			File:     path,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("%s is not pinned in %s; run wetwire-github pin", uses, filepath.Base(lock.Path)),
+			Rule:     r.ID(),
+			Fixable:  false,
+		})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		typeName := getTypeName(lit.Type)
+		switch typeName {
+		case "workflow.Step", "Step", "workflow.Job", "Job":
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok || key.Name != "Uses" {
+					continue
+				}
+				lit, _ := kv.Value.(*ast.BasicLit)
+				if uses, ok := stringLit(lit); ok {
+					report(kv.Value, uses)
+				}
+			}
+		default:
+			pkg, typ, ok := strings.Cut(typeName, ".")
+			if !ok {
+				return true
+			}
+			if dir, ok := wrappers[pkg]; ok {
+				if uses, ok := wrapperRefs[dir+"."+typ]; ok {
+					report(lit, uses)
+				}
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// lock returns the lock file for the directory of path.
+// Errors reading the lock file are left to the build to report.
+func (r *WAG022) lock(path string) *pin.Lock {
+	dir := filepath.Dir(path)
+	if r.locks == nil {
+		r.locks = make(map[string]*pin.Lock)
+	}
+	if lock, ok := r.locks[dir]; ok {
+		return lock
+	}
+	lock, _ := pin.Find(dir)
+	r.locks[dir] = lock
+	return lock
+}
+
+// wrapperImports maps the names under which a file imports typed action
+// wrapper packages to the package directory under actions/.
+func wrapperImports(file *ast.File) map[string]string {
+	const prefix = "github.com/lex00/wetwire-github-go/actions/"
+
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || !strings.HasPrefix(importPath, prefix) {
+			continue
+		}
+		dir := strings.TrimPrefix(importPath, prefix)
+		name := dir
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = dir
+	}
+	return imports
+}
+
+// WAG023 detects script injection: attacker-controlled contexts such as
+// github.event.pull_request.title or github.head_ref interpolated with
+// ${{ }} into run scripts or github-script code. Taint is followed through
+// package-level variables, workflow.Format and other expression builders,
+// job outputs and env values.
+type WAG023 struct {
+	// files caches the parsed files of each package directory by path
+	files map[string]map[string]*ast.File
+}
+
+func (r *WAG023) ID() string { return "WAG023" }
+func (r *WAG023) Description() string {
+	return "Detect untrusted contexts interpolated into run scripts"
+}
+
+func (r *WAG023) Check(fset *token.FileSet, file *ast.File, path string) []LintIssue {
+	ix := r.index(file, path)
+	var issues []LintIssue
+
+	for _, target := range injectionTargets(file) {
+		sources := target.sources(ix)
+		if len(sources) == 0 {
+			continue
+		}
+
+		where := "run script; pass it through Env and read it from the environment"
+		switch {
+		case target.step == nil:
+			where = "github-script; the github_script wrapper has no Env, so this is not fixed automatically: " +
+				"use workflow.ActionStep to pass it through Env and read it from process.env"
+		case target.style == styleJavaScript:
+			where = "github-script; pass it through Env and read it from process.env"
+		}
+		_, fixable := planInjectionFix(fset, ix, target)
+		pos := fset.Position(target.value.Pos())
+		issues = append(issues, LintIssue{
+			File:     path,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("untrusted %s interpolated into %s", strings.Join(sources, ", "), where),
+			Rule:     r.ID(),
+			Fixable:  fixable,
+		})
+	}
+
+	return issues
+}
+
+// Fix moves the untrusted values of the step the issue points at into its
+// Env and references them as environment variables.
+func (r *WAG023) Fix(fset *token.FileSet, file *ast.File, path string, src []byte, issue LintIssue) ([]byte, error) {
+	ix := r.index(file, path)
+
+	for _, target := range injectionTargets(file) {
+		if fset.Position(target.value.Pos()).Line != issue.Line {
+			continue
+		}
+		if edits, ok := planInjectionFix(fset, ix, target); ok {
+			return format.Source(applyEdits(src, edits))
+		}
+	}
+	return nil, fmt.Errorf("no fixable script injection at line %d", issue.Line)
+}
+
+// index builds the taint index for path's package, using file in place of
+// the copy of path on disk.
+func (r *WAG023) index(file *ast.File, path string) *taintIndex {
+	dir := filepath.Dir(path)
+	if r.files == nil {
+		r.files = make(map[string]map[string]*ast.File)
+	}
+	siblings, ok := r.files[dir]
+	if !ok {
+		siblings = packageFiles(dir)
+		r.files[dir] = siblings
+	}
+
+	files := make([]*ast.File, 0, len(siblings)+1)
+	for siblingPath, f := range siblings {
+		if siblingPath != path && f.Name.Name == file.Name.Name {
+			files = append(files, f)
+		}
+	}
+	return newTaintIndex(append(files, file))
+}
@@ -171,6 +171,19 @@ type WAG007 struct {
 func (r *WAG007) ID() string          { return "WAG007" }
 func (r *WAG007) Description() string { return "Flag oversized files (>N jobs)" }
 
+// Configure sets the job threshold from the max-jobs option.
+func (r *WAG007) Configure(options map[string]any) error {
+	if err := checkOptions(options, "max-jobs"); err != nil {
+		return err
+	}
+	if n, ok, err := intOption(options, "max-jobs"); err != nil {
+		return err
+	} else if ok {
+		r.MaxJobs = n
+	}
+	return nil
+}
+
 func (r *WAG007) Check(fset *token.FileSet, file *ast.File, path string) []LintIssue {
 	var issues []LintIssue
 	jobCount := 0
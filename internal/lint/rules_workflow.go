@@ -3,8 +3,14 @@ package lint
 import (
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/token"
+	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/inventory"
+	"github.com/lex00/wetwire-github-go/workflow"
 )
 
 // WAG002 checks for raw expression strings instead of condition builders.
@@ -115,11 +121,36 @@ func (r *WAG009) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 }
 
 // WAG014 checks for jobs without TimeoutMinutes set.
-type WAG014 struct{}
+type WAG014 struct {
+	// DefaultTimeout is the timeout in minutes suggested for jobs without
+	// one. Zero means 30.
+	DefaultTimeout int
+}
 
 func (r *WAG014) ID() string          { return "WAG014" }
 func (r *WAG014) Description() string { return "Jobs should have timeout-minutes set" }
 
+// Configure sets the suggested timeout from the default-timeout option.
+func (r *WAG014) Configure(options map[string]any) error {
+	if err := checkOptions(options, "default-timeout"); err != nil {
+		return err
+	}
+	if n, ok, err := intOption(options, "default-timeout"); err != nil {
+		return err
+	} else if ok {
+		r.DefaultTimeout = n
+	}
+	return nil
+}
+
+// defaultTimeout returns the timeout in minutes suggested for jobs.
+func (r *WAG014) defaultTimeout() int {
+	if r.DefaultTimeout > 0 {
+		return r.DefaultTimeout
+	}
+	return 30
+}
+
 func (r *WAG014) Check(fset *token.FileSet, file *ast.File, path string) []LintIssue {
 	var issues []LintIssue
 
@@ -157,9 +188,9 @@ func (r *WAG014) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 				Line:     pos.Line,
 				Column:   pos.Column,
 				Severity: SeverityWarning,
-				Message:  "Job missing TimeoutMinutes - consider adding a timeout (e.g., 30 minutes)",
+				Message:  fmt.Sprintf("Job missing TimeoutMinutes - consider adding a timeout (e.g., %d minutes)", r.defaultTimeout()),
 				Rule:     r.ID(),
-				Fixable:  false,
+				Fixable:  fieldValue(lit, "Uses") == nil,
 			})
 		}
 		return true
@@ -168,6 +199,50 @@ func (r *WAG014) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 	return issues
 }
 
+// Fix sets TimeoutMinutes on the job the issue points at to the default
+// timeout. Jobs calling a reusable workflow cannot set one.
+func (r *WAG014) Fix(fset *token.FileSet, file *ast.File, path string, src []byte, issue LintIssue) ([]byte, error) {
+	needsTimeout := func(lit *ast.CompositeLit) bool {
+		return fieldValue(lit, "TimeoutMinutes") == nil && fieldValue(lit, "Uses") == nil
+	}
+	lit := litAt(fset, file, issue, isJobLit, needsTimeout)
+	if lit == nil || !needsTimeout(lit) {
+		return nil, fmt.Errorf("no job without a timeout found")
+	}
+
+	edit := appendElts(fset, lit, []string{fmt.Sprintf("TimeoutMinutes: %d", r.defaultTimeout())})
+	return format.Source(applyEdits(src, []textEdit{edit}))
+}
+
+var _ Fixer = (*WAG014)(nil)
+
+// isJobLit reports whether lit is a workflow.Job literal.
+func isJobLit(lit *ast.CompositeLit) bool {
+	typeName := getTypeName(lit.Type)
+	return typeName == "workflow.Job" || typeName == "Job"
+}
+
+// CheckModel reports built jobs without a timeout, including jobs whose
+// fields come from helper functions that Check cannot see into. Jobs that
+// call a reusable workflow cannot set one and are skipped.
+func (r *WAG014) CheckModel(m *Model) []LintIssue {
+	var issues []LintIssue
+
+	for i := range m.Workflows {
+		w := &m.Workflows[i]
+		for _, id := range w.jobIDs() {
+			job := w.Workflow.Jobs[id]
+			if job.TimeoutMinutes > 0 || (job.Uses != nil && job.Uses != "") {
+				continue
+			}
+			issues = append(issues, w.issue(id, r.ID(), SeverityWarning,
+				fmt.Sprintf("Job missing TimeoutMinutes - consider adding a timeout (e.g., %d minutes)", r.defaultTimeout())))
+		}
+	}
+
+	return issues
+}
+
 // WAG016 validates concurrency settings.
 type WAG016 struct{}
 
@@ -237,3 +312,177 @@ func (r *WAG016) Check(fset *token.FileSet, file *ast.File, path string) []LintI
 
 	return issues
 }
+
+// WAG021 validates runner labels against the built-in list of GitHub-hosted
+// images and the project's runner inventory, if any. Retired labels are
+// errors when the project has an inventory and warnings otherwise, matching
+// what the build rejects.
+type WAG021 struct {
+	// inventories caches the inventory found for each directory
+	inventories map[string]*inventory.Inventory
+}
+
+func (r *WAG021) ID() string          { return "WAG021" }
+func (r *WAG021) Description() string { return "Validate runner labels" }
+
+func (r *WAG021) Check(fset *token.FileSet, file *ast.File, path string) []LintIssue {
+	var issues []LintIssue
+	inv := r.inventory(path)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		typeName := getTypeName(lit.Type)
+		if typeName != "workflow.Job" && typeName != "Job" {
+			return true
+		}
+
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok || key.Name != "RunsOn" {
+				continue
+			}
+
+			runsOn, ok := runsOnFromAST(kv.Value)
+			if !ok {
+				continue
+			}
+
+			pos := fset.Position(kv.Value.Pos())
+			for _, f := range inv.Check(runsOn) {
+				severity := SeverityWarning
+				if f.Problem == inventory.ProblemRetired && inv != nil {
+					severity = SeverityError
+				}
+				issues = append(issues, LintIssue{
+					File:     path,
+					Line:     pos.Line,
+					Column:   pos.Column,
+					Severity: severity,
+					Message:  f.Message,
+					Rule:     r.ID(),
+					Fixable:  false,
+				})
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// inventory returns the runner inventory for the directory of path.
+// Errors reading the inventory are left to the build to report.
+func (r *WAG021) inventory(path string) *inventory.Inventory {
+	dir := filepath.Dir(path)
+	if r.inventories == nil {
+		r.inventories = make(map[string]*inventory.Inventory)
+	}
+	if inv, ok := r.inventories[dir]; ok {
+		return inv
+	}
+	inv, _ := inventory.Find(dir)
+	r.inventories[dir] = inv
+	return inv
+}
+
+// runsOnFromAST converts a literal RunsOn value to workflow.RunsOn. It
+// handles string literals, label slices, RunsOn literals and the
+// Hosted, SelfHosted and RunnerGroup constructors.
+func runsOnFromAST(expr ast.Expr) (workflow.RunsOn, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if s, ok := stringLit(e); ok {
+			return workflow.ParseRunsOn(s)
+		}
+
+	case *ast.CompositeLit:
+		if at, ok := e.Type.(*ast.ArrayType); ok && getTypeName(at.Elt) == "string" {
+			labels, ok := stringLits(e.Elts)
+			return workflow.RunsOn{Labels: labels}, ok
+		}
+		switch getTypeName(e.Type) {
+		case "workflow.RunsOn", "RunsOn":
+			var runsOn workflow.RunsOn
+			for _, elt := range e.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					return workflow.RunsOn{}, false
+				}
+				key, _ := kv.Key.(*ast.Ident)
+				switch {
+				case key == nil:
+					return workflow.RunsOn{}, false
+				case key.Name == "Group":
+					lit, _ := kv.Value.(*ast.BasicLit)
+					group, ok := stringLit(lit)
+					if !ok {
+						return workflow.RunsOn{}, false
+					}
+					runsOn.Group = group
+				case key.Name == "Labels":
+					labelsLit, ok := kv.Value.(*ast.CompositeLit)
+					if !ok {
+						return workflow.RunsOn{}, false
+					}
+					if runsOn.Labels, ok = stringLits(labelsLit.Elts); !ok {
+						return workflow.RunsOn{}, false
+					}
+				default:
+					return workflow.RunsOn{}, false
+				}
+			}
+			return runsOn, true
+		}
+
+	case *ast.CallExpr:
+		args, ok := stringLits(e.Args)
+		if !ok || e.Ellipsis.IsValid() {
+			return workflow.RunsOn{}, false
+		}
+		switch strings.TrimPrefix(getTypeName(e.Fun), "workflow.") {
+		case "Hosted":
+			if len(args) == 1 {
+				return workflow.Hosted(args[0]), true
+			}
+		case "SelfHosted":
+			return workflow.SelfHosted(args...), true
+		case "RunnerGroup":
+			if len(args) >= 1 {
+				return workflow.RunnerGroup(args[0], args[1:]...), true
+			}
+		}
+	}
+
+	return workflow.RunsOn{}, false
+}
+
+// stringLit returns the value of a string literal.
+func stringLit(lit *ast.BasicLit) (string, bool) {
+	if lit == nil || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// stringLits returns the values of a list of string literals.
+func stringLits(exprs []ast.Expr) ([]string, bool) {
+	values := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		lit, _ := expr.(*ast.BasicLit)
+		s, ok := stringLit(lit)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	return values, true
+}
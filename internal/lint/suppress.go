@@ -0,0 +1,220 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// RuleUnusedSuppression is the rule ID of issues reporting //wetwire:ignore
+// comments that suppress nothing.
+const RuleUnusedSuppression = "unused-suppression"
+
+// suppressionPrefix starts an inline suppression comment:
+//
+//	//wetwire:ignore WAG008 matrix values come from a generated file
+//	var Matrix = ...
+//
+// A comment on its own line covers the declaration, statement or element
+// that starts on the next line. A comment at the end of a line covers the
+// outermost node starting on that line. Several rules can be listed,
+// separated by commas or spaces; the rest of the comment is the reason.
+const suppressionPrefix = "//wetwire:ignore"
+
+var ruleIDPattern = regexp.MustCompile(`^(?i)WAG\d{3}$`)
+
+// suppression is a parsed //wetwire:ignore comment.
+type suppression struct {
+	rules []string
+	pos   token.Position
+
+	// from and to are the lines the suppression covers.
+	from, to int
+
+	used map[string]bool
+}
+
+// covers reports whether the suppression applies to issue.
+func (s *suppression) covers(issue LintIssue) bool {
+	if issue.Line < s.from || issue.Line > s.to {
+		return false
+	}
+	for _, id := range s.rules {
+		if id == issue.Rule {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSuppressions returns the //wetwire:ignore comments in file, and
+// issues for comments that name no rule.
+func parseSuppressions(fset *token.FileSet, file *ast.File, path string) ([]*suppression, []LintIssue) {
+	var sups []*suppression
+	var issues []LintIssue
+	var nodes *lineNodes
+
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, suppressionPrefix) {
+				continue
+			}
+			rest := strings.TrimPrefix(c.Text, suppressionPrefix)
+			if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+				continue
+			}
+
+			pos := fset.Position(c.Pos())
+			rules := suppressedRules(rest)
+			if len(rules) == 0 {
+				issues = append(issues, LintIssue{
+					File:     path,
+					Line:     pos.Line,
+					Column:   pos.Column,
+					Severity: SeverityWarning,
+					Message:  suppressionPrefix + " must name the rules it suppresses, e.g. " + suppressionPrefix + " WAG008 reason",
+					Rule:     RuleUnusedSuppression,
+				})
+				continue
+			}
+
+			if nodes == nil {
+				nodes = newLineNodes(fset, file)
+			}
+			line := pos.Line
+			if !nodes.trailing(c) {
+				line = fset.Position(group.End()).Line + 1
+			}
+			from, to := line, line
+			if n, ok := nodes.outermost[line]; ok {
+				to = fset.Position(n.End()).Line
+			}
+
+			sups = append(sups, &suppression{
+				rules: rules,
+				pos:   pos,
+				from:  from,
+				to:    to,
+				used:  make(map[string]bool),
+			})
+		}
+	}
+	return sups, issues
+}
+
+// suppressedRules returns the rule IDs at the start of a suppression
+// comment's text.
+func suppressedRules(text string) []string {
+	var rules []string
+	for _, field := range strings.Fields(text) {
+		var ids []string
+		for _, id := range strings.Split(field, ",") {
+			if id == "" {
+				continue
+			}
+			if !ruleIDPattern.MatchString(id) {
+				return rules
+			}
+			ids = append(ids, strings.ToUpper(id))
+		}
+		rules = append(rules, ids...)
+	}
+	return rules
+}
+
+// lineNodes records, for each line of a file, the outermost node starting
+// on it and where the first node on it starts.
+type lineNodes struct {
+	fset      *token.FileSet
+	outermost map[int]ast.Node
+	first     map[int]token.Pos
+}
+
+func newLineNodes(fset *token.FileSet, file *ast.File) *lineNodes {
+	ln := &lineNodes{
+		fset:      fset,
+		outermost: make(map[int]ast.Node),
+		first:     make(map[int]token.Pos),
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil, *ast.File, *ast.Comment, *ast.CommentGroup:
+			return n != nil
+		}
+		line := fset.Position(n.Pos()).Line
+		if _, ok := ln.outermost[line]; !ok {
+			ln.outermost[line] = n
+		}
+		if first, ok := ln.first[line]; !ok || n.Pos() < first {
+			ln.first[line] = n.Pos()
+		}
+		return true
+	})
+	return ln
+}
+
+// trailing reports whether code precedes comment c on its line.
+func (ln *lineNodes) trailing(c *ast.Comment) bool {
+	first, ok := ln.first[ln.fset.Position(c.Pos()).Line]
+	return ok && first < c.Pos()
+}
+
+// applySuppressions drops the issues covered by a suppression and reports
+// suppressions of enabled rules that covered nothing.
+func applySuppressions(sups []*suppression, issues []LintIssue, enabled map[string]bool, path string) []LintIssue {
+	if len(sups) == 0 {
+		return issues
+	}
+
+	kept := issues[:0:0]
+	for _, issue := range issues {
+		suppressed := false
+		for _, s := range sups {
+			if s.covers(issue) {
+				s.used[issue.Rule] = true
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			kept = append(kept, issue)
+		}
+	}
+
+	for _, s := range sups {
+		for _, id := range s.rules {
+			if s.used[id] {
+				continue
+			}
+			var msg string
+			switch {
+			case !knownRule(id):
+				msg = fmt.Sprintf("%s names unknown rule %s", suppressionPrefix, id)
+			case enabled[id]:
+				msg = fmt.Sprintf("%s %s does not suppress any issue", suppressionPrefix, id)
+			default:
+				continue
+			}
+			kept = append(kept, LintIssue{
+				File:     path,
+				Line:     s.pos.Line,
+				Column:   s.pos.Column,
+				Severity: SeverityWarning,
+				Message:  msg,
+				Rule:     RuleUnusedSuppression,
+			})
+		}
+	}
+	return kept
+}
+
+// knownRule reports whether id is one of the default rules.
+func knownRule(id string) bool {
+	for _, rule := range defaultRules() {
+		if rule.ID() == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSuppressions(t *testing.T) {
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+//wetwire:ignore WAG014 the deploy job has its own watchdog
+var Deploy = workflow.Job{
+	RunsOn: "ubuntu-latest",
+}
+
+var Test = workflow.Job{ //wetwire:ignore WAG014,WAG008 generated
+	RunsOn: "ubuntu-latest",
+}
+
+var Lint = workflow.Job{
+	RunsOn: "ubuntu-latest",
+}
+
+//wetwire:ignore WAG003 nothing to suppress here
+var Name = "ci"
+
+//wetwire:ignore no rule given
+var Other = "x"
+`
+	l := NewLinter(&WAG003{}, &WAG008{}, &WAG014{})
+	result, err := l.LintContent("test.go", []byte(src))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range result.Issues {
+		got = append(got, fmt.Sprintf("%s@%d: %s", issue.Rule, issue.Line, issue.Message))
+	}
+	want := []string{
+		"WAG014@14",
+		"unused-suppression@10: //wetwire:ignore WAG008 does not suppress any issue",
+		"unused-suppression@18: //wetwire:ignore WAG003 does not suppress any issue",
+		"unused-suppression@21: //wetwire:ignore must name the rules it suppresses",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Issues = %v, want %d", got, len(want))
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("issue %d = %q, want prefix %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSuppressions_DisabledRule(t *testing.T) {
+	src := `package main
+
+//wetwire:ignore WAG014 not enabled in this linter
+var Name = "ci"
+
+//wetwire:ignore WAG999 typo
+var Other = "x"
+`
+	l := NewLinter(&WAG003{})
+	result, err := l.LintContent("test.go", []byte(src))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0].Message, "unknown rule WAG999") {
+		t.Errorf("Expected only the unknown rule to be reported, got %v", result.Issues)
+	}
+}
+
+func TestSuppressions_Fix(t *testing.T) {
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+//wetwire:ignore WAG023 title is validated by an earlier step
+var Greet = workflow.Step{
+	Run: "echo ${{ github.event.pull_request.title }}",
+}
+`
+	l := NewLinter(&WAG023{})
+	result, err := l.Fix("test.go", []byte(src))
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result.FixedCount != 0 || string(result.Content) != src {
+		t.Errorf("suppressed issue should not be fixed:\n%s", result.Content)
+	}
+}
+
+func TestSuppressedRules(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{" WAG008 reason", "WAG008"},
+		{" wag008,WAG014 reason", "WAG008 WAG014"},
+		{" WAG008 WAG014", "WAG008 WAG014"},
+		{" reason WAG008", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(suppressedRules(tt.text), " "); got != tt.want {
+			t.Errorf("suppressedRules(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,1051 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// untrustedContexts lists the expression contexts whose values an attacker
+// controls, for example by naming a branch or titling a pull request. A "*"
+// segment matches any property or array index.
+var untrustedContexts = []string{
+	"github.head_ref",
+	"github.event.issue.title",
+	"github.event.issue.body",
+	"github.event.pull_request.title",
+	"github.event.pull_request.body",
+	"github.event.pull_request.head.ref",
+	"github.event.pull_request.head.label",
+	"github.event.pull_request.head.repo.default_branch",
+	"github.event.comment.body",
+	"github.event.review.body",
+	"github.event.review_comment.body",
+	"github.event.pages.*.page_name",
+	"github.event.commits.*.message",
+	"github.event.commits.*.author.email",
+	"github.event.commits.*.author.name",
+	"github.event.head_commit.message",
+	"github.event.head_commit.author.email",
+	"github.event.head_commit.author.name",
+	"github.event.discussion.title",
+	"github.event.discussion.body",
+	"github.event.workflow_run.head_branch",
+	"github.event.workflow_run.head_commit.message",
+	"github.event.workflow_run.head_commit.author.email",
+	"github.event.workflow_run.head_commit.author.name",
+	"github.event.workflow_run.pull_requests.*.head.ref",
+}
+
+// isUntrusted reports whether an expression path such as
+// "github.event.pull_request.title" is attacker-controlled. Paths naming an
+// object that contains untrusted values, such as github.event.pull_request,
+// are untrusted too.
+func isUntrusted(path string) bool {
+	segs := strings.Split(strings.ToLower(path), ".")
+	for _, pattern := range untrustedContexts {
+		want := strings.Split(pattern, ".")
+		if len(segs) > len(want) {
+			continue
+		}
+		match := true
+		for i, seg := range segs {
+			if want[i] != "*" && seg != "*" && seg != want[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// exprPath returns the property path an expression node reads, with
+// dynamic indexes written as "*". It returns false for nodes that are not
+// a context access.
+func exprPath(node workflow.ExprNode) (string, bool) {
+	switch n := node.(type) {
+	case *workflow.VariableNode:
+		return n.Name, true
+	case *workflow.PropertyNode:
+		recv, ok := exprPath(n.Receiver)
+		return recv + "." + n.Name, ok
+	case *workflow.FilterNode:
+		recv, ok := exprPath(n.Receiver)
+		return recv + ".*", ok
+	case *workflow.IndexNode:
+		recv, ok := exprPath(n.Receiver)
+		if s, isString := n.Index.(*workflow.StringNode); isString {
+			return recv + "." + s.Value, ok
+		}
+		return recv + ".*", ok
+	}
+	return "", false
+}
+
+// booleanFuncs are expression functions whose result cannot carry the text
+// of their arguments.
+var booleanFuncs = map[string]bool{
+	"contains":   true,
+	"startswith": true,
+	"endswith":   true,
+	"success":    true,
+	"failure":    true,
+	"always":     true,
+	"cancelled":  true,
+	"hashfiles":  true,
+}
+
+// maxTaintDepth bounds how far taint is followed through variables, job
+// outputs and env values.
+const maxTaintDepth = 16
+
+// declValue is a package-level declaration together with the name its file
+// imports the workflow package under.
+type declValue struct {
+	expr ast.Expr
+	wf   string
+}
+
+// taintIndex holds the package-level declarations WAG023 follows: variable
+// and constant values, job outputs and env values.
+type taintIndex struct {
+	values map[string]declValue
+	jobIDs map[string]string
+	jobs   map[string]map[string]declValue
+	env    map[string][]declValue
+}
+
+// newTaintIndex indexes the given files of a package.
+func newTaintIndex(files []*ast.File) *taintIndex {
+	ix := &taintIndex{
+		values: make(map[string]declValue),
+		jobIDs: make(map[string]string),
+		jobs:   make(map[string]map[string]declValue),
+		env:    make(map[string][]declValue),
+	}
+	for _, file := range files {
+		ix.add(file)
+	}
+	return ix
+}
+
+func (ix *taintIndex) add(file *ast.File) {
+	wf := workflowImport(file)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.VAR && gen.Tok != token.CONST) {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) != len(vs.Names) {
+				continue
+			}
+			for i, name := range vs.Names {
+				value := vs.Values[i]
+				ix.values[name.Name] = declValue{value, wf}
+				if lit := jobLit(value, wf); lit != nil {
+					ix.addJob(name.Name, lit, wf)
+				}
+			}
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "Env" {
+			return true
+		}
+		env, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		for _, elt := range env.Elts {
+			entry, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			keyLit, _ := entry.Key.(*ast.BasicLit)
+			if name, ok := stringLit(keyLit); ok {
+				ix.env[name] = append(ix.env[name], declValue{entry.Value, wf})
+			}
+		}
+		return true
+	})
+}
+
+func (ix *taintIndex) addJob(varName string, lit *ast.CompositeLit, wf string) {
+	id := varName
+	outputs := make(map[string]declValue)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Name":
+			valueLit, _ := kv.Value.(*ast.BasicLit)
+			if name, ok := stringLit(valueLit); ok && name != "" {
+				id = name
+			}
+		case "Outputs":
+			m, ok := kv.Value.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			for _, e := range m.Elts {
+				entry, ok := e.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				keyLit, _ := entry.Key.(*ast.BasicLit)
+				if name, ok := stringLit(keyLit); ok {
+					outputs[name] = declValue{entry.Value, wf}
+				}
+			}
+		}
+	}
+	ix.jobIDs[varName] = id
+	ix.jobs[id] = outputs
+}
+
+// jobLit returns the workflow.Job literal a declaration's value is, if any.
+func jobLit(expr ast.Expr, wf string) *ast.CompositeLit {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || !isWorkflowSel(lit.Type, wf, "Job") {
+		return nil
+	}
+	return lit
+}
+
+// workflowImport returns the name a file imports the workflow package
+// under: "workflow", an alias, or "." for a dot import. It returns
+// "workflow" if the file does not import it.
+func workflowImport(file *ast.File) string {
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || importPath != "github.com/lex00/wetwire-github-go/workflow" {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+	}
+	return "workflow"
+}
+
+// isWorkflowSel reports whether expr names the workflow package's name,
+// as wf.name, or as a bare name in dot-importing files.
+func isWorkflowSel(expr ast.Expr, wf, name string) bool {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		return ok && x.Name == wf && e.Sel.Name == name
+	case *ast.Ident:
+		return wf == "." && e.Name == name
+	}
+	return false
+}
+
+// goValue is the value of a Go expression: a string, or the raw text of a
+// workflow.Expression.
+type goValue struct {
+	text string
+	expr bool
+}
+
+// str returns the value as it appears when formatted into a string.
+func (v goValue) str() string {
+	if v.expr {
+		return workflow.Expression(v.text).String()
+	}
+	return v.text
+}
+
+var expressionType = reflect.TypeOf(workflow.Expression(""))
+
+// exprContexts are the workflow package's context accessors.
+var exprContexts = map[string]reflect.Value{
+	"GitHub":        reflect.ValueOf(workflow.GitHub),
+	"Runner":        reflect.ValueOf(workflow.Runner),
+	"Secrets":       reflect.ValueOf(workflow.Secrets),
+	"MatrixContext": reflect.ValueOf(workflow.MatrixContext),
+	"Steps":         reflect.ValueOf(workflow.Steps),
+	"Needs":         reflect.ValueOf(workflow.Needs),
+	"Inputs":        reflect.ValueOf(workflow.Inputs),
+	"Vars":          reflect.ValueOf(workflow.Vars),
+	"EnvContext":    reflect.ValueOf(workflow.EnvContext),
+}
+
+// exprFuncs are the workflow package's expression builders.
+var exprFuncs = map[string]reflect.Value{
+	"Always":      reflect.ValueOf(workflow.Always),
+	"Failure":     reflect.ValueOf(workflow.Failure),
+	"Success":     reflect.ValueOf(workflow.Success),
+	"Cancelled":   reflect.ValueOf(workflow.Cancelled),
+	"Branch":      reflect.ValueOf(workflow.Branch),
+	"Tag":         reflect.ValueOf(workflow.Tag),
+	"TagPrefix":   reflect.ValueOf(workflow.TagPrefix),
+	"Push":        reflect.ValueOf(workflow.Push),
+	"PullRequest": reflect.ValueOf(workflow.PullRequest),
+	"Contains":    reflect.ValueOf(workflow.Contains),
+	"StartsWith":  reflect.ValueOf(workflow.StartsWith),
+	"EndsWith":    reflect.ValueOf(workflow.EndsWith),
+	"Format":      reflect.ValueOf(workflow.Format),
+	"Join":        reflect.ValueOf(workflow.Join),
+	"ToJSON":      reflect.ValueOf(workflow.ToJSON),
+	"FromJSON":    reflect.ValueOf(workflow.FromJSON),
+}
+
+// eval statically evaluates a Go expression built from string literals,
+// package-level declarations and the workflow package's expression
+// builders. Parts it cannot evaluate are reported as not ok.
+func (ix *taintIndex) eval(expr ast.Expr, wf string, depth int) (goValue, bool) {
+	if depth > maxTaintDepth {
+		return goValue{}, false
+	}
+	depth++
+
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if s, ok := stringLit(e); ok {
+			return goValue{text: s}, true
+		}
+		return goValue{text: e.Value}, true
+
+	case *ast.ParenExpr:
+		return ix.eval(e.X, wf, depth)
+
+	case *ast.Ident:
+		if d, ok := ix.values[e.Name]; ok {
+			return ix.eval(d.expr, d.wf, depth)
+		}
+
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			break
+		}
+		// Unknown operands count as empty so that the known parts of a
+		// concatenation are still checked.
+		left, lok := ix.eval(e.X, wf, depth)
+		right, rok := ix.eval(e.Y, wf, depth)
+		if !lok && !rok {
+			break
+		}
+		return goValue{text: left.text + right.text, expr: left.expr || right.expr}, true
+
+	case *ast.CallExpr:
+		return ix.evalCall(e, wf, depth)
+	}
+	return goValue{}, false
+}
+
+func (ix *taintIndex) evalCall(call *ast.CallExpr, wf string, depth int) (goValue, bool) {
+	if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "string" && len(call.Args) == 1 {
+		v, ok := ix.eval(call.Args[0], wf, depth)
+		return goValue{text: v.text}, ok
+	}
+	if isWorkflowSel(call.Fun, wf, "Expression") && len(call.Args) == 1 {
+		v, ok := ix.eval(call.Args[0], wf, depth)
+		return goValue{text: v.text, expr: true}, ok
+	}
+	for name, fn := range exprFuncs {
+		if isWorkflowSel(call.Fun, wf, name) {
+			return ix.callReflect(fn, call.Args, wf, depth)
+		}
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return goValue{}, false
+	}
+	for name, ctx := range exprContexts {
+		if isWorkflowSel(sel.X, wf, name) {
+			return ix.callReflect(ctx.MethodByName(sel.Sel.Name), call.Args, wf, depth)
+		}
+	}
+	if x, ok := sel.X.(*ast.Ident); ok && x.Name == "fmt" && sel.Sel.Name == "Sprintf" {
+		return ix.sprintf(call.Args, wf, depth)
+	}
+	if sel.Sel.Name == "Output" && len(call.Args) == 1 {
+		recv := sel.X
+		if u, ok := recv.(*ast.UnaryExpr); ok && u.Op == token.AND {
+			recv = u.X
+		}
+		if x, ok := recv.(*ast.Ident); ok {
+			if id, ok := ix.jobIDs[x.Name]; ok {
+				name, ok := ix.eval(call.Args[0], wf, depth)
+				return goValue{text: workflow.Needs.Get(id, name.text).Raw(), expr: true}, ok
+			}
+		}
+	}
+
+	recv, ok := ix.eval(sel.X, wf, depth)
+	if !ok || !recv.expr {
+		return goValue{}, false
+	}
+	return ix.callReflect(reflect.ValueOf(workflow.Expression(recv.text)).MethodByName(sel.Sel.Name), call.Args, wf, depth)
+}
+
+// callReflect calls a workflow function or method whose parameters and
+// result are all strings or Expressions.
+func (ix *taintIndex) callReflect(fn reflect.Value, args []ast.Expr, wf string, depth int) (goValue, bool) {
+	if !fn.IsValid() {
+		return goValue{}, false
+	}
+	ft := fn.Type()
+	if ft.NumOut() != 1 || ft.Out(0).Kind() != reflect.String {
+		return goValue{}, false
+	}
+	if len(args) < ft.NumIn()-1 || (!ft.IsVariadic() && len(args) != ft.NumIn()) {
+		return goValue{}, false
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var t reflect.Type
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			t = ft.In(ft.NumIn() - 1).Elem()
+		} else {
+			t = ft.In(i)
+		}
+		if t.Kind() != reflect.String {
+			return goValue{}, false
+		}
+		v, ok := ix.eval(arg, wf, depth)
+		if !ok {
+			return goValue{}, false
+		}
+		in[i] = reflect.ValueOf(v.text).Convert(t)
+	}
+
+	out := fn.Call(in)[0]
+	return goValue{text: out.String(), expr: out.Type() == expressionType}, true
+}
+
+// sprintf evaluates fmt.Sprintf. Expressions format through their String
+// method, as they do at run time.
+func (ix *taintIndex) sprintf(args []ast.Expr, wf string, depth int) (goValue, bool) {
+	if len(args) == 0 {
+		return goValue{}, false
+	}
+	format, ok := ix.eval(args[0], wf, depth)
+	if !ok || format.expr {
+		return goValue{}, false
+	}
+	values := make([]any, len(args)-1)
+	for i, arg := range args[1:] {
+		v, _ := ix.eval(arg, wf, depth)
+		if v.expr {
+			values[i] = workflow.Expression(v.text)
+		} else {
+			values[i] = v.text
+		}
+	}
+	return goValue{text: fmt.Sprintf(format.text, values...)}, true
+}
+
+// taintSources returns the untrusted contexts whose text flows into the
+// result of an expression. Comparisons and boolean functions do not carry
+// text, so their operands are not followed.
+func (ix *taintIndex) taintSources(node workflow.ExprNode, depth int) []string {
+	switch n := node.(type) {
+	case *workflow.VariableNode, *workflow.PropertyNode, *workflow.IndexNode, *workflow.FilterNode:
+		path, ok := exprPath(n)
+		if !ok {
+			// A property of a function result, such as fromJSON(x).title.
+			switch n := n.(type) {
+			case *workflow.PropertyNode:
+				return ix.taintSources(n.Receiver, depth)
+			case *workflow.IndexNode:
+				return ix.taintSources(n.Receiver, depth)
+			case *workflow.FilterNode:
+				return ix.taintSources(n.Receiver, depth)
+			}
+			return nil
+		}
+		return ix.pathSources(path, depth)
+
+	case *workflow.LogicalNode:
+		return append(ix.taintSources(n.Left, depth), ix.taintSources(n.Right, depth)...)
+
+	case *workflow.CallNode:
+		if booleanFuncs[strings.ToLower(n.Name)] {
+			return nil
+		}
+		var sources []string
+		for _, arg := range n.Args {
+			sources = append(sources, ix.taintSources(arg, depth)...)
+		}
+		return sources
+	}
+	return nil
+}
+
+// pathSources returns the untrusted contexts a context path reads,
+// following job outputs and env values to their definitions.
+func (ix *taintIndex) pathSources(path string, depth int) []string {
+	if isUntrusted(path) {
+		return []string{strings.ToLower(path)}
+	}
+	if depth > maxTaintDepth {
+		return nil
+	}
+
+	segs := strings.Split(path, ".")
+	var values []declValue
+	switch {
+	case len(segs) == 4 && segs[0] == "needs" && segs[2] == "outputs":
+		if d, ok := ix.jobs[segs[1]][segs[3]]; ok {
+			values = append(values, d)
+		}
+	case len(segs) == 2 && segs[0] == "env":
+		values = ix.env[segs[1]]
+	default:
+		return nil
+	}
+
+	var sources []string
+	for _, d := range values {
+		v, ok := ix.eval(d.expr, d.wf, depth)
+		if !ok {
+			continue
+		}
+		for _, inner := range ix.stringSources(v.str(), depth+1) {
+			sources = append(sources, inner+" via "+path)
+		}
+	}
+	return sources
+}
+
+// stringSources returns the untrusted contexts interpolated into s through
+// ${{ }} placeholders.
+func (ix *taintIndex) stringSources(s string, depth int) []string {
+	exprs, err := workflow.ExtractExpressions(s)
+	if err != nil {
+		return nil
+	}
+	var sources []string
+	for _, expr := range exprs {
+		node, err := workflow.ParseExpression(expr)
+		if err != nil {
+			continue
+		}
+		sources = append(sources, ix.taintSources(node, depth)...)
+	}
+	return sources
+}
+
+// packageFiles parses the non-test Go files in dir, keyed by path.
+func packageFiles(dir string) map[string]*ast.File {
+	files := make(map[string]*ast.File)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return files
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			continue
+		}
+		files[path] = file
+	}
+	return files
+}
+
+// scriptStyle is how a script reads environment variables.
+type scriptStyle int
+
+const (
+	styleShell scriptStyle = iota
+	stylePowerShell
+	styleCmd
+	styleJavaScript
+)
+
+// ref returns the script syntax for reading environment variable name.
+// Shell references are double-quoted so the value is not split or globbed.
+func (s scriptStyle) ref(name string) string {
+	switch s {
+	case stylePowerShell:
+		return "$env:" + name
+	case styleCmd:
+		return "%" + name + "%"
+	case styleJavaScript:
+		return "process.env." + name
+	}
+	return `"$` + name + `"`
+}
+
+// quotedRef returns the replacement for a placeholder wrapped in quote.
+// Shells do not expand variables in single quotes, and in JavaScript the
+// environment variable replaces the string literal.
+func (s scriptStyle) quotedRef(name string, quote byte) (string, bool) {
+	switch {
+	case s == styleJavaScript && (quote == '\'' || quote == '"' || quote == '`'):
+		return s.ref(name), true
+	case s == styleShell && quote == '\'':
+		return s.ref(name), true
+	case s == stylePowerShell && quote == '\'':
+		return `"` + s.ref(name) + `"`, true
+	}
+	return "", false
+}
+
+// inDoubleQuotes reports whether offset pos of a shell script is inside a
+// double-quoted string.
+func inDoubleQuotes(script string, pos int) bool {
+	var quote byte
+	for i := 0; i < pos; i++ {
+		c := script[i]
+		switch {
+		case c == '\\' && quote != '\'':
+			i++
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == quote:
+			quote = 0
+		}
+	}
+	return quote == '"'
+}
+
+// injectionTarget is a script that WAG023 checks: the Run of a step, the
+// script input of an actions/github-script step, or the Script of the
+// github_script wrapper. step is nil for wrappers, which have no Env.
+type injectionTarget struct {
+	step  *ast.CompositeLit
+	value ast.Expr
+	style scriptStyle
+	wf    string
+}
+
+// injectionTargets returns the scripts in file.
+func injectionTargets(file *ast.File) []injectionTarget {
+	wf := workflowImport(file)
+	wrappers := wrapperImports(file)
+	var targets []injectionTarget
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		if pkg, typ, ok := strings.Cut(getTypeName(lit.Type), "."); ok && wrappers[pkg] == "github_script" && typ == "GithubScript" {
+			if script := fieldValue(lit, "Script"); script != nil {
+				targets = append(targets, injectionTarget{value: script, style: styleJavaScript, wf: wf})
+			}
+			return true
+		}
+		if !isWorkflowSel(lit.Type, wf, "Step") && getTypeName(lit.Type) != "workflow.Step" {
+			return true
+		}
+
+		if run := fieldValue(lit, "Run"); run != nil {
+			style := styleShell
+			shellLit, _ := fieldValue(lit, "Shell").(*ast.BasicLit)
+			if shell, ok := stringLit(shellLit); ok && strings.TrimSpace(shell) != "" {
+				switch strings.ToLower(strings.Fields(shell)[0]) {
+				case "pwsh", "powershell":
+					style = stylePowerShell
+				case "cmd":
+					style = styleCmd
+				}
+			}
+			targets = append(targets, injectionTarget{step: lit, value: run, style: style, wf: wf})
+		}
+
+		usesLit, _ := fieldValue(lit, "Uses").(*ast.BasicLit)
+		if uses, ok := stringLit(usesLit); ok && parseActionRef(uses) == "actions/github-script" {
+			if with, ok := fieldValue(lit, "With").(*ast.CompositeLit); ok {
+				for _, elt := range with.Elts {
+					entry, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					keyLit, _ := entry.Key.(*ast.BasicLit)
+					if key, ok := stringLit(keyLit); ok && key == "script" {
+						targets = append(targets, injectionTarget{step: lit, value: entry.Value, style: styleJavaScript, wf: wf})
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return targets
+}
+
+// fieldValue returns the value of a keyed field in a composite literal.
+func fieldValue(lit *ast.CompositeLit, field string) ast.Expr {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == field {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+// sources returns the distinct untrusted contexts interpolated into the
+// target's script.
+func (t injectionTarget) sources(ix *taintIndex) []string {
+	v, ok := ix.eval(t.value, t.wf, 0)
+	if !ok {
+		return nil
+	}
+	var sources []string
+	seen := make(map[string]bool)
+	for _, source := range ix.stringSources(v.str(), 0) {
+		if !seen[source] {
+			seen[source] = true
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// injectionFix plans the WAG023 fix for one step: each untrusted value is
+// moved into an Env entry and the script reads the environment variable.
+type injectionFix struct {
+	fset   *token.FileSet
+	ix     *taintIndex
+	target injectionTarget
+
+	edits   []textEdit
+	entries []string
+	names   map[string]string
+	taken   map[string]bool
+	failed  bool
+}
+
+// planInjectionFix returns the edits that fix target, or false if the
+// untrusted values cannot all be moved, for example because they come
+// from a variable declared elsewhere.
+func planInjectionFix(fset *token.FileSet, ix *taintIndex, target injectionTarget) ([]textEdit, bool) {
+	if target.step == nil || len(target.sources(ix)) == 0 {
+		return nil, false
+	}
+
+	f := &injectionFix{
+		fset:   fset,
+		ix:     ix,
+		target: target,
+		names:  make(map[string]string),
+		taken:  make(map[string]bool),
+	}
+
+	env := fieldValue(target.step, "Env")
+	envLit, _ := env.(*ast.CompositeLit)
+	if env != nil && envLit == nil {
+		return nil, false
+	}
+	if envLit != nil {
+		for _, elt := range envLit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				keyLit, _ := kv.Key.(*ast.BasicLit)
+				if key, ok := stringLit(keyLit); ok {
+					f.taken[key] = true
+				}
+			}
+		}
+	}
+
+	f.plan(target.value)
+	if f.failed || len(f.edits) == 0 {
+		return nil, false
+	}
+
+	if len(f.entries) > 0 {
+		if envLit != nil {
+			f.edits = append(f.edits, appendElts(f.fset, envLit, f.entries))
+		} else {
+			q := f.qualifier()
+			multiline := fset.Position(target.step.Rbrace).Line > fset.Position(target.step.Lbrace).Line
+			var env string
+			if multiline {
+				env = "Env: " + q + "Env{\n" + strings.Join(f.entries, ",\n") + ",\n}"
+			} else {
+				env = "Env: " + q + "Env{" + strings.Join(f.entries, ", ") + "}"
+			}
+			f.edits = append(f.edits, appendElts(f.fset, target.step, []string{env}))
+		}
+	}
+	return f.edits, true
+}
+
+// plan rewrites the parts of a script expression that carry untrusted
+// values.
+func (f *injectionFix) plan(expr ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		f.planLiteral(e)
+		return
+
+	case *ast.ParenExpr:
+		f.plan(e.X)
+		return
+
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			f.plan(e.X)
+			f.plan(e.Y)
+			return
+		}
+
+	case *ast.CallExpr:
+		sel, _ := e.Fun.(*ast.SelectorExpr)
+		if sel != nil && len(e.Args) > 0 {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == "fmt" && sel.Sel.Name == "Sprintf" {
+				f.plan(e.Args[0])
+				for _, arg := range e.Args[1:] {
+					if v, ok := f.ix.eval(arg, f.target.wf, 0); ok && v.expr {
+						f.planExpression(arg, v)
+					} else {
+						f.plan(arg)
+					}
+				}
+				return
+			}
+		}
+		if sel != nil && sel.Sel.Name == "String" && len(e.Args) == 0 {
+			if v, ok := f.ix.eval(sel.X, f.target.wf, 0); ok && v.expr {
+				f.planExpression(e, v)
+				return
+			}
+		}
+	}
+
+	if v, ok := f.ix.eval(expr, f.target.wf, 0); ok && len(f.ix.stringSources(v.str(), 0)) > 0 {
+		f.failed = true
+	}
+}
+
+// planExpression moves a tainted Expression-valued node into Env, using
+// its source as the Env value. Only references to a context value are
+// moved: the value of a composite expression such as workflow.Format can
+// carry script code along with the untrusted text, and reading it from
+// the environment would stop that code from running.
+func (f *injectionFix) planExpression(node ast.Expr, v goValue) {
+	sources := f.ix.stringSources(v.str(), 0)
+	if len(sources) == 0 {
+		return
+	}
+	parsed, err := workflow.ParseExpression(v.text)
+	if err != nil {
+		f.failed = true
+		return
+	}
+	path, ok := exprPath(parsed)
+	if !ok {
+		f.failed = true
+		return
+	}
+
+	value := node
+	if call, ok := node.(*ast.CallExpr); ok {
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "String" {
+			value = sel.X
+		}
+	}
+	name := f.envName(f.source(value), path)
+	f.edits = append(f.edits, replaceNode(f.fset, node, strconv.Quote(f.target.style.ref(name))))
+}
+
+// planLiteral replaces the untrusted placeholders in a string literal.
+func (f *injectionFix) planLiteral(lit *ast.BasicLit) {
+	s, ok := stringLit(lit)
+	if !ok {
+		return
+	}
+
+	var b strings.Builder
+	changed := false
+	offset := 0
+	for {
+		start := strings.Index(s[offset:], "${{")
+		if start < 0 {
+			break
+		}
+		start += offset
+		end := placeholderEnd(s, start)
+		if end < 0 {
+			break
+		}
+
+		inner := strings.TrimSpace(s[start+3 : end-2])
+		node, err := workflow.ParseExpression(inner)
+		if err != nil || len(f.ix.taintSources(node, 0)) == 0 {
+			b.WriteString(s[offset:end])
+			offset = end
+			continue
+		}
+
+		name := f.placeholderEnv(inner, node)
+		from, to := start, end
+		replacement := f.target.style.ref(name)
+		if f.target.style == styleShell && inDoubleQuotes(s, start) {
+			replacement = "${" + name + "}"
+		}
+		if start > 0 && end < len(s) && s[start-1] == s[end] {
+			if quoted, ok := f.target.style.quotedRef(name, s[end]); ok {
+				from, to, replacement = start-1, end+1, quoted
+			}
+		}
+		b.WriteString(s[offset:from])
+		b.WriteString(replacement)
+		offset = to
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	b.WriteString(s[offset:])
+
+	text := b.String()
+	quoted := strconv.Quote(text)
+	if strings.HasPrefix(lit.Value, "`") && !strings.Contains(text, "`") {
+		quoted = "`" + text + "`"
+	}
+	f.edits = append(f.edits, replaceNode(f.fset, lit, quoted))
+}
+
+// placeholderEnv returns the Env variable for an untrusted placeholder,
+// adding an Env entry unless it reads env already.
+func (f *injectionFix) placeholderEnv(inner string, node workflow.ExprNode) string {
+	path, isPath := exprPath(node)
+	if isPath {
+		segs := strings.Split(path, ".")
+		if len(segs) == 2 && segs[0] == "env" {
+			return segs[1]
+		}
+	}
+
+	q := f.qualifier()
+	value := q + "Expression(" + strconv.Quote(inner) + ")"
+	hint := path
+	segs := strings.Split(path, ".")
+	switch {
+	case !isPath:
+		hint = f.ix.taintSources(node, 0)[0]
+	case strings.Contains(path, "*"):
+	case strings.EqualFold(path, "github.head_ref"):
+		value = q + "GitHub.HeadRef()"
+	case strings.HasPrefix(path, "github.event."):
+		value = q + "GitHub.Event(" + strconv.Quote(strings.TrimPrefix(path, "github.event.")) + ")"
+	case len(segs) == 4 && segs[0] == "needs" && segs[2] == "outputs":
+		value = q + "Needs.Get(" + strconv.Quote(segs[1]) + ", " + strconv.Quote(segs[3]) + ")"
+	}
+	return f.envName(value, hint)
+}
+
+// envName returns the Env variable holding value, adding an entry named
+// after the context path hint if there is none yet.
+func (f *injectionFix) envName(value, hint string) string {
+	if name, ok := f.names[value]; ok {
+		return name
+	}
+
+	hint, _, _ = strings.Cut(hint, " ")
+	var parts []string
+	for _, seg := range strings.Split(hint, ".") {
+		switch seg {
+		case "github", "event", "needs", "outputs", "env", "*", "":
+			continue
+		}
+		if _, err := strconv.Atoi(seg); err == nil {
+			continue
+		}
+		parts = append(parts, seg)
+	}
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	base := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, strings.Join(parts, "_"))
+	if base == "" {
+		base = "VALUE"
+	}
+
+	name := base
+	for i := 2; f.taken[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	f.taken[name] = true
+	f.names[value] = name
+	f.entries = append(f.entries, strconv.Quote(name)+": "+value)
+	return name
+}
+
+// qualifier returns the prefix for workflow package names in the file.
+func (f *injectionFix) qualifier() string {
+	if f.target.wf == "." {
+		return ""
+	}
+	return f.target.wf + "."
+}
+
+// source returns the Go source of node.
+func (f *injectionFix) source(node ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, f.fset, node); err != nil {
+		f.failed = true
+	}
+	return buf.String()
+}
+
+// placeholderEnd returns the end of the ${{ }} placeholder starting at
+// start, or -1 if it is unterminated. A closing }} inside a string literal
+// does not end the placeholder.
+func placeholderEnd(s string, start int) int {
+	inString := false
+	for i := start + 3; i < len(s); i++ {
+		if s[i] == '\'' {
+			inString = !inString
+		} else if !inString && strings.HasPrefix(s[i:], "}}") {
+			return i + 2
+		}
+	}
+	return -1
+}
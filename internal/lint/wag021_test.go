@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// WAG021 Tests - Validate runner labels
+
+func TestWAG021_Check_RetiredImage(t *testing.T) {
+	content := []byte(`package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = workflow.Job{
+	RunsOn: "ubuntu-20.04",
+}
+
+var Test = workflow.Job{
+	RunsOn: workflow.Hosted("macos-12"),
+}
+
+var Lint = workflow.Job{
+	RunsOn: "ubuntu-24.04",
+}
+
+var Legacy = workflow.Job{
+	RunsOn: workflow.SelfHosted("ubuntu-20.04"),
+}
+`)
+
+	l := NewLinter(&WAG021{})
+	result, err := l.LintContent(filepath.Join(t.TempDir(), "test.go"), content)
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(result.Issues), result.Issues)
+	}
+	for _, issue := range result.Issues {
+		if issue.Severity != SeverityWarning {
+			t.Errorf("retired image without an inventory should be a warning, got %v", issue.Severity)
+		}
+	}
+	if !strings.Contains(result.Issues[0].Message, "ubuntu-24.04") {
+		t.Errorf("expected a replacement hint, got %q", result.Issues[0].Message)
+	}
+}
+
+func TestWAG021_Check_Inventory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	inventory := "labels:\n  - gpu\ngroups:\n  - fleet\ndeprecated:\n  ubuntu-22.04: use ubuntu-24.04\n"
+	if err := os.WriteFile(filepath.Join(dir, "wetwire-runners.yml"), []byte(inventory), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte(`package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Known = workflow.Job{
+	RunsOn: workflow.SelfHosted("linux", "gpu"),
+}
+
+var Group = workflow.Job{
+	RunsOn: workflow.RunsOn{Group: "fleet", Labels: []string{"gpu"}},
+}
+
+var Unknown = workflow.Job{
+	RunsOn: []string{"self-hosted", "tpu"},
+}
+
+var UnknownGroup = workflow.Job{
+	RunsOn: workflow.RunnerGroup("other"),
+}
+
+var Deprecated = workflow.Job{
+	RunsOn: "ubuntu-22.04",
+}
+
+var Matrix = workflow.Job{
+	RunsOn: workflow.MatrixContext.Get("os"),
+}
+`)
+
+	l := NewLinter(&WAG021{})
+	result, err := l.LintContent(filepath.Join(dir, "jobs.go"), content)
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+
+	var messages []string
+	for _, issue := range result.Issues {
+		if issue.Severity != SeverityWarning {
+			t.Errorf("expected a warning, got %v for %q", issue.Severity, issue.Message)
+		}
+		messages = append(messages, issue.Message)
+	}
+	joined := strings.Join(messages, "\n")
+
+	if len(result.Issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d:\n%s", len(result.Issues), joined)
+	}
+	for _, want := range []string{`"tpu"`, `group "other"`, `"ubuntu-22.04" is deprecated`} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected an issue containing %q, got:\n%s", want, joined)
+		}
+	}
+}
+
+func TestWAG021_Check_RetiredWithInventory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	inventory := "labels:\n  - macos-12\n"
+	if err := os.WriteFile(filepath.Join(dir, "wetwire-runners.yml"), []byte(inventory), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte(`package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = workflow.Job{
+	RunsOn: "ubuntu-20.04",
+}
+
+var Mac = workflow.Job{
+	RunsOn: "macos-12",
+}
+`)
+
+	l := NewLinter(&WAG021{})
+	result, err := l.LintContent(filepath.Join(dir, "jobs.go"), content)
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %v", len(result.Issues), result.Issues)
+	}
+	if issue := result.Issues[0]; issue.Severity != SeverityError || !strings.Contains(issue.Message, `"ubuntu-20.04" is retired`) {
+		t.Errorf("expected the retired image as an error, got %v %q", issue.Severity, issue.Message)
+	}
+}
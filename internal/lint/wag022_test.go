@@ -0,0 +1,106 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// WAG022 Tests - Require pinned action references
+
+const wag022Source = `package main
+
+import (
+	"github.com/lex00/wetwire-github-go/actions/checkout"
+	gosetup "github.com/lex00/wetwire-github-go/actions/setup_go"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+var Steps = []any{
+	checkout.Checkout{},
+	gosetup.SetupGo{GoVersion: "1.23"},
+	workflow.Step{Uses: "example/deploy@v2"},
+	workflow.Step{Uses: "./.github/actions/local"},
+	workflow.Step{Uses: "example/tool@0123456789abcdef0123456789abcdef01234567"},
+}
+
+var Call = workflow.Job{
+	Uses: "example/shared/.github/workflows/ci.yml@v1",
+}
+`
+
+func TestWAG022_Check_NoLockFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLinter(&WAG022{})
+	result, err := l.LintContent(filepath.Join(dir, "test.go"), []byte(wag022Source))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	if len(result.Issues) != 0 {
+		t.Errorf("Expected no issues without a lock file, got %v", result.Issues)
+	}
+}
+
+func TestWAG022_Check_LockFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lock := "actions:\n  actions/checkout@v4:\n    sha: 11bd71901bbe5b1630ceea73d27597364c9af683\n    version: v4.2.2\n"
+	if err := os.WriteFile(filepath.Join(dir, "wetwire-github.lock"), []byte(lock), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLinter(&WAG022{})
+	result, err := l.LintContent(filepath.Join(dir, "test.go"), []byte(wag022Source))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+
+	var refs []string
+	for _, issue := range result.Issues {
+		if issue.Severity != SeverityError {
+			t.Errorf("unpinned reference should be an error, got %v", issue.Severity)
+		}
+		refs = append(refs, strings.Fields(issue.Message)[0])
+	}
+	want := []string{"actions/setup-go@v5", "example/deploy@v2", "example/shared/.github/workflows/ci.yml@v1"}
+	if strings.Join(refs, " ") != strings.Join(want, " ") {
+		t.Errorf("Reported %v, want %v", refs, want)
+	}
+}
+
+func TestWrapperRefs_MatchActions(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "actions", "*", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pattern := regexp.MustCompile(`func \(\w+ \*?(\w+)\) Action\(\) string \{\s*return "([^"]+)"`)
+	found := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg := filepath.Base(filepath.Dir(file))
+		for _, m := range pattern.FindAllStringSubmatch(string(src), -1) {
+			found++
+			if got := wrapperRefs[pkg+"."+m[1]]; got != m[2] {
+				t.Errorf("wrapperRefs[%q] = %q, want %q", pkg+"."+m[1], got, m[2])
+			}
+		}
+	}
+	if found != len(wrapperRefs) {
+		t.Errorf("found %d wrappers, wrapperRefs has %d", found, len(wrapperRefs))
+	}
+}
@@ -0,0 +1,386 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// WAG023 Tests - Script injection from untrusted contexts
+
+func lintWAG023(t *testing.T, src string) []LintIssue {
+	t.Helper()
+	l := NewLinter(&WAG023{})
+	result, err := l.LintContent(filepath.Join(t.TempDir(), "test.go"), []byte(src))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	return result.Issues
+}
+
+func TestWAG023_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		fixable bool
+	}{
+		{
+			name: "literal placeholder",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Greet = workflow.Step{
+	Run: "echo \"${{ github.event.pull_request.title }}\"",
+}
+`,
+			want:    "untrusted github.event.pull_request.title interpolated into run script",
+			fixable: true,
+		},
+		{
+			name: "expression builder concatenated",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Branch = workflow.Step{
+	Run: "git push origin " + workflow.GitHub.HeadRef().String(),
+}
+`,
+			want:    "untrusted github.head_ref",
+			fixable: true,
+		},
+		{
+			name: "format through Sprintf",
+			src: `package main
+
+import (
+	"fmt"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+var comment = workflow.Format("{0}: {1}", workflow.GitHub.Actor(), workflow.GitHub.Event("comment.body"))
+
+var Reply = workflow.Step{
+	Run: fmt.Sprintf("echo %s", comment),
+}
+`,
+			want:    "untrusted github.event.comment.body",
+			fixable: false,
+		},
+		{
+			name: "job output",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Meta = workflow.Job{
+	Name: "meta",
+	Outputs: map[string]any{
+		"title": workflow.GitHub.Event("issue.title").String(),
+	},
+}
+
+var Label = workflow.Step{
+	Run: "./label.sh " + Meta.Output("title").String(),
+}
+`,
+			want:    "untrusted github.event.issue.title via needs.meta.outputs.title",
+			fixable: true,
+		},
+		{
+			name: "env value",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = workflow.Job{
+	Env: workflow.Env{"BODY": workflow.GitHub.Event("issue.body")},
+}
+
+var Print = workflow.Step{
+	Run: "echo ${{ env.BODY }}",
+}
+`,
+			want:    "untrusted github.event.issue.body via env.BODY",
+			fixable: true,
+		},
+		{
+			name: "github-script wrapper",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/actions/github_script"
+
+var Triage = github_script.GithubScript{
+	Script: "console.log('${{ github.event.issue.title }}')",
+}
+`,
+			want:    "untrusted github.event.issue.title interpolated into github-script; the github_script wrapper has no Env, so this is not fixed automatically",
+			fixable: false,
+		},
+		{
+			name: "variable defined elsewhere",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var script = "echo ${{ github.event.discussion.body }}"
+
+var Print = workflow.Step{
+	Run: script,
+}
+`,
+			want:    "untrusted github.event.discussion.body",
+			fixable: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := lintWAG023(t, tt.src)
+			if len(issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %v", issues)
+			}
+			if !strings.Contains(issues[0].Message, tt.want) {
+				t.Errorf("Message = %q, want it to contain %q", issues[0].Message, tt.want)
+			}
+			if issues[0].Severity != SeverityError {
+				t.Errorf("Severity = %v, want error", issues[0].Severity)
+			}
+			if issues[0].Fixable != tt.fixable {
+				t.Errorf("Fixable = %v, want %v", issues[0].Fixable, tt.fixable)
+			}
+		})
+	}
+}
+
+func TestWAG023_Check_Trusted(t *testing.T) {
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Steps = []any{
+	workflow.Step{Run: "echo ${{ github.event.pull_request.number }} ${{ github.sha }}"},
+	workflow.Step{Run: "echo ${{ contains(github.event.pull_request.title, 'wip') }}"},
+	workflow.Step{Run: "echo \"$TITLE\"", Env: workflow.Env{"TITLE": workflow.GitHub.Event("pull_request.title")}},
+	workflow.Step{Uses: "actions/checkout@v4", With: map[string]any{"ref": workflow.GitHub.HeadRef()}},
+}
+`
+	if issues := lintWAG023(t, src); len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+func TestWAG023_Check_SiblingFile(t *testing.T) {
+	dir := t.TempDir()
+	jobs := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Meta = workflow.Job{
+	Outputs: map[string]any{"branch": workflow.GitHub.HeadRef().String()},
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "jobs.go"), []byte(jobs), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Deploy = workflow.Step{
+	Run: "deploy ${{ needs.Meta.outputs.branch }}",
+}
+`
+	l := NewLinter(&WAG023{})
+	result, err := l.LintContent(filepath.Join(dir, "steps.go"), []byte(src))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0].Message, "github.head_ref via needs.Meta.outputs.branch") {
+		t.Errorf("Expected taint through the sibling job output, got %v", result.Issues)
+	}
+}
+
+func TestWAG023_Fix(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "new env",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Greet = workflow.Step{
+	Name: "Greet",
+	Run:  "echo '${{ github.event.pull_request.title }}' on ${{ github.head_ref }} by ${{ github.actor }}",
+}
+`,
+			want: []string{
+				`Run:  "echo \"$PULL_REQUEST_TITLE\" on \"$HEAD_REF\" by ${{ github.actor }}",`,
+				`"PULL_REQUEST_TITLE": workflow.GitHub.Event("pull_request.title"),`,
+				`"HEAD_REF":           workflow.GitHub.HeadRef(),`,
+			},
+		},
+		{
+			name: "existing env and expression operand",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Comment = workflow.Step{
+	Run: "echo " + workflow.GitHub.Event("comment.body").String(),
+	Env: workflow.Env{"BODY": "x"},
+}
+`,
+			want: []string{
+				`Run: "echo " + "\"$COMMENT_BODY\"",`,
+				`Env: workflow.Env{"BODY": "x", "COMMENT_BODY": workflow.GitHub.Event("comment.body")},`,
+			},
+		},
+		{
+			name: "inside double quotes",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Greet = workflow.Step{Run: "echo \"Title: ${{ github.event.issue.title }}\""}
+`,
+			want: []string{
+				`Run: "echo \"Title: ${ISSUE_TITLE}\""`,
+			},
+		},
+		{
+			name: "powershell",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Print = workflow.Step{Shell: "pwsh", Run: "Write-Host ${{ github.event.issue.title }}"}
+`,
+			want: []string{
+				`Run: "Write-Host $env:ISSUE_TITLE"`,
+				`Env: workflow.Env{"ISSUE_TITLE": workflow.GitHub.Event("issue.title")}`,
+			},
+		},
+		{
+			name: "github-script step",
+			src: `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Triage = workflow.Step{
+	Uses: "actions/github-script@v7",
+	With: map[string]any{
+		"script": "core.info('${{ github.event.issue.body }}')",
+	},
+}
+`,
+			want: []string{
+				`"script": "core.info(process.env.ISSUE_BODY)",`,
+				`"ISSUE_BODY": workflow.GitHub.Event("issue.body"),`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLinter(&WAG023{})
+			result, err := l.Fix(filepath.Join(t.TempDir(), "test.go"), []byte(tt.src))
+			if err != nil {
+				t.Fatalf("Fix() error = %v", err)
+			}
+			if result.FixedCount != 1 {
+				t.Errorf("FixedCount = %d, want 1; remaining %v", result.FixedCount, result.Issues)
+			}
+			out := string(result.Content)
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("fixed source missing %q:\n%s", want, out)
+				}
+			}
+
+			after := lintWAG023(t, out)
+			if len(after) != 0 {
+				t.Errorf("Expected no issues after fix, got %v", after)
+			}
+		})
+	}
+}
+
+func TestWAG023_Fix_MultipleSteps(t *testing.T) {
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Steps = []any{
+	workflow.Step{
+		Run: "echo ${{ github.event.issue.title }}",
+	},
+	workflow.Step{
+		Run: "echo ${{ github.event.issue.body }}",
+	},
+}
+`
+	l := NewLinter(&WAG023{})
+	result, err := l.Fix(filepath.Join(t.TempDir(), "test.go"), []byte(src))
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result.FixedCount != 2 {
+		t.Errorf("FixedCount = %d, want 2", result.FixedCount)
+	}
+	if after := lintWAG023(t, string(result.Content)); len(after) != 0 {
+		t.Errorf("Expected no issues after fix, got %v\n%s", after, result.Content)
+	}
+}
+
+func TestWAG023_Fix_NoStepAtLine(t *testing.T) {
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Steps = []any{
+	workflow.Step{Run: "echo ${{ github.event.issue.title }}"},
+}
+`
+	l := NewLinter(&WAG023{})
+	path := filepath.Join(t.TempDir(), "test.go")
+	issue := LintIssue{File: path, Line: 3, Rule: "WAG023", Fixable: true}
+	if _, err := l.FixIssue(path, []byte(src), issue); err == nil {
+		t.Error("FixIssue() should fail when no step is at the issue's line")
+	}
+}
+
+func TestWAG023_Fix_FormatNotFixed(t *testing.T) {
+	src := `package main
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var title = workflow.GitHub.Event("pull_request.title")
+
+var Greet = workflow.Step{
+	Run: workflow.Format("echo {0}", title).String(),
+}
+`
+	issues := lintWAG023(t, src)
+	if len(issues) != 1 || issues[0].Fixable {
+		t.Fatalf("Expected one unfixable issue, got %v", issues)
+	}
+
+	l := NewLinter(&WAG023{})
+	result, err := l.Fix(filepath.Join(t.TempDir(), "test.go"), []byte(src))
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if result.FixedCount != 0 || string(result.Content) != src {
+		t.Errorf("Fix() should leave the formatted script alone, got:\n%s", result.Content)
+	}
+}
@@ -0,0 +1,77 @@
+package lint
+
+// wrapperRefs maps typed action wrappers, by package and type name, to the
+// reference their Action method returns.
+var wrapperRefs = map[string]string{
+	"actions_rs_toolchain.Toolchain":                    "actions-rs/toolchain@v1",
+	"add_and_commit.AddAndCommit":                       "EndBug/add-and-commit@v9",
+	"add_to_project.AddToProject":                       "actions/add-to-project@v1",
+	"attest_build_provenance.AttestBuildProvenance":     "actions/attest-build-provenance@v1",
+	"aws_configure_credentials.AWSConfigureCredentials": "aws-actions/configure-aws-credentials@v4",
+	"aws_ecr_login.AWSECRLogin":                         "aws-actions/amazon-ecr-login@v2",
+	"azure_docker_login.AzureDockerLogin":               "azure/docker-login@v2",
+	"azure_login.AzureLogin":                            "azure/login@v2",
+	"azure_webapps_deploy.AzureWebappsDeploy":           "azure/webapps-deploy@v3",
+	"cache.Cache":                                  "actions/cache@v4",
+	"cargo.Cargo":                                  "actions-rs/cargo@v1",
+	"checkout.Checkout":                            "actions/checkout@v4",
+	"codecov.Codecov":                              "codecov/codecov-action@v5",
+	"codeql_analyze.CodeQLAnalyze":                 "github/codeql-action/analyze@v3",
+	"codeql_init.CodeQLInit":                       "github/codeql-action/init@v3",
+	"configure_pages.ConfigurePages":               "actions/configure-pages@v5",
+	"cosign_installer.CosignInstaller":             "sigstore/cosign-installer@v3",
+	"create_github_app_token.CreateGithubAppToken": "actions/create-github-app-token@v1",
+	"create_pull_request.CreatePullRequest":        "peter-evans/create-pull-request@v6",
+	"create_release.CreateRelease":                 "actions/create-release@v1",
+	"dawidd6_download_artifact.DownloadArtifact":   "dawidd6/action-download-artifact@v6",
+	"dependency_review.DependencyReview":           "actions/dependency-review-action@v4",
+	"deploy_pages.DeployPages":                     "actions/deploy-pages@v4",
+	"docker_build_push.DockerBuildPush":            "docker/build-push-action@v6",
+	"docker_login.DockerLogin":                     "docker/login-action@v3",
+	"docker_metadata.DockerMetadata":               "docker/metadata-action@v5",
+	"docker_setup_buildx.DockerSetupBuildx":        "docker/setup-buildx-action@v3",
+	"download_artifact.DownloadArtifact":           "actions/download-artifact@v4",
+	"first_interaction.FirstInteraction":           "actions/first-interaction@v1",
+	"fossa.Fossa":                                  "fossas/fossa-action@v1",
+	"gcp_auth.GCPAuth":                             "google-github-actions/auth@v2",
+	"gcp_deploy_cloudrun.GCPDeployCloudRun":        "google-github-actions/deploy-cloudrun@v2",
+	"gcp_setup_gcloud.GCPSetupGcloud":              "google-github-actions/setup-gcloud@v2",
+	"gh_pages_deploy.GitHubPagesDeploy":            "JamesIves/github-pages-deploy-action@v4",
+	"gh_pages_peaceiris.GHPagesPeaceiris":          "peaceiris/actions-gh-pages@v4",
+	"gh_release.GHRelease":                         "softprops/action-gh-release@v2",
+	"github_script.GithubScript":                   "actions/github-script@v7",
+	"github_tag_action.GitHubTagAction":            "anothrNick/github-tag-action@v1",
+	"golangci_lint.GolangciLint":                   "golangci/golangci-lint-action@v6",
+	"helm_chart_releaser.HelmChartReleaser":        "helm/chart-releaser-action@v1",
+	"hugo.Hugo":                                    "peaceiris/actions-hugo@v3",
+	"import_gpg.ImportGPG":                         "crazy-max/ghaction-import-gpg@v6",
+	"junit_report.JUnitReport":                     "mikepenz/action-junit-report@v4",
+	"k8s_set_context.K8sSetContext":                "azure/k8s-set-context@v4",
+	"kind.Kind":                                    "helm/kind-action@v1",
+	"kustomize.Kustomize":                          "stefanprodan/kustomize-action@master",
+	"labeler.Labeler":                              "actions/labeler@v5",
+	"ncipollo_release.NcipolloRelease":             "ncipollo/release-action@v1",
+	"pre_commit.PreCommit":                         "pre-commit/action@v3.0.1",
+	"pulumi.Pulumi":                                "pulumi/actions@v6",
+	"reviewdog.Reviewdog":                          "reviewdog/action-setup@v1",
+	"reviewdog.ReviewdogReporter":                  "reviewdog/action-reviewdog@v1",
+	"scorecard.Scorecard":                          "ossf/scorecard-action@v2.4.0",
+	"setup_dotnet.SetupDotnet":                     "actions/setup-dotnet@v4",
+	"setup_go.SetupGo":                             "actions/setup-go@v5",
+	"setup_helm.SetupHelm":                         "azure/setup-helm@v4",
+	"setup_java.SetupJava":                         "actions/setup-java@v4",
+	"setup_node.SetupNode":                         "actions/setup-node@v4",
+	"setup_python.SetupPython":                     "actions/setup-python@v5",
+	"setup_ruby.SetupRuby":                         "ruby/setup-ruby@v1",
+	"setup_rust.SetupRust":                         "dtolnay/rust-toolchain@stable",
+	"setup_terraform.SetupTerraform":               "hashicorp/setup-terraform@v3",
+	"slack.Slack":                                  "slackapi/slack-github-action@v1",
+	"sonarcloud.SonarCloud":                        "SonarSource/sonarcloud-github-action@v3",
+	"stale.Stale":                                  "actions/stale@v9",
+	"super_linter.SuperLinter":                     "super-linter/super-linter@v7",
+	"trivy.Trivy":                                  "aquasecurity/trivy-action@0.28.0",
+	"upload_artifact.UploadArtifact":               "actions/upload-artifact@v4",
+	"upload_pages_artifact.UploadPagesArtifact":    "actions/upload-pages-artifact@v3",
+	"upload_release_asset.UploadReleaseAsset":      "actions/upload-release-asset@v1",
+	"upload_sarif.UploadSarif":                     "github/codeql-action/upload-sarif@v3",
+}
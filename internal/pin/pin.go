@@ -0,0 +1,188 @@
+// Package pin maps action references to commit SHAs through a lock file.
+//
+// The lock file, wetwire-github.lock, lives next to go.mod and is written by
+// "wetwire-github pin" from a local source of truth: a directory of git
+// clones or a vendored JSON file. Build replaces each locked reference with
+// its SHA and keeps the version as a trailing comment:
+//
+//	uses: actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.2
+package pin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the lock file.
+const FileName = "wetwire-github.lock"
+
+// header is written at the top of every lock file.
+const header = `# Action references pinned to commit SHAs.
+# Generated by "wetwire-github pin"; do not edit by hand.
+`
+
+// Entry is the pinned commit of an action reference.
+type Entry struct {
+	// SHA is the full commit SHA the reference resolved to.
+	SHA string `yaml:"sha"`
+
+	// Version is the most specific tag on the commit, e.g. "v4.2.2".
+	Version string `yaml:"version,omitempty"`
+}
+
+// Lock maps action references such as "actions/checkout@v4" to their
+// pinned commits.
+type Lock struct {
+	// Actions holds the pinned references.
+	Actions map[string]Entry `yaml:"actions"`
+
+	// Path is the file the lock was loaded from.
+	Path string `yaml:"-"`
+}
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// IsSHA reports whether ref is a full commit SHA.
+func IsSHA(ref string) bool {
+	return shaPattern.MatchString(ref)
+}
+
+// Pinnable reports whether uses refers to a remote action or reusable
+// workflow by tag or branch. Local paths, Docker images and references
+// already pinned to a SHA are not pinnable.
+func Pinnable(uses string) bool {
+	if uses == "" || strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+		return false
+	}
+	if strings.Contains(uses, "${{") || strings.ContainsAny(uses, " \t") {
+		return false
+	}
+	repo, ref, ok := Split(uses)
+	return ok && repo != "" && !IsSHA(ref)
+}
+
+// Split divides a reference such as "github/codeql-action/analyze@v3" into
+// the repository ("github/codeql-action") and the git ref ("v3").
+func Split(uses string) (repo, ref string, ok bool) {
+	path, ref, ok := strings.Cut(uses, "@")
+	if !ok || ref == "" {
+		return "", "", false
+	}
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0] + "/" + parts[1], ref, true
+}
+
+// Load reads a lock file.
+func Load(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lock{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if lock.Actions == nil {
+		lock.Actions = make(map[string]Entry)
+	}
+	for ref, entry := range lock.Actions {
+		if !IsSHA(entry.SHA) {
+			return nil, fmt.Errorf("%s: %s: %q is not a full commit SHA", path, ref, entry.SHA)
+		}
+	}
+	lock.Path = path
+	return lock, nil
+}
+
+// Find looks for a lock file in dir and its parents, stopping at the
+// directory containing go.mod. It returns nil if there is none.
+func Find(dir string) (*Lock, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Save writes the lock file to path with references in sorted order.
+func (l *Lock) Save(path string) error {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(l); err != nil {
+		return fmt.Errorf("encoding lock file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encoding lock file: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	l.Path = path
+	return nil
+}
+
+// Refs returns the locked references in sorted order.
+func (l *Lock) Refs() []string {
+	refs := make([]string, 0, len(l.Actions))
+	for ref := range l.Actions {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// Has reports whether uses is locked. A nil lock has no entries.
+func (l *Lock) Has(uses string) bool {
+	if l == nil {
+		return false
+	}
+	_, ok := l.Actions[uses]
+	return ok
+}
+
+// Apply returns the pinned form of uses, "owner/repo@<sha> # <version>",
+// or uses unchanged if it is not locked.
+func (l *Lock) Apply(uses string) string {
+	if l == nil {
+		return uses
+	}
+	entry, ok := l.Actions[uses]
+	if !ok {
+		return uses
+	}
+	path, ref, _ := strings.Cut(uses, "@")
+	comment := entry.Version
+	if comment == "" {
+		comment = ref
+	}
+	return path + "@" + entry.SHA + " # " + comment
+}
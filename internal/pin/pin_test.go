@@ -0,0 +1,121 @@
+package pin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSHA = "11bd71901bbe5b1630ceea73d27597364c9af683"
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		uses      string
+		repo, ref string
+		ok        bool
+	}{
+		{"actions/checkout@v4", "actions/checkout", "v4", true},
+		{"github/codeql-action/analyze@v3", "github/codeql-action", "v3", true},
+		{"org/repo/.github/workflows/ci.yml@main", "org/repo", "main", true},
+		{"actions/checkout", "", "", false},
+		{"checkout@v4", "", "", false},
+	}
+
+	for _, tt := range tests {
+		repo, ref, ok := Split(tt.uses)
+		if repo != tt.repo || ref != tt.ref || ok != tt.ok {
+			t.Errorf("Split(%q) = %q, %q, %v; want %q, %q, %v", tt.uses, repo, ref, ok, tt.repo, tt.ref, tt.ok)
+		}
+	}
+}
+
+func TestPinnable(t *testing.T) {
+	tests := map[string]bool{
+		"actions/checkout@v4":                  true,
+		"org/repo/.github/workflows/ci.yml@v1": true,
+		"actions/checkout@" + testSHA:          false,
+		"./.github/actions/setup":              false,
+		"docker://alpine:3":                    false,
+		"${{ matrix.action }}":                 false,
+		"":                                     false,
+	}
+	for uses, want := range tests {
+		if got := Pinnable(uses); got != want {
+			t.Errorf("Pinnable(%q) = %v, want %v", uses, got, want)
+		}
+	}
+}
+
+func TestLock_Apply(t *testing.T) {
+	lock := &Lock{Actions: map[string]Entry{
+		"actions/checkout@v4":             {SHA: testSHA, Version: "v4.2.2"},
+		"github/codeql-action/analyze@v3": {SHA: testSHA},
+	}}
+
+	tests := map[string]string{
+		"actions/checkout@v4":             "actions/checkout@" + testSHA + " # v4.2.2",
+		"github/codeql-action/analyze@v3": "github/codeql-action/analyze@" + testSHA + " # v3",
+		"actions/setup-go@v5":             "actions/setup-go@v5",
+	}
+	for uses, want := range tests {
+		if got := lock.Apply(uses); got != want {
+			t.Errorf("Apply(%q) = %q, want %q", uses, got, want)
+		}
+	}
+
+	var nilLock *Lock
+	if got := nilLock.Apply("actions/checkout@v4"); got != "actions/checkout@v4" {
+		t.Errorf("nil lock Apply() = %q", got)
+	}
+}
+
+func TestLock_SaveLoadFind(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(dir, "workflows")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if lock, err := Find(sub); err != nil || lock != nil {
+		t.Fatalf("Find() without a lock file = %v, %v", lock, err)
+	}
+
+	lock := &Lock{Actions: map[string]Entry{
+		"actions/setup-go@v5": {SHA: testSHA, Version: "v5.1.0"},
+		"actions/checkout@v4": {SHA: testSHA, Version: "v4.2.2"},
+	}}
+	path := filepath.Join(dir, FileName)
+	if err := lock.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "# Action references pinned") {
+		t.Errorf("lock file should start with a header:\n%s", data)
+	}
+	if strings.Index(string(data), "actions/checkout@v4") > strings.Index(string(data), "actions/setup-go@v5") {
+		t.Errorf("lock file entries should be sorted:\n%s", data)
+	}
+
+	found, err := Find(sub)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found == nil || found.Path != path || found.Actions["actions/checkout@v4"].Version != "v4.2.2" {
+		t.Errorf("Find() = %+v", found)
+	}
+}
+
+func TestLoad_InvalidSHA(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("actions:\n  actions/checkout@v4:\n    sha: v4\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "not a full commit SHA") {
+		t.Errorf("Load() error = %v, want invalid SHA error", err)
+	}
+}
@@ -0,0 +1,212 @@
+package pin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/git"
+)
+
+// Resolver resolves a git ref of an action repository to a commit.
+type Resolver interface {
+	// Resolve returns the entry for ref in repo ("owner/repo").
+	Resolve(repo, ref string) (Entry, error)
+}
+
+// Mirror resolves refs from git clones in a directory laid out as
+// <dir>/<owner>/<repo> or <dir>/<owner>/<repo>.git. Bare mirrors made with
+// "git clone --mirror" and regular clones both work.
+type Mirror struct {
+	Dir string
+}
+
+// Resolve implements Resolver.
+func (m Mirror) Resolve(repo, ref string) (Entry, error) {
+	gitDir, err := m.repoDir(repo)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	var sha string
+	for _, candidate := range []string{ref, "origin/" + ref} {
+		if out, err := git.Run(gitDir, "rev-parse", "--verify", "--quiet", candidate+"^{commit}"); err == nil {
+			sha = out
+			break
+		}
+	}
+	if sha == "" {
+		return Entry{}, fmt.Errorf("%s: ref %q not found in %s", repo, ref, gitDir)
+	}
+
+	tags, err := git.Run(gitDir, "tag", "--points-at", sha)
+	if err != nil {
+		return Entry{}, fmt.Errorf("%s: listing tags: %w", repo, err)
+	}
+	return Entry{SHA: sha, Version: bestVersion(ref, strings.Fields(tags))}, nil
+}
+
+func (m Mirror) repoDir(repo string) (string, error) {
+	base := filepath.Join(m.Dir, filepath.FromSlash(repo))
+	for _, dir := range []string{base, base + ".git"} {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("%s: no clone in %s", repo, m.Dir)
+}
+
+// Vendored resolves refs from a JSON file mapping repositories to their
+// tags and commit SHAs:
+//
+//	{
+//	  "actions/checkout": {
+//	    "v4": "11bd71901bbe5b1630ceea73d27597364c9af683",
+//	    "v4.2.2": "11bd71901bbe5b1630ceea73d27597364c9af683"
+//	  }
+//	}
+type Vendored map[string]map[string]string
+
+// LoadVendored reads a vendored JSON file.
+func LoadVendored(path string) (Vendored, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var v Vendored
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// Resolve implements Resolver.
+func (v Vendored) Resolve(repo, ref string) (Entry, error) {
+	tags, ok := v[repo]
+	if !ok {
+		for name, t := range v {
+			if strings.EqualFold(name, repo) {
+				tags, ok = t, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return Entry{}, fmt.Errorf("%s: not in vendored refs", repo)
+	}
+
+	sha, ok := tags[ref]
+	if !ok {
+		return Entry{}, fmt.Errorf("%s: ref %q not in vendored refs", repo, ref)
+	}
+	if !IsSHA(sha) {
+		return Entry{}, fmt.Errorf("%s@%s: %q is not a full commit SHA", repo, ref, sha)
+	}
+
+	var same []string
+	for tag, s := range tags {
+		if s == sha {
+			same = append(same, tag)
+		}
+	}
+	return Entry{SHA: sha, Version: bestVersion(ref, same)}, nil
+}
+
+// Resolvers tries each resolver in order.
+type Resolvers []Resolver
+
+// Resolve implements Resolver.
+func (rs Resolvers) Resolve(repo, ref string) (Entry, error) {
+	var errs []string
+	for _, r := range rs {
+		entry, err := r.Resolve(repo, ref)
+		if err == nil {
+			return entry, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	if len(errs) == 0 {
+		return Entry{}, fmt.Errorf("%s@%s: no source to resolve from", repo, ref)
+	}
+	return Entry{}, fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// bestVersion picks the most specific tag for a commit: the tag extending
+// ref (v4 → v4.2.2) with the most components, else the most specific of all
+// tags. It returns "" when no tag points at the commit.
+func bestVersion(ref string, tags []string) string {
+	var extending []string
+	for _, tag := range tags {
+		if tag == ref || strings.HasPrefix(tag, ref+".") {
+			extending = append(extending, tag)
+		}
+	}
+	if len(extending) > 0 {
+		return mostSpecific(extending)
+	}
+	return mostSpecific(tags)
+}
+
+func mostSpecific(tags []string) string {
+	sort.Strings(tags)
+	best := ""
+	for _, tag := range tags {
+		if best == "" || strings.Count(tag, ".") > strings.Count(best, ".") {
+			best = tag
+		}
+	}
+	return best
+}
+
+// Update resolves each reference missing from the lock, or every reference
+// when all is true, and records the result. It returns the references that
+// changed and the errors for references that could not be resolved.
+func (l *Lock) Update(refs []string, r Resolver, all bool) (changed []string, errs []error) {
+	if l.Actions == nil {
+		l.Actions = make(map[string]Entry)
+	}
+
+	seen := make(map[string]bool)
+	for _, uses := range refs {
+		if seen[uses] || !Pinnable(uses) {
+			continue
+		}
+		seen[uses] = true
+
+		if _, ok := l.Actions[uses]; ok && !all {
+			continue
+		}
+
+		repo, ref, _ := Split(uses)
+		entry, err := r.Resolve(repo, ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if old, ok := l.Actions[uses]; !ok || old != entry {
+			l.Actions[uses] = entry
+			changed = append(changed, uses)
+		}
+	}
+	sort.Strings(changed)
+	return changed, errs
+}
+
+// Prune removes entries for references not in refs and returns them.
+func (l *Lock) Prune(refs []string) []string {
+	keep := make(map[string]bool, len(refs))
+	for _, uses := range refs {
+		keep[uses] = true
+	}
+	var removed []string
+	for _, uses := range l.Refs() {
+		if !keep[uses] {
+			delete(l.Actions, uses)
+			removed = append(removed, uses)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,115 @@
+package pin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVendored_Resolve(t *testing.T) {
+	v := Vendored{
+		"actions/checkout": {
+			"v4":     testSHA,
+			"v4.2":   testSHA,
+			"v4.2.2": testSHA,
+			"v3":     strings.Repeat("a", 40),
+		},
+	}
+
+	entry, err := v.Resolve("actions/checkout", "v4")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if entry != (Entry{SHA: testSHA, Version: "v4.2.2"}) {
+		t.Errorf("Resolve() = %+v", entry)
+	}
+
+	if _, err := v.Resolve("actions/checkout", "v5"); err == nil {
+		t.Error("Resolve() should fail for an unknown ref")
+	}
+	if _, err := v.Resolve("actions/cache", "v4"); err == nil {
+		t.Error("Resolve() should fail for an unknown repository")
+	}
+}
+
+func TestMirror_Resolve(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	mirror := t.TempDir()
+	repo := filepath.Join(mirror, "actions", "checkout")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "release")
+	git("tag", "v4.2.2")
+	git("tag", "v4")
+	sha := git("rev-parse", "HEAD")
+
+	entry, err := Mirror{Dir: mirror}.Resolve("actions/checkout", "v4")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if entry != (Entry{SHA: sha, Version: "v4.2.2"}) {
+		t.Errorf("Resolve() = %+v, want %s v4.2.2", entry, sha)
+	}
+
+	if _, err := (Mirror{Dir: mirror}).Resolve("actions/cache", "v4"); err == nil {
+		t.Error("Resolve() should fail without a clone")
+	}
+}
+
+func TestLock_UpdateAndPrune(t *testing.T) {
+	other := strings.Repeat("b", 40)
+	lock := &Lock{Actions: map[string]Entry{
+		"actions/checkout@v4": {SHA: other, Version: "v4.0.0"},
+		"actions/cache@v3":    {SHA: other},
+	}}
+	v := Vendored{
+		"actions/checkout": {"v4": testSHA, "v4.2.2": testSHA},
+		"actions/setup-go": {"v5": testSHA, "v5.1.0": testSHA},
+	}
+	refs := []string{"actions/checkout@v4", "actions/setup-go@v5", "example/missing@v1", "./local"}
+
+	changed, errs := lock.Update(refs, v, false)
+	if !reflect.DeepEqual(changed, []string{"actions/setup-go@v5"}) {
+		t.Errorf("Update() changed = %v", changed)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "example/missing") {
+		t.Errorf("Update() errs = %v", errs)
+	}
+	if lock.Actions["actions/checkout@v4"].SHA != other {
+		t.Error("Update() without all should keep existing entries")
+	}
+
+	changed, _ = lock.Update(refs, v, true)
+	if !reflect.DeepEqual(changed, []string{"actions/checkout@v4"}) {
+		t.Errorf("Update(all) changed = %v", changed)
+	}
+
+	removed := lock.Prune(refs)
+	if !reflect.DeepEqual(removed, []string{"actions/cache@v3"}) {
+		t.Errorf("Prune() = %v", removed)
+	}
+	if !reflect.DeepEqual(lock.Refs(), []string{"actions/checkout@v4", "actions/setup-go@v5"}) {
+		t.Errorf("Refs() = %v", lock.Refs())
+	}
+}
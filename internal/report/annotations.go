@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Annotations returns the report as GitHub Actions workflow commands, one
+// per finding:
+//
+//	::error file=workflows/jobs.go,line=12,col=2,title=WAG023::untrusted ...
+//
+// Run inside a workflow, each command annotates the line it names in the
+// pull request diff. Info findings become notices.
+func (r *Report) Annotations() string {
+	var sb strings.Builder
+	for _, f := range r.Findings {
+		var props []string
+		if f.File != "" {
+			props = append(props, "file="+escapeProperty(r.relPath(f.File)))
+			if f.Line > 0 {
+				props = append(props, fmt.Sprintf("line=%d", f.Line))
+			}
+			if f.Column > 0 {
+				props = append(props, fmt.Sprintf("col=%d", f.Column))
+			}
+		}
+		if f.Rule != "" {
+			props = append(props, "title="+escapeProperty(f.Rule))
+		}
+
+		sb.WriteString("::")
+		sb.WriteString(annotationCommand(f.Severity))
+		if len(props) > 0 {
+			sb.WriteString(" ")
+			sb.WriteString(strings.Join(props, ","))
+		}
+		sb.WriteString("::")
+		sb.WriteString(escapeData(f.Message))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// annotationCommand returns the workflow command for a severity.
+func annotationCommand(severity string) string {
+	switch strings.ToLower(severity) {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// escapeData escapes a workflow command message.
+func escapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeProperty escapes a workflow command property value.
+func escapeProperty(s string) string {
+	s = escapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
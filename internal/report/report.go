@@ -0,0 +1,203 @@
+// Package report renders lint and validation findings for CI systems: as a
+// SARIF 2.1.0 log for code scanning, or as GitHub Actions workflow commands
+// that annotate the changed files of a pull request.
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Formats lists the output formats rendered by this package.
+var Formats = []string{"sarif", "github"}
+
+// IsFormat reports whether format is rendered by this package rather than
+// the generic result formatter.
+func IsFormat(format string) bool {
+	for _, f := range Formats {
+		if strings.EqualFold(f, format) {
+			return true
+		}
+	}
+	return false
+}
+
+// Report is the findings of one tool run.
+type Report struct {
+	// Tool is the name of the tool, e.g. "wetwire-github lint".
+	Tool string
+
+	// Version is the tool version.
+	Version string
+
+	// InformationURI documents the tool.
+	InformationURI string
+
+	// Rules describes the rules findings may refer to.
+	Rules []Rule
+
+	// Findings are the issues found, in report order.
+	Findings []Finding
+
+	// BaseDir is the directory file paths are made relative to, usually
+	// the repository root. Empty means the working directory.
+	BaseDir string
+}
+
+// Rule describes a rule.
+type Rule struct {
+	ID          string
+	Description string
+	HelpURI     string
+}
+
+// Finding is a single issue.
+type Finding struct {
+	File    string
+	Line    int
+	Column  int
+	Rule    string
+	Message string
+
+	// Severity is "error", "warning" or "info".
+	Severity string
+
+	// Fix is a suggested change that resolves the finding, if any.
+	Fix *Fix
+}
+
+// Fix is a suggested change to the file of a finding.
+type Fix struct {
+	Description  string
+	Replacements []Replacement
+}
+
+// Replacement replaces the text between two positions with Text. Lines
+// and columns are 1-based; columns count Unicode code points, and the end
+// position is exclusive.
+type Replacement struct {
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+	Text        string
+}
+
+// NewFix returns the fix that turns before into after, as a single
+// replacement of the region where they differ. It returns nil if they are
+// equal.
+func NewFix(description string, before, after []byte) *Fix {
+	if string(before) == string(after) {
+		return nil
+	}
+
+	old, updated := []rune(string(before)), []rune(string(after))
+	prefix := 0
+	for prefix < len(old) && prefix < len(updated) && old[prefix] == updated[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(updated)-prefix &&
+		old[len(old)-1-suffix] == updated[len(updated)-1-suffix] {
+		suffix++
+	}
+
+	startLine, startColumn := position(old, prefix)
+	endLine, endColumn := position(old, len(old)-suffix)
+	return &Fix{
+		Description: description,
+		Replacements: []Replacement{{
+			StartLine:   startLine,
+			StartColumn: startColumn,
+			EndLine:     endLine,
+			EndColumn:   endColumn,
+			Text:        string(updated[prefix : len(updated)-suffix]),
+		}},
+	}
+}
+
+// position returns the line and column of offset in text.
+func position(text []rune, offset int) (line, column int) {
+	line, column = 1, 1
+	for _, r := range text[:offset] {
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// AddRule adds a rule unless one with the same ID is already described.
+func (r *Report) AddRule(rule Rule) {
+	for _, existing := range r.Rules {
+		if existing.ID == rule.ID {
+			return
+		}
+	}
+	r.Rules = append(r.Rules, rule)
+}
+
+// Render renders the report in format, one of Formats.
+func (r *Report) Render(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "sarif":
+		data, err := r.SARIF()
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case "github":
+		return r.Annotations(), nil
+	}
+	return "", fmt.Errorf("unsupported report format: %s (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+// relPath returns path relative to the base directory with forward
+// slashes, or as an absolute path if it lies outside it.
+func (r *Report) relPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	base := r.BaseDir
+	if base == "" {
+		base = "."
+	}
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(absPath)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// undescribedRules returns the IDs used by findings that no rule describes,
+// sorted.
+func (r *Report) undescribedRules() []string {
+	described := make(map[string]bool, len(r.Rules))
+	for _, rule := range r.Rules {
+		described[rule.ID] = true
+	}
+	seen := make(map[string]bool)
+	var ids []string
+	for _, f := range r.Findings {
+		if f.Rule == "" || described[f.Rule] || seen[f.Rule] {
+			continue
+		}
+		seen[f.Rule] = true
+		ids = append(ids, f.Rule)
+	}
+	sort.Strings(ids)
+	return ids
+}
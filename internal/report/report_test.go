@@ -0,0 +1,143 @@
+package report
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFix(t *testing.T) {
+	before := "package main\n\nvar X = \"héllo\"\n"
+	after := "package main\n\nvar X = \"héllo, world\"\n"
+
+	fix := NewFix("append", []byte(before), []byte(after))
+	if fix == nil || len(fix.Replacements) != 1 {
+		t.Fatalf("NewFix() = %+v", fix)
+	}
+	want := Replacement{StartLine: 3, StartColumn: 15, EndLine: 3, EndColumn: 15, Text: ", world"}
+	if fix.Replacements[0] != want {
+		t.Errorf("Replacement = %+v, want %+v", fix.Replacements[0], want)
+	}
+
+	if NewFix("none", []byte(before), []byte(before)) != nil {
+		t.Error("NewFix() of equal content should be nil")
+	}
+}
+
+func TestNewFix_Multiline(t *testing.T) {
+	before := "a\nb\nc\n"
+	after := "a\nx\ny\nc\n"
+
+	fix := NewFix("replace", []byte(before), []byte(after))
+	want := Replacement{StartLine: 2, StartColumn: 1, EndLine: 2, EndColumn: 2, Text: "x\ny"}
+	if fix.Replacements[0] != want {
+		t.Errorf("Replacement = %+v, want %+v", fix.Replacements[0], want)
+	}
+}
+
+func testReport(dir string) *Report {
+	return &Report{
+		Tool:    "wetwire-github",
+		Version: "1.2.3",
+		BaseDir: dir,
+		Rules: []Rule{
+			{ID: "WAG014", Description: "Jobs should have timeout-minutes set"},
+			{ID: "WAG023", Description: "Detect untrusted contexts interpolated into run scripts"},
+		},
+		Findings: []Finding{
+			{
+				File:     filepath.Join(dir, "workflows", "jobs.go"),
+				Line:     12,
+				Column:   2,
+				Rule:     "WAG023",
+				Message:  "untrusted github.head_ref interpolated into run script",
+				Severity: "error",
+				Fix: &Fix{
+					Description:  "Apply the WAG023 auto-fix",
+					Replacements: []Replacement{{StartLine: 12, StartColumn: 10, EndLine: 12, EndColumn: 30, Text: "${HEAD_REF}"}},
+				},
+			},
+			{File: filepath.Join(dir, "jobs.go"), Line: 4, Rule: "unused-suppression", Message: "100% unused,\nreally", Severity: "info"},
+		},
+	}
+}
+
+func TestReport_SARIF(t *testing.T) {
+	dir := t.TempDir()
+	data, err := testReport(dir).SARIF()
+	if err != nil {
+		t.Fatalf("SARIF() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	run := log.Runs[0]
+
+	var ids []string
+	for _, rule := range run.Tool.Driver.Rules {
+		ids = append(ids, rule.ID)
+	}
+	if got := strings.Join(ids, " "); got != "WAG014 WAG023 unused-suppression" {
+		t.Errorf("rules = %s, want described rules followed by undescribed ones", got)
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("results = %+v", run.Results)
+	}
+	first := run.Results[0]
+	if first.RuleIndex != 1 || first.Level != "error" {
+		t.Errorf("first result = %+v", first)
+	}
+	loc := first.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "workflows/jobs.go" || loc.Region.StartLine != 12 || loc.Region.StartColumn != 2 {
+		t.Errorf("location = %+v", loc)
+	}
+	if len(first.Fixes) != 1 {
+		t.Fatalf("fixes = %+v", first.Fixes)
+	}
+	rep := first.Fixes[0].ArtifactChanges[0].Replacements[0]
+	if rep.DeletedRegion.StartColumn != 10 || rep.DeletedRegion.EndColumn != 30 || rep.InsertedContent.Text != "${HEAD_REF}" {
+		t.Errorf("replacement = %+v", rep)
+	}
+
+	if second := run.Results[1]; second.Level != "note" || second.RuleIndex != 2 || second.Fixes != nil {
+		t.Errorf("second result = %+v", second)
+	}
+}
+
+func TestReport_Annotations(t *testing.T) {
+	dir := t.TempDir()
+	got := testReport(dir).Annotations()
+	want := "::error file=workflows/jobs.go,line=12,col=2,title=WAG023::untrusted github.head_ref interpolated into run script\n" +
+		"::notice file=jobs.go,line=4,title=unused-suppression::100%25 unused,%0Areally\n"
+	if got != want {
+		t.Errorf("Annotations() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReport_PathOutsideBaseDir(t *testing.T) {
+	r := &Report{BaseDir: t.TempDir()}
+	outside := filepath.Join(filepath.Dir(r.BaseDir), "other", "a.go")
+	if got := r.uri(outside); got != "file://"+filepath.ToSlash(outside) {
+		t.Errorf("uri() = %q", got)
+	}
+}
+
+func TestReport_Render(t *testing.T) {
+	r := &Report{Tool: "wetwire-github"}
+	if out, err := r.Render("github"); err != nil || out != "" {
+		t.Errorf("Render(github) = %q, %v; want no annotations", out, err)
+	}
+	if out, err := r.Render("SARIF"); err != nil || !strings.Contains(out, `"results": []`) {
+		t.Errorf("Render(SARIF) = %q, %v", out, err)
+	}
+	if _, err := r.Render("xml"); err == nil {
+		t.Error("Render(xml) should fail")
+	}
+}
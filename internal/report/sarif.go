@@ -0,0 +1,194 @@
+package report
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+)
+
+// SARIF schema and version written by SARIF.
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// sarifLog is the root of a SARIF 2.1.0 log. Only the properties used by
+// code scanning are modeled.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+// SARIF returns the report as a SARIF 2.1.0 log with a single run. Every
+// rule is listed in the tool metadata, and findings with a fix carry it
+// as a SARIF fix on the finding's file.
+func (r *Report) SARIF() ([]byte, error) {
+	driver := sarifDriver{
+		Name:           r.Tool,
+		Version:        r.Version,
+		InformationURI: r.InformationURI,
+		Rules:          []sarifRule{},
+	}
+	index := make(map[string]int)
+	addRule := func(rule Rule) {
+		index[rule.ID] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               rule.ID,
+			ShortDescription: sarifMessage{Text: rule.Description},
+			HelpURI:          rule.HelpURI,
+		})
+	}
+	for _, rule := range r.Rules {
+		addRule(rule)
+	}
+	for _, id := range r.undescribedRules() {
+		addRule(Rule{ID: id, Description: id})
+	}
+
+	results := make([]sarifResult, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		result := sarifResult{
+			RuleID:    f.Rule,
+			RuleIndex: index[f.Rule],
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: f.Message},
+		}
+
+		if f.File != "" {
+			artifact := sarifArtifactLocation{URI: r.uri(f.File)}
+			loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact}}
+			if f.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+			}
+			result.Locations = []sarifLocation{loc}
+
+			if f.Fix != nil {
+				change := sarifArtifactChange{ArtifactLocation: artifact}
+				for _, rep := range f.Fix.Replacements {
+					change.Replacements = append(change.Replacements, sarifReplacement{
+						DeletedRegion: sarifRegion{
+							StartLine:   rep.StartLine,
+							StartColumn: rep.StartColumn,
+							EndLine:     rep.EndLine,
+							EndColumn:   rep.EndColumn,
+						},
+						InsertedContent: &sarifMessage{Text: rep.Text},
+					})
+				}
+				result.Fixes = []sarifFix{{
+					Description:     sarifMessage{Text: f.Fix.Description},
+					ArtifactChanges: []sarifArtifactChange{change},
+				}}
+			}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:       sarifTool{Driver: driver},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// uri returns the SARIF artifact URI of path: relative to the base
+// directory, or a file URI outside it.
+func (r *Report) uri(path string) string {
+	rel := r.relPath(path)
+	if !filepath.IsAbs(filepath.FromSlash(rel)) {
+		return rel
+	}
+	if !strings.HasPrefix(rel, "/") {
+		rel = "/" + rel
+	}
+	return "file://" + rel
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "note"
+	}
+}
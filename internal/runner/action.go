@@ -0,0 +1,207 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+)
+
+// ExtractedAction contains the extracted values for an action.
+type ExtractedAction struct {
+	Name string         `json:"name"`
+	Data map[string]any `json:"data"`
+}
+
+// ActionExtractionResult contains all extracted actions.
+type ActionExtractionResult struct {
+	Actions []ExtractedAction `json:"actions"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// ExtractActions extracts values from discovered actions.
+func (r *Runner) ExtractActions(dir string, discovered *discover.ActionDiscoveryResult) (*ActionExtractionResult, error) {
+	if len(discovered.Actions) == 0 {
+		return &ActionExtractionResult{
+			Actions: []ExtractedAction{},
+		}, nil
+	}
+
+	// Get absolute path for consistent path handling
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving path: %w", err)
+	}
+
+	// Parse go.mod to get module path
+	modulePath, err := r.parseGoMod(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go.mod: %w", err)
+	}
+
+	// Generate the temporary extraction program
+	program, err := r.generateActionProgram(modulePath, absDir, discovered)
+	if err != nil {
+		return nil, fmt.Errorf("generating program: %w", err)
+	}
+
+	// Create temp directory for the program
+	tempDir, err := os.MkdirTemp(r.TempDir, "wetwire-extract-action-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Write the program
+	programPath := filepath.Join(tempDir, "main.go")
+	if err := os.WriteFile(programPath, []byte(program), 0644); err != nil {
+		return nil, fmt.Errorf("writing program: %w", err)
+	}
+
+	// Write go.mod with replace directive
+	goMod := r.generateGoMod(modulePath, dir)
+	goModPath := filepath.Join(tempDir, "go.mod")
+	if err := os.WriteFile(goModPath, []byte(goMod), 0644); err != nil {
+		return nil, fmt.Errorf("writing go.mod: %w", err)
+	}
+
+	// Run go mod tidy
+	tidyCmd := exec.Command(r.GoPath, "mod", "tidy")
+	tidyCmd.Dir = tempDir
+	if output, err := tidyCmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("go mod tidy: %w\n%s", err, output)
+	}
+
+	// Execute the program
+	runCmd := exec.Command(r.GoPath, "run", "main.go")
+	runCmd.Dir = tempDir
+	output, err := runCmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("running extraction: %w\n%s", err, output)
+	}
+
+	// Parse the JSON output
+	var result ActionExtractionResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("parsing output: %w\nOutput: %s", err, output)
+	}
+
+	return &result, nil
+}
+
+// generateActionProgram creates the extraction program for actions.
+func (r *Runner) generateActionProgram(modulePath, baseDir string, discovered *discover.ActionDiscoveryResult) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString(`package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+`)
+
+	// Import the user's package
+	packages := make(map[string]bool)
+	for _, a := range discovered.Actions {
+		pkgPath := r.getPackagePath(modulePath, baseDir, a.File)
+		packages[pkgPath] = true
+	}
+
+	for pkgPath := range packages {
+		alias := r.pkgAlias(pkgPath)
+		sb.WriteString(fmt.Sprintf("\t%s %q\n", alias, pkgPath))
+	}
+
+	sb.WriteString(`)
+
+type ActionExtractionResult struct {
+	Actions []ExtractedAction ` + "`json:\"actions\"`" + `
+}
+
+type ExtractedAction struct {
+	Name string         ` + "`json:\"name\"`" + `
+	Data map[string]any ` + "`json:\"data\"`" + `
+}
+
+func toMap(v any) map[string]any {
+	result := make(map[string]any)
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return result
+	}
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		result[field.Name] = val.Field(i).Interface()
+	}
+	return result
+}
+
+// stepAction matches typed action wrappers (workflow.StepAction).
+type stepAction interface {
+	Action() string
+	Inputs() map[string]any
+}
+
+// actionToMap converts an action to a map, replacing action wrapper steps
+// of composite actions with their uses/with form so they survive JSON
+// encoding.
+func actionToMap(v any) map[string]any {
+	result := toMap(v)
+	runs := toMap(result["Runs"])
+	steps, ok := runs["Steps"].([]any)
+	if !ok {
+		return result
+	}
+	converted := make([]any, len(steps))
+	for i, step := range steps {
+		if a, ok := step.(stepAction); ok {
+			converted[i] = map[string]any{"Uses": a.Action(), "With": a.Inputs()}
+			continue
+		}
+		converted[i] = step
+	}
+	runs["Steps"] = converted
+	result["Runs"] = runs
+	return result
+}
+
+func main() {
+	result := ActionExtractionResult{
+		Actions: []ExtractedAction{},
+	}
+
+`)
+
+	// Add action extractions
+	for _, a := range discovered.Actions {
+		alias := r.pkgAlias(r.getPackagePath(modulePath, baseDir, a.File))
+		sb.WriteString(fmt.Sprintf("\tresult.Actions = append(result.Actions, ExtractedAction{Name: %q, Data: actionToMap(%s.%s)})\n",
+			a.Name, alias, a.Name))
+	}
+
+	sb.WriteString(`
+	data, err := json.Marshal(result)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling result: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+`)
+
+	return sb.String(), nil
+}
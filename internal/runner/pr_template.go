@@ -13,8 +13,9 @@ import (
 
 // ExtractedPRTemplate contains the extracted values for a PRTemplate.
 type ExtractedPRTemplate struct {
-	Name    string `json:"name"`
-	Content string `json:"content"`
+	Name         string `json:"name"`
+	TemplateName string `json:"template_name,omitempty"`
+	Content      string `json:"content"`
 }
 
 // PRTemplateExtractionResult contains all extracted PRTemplates.
@@ -111,8 +112,8 @@ func (r *Runner) generatePRTemplateProgram(modulePath, baseDir string, discovere
 		imports = append(imports, fmt.Sprintf(`%s "%s"`, alias, pkgPath))
 
 		for _, tmpl := range templates {
-			vars = append(vars, fmt.Sprintf(`{Name: "%s", Content: %s.%s.Content}`,
-				tmpl.Name, alias, tmpl.Name))
+			vars = append(vars, fmt.Sprintf(`{Name: "%s", TemplateName: %s.%s.Name, Content: %s.%s.Content}`,
+				tmpl.Name, alias, tmpl.Name, alias, tmpl.Name))
 		}
 	}
 
@@ -125,8 +126,9 @@ import (
 )
 
 type ExtractedPRTemplate struct {
-	Name    string ` + "`json:\"name\"`" + `
-	Content string ` + "`json:\"content\"`" + `
+	Name         string ` + "`json:\"name\"`" + `
+	TemplateName string ` + "`json:\"template_name,omitempty\"`" + `
+	Content      string ` + "`json:\"content\"`" + `
 }
 
 type PRTemplateExtractionResult struct {
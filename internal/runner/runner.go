@@ -48,6 +48,10 @@ type ExtractionResult struct {
 	Workflows []ExtractedWorkflow `json:"workflows"`
 	Jobs      []ExtractedJob      `json:"jobs"`
 	Error     string              `json:"error,omitempty"`
+
+	// JobRefs maps the placeholder IDs returned by workflow.JobRef to job
+	// variable names
+	JobRefs map[string]string `json:"job_refs,omitempty"`
 }
 
 // ExtractValues extracts values from discovered workflows and jobs.
@@ -215,6 +219,7 @@ import (
 	"os"
 	"reflect"
 
+	wetwire_workflow "github.com/lex00/wetwire-github-go/workflow"
 `)
 
 	// Import the user's package
@@ -239,6 +244,7 @@ import (
 type ExtractionResult struct {
 	Workflows []ExtractedWorkflow ` + "`json:\"workflows\"`" + `
 	Jobs      []ExtractedJob      ` + "`json:\"jobs\"`" + `
+	JobRefs   map[string]string   ` + "`json:\"job_refs,omitempty\"`" + `
 }
 
 type ExtractedWorkflow struct {
@@ -271,10 +277,49 @@ func toMap(v any) map[string]any {
 	return result
 }
 
+// stepAction matches typed action wrappers (workflow.StepAction).
+type stepAction interface {
+	Action() string
+	Inputs() map[string]any
+}
+
+// jobToMap converts a job to a map, replacing action wrapper steps with
+// their uses/with form so they survive JSON encoding.
+func jobToMap(v any) map[string]any {
+	result := toMap(v)
+	steps, ok := result["Steps"].([]any)
+	if !ok {
+		return result
+	}
+	converted := make([]any, len(steps))
+	for i, step := range steps {
+		if a, ok := step.(stepAction); ok {
+			converted[i] = map[string]any{"Uses": a.Action(), "With": a.Inputs()}
+			continue
+		}
+		converted[i] = step
+	}
+	result["Steps"] = converted
+	return result
+}
+
+// jobRef returns the placeholder ID of a job variable, which may itself
+// be declared as a pointer.
+func jobRef(v any) string {
+	switch j := v.(type) {
+	case *wetwire_workflow.Job:
+		return wetwire_workflow.JobRef(j)
+	case **wetwire_workflow.Job:
+		return wetwire_workflow.JobRef(*j)
+	}
+	return ""
+}
+
 func main() {
 	result := ExtractionResult{
 		Workflows: []ExtractedWorkflow{},
 		Jobs:      []ExtractedJob{},
+		JobRefs:   map[string]string{},
 	}
 
 `)
@@ -289,8 +334,9 @@ func main() {
 	// Add job extractions
 	for _, j := range discovered.Jobs {
 		alias := r.pkgAlias(r.getPackagePath(modulePath, baseDir, j.File))
-		sb.WriteString(fmt.Sprintf("\tresult.Jobs = append(result.Jobs, ExtractedJob{Name: %q, Data: toMap(%s.%s)})\n",
+		sb.WriteString(fmt.Sprintf("\tresult.Jobs = append(result.Jobs, ExtractedJob{Name: %q, Data: jobToMap(%s.%s)})\n",
 			j.Name, alias, j.Name))
+		sb.WriteString(fmt.Sprintf("\tresult.JobRefs[jobRef(&%s.%s)] = %q\n", alias, j.Name, j.Name))
 	}
 
 	sb.WriteString(`
@@ -36,6 +36,7 @@ func TestRunner_generateProgram(t *testing.T) {
 		"ExtractedJob",
 		"toMap",
 		"json.Marshal",
+		"result.JobRefs[jobRef(&test.Build)] = \"Build\"",
 	}
 
 	for _, expected := range expectedStrings {
@@ -78,6 +79,38 @@ func TestRunner_generateDependabotProgram(t *testing.T) {
 	}
 }
 
+// Test generateActionProgram
+func TestRunner_generateActionProgram(t *testing.T) {
+	r := NewRunner()
+	baseDir := "/project"
+
+	discovered := &discover.ActionDiscoveryResult{
+		Actions: []discover.DiscoveredAction{
+			{Name: "SetupToolchain", File: "/project/actions.go", Line: 10},
+		},
+	}
+
+	program, err := r.generateActionProgram("github.com/example/test", baseDir, discovered)
+	if err != nil {
+		t.Fatalf("generateActionProgram() error = %v", err)
+	}
+
+	expectedStrings := []string{
+		"package main",
+		"ActionExtractionResult",
+		"ExtractedAction",
+		"actionToMap",
+		"json.Marshal",
+		`ExtractedAction{Name: "SetupToolchain", Data: actionToMap(test.SetupToolchain)}`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(program, expected) {
+			t.Errorf("generateActionProgram() missing %q\n\nGenerated:\n%s", expected, program)
+		}
+	}
+}
+
 // Test generateIssueTemplateProgram
 func TestRunner_generateIssueTemplateProgram(t *testing.T) {
 	r := NewRunner()
@@ -0,0 +1,118 @@
+package serialize
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lex00/wetwire-github-go/action"
+)
+
+// ActionToYAML serializes an action to action.yml bytes.
+func ActionToYAML(a *action.Action) ([]byte, error) {
+	m, err := actionToMap(a)
+	if err != nil {
+		return nil, fmt.Errorf("converting action to map: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(m); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("closing encoder: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// actionToMap converts an Action to a map for YAML serialization.
+func actionToMap(a *action.Action) (map[string]any, error) {
+	m := make(map[string]any)
+
+	m["name"] = a.Name
+	m["description"] = a.Description
+
+	if a.Author != "" {
+		m["author"] = a.Author
+	}
+
+	if len(a.Inputs) > 0 {
+		inputs := make(map[string]any)
+		for name, input := range a.Inputs {
+			inputs[name] = structToMap(input)
+		}
+		m["inputs"] = inputs
+	}
+
+	if len(a.Outputs) > 0 {
+		outputs := make(map[string]any)
+		for name, output := range a.Outputs {
+			o := map[string]any{"description": output.Description}
+			if output.Value != "" {
+				o["value"] = output.Value.String()
+			}
+			outputs[name] = o
+		}
+		m["outputs"] = outputs
+	}
+
+	runs, err := actionRunsToMap(&a.Runs)
+	if err != nil {
+		return nil, err
+	}
+	m["runs"] = runs
+
+	if a.Branding != nil {
+		m["branding"] = structToMap(a.Branding)
+	}
+
+	return m, nil
+}
+
+// actionRunsToMap converts Runs to a map for YAML serialization.
+func actionRunsToMap(r *action.Runs) (map[string]any, error) {
+	m := make(map[string]any)
+	m["using"] = r.Using
+
+	if len(r.Steps) > 0 {
+		steps := make([]any, 0, len(r.Steps))
+		for i, s := range r.Steps {
+			stepMap, err := anyStepToMap(s)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: %w", i, err)
+			}
+			steps = append(steps, stepMap)
+		}
+		m["steps"] = steps
+	}
+
+	for key, value := range map[string]string{
+		"main":            r.Main,
+		"pre":             r.Pre,
+		"pre-if":          r.PreIf,
+		"post":            r.Post,
+		"post-if":         r.PostIf,
+		"image":           r.Image,
+		"entrypoint":      r.Entrypoint,
+		"pre-entrypoint":  r.PreEntrypoint,
+		"post-entrypoint": r.PostEntrypoint,
+	} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+
+	if len(r.Args) > 0 {
+		m["args"] = r.Args
+	}
+
+	if len(r.Env) > 0 {
+		m["env"] = serializeEnv(r.Env)
+	}
+
+	return m, nil
+}
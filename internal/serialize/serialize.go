@@ -102,6 +102,26 @@ func jobToMap(j *workflow.Job) (map[string]any, error) {
 		m["if"] = serializeCondition(j.If)
 	}
 
+	if j.Uses != nil {
+		uses, err := serializeUses(j.Uses)
+		if err != nil {
+			return nil, err
+		}
+		m["uses"] = usesValue(uses)
+	}
+
+	if len(j.With) > 0 {
+		m["with"] = serializeEnv(j.With)
+	}
+
+	if j.Secrets != nil {
+		secrets, err := serializeSecrets(j.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		m["secrets"] = secrets
+	}
+
 	if j.Permissions != nil {
 		m["permissions"] = permissionsToMap(j.Permissions)
 	}
@@ -202,7 +222,7 @@ func stepToMap(s *workflow.Step) (map[string]any, error) {
 	}
 
 	if s.Uses != "" {
-		m["uses"] = s.Uses
+		m["uses"] = usesValue(s.Uses)
 	}
 
 	if len(s.With) > 0 {
@@ -569,6 +589,9 @@ func permissionsToMap(p *workflow.Permissions) map[string]any {
 	if p.Actions != "" {
 		m["actions"] = p.Actions
 	}
+	if p.Attestations != "" {
+		m["attestations"] = p.Attestations
+	}
 	if p.Checks != "" {
 		m["checks"] = p.Checks
 	}
@@ -651,11 +674,67 @@ func serializeNeeds(needs []any) []string {
 	return result
 }
 
+// serializeUses converts a reusable workflow reference to its path.
+// Workflow variables must be resolved to a path by the builder first.
+func serializeUses(uses any) (string, error) {
+	switch v := uses.(type) {
+	case string:
+		return v, nil
+	case workflow.Workflow, *workflow.Workflow:
+		return "", fmt.Errorf("uses: workflow reference was not resolved to a path")
+	default:
+		return "", fmt.Errorf("uses: unsupported type %T", uses)
+	}
+}
+
+// usesValue returns the YAML value for an action or workflow reference.
+// A pinned reference written as "ref # version" keeps the version as a
+// trailing comment.
+func usesValue(uses string) any {
+	ref, comment, ok := strings.Cut(uses, " # ")
+	if !ok {
+		return uses
+	}
+	return &yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Value:       ref,
+		LineComment: "# " + comment,
+	}
+}
+
+// serializeSecrets converts job secrets, which are either "inherit" or a map.
+func serializeSecrets(secrets any) (any, error) {
+	switch v := secrets.(type) {
+	case string:
+		if v != workflow.SecretsInherit {
+			return nil, fmt.Errorf("secrets: expected %q or a map, got %q", workflow.SecretsInherit, v)
+		}
+		return v, nil
+	case map[string]any:
+		return serializeEnv(v), nil
+	case map[string]string:
+		result := make(map[string]any, len(v))
+		for k, val := range v {
+			result[k] = val
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("secrets: unsupported type %T", secrets)
+	}
+}
+
 // serializeValue converts a value to YAML-safe format.
 func serializeValue(v any) any {
 	switch val := v.(type) {
 	case workflow.Expression:
 		return val.String()
+	case workflow.RunsOn:
+		return val.Value()
+	case *workflow.RunsOn:
+		if val == nil {
+			return nil
+		}
+		return val.Value()
 	default:
 		return v
 	}
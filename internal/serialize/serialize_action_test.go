@@ -0,0 +1,82 @@
+package serialize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/action"
+	"github.com/lex00/wetwire-github-go/actions/checkout"
+	"github.com/lex00/wetwire-github-go/internal/serialize"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// TestCompositeActionToYAML tests composite action serialization.
+func TestCompositeActionToYAML(t *testing.T) {
+	a := &action.Action{
+		Name:        "Setup",
+		Description: "Prepare the workspace",
+		Inputs: map[string]action.Input{
+			"target": {Description: "Build target", Required: true},
+		},
+		Outputs: map[string]action.Output{
+			"path": {Description: "Output path", Value: workflow.Steps.Get("build", "path")},
+		},
+		Runs: action.Composite(
+			checkout.Checkout{FetchDepth: 1},
+			workflow.Step{ID: "build", Run: "make ${{ inputs.target }}", Shell: "bash"},
+		),
+		Branding: &action.Branding{Icon: "package", Color: "blue"},
+	}
+
+	yaml, err := serialize.ActionToYAML(a)
+	if err != nil {
+		t.Fatalf("ActionToYAML failed: %v", err)
+	}
+
+	yamlStr := string(yaml)
+
+	for _, want := range []string{
+		"name: Setup",
+		"description: Prepare the workspace",
+		"required: true",
+		"value: ${{ steps.build.outputs.path }}",
+		"using: composite",
+		"uses: actions/checkout@v4",
+		"fetch-depth: 1",
+		"shell: bash",
+		"icon: package",
+	} {
+		if !strings.Contains(yamlStr, want) {
+			t.Errorf("expected %q, got:\n%s", want, yamlStr)
+		}
+	}
+}
+
+// TestDockerActionToYAML tests Docker action serialization.
+func TestDockerActionToYAML(t *testing.T) {
+	a := &action.Action{
+		Name:        "Lint",
+		Description: "Run the linter",
+		Runs:        action.Docker("Dockerfile", "--strict"),
+	}
+
+	yaml, err := serialize.ActionToYAML(a)
+	if err != nil {
+		t.Fatalf("ActionToYAML failed: %v", err)
+	}
+
+	yamlStr := string(yaml)
+
+	if !strings.Contains(yamlStr, "using: docker") {
+		t.Errorf("expected docker runtime, got:\n%s", yamlStr)
+	}
+	if !strings.Contains(yamlStr, "image: Dockerfile") {
+		t.Errorf("expected image, got:\n%s", yamlStr)
+	}
+	if !strings.Contains(yamlStr, "- --strict") {
+		t.Errorf("expected args, got:\n%s", yamlStr)
+	}
+	if strings.Contains(yamlStr, "main:") {
+		t.Errorf("unexpected main for docker action, got:\n%s", yamlStr)
+	}
+}
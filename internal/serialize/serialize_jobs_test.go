@@ -531,8 +531,9 @@ func TestJobWithAllFields(t *testing.T) {
 				Name:   "Test Job",
 				RunsOn: "ubuntu-latest",
 				Permissions: &workflow.Permissions{
-					Contents: "read",
-					Issues:   "write",
+					Attestations: "write",
+					Contents:     "read",
+					Issues:       "write",
 				},
 				Environment: &workflow.Environment{
 					Name: "production",
@@ -568,6 +569,7 @@ func TestJobWithAllFields(t *testing.T) {
 		"name: Test Job",
 		"runs-on: ubuntu-latest",
 		"permissions:",
+		"attestations: write",
 		"environment:",
 		"concurrency:",
 		"outputs:",
@@ -744,3 +746,105 @@ func TestContainerAndServices(t *testing.T) {
 		t.Errorf("expected container image, got:\n%s", yamlStr)
 	}
 }
+
+func TestReusableWorkflowCall(t *testing.T) {
+	w := &workflow.Workflow{
+		Name: "Release",
+		On: workflow.Triggers{
+			Push: &workflow.PushTrigger{Tags: []string{"v*"}},
+		},
+		Jobs: map[string]workflow.Job{
+			"publish": {
+				Uses: "org/shared/.github/workflows/publish.yml@v1",
+				With: map[string]any{
+					"version": workflow.GitHub.RefName(),
+				},
+				Secrets: map[string]any{
+					"token": workflow.Secrets.Get("NPM_TOKEN"),
+				},
+			},
+			"docs": {
+				Uses:    "./.github/workflows/docs.yml",
+				Secrets: workflow.SecretsInherit,
+			},
+		},
+	}
+
+	yaml, err := serialize.ToYAML(w)
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+
+	yamlStr := string(yaml)
+
+	for _, want := range []string{
+		"uses: org/shared/.github/workflows/publish.yml@v1",
+		"version: ${{ github.ref_name }}",
+		"token: ${{ secrets.NPM_TOKEN }}",
+		"uses: ./.github/workflows/docs.yml",
+		"secrets: inherit",
+	} {
+		if !strings.Contains(yamlStr, want) {
+			t.Errorf("expected %q, got:\n%s", want, yamlStr)
+		}
+	}
+	if strings.Contains(yamlStr, "steps:") || strings.Contains(yamlStr, "runs-on:") {
+		t.Errorf("caller jobs should not emit steps or runs-on, got:\n%s", yamlStr)
+	}
+}
+
+func TestReusableWorkflowCallErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		job  workflow.Job
+	}{
+		{"unresolved workflow", workflow.Job{Uses: workflow.Workflow{Name: "Docs"}}},
+		{"invalid secrets string", workflow.Job{Uses: "./.github/workflows/docs.yml", Secrets: "all"}},
+		{"unsupported secrets type", workflow.Job{Uses: "./.github/workflows/docs.yml", Secrets: 42}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &workflow.Workflow{
+				Name: "Test",
+				On:   workflow.Triggers{Push: &workflow.PushTrigger{}},
+				Jobs: map[string]workflow.Job{"call": tt.job},
+			}
+			if _, err := serialize.ToYAML(w); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestJobTypedRunsOn tests serialization of typed runs-on values.
+func TestJobTypedRunsOn(t *testing.T) {
+	w := &workflow.Workflow{
+		Name: "CI",
+		On:   workflow.Triggers{Push: &workflow.PushTrigger{}},
+		Jobs: map[string]workflow.Job{
+			"hosted": {RunsOn: workflow.Hosted("ubuntu-24.04"), Steps: []any{workflow.Step{Run: "make"}}},
+			"gpu":    {RunsOn: workflow.SelfHosted("linux", "gpu"), Steps: []any{workflow.Step{Run: "make"}}},
+			"fleet":  {RunsOn: workflow.RunnerGroup("fleet", "arm64"), Steps: []any{workflow.Step{Run: "make"}}},
+			"matrix": {RunsOn: workflow.RunsOnExpr(workflow.MatrixContext.Get("os")), Steps: []any{workflow.Step{Run: "make"}}},
+		},
+	}
+
+	yaml, err := serialize.ToYAML(w)
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+
+	yamlStr := string(yaml)
+
+	for _, want := range []string{
+		"runs-on: ubuntu-24.04",
+		"runs-on:\n      - self-hosted\n      - linux\n      - gpu",
+		"runs-on:\n      group: fleet\n      labels:\n        - arm64",
+		"runs-on: ${{ matrix.os }}",
+	} {
+		if !strings.Contains(yamlStr, want) {
+			t.Errorf("expected %q, got:\n%s", want, yamlStr)
+		}
+	}
+}
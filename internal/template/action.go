@@ -0,0 +1,215 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/action"
+	"github.com/lex00/wetwire-github-go/codegen"
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/internal/serialize"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// ActionBuildResult contains the result of building actions.
+type ActionBuildResult struct {
+	// Actions contains the assembled actions with YAML output
+	Actions []BuiltAction
+
+	// Errors contains any non-fatal errors encountered
+	Errors []string
+}
+
+// BuiltAction represents an action ready for output.
+type BuiltAction struct {
+	// Name is the action variable name
+	Name string
+
+	// Dir is the directory name under .github/actions
+	Dir string
+
+	// Action is the assembled action
+	Action *action.Action
+
+	// YAML is the serialized action.yml output
+	YAML []byte
+
+	// Wrapper is the generated typed wrapper for calling the action
+	Wrapper *codegen.GeneratedCode
+}
+
+// BuildActions assembles actions from discovery and extraction results.
+func (b *Builder) BuildActions(discovered *discover.ActionDiscoveryResult, extracted *runner.ActionExtractionResult) (*ActionBuildResult, error) {
+	result := &ActionBuildResult{
+		Actions: []BuiltAction{},
+		Errors:  []string{},
+	}
+
+	// Process each action
+	for _, da := range discovered.Actions {
+		// Find the extracted action data
+		var actionData map[string]any
+		for _, ea := range extracted.Actions {
+			if ea.Name == da.Name {
+				actionData = ea.Data
+				break
+			}
+		}
+
+		if actionData == nil {
+			result.Errors = append(result.Errors, "action "+da.Name+": extraction data not found")
+			continue
+		}
+
+		// Reconstruct the action from the map
+		a, err := b.reconstructAction(actionData)
+		if err != nil {
+			result.Errors = append(result.Errors, "action "+da.Name+": "+err.Error())
+			continue
+		}
+
+		if errs := validateAction(a); len(errs) > 0 {
+			for _, e := range errs {
+				result.Errors = append(result.Errors, "action "+da.Name+": "+e)
+			}
+			continue
+		}
+
+		// Serialize to YAML
+		yaml, err := serialize.ActionToYAML(b.pinAction(a))
+		if err != nil {
+			result.Errors = append(result.Errors, "action "+da.Name+": "+err.Error())
+			continue
+		}
+
+		dir := ToFilename(da.Name)
+		wrapper, err := actionWrapper(da.Name, dir, a)
+		if err != nil {
+			result.Errors = append(result.Errors, "action "+da.Name+": "+err.Error())
+			continue
+		}
+
+		result.Actions = append(result.Actions, BuiltAction{
+			Name:    da.Name,
+			Dir:     dir,
+			Action:  a,
+			YAML:    yaml,
+			Wrapper: wrapper,
+		})
+	}
+
+	return result, nil
+}
+
+// reconstructAction reconstructs an Action from a map.
+// Extracted data is keyed by Go field names, so it decodes directly into the
+// action types; composite steps and output expressions need extra handling.
+func (b *Builder) reconstructAction(data map[string]any) (*action.Action, error) {
+	a := &action.Action{}
+
+	if err := decodeFields(data, a); err != nil {
+		return nil, err
+	}
+
+	if runsMap, ok := data["Runs"].(map[string]any); ok {
+		if steps, ok := runsMap["Steps"].([]any); ok {
+			a.Runs.Steps = b.reconstructStepsAsAny(steps)
+		}
+	}
+
+	// Values are extracted in ${{ }} form; Expression adds its own
+	for name, output := range a.Outputs {
+		if value, ok := unwrapCondition(string(output.Value)).(string); ok {
+			output.Value = workflow.Expression(value)
+		}
+		a.Outputs[name] = output
+	}
+
+	return a, nil
+}
+
+// validateAction checks that an action sets the fields its runtime requires.
+func validateAction(a *action.Action) []string {
+	var errors []string
+
+	if a.Name == "" {
+		errors = append(errors, "name is required")
+	}
+	if a.Description == "" {
+		errors = append(errors, "description is required")
+	}
+
+	switch {
+	case a.Runs.Using == "":
+		errors = append(errors, "runs.using is required")
+
+	case a.Runs.Using == action.UsingComposite:
+		if len(a.Runs.Steps) == 0 {
+			errors = append(errors, "composite action has no steps")
+		}
+		for i, s := range a.Runs.Steps {
+			if step, ok := s.(workflow.Step); ok && step.Run != "" && step.Shell == "" {
+				errors = append(errors, fmt.Sprintf("step %d: run steps in composite actions must set Shell", i))
+			}
+		}
+		for _, name := range sortedKeys(a.Outputs) {
+			if a.Outputs[name].Value == "" {
+				errors = append(errors, fmt.Sprintf("output %q: composite action outputs must set Value", name))
+			}
+		}
+
+	case a.Runs.Using == action.UsingDocker:
+		if a.Runs.Image == "" {
+			errors = append(errors, "docker action has no image")
+		}
+
+	case strings.HasPrefix(a.Runs.Using, "node"):
+		if a.Runs.Main == "" {
+			errors = append(errors, "JavaScript action has no main")
+		}
+
+	default:
+		errors = append(errors, fmt.Sprintf("unknown runs.using %q", a.Runs.Using))
+	}
+
+	return errors
+}
+
+// actionWrapper generates a typed wrapper for calling a local action, in the
+// same style as the actions/* packages.
+func actionWrapper(name, dir string, a *action.Action) (*codegen.GeneratedCode, error) {
+	spec := &codegen.ActionSpec{
+		Name:        a.Name,
+		Description: a.Description,
+		Inputs:      make(map[string]codegen.ActionInput, len(a.Inputs)),
+		Outputs:     make(map[string]codegen.ActionOutput, len(a.Outputs)),
+	}
+	for inputName, input := range a.Inputs {
+		spec.Inputs[inputName] = codegen.ActionInput{
+			Description: input.Description,
+			Required:    input.Required,
+			Default:     input.Default,
+		}
+	}
+	for outputName, output := range a.Outputs {
+		spec.Outputs[outputName] = codegen.ActionOutput{Description: output.Description}
+	}
+
+	code, err := codegen.NewGenerator().GenerateActionWrapper(codegen.ActionWrapperConfig{
+		ActionRef:   LocalActionPath(name),
+		PackageName: strings.ReplaceAll(dir, "-", "_"),
+		TypeName:    name,
+		Spec:        spec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating wrapper: %w", err)
+	}
+	return code, nil
+}
+
+// LocalActionPath returns the path workflows use to reference an action
+// generated from the given variable name.
+func LocalActionPath(name string) string {
+	return "./.github/actions/" + ToFilename(name)
+}
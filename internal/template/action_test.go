@@ -0,0 +1,215 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+)
+
+func TestBuilder_BuildActions_Composite(t *testing.T) {
+	b := NewBuilder()
+
+	discovered := &discover.ActionDiscoveryResult{
+		Actions: []discover.DiscoveredAction{
+			{Name: "SetupToolchain", File: "action.go", Line: 10},
+		},
+	}
+	extracted := &runner.ActionExtractionResult{
+		Actions: []runner.ExtractedAction{
+			{
+				Name: "SetupToolchain",
+				Data: map[string]any{
+					"Name":        "Setup toolchain",
+					"Description": "Install the toolchain",
+					"Inputs": map[string]any{
+						"go-version": map[string]any{"Description": "Go version", "Default": "1.23"},
+						"verbose":    map[string]any{"Description": "Verbose output", "Default": "false"},
+					},
+					"Outputs": map[string]any{
+						"cache-hit": map[string]any{
+							"Description": "Whether the cache was restored",
+							"Value":       "${{ steps.cache.outputs.cache-hit }}",
+						},
+					},
+					"Runs": map[string]any{
+						"Using": "composite",
+						"Steps": []any{
+							map[string]any{
+								"Uses": "actions/setup-go@v5",
+								"With": map[string]any{"go-version": "${{ inputs.go-version }}"},
+							},
+							map[string]any{"ID": "cache", "Run": "./restore.sh", "Shell": "bash"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := b.BuildActions(discovered, extracted)
+	if err != nil {
+		t.Fatalf("BuildActions() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(result.Actions) != 1 {
+		t.Fatalf("Expected 1 action, got %d", len(result.Actions))
+	}
+
+	built := result.Actions[0]
+	if built.Dir != "setup-toolchain" {
+		t.Errorf("Dir = %q, want %q", built.Dir, "setup-toolchain")
+	}
+
+	yaml := string(built.YAML)
+	for _, want := range []string{
+		"using: composite",
+		"uses: actions/setup-go@v5",
+		"shell: bash",
+		"value: ${{ steps.cache.outputs.cache-hit }}",
+	} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("YAML missing %q:\n%s", want, yaml)
+		}
+	}
+
+	if built.Wrapper.PackageName != "setup_toolchain" {
+		t.Errorf("Wrapper.PackageName = %q, want %q", built.Wrapper.PackageName, "setup_toolchain")
+	}
+	code := string(built.Wrapper.Code)
+	for _, want := range []string{
+		"package setup_toolchain",
+		"type SetupToolchain struct",
+		`return "./.github/actions/setup-toolchain"`,
+		"GoVersion string",
+		"Verbose bool",
+		"CacheHit",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("wrapper missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestBuilder_BuildActions_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr string
+	}{
+		{
+			name: "missing description",
+			data: map[string]any{
+				"Name": "A",
+				"Runs": map[string]any{"Using": "node20", "Main": "index.js"},
+			},
+			wantErr: "description is required",
+		},
+		{
+			name: "composite run step without shell",
+			data: map[string]any{
+				"Name": "A", "Description": "d",
+				"Runs": map[string]any{
+					"Using": "composite",
+					"Steps": []any{map[string]any{"Run": "make"}},
+				},
+			},
+			wantErr: "must set Shell",
+		},
+		{
+			name: "composite output without value",
+			data: map[string]any{
+				"Name": "A", "Description": "d",
+				"Outputs": map[string]any{"out": map[string]any{"Description": "o"}},
+				"Runs": map[string]any{
+					"Using": "composite",
+					"Steps": []any{map[string]any{"Run": "make", "Shell": "bash"}},
+				},
+			},
+			wantErr: `output "out"`,
+		},
+		{
+			name: "docker without image",
+			data: map[string]any{
+				"Name": "A", "Description": "d",
+				"Runs": map[string]any{"Using": "docker"},
+			},
+			wantErr: "no image",
+		},
+		{
+			name: "node without main",
+			data: map[string]any{
+				"Name": "A", "Description": "d",
+				"Runs": map[string]any{"Using": "node24"},
+			},
+			wantErr: "no main",
+		},
+		{
+			name: "unknown runtime",
+			data: map[string]any{
+				"Name": "A", "Description": "d",
+				"Runs": map[string]any{"Using": "python"},
+			},
+			wantErr: `unknown runs.using "python"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovered := &discover.ActionDiscoveryResult{
+				Actions: []discover.DiscoveredAction{{Name: "MyAction"}},
+			}
+			extracted := &runner.ActionExtractionResult{
+				Actions: []runner.ExtractedAction{{Name: "MyAction", Data: tt.data}},
+			}
+
+			result, err := NewBuilder().BuildActions(discovered, extracted)
+			if err != nil {
+				t.Fatalf("BuildActions() error = %v", err)
+			}
+			if len(result.Actions) != 0 {
+				t.Errorf("Expected no actions, got %d", len(result.Actions))
+			}
+			if len(result.Errors) == 0 || !strings.Contains(strings.Join(result.Errors, "\n"), tt.wantErr) {
+				t.Errorf("Errors = %v, want one containing %q", result.Errors, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuilder_BuildActions_MissingData(t *testing.T) {
+	discovered := &discover.ActionDiscoveryResult{
+		Actions: []discover.DiscoveredAction{{Name: "Missing"}},
+	}
+
+	result, err := NewBuilder().BuildActions(discovered, &runner.ActionExtractionResult{})
+	if err != nil {
+		t.Fatalf("BuildActions() error = %v", err)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "extraction data not found") {
+		t.Errorf("Errors = %v", result.Errors)
+	}
+}
+
+func TestBuilder_BuildActions_TypeMismatch(t *testing.T) {
+	discovered := &discover.ActionDiscoveryResult{
+		Actions: []discover.DiscoveredAction{{Name: "Greet"}},
+	}
+	extracted := &runner.ActionExtractionResult{
+		Actions: []runner.ExtractedAction{{
+			Name: "Greet",
+			Data: map[string]any{"Name": "Greet", "Description": []any{"not", "a", "string"}},
+		}},
+	}
+
+	result, err := NewBuilder().BuildActions(discovered, extracted)
+	if err != nil {
+		t.Fatalf("BuildActions() error = %v", err)
+	}
+	if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], "action Greet: ") || len(result.Actions) != 0 {
+		t.Errorf("Errors = %v, want the decode error reported", result.Errors)
+	}
+}
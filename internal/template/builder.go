@@ -1,10 +1,14 @@
 package template
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/inventory"
+	"github.com/lex00/wetwire-github-go/internal/pin"
 	"github.com/lex00/wetwire-github-go/internal/runner"
 	"github.com/lex00/wetwire-github-go/internal/serialize"
 	"github.com/lex00/wetwire-github-go/workflow"
@@ -14,6 +18,19 @@ import (
 type Builder struct {
 	// Verbose enables verbose output
 	Verbose bool
+
+	// Runners is the project's runner inventory, if it has one.
+	// Retired runner labels are rejected either way.
+	Runners *inventory.Inventory
+
+	// Lock pins action references to commit SHAs in the generated YAML,
+	// if the project has a lock file.
+	Lock *pin.Lock
+
+	// WorkflowDir is the directory workflows are generated in, relative
+	// to the repository root, which calls to reusable workflows of the
+	// module reference. Empty means ".github/workflows".
+	WorkflowDir string
 }
 
 // NewBuilder creates a new Builder.
@@ -43,6 +60,9 @@ type BuiltWorkflow struct {
 
 	// Jobs lists the job names in dependency order
 	Jobs []string
+
+	// JobIDs maps each job name to its ID in the workflow's jobs
+	JobIDs map[string]string
 }
 
 // Build assembles workflow templates from discovery and extraction results.
@@ -59,6 +79,21 @@ func (b *Builder) Build(discovered *discover.DiscoveryResult, extracted *runner.
 		jobMap[job.Name] = job
 	}
 
+	// Replace Job values in Needs with job IDs
+	b.resolveNeeds(discovered, jobMap)
+
+	// Resolve and validate calls to reusable workflows declared in Go
+	result.Errors = append(result.Errors, b.resolveWorkflowCalls(discovered, extracted, jobMap)...)
+
+	// Resolve references to job outputs made with Job.Output
+	result.Errors = append(result.Errors, b.resolveJobOutputs(discovered, extracted, jobMap)...)
+
+	// Reject malformed expressions before they reach the YAML
+	result.Errors = append(result.Errors, b.checkExpressions(discovered, extracted, jobMap)...)
+
+	// Reject runner labels that no runner will pick up
+	result.Errors = append(result.Errors, b.checkRunners(discovered, jobMap)...)
+
 	// Build job dependency graph
 	graph := NewGraph()
 	jobDeps := make(map[string][]string)
@@ -107,7 +142,7 @@ func (b *Builder) Build(discovered *discover.DiscoveryResult, extracted *runner.
 		}
 
 		// Serialize to YAML
-		yaml, err := serialize.ToYAML(wf)
+		yaml, err := serialize.ToYAML(b.pinWorkflow(wf))
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("workflow %s: serialization failed: %v", dw.Name, err))
 			continue
@@ -116,11 +151,19 @@ func (b *Builder) Build(discovered *discover.DiscoveryResult, extracted *runner.
 		// Get ordered jobs for this workflow
 		orderedJobs := b.filterAndOrderJobs(dw.Jobs, sortedJobs)
 
+		jobIDs := make(map[string]string, len(orderedJobs))
+		for _, jobName := range orderedJobs {
+			if ej, ok := jobMap[jobName]; ok {
+				jobIDs[jobName] = jobID(jobName, ej)
+			}
+		}
+
 		result.Workflows = append(result.Workflows, BuiltWorkflow{
 			Name:     dw.Name,
 			Workflow: wf,
 			YAML:     yaml,
 			Jobs:     orderedJobs,
+			JobIDs:   jobIDs,
 		})
 	}
 
@@ -140,7 +183,11 @@ func (b *Builder) buildWorkflow(dw discover.DiscoveredWorkflow, data map[string]
 	if on, ok := data["On"].(workflow.Triggers); ok {
 		wf.On = on
 	} else if onMap, ok := data["On"].(map[string]any); ok {
-		wf.On = b.reconstructTriggers(onMap)
+		on, err := b.reconstructTriggers(onMap)
+		if err != nil {
+			return nil, err
+		}
+		wf.On = on
 	}
 
 	// Set env
@@ -159,9 +206,11 @@ func (b *Builder) buildWorkflow(dw discover.DiscoveredWorkflow, data map[string]
 	}
 
 	// Set permissions
-	if permissions, ok := data["Permissions"].(*workflow.Permissions); ok {
-		wf.Permissions = permissions
+	permissions, err := reconstructPermissions(data["Permissions"])
+	if err != nil {
+		return nil, err
 	}
+	wf.Permissions = permissions
 
 	// Add jobs in dependency order
 	wf.Jobs = make(map[string]workflow.Job)
@@ -178,12 +227,7 @@ func (b *Builder) buildWorkflow(dw discover.DiscoveredWorkflow, data map[string]
 			return nil, fmt.Errorf("building job %s: %w", jobName, err)
 		}
 
-		// Use the job's Name field for the YAML key, or fall back to variable name
-		yamlKey := wfJob.Name
-		if yamlKey == "" {
-			yamlKey = jobName
-		}
-		wf.Jobs[yamlKey] = *wfJob
+		wf.Jobs[jobID(jobName, job)] = *wfJob
 	}
 
 	return wf, nil
@@ -205,15 +249,36 @@ func (b *Builder) buildJob(ej *runner.ExtractedJob) (*workflow.Job, error) {
 	}
 
 	if ifCond, ok := data["If"]; ok {
-		job.If = ifCond
+		job.If = unwrapCondition(ifCond)
+	}
+
+	// Handle reusable workflow calls. Workflow references are resolved
+	// to a path string by resolveWorkflowCalls before this point.
+	if uses, ok := data["Uses"].(string); ok {
+		job.Uses = uses
+	}
+
+	if with, ok := data["With"].(map[string]any); ok {
+		job.With = with
+	}
+
+	switch secrets := data["Secrets"].(type) {
+	case string:
+		job.Secrets = secrets
+	case map[string]any:
+		job.Secrets = secrets
 	}
 
 	if env, ok := data["Env"].(map[string]any); ok {
 		job.Env = env
 	}
 
-	if timeoutMinutes, ok := data["TimeoutMinutes"].(int); ok {
+	// Numbers decoded from the extraction JSON are float64
+	switch timeoutMinutes := data["TimeoutMinutes"].(type) {
+	case int:
 		job.TimeoutMinutes = timeoutMinutes
+	case float64:
+		job.TimeoutMinutes = int(timeoutMinutes)
 	}
 
 	if continueOnError, ok := data["ContinueOnError"].(bool); ok {
@@ -236,9 +301,11 @@ func (b *Builder) buildJob(ej *runner.ExtractedJob) (*workflow.Job, error) {
 	}
 
 	// Handle permissions
-	if permissions, ok := data["Permissions"].(*workflow.Permissions); ok {
-		job.Permissions = permissions
+	permissions, err := reconstructPermissions(data["Permissions"])
+	if err != nil {
+		return nil, err
 	}
+	job.Permissions = permissions
 
 	// Handle defaults
 	if defaults, ok := data["Defaults"].(*workflow.JobDefaults); ok {
@@ -280,6 +347,22 @@ func (b *Builder) buildJob(ej *runner.ExtractedJob) (*workflow.Job, error) {
 	return job, nil
 }
 
+// reconstructPermissions converts extracted permissions to
+// workflow.Permissions. Extracted data is keyed by Go field names.
+func reconstructPermissions(data any) (*workflow.Permissions, error) {
+	switch p := data.(type) {
+	case *workflow.Permissions:
+		return p, nil
+	case map[string]any:
+		permissions := &workflow.Permissions{}
+		if err := decodeFields(p, permissions); err != nil {
+			return nil, fmt.Errorf("permissions: %w", err)
+		}
+		return permissions, nil
+	}
+	return nil, nil
+}
+
 // filterAndOrderJobs returns jobs in dependency order, filtered to only include specified jobs.
 func (b *Builder) filterAndOrderJobs(jobNames []string, sortedJobs []string) []string {
 	// Create a set of job names
@@ -353,57 +436,38 @@ func ValidateJobDependencies(jobs []discover.DiscoveredJob) []string {
 }
 
 // reconstructTriggers builds a Triggers struct from a generic map.
-func (b *Builder) reconstructTriggers(data map[string]any) workflow.Triggers {
+// Extracted trigger data is keyed by Go field names, so it decodes directly
+// into the trigger types; only workflow_call outputs need their expressions
+// unwrapped.
+func (b *Builder) reconstructTriggers(data map[string]any) (workflow.Triggers, error) {
 	triggers := workflow.Triggers{}
 
-	if pushData, ok := data["Push"]; ok {
-		if pushMap, ok := pushData.(map[string]any); ok {
-			push := &workflow.PushTrigger{}
-			if branches, ok := pushMap["Branches"].([]any); ok {
-				push.Branches = anySliceToStrings(branches)
-			}
-			if tags, ok := pushMap["Tags"].([]any); ok {
-				push.Tags = anySliceToStrings(tags)
-			}
-			if paths, ok := pushMap["Paths"].([]any); ok {
-				push.Paths = anySliceToStrings(paths)
-			}
-			triggers.Push = push
-		}
+	if err := decodeFields(data, &triggers); err != nil {
+		return triggers, fmt.Errorf("triggers: %w", err)
 	}
 
-	if prData, ok := data["PullRequest"]; ok {
-		if prMap, ok := prData.(map[string]any); ok {
-			pr := &workflow.PullRequestTrigger{}
-			if branches, ok := prMap["Branches"].([]any); ok {
-				pr.Branches = anySliceToStrings(branches)
-			}
-			if types, ok := prMap["Types"].([]any); ok {
-				pr.Types = anySliceToStrings(types)
-			}
-			triggers.PullRequest = pr
+	if wcData, ok := data["WorkflowCall"]; ok && wcData != nil {
+		wc := &workflow.WorkflowCallTrigger{}
+		if wcMap, ok := wcData.(map[string]any); ok {
+			wc.Inputs = reconstructWorkflowInputs(wcMap["Inputs"])
+			wc.Outputs = reconstructWorkflowOutputs(wcMap["Outputs"])
+			wc.Secrets = reconstructWorkflowSecrets(wcMap["Secrets"])
 		}
+		triggers.WorkflowCall = wc
 	}
 
-	if wdData, ok := data["WorkflowDispatch"]; ok && wdData != nil {
-		triggers.WorkflowDispatch = &workflow.WorkflowDispatchTrigger{}
-	}
-
-	if wcData, ok := data["WorkflowCall"]; ok && wcData != nil {
-		triggers.WorkflowCall = &workflow.WorkflowCallTrigger{}
-	}
+	return triggers, nil
+}
 
-	if schedData, ok := data["Schedule"].([]any); ok {
-		for _, s := range schedData {
-			if sched, ok := s.(map[string]any); ok {
-				if cron, ok := sched["Cron"].(string); ok {
-					triggers.Schedule = append(triggers.Schedule, workflow.ScheduleTrigger{Cron: cron})
-				}
-			}
-		}
+// decodeFields decodes extracted data, keyed by Go field names, into the
+// struct v points to. A value whose type does not match its field is an
+// error rather than a field left empty.
+func decodeFields(data any, v any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
 	}
-
-	return triggers
+	return json.Unmarshal(encoded, v)
 }
 
 // reconstructStepsAsAny builds a slice of Steps from a generic slice.
@@ -434,7 +498,7 @@ func (b *Builder) reconstructStepsAsAny(data []any) []any {
 			step.Shell = shell
 		}
 		if ifCond, ok := stepMap["If"].(string); ok {
-			step.If = ifCond
+			step.If = unwrapCondition(ifCond)
 		}
 		if wd, ok := stepMap["WorkingDirectory"].(string); ok {
 			step.WorkingDirectory = wd
@@ -455,6 +519,20 @@ func (b *Builder) reconstructStepsAsAny(data []any) []any {
 	return steps
 }
 
+// unwrapCondition strips the ${{ }} wrapper from an extracted condition.
+// Expressions are extracted in wrapped form, but if: fields don't need it.
+func unwrapCondition(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "${{") && strings.HasSuffix(trimmed, "}}") && strings.Count(trimmed, "${{") == 1 {
+		return strings.TrimSpace(trimmed[3 : len(trimmed)-2])
+	}
+	return s
+}
+
 // anySliceToStrings converts []any to []string.
 func anySliceToStrings(slice []any) []string {
 	var result []string
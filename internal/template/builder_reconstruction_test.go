@@ -1,6 +1,7 @@
 package template
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lex00/wetwire-github-go/internal/discover"
@@ -79,6 +80,37 @@ func TestBuilder_reconstructTriggers(t *testing.T) {
 					t.Schedule[1].Cron == "0 12 * * *"
 			},
 		},
+		{
+			name: "filters and activity types",
+			data: map[string]any{
+				"Push": map[string]any{
+					"BranchesIgnore": []any{"gh-pages"},
+					"PathsIgnore":    []any{"docs/**"},
+				},
+				"WorkflowRun": map[string]any{
+					"Workflows": []any{"CI"},
+					"Types":     []any{"completed"},
+				},
+				"Issues": map[string]any{
+					"Types": []any{"opened"},
+				},
+				"WorkflowDispatch": map[string]any{
+					"Inputs": map[string]any{
+						"environment": map[string]any{"Type": "choice", "Options": []any{"dev", "prod"}},
+					},
+				},
+			},
+			want: func(t workflow.Triggers) bool {
+				return t.Push != nil &&
+					len(t.Push.BranchesIgnore) == 1 &&
+					len(t.Push.PathsIgnore) == 1 &&
+					t.WorkflowRun != nil &&
+					t.WorkflowRun.Workflows[0] == "CI" &&
+					t.Issues != nil &&
+					t.Issues.Types[0] == "opened" &&
+					len(t.WorkflowDispatch.Inputs["environment"].Options) == 2
+			},
+		},
 		{
 			name: "multiple triggers",
 			data: map[string]any{
@@ -98,7 +130,10 @@ func TestBuilder_reconstructTriggers(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := b.reconstructTriggers(tt.data)
+			result, err := b.reconstructTriggers(tt.data)
+			if err != nil {
+				t.Fatalf("reconstructTriggers() error = %v", err)
+			}
 			if !tt.want(result) {
 				t.Errorf("reconstructTriggers() validation failed")
 			}
@@ -106,6 +141,17 @@ func TestBuilder_reconstructTriggers(t *testing.T) {
 	}
 }
 
+func TestBuilder_reconstructTriggers_TypeMismatch(t *testing.T) {
+	b := NewBuilder()
+
+	_, err := b.reconstructTriggers(map[string]any{
+		"Push": map[string]any{"Branches": "main"},
+	})
+	if err == nil {
+		t.Error("reconstructTriggers() should report a value that does not match its field")
+	}
+}
+
 func TestBuilder_reconstructStepsAsAny(t *testing.T) {
 	b := NewBuilder()
 
@@ -264,6 +310,28 @@ func TestBuilder_anySliceToStrings(t *testing.T) {
 	}
 }
 
+func TestUnwrapCondition(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  any
+	}{
+		{"wrapped", "${{ github.ref == 'refs/heads/main' }}", "github.ref == 'refs/heads/main'"},
+		{"raw", "success()", "success()"},
+		{"mixed text", "${{ a }} and ${{ b }}", "${{ a }} and ${{ b }}"},
+		{"non-string", true, true},
+		{"nil", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unwrapCondition(tt.input); got != tt.want {
+				t.Errorf("unwrapCondition(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBuilder_buildJob(t *testing.T) {
 	b := NewBuilder()
 
@@ -301,6 +369,26 @@ func TestBuilder_buildJob(t *testing.T) {
 				return j.Env != nil && j.Env["GO_VERSION"] == "1.23"
 			},
 		},
+		{
+			name: "job with extracted permissions",
+			job: &runner.ExtractedJob{
+				Name: "Release",
+				Data: map[string]any{
+					"RunsOn": "ubuntu-latest",
+					"Permissions": map[string]any{
+						"Contents":     "write",
+						"PullRequests": "write",
+						"IDToken":      "",
+					},
+				},
+			},
+			want: func(j *workflow.Job) bool {
+				return j.Permissions != nil &&
+					j.Permissions.Contents == "write" &&
+					j.Permissions.PullRequests == "write" &&
+					j.Permissions.IDToken == ""
+			},
+		},
 		{
 			name: "job with timeout",
 			job: &runner.ExtractedJob{
@@ -315,6 +403,20 @@ func TestBuilder_buildJob(t *testing.T) {
 				return j.TimeoutMinutes == 60
 			},
 		},
+		{
+			name: "job with timeout decoded from JSON",
+			job: &runner.ExtractedJob{
+				Name: "LongJob",
+				Data: map[string]any{
+					"Name":           "long-job",
+					"RunsOn":         "ubuntu-latest",
+					"TimeoutMinutes": 45.0,
+				},
+			},
+			want: func(j *workflow.Job) bool {
+				return j.TimeoutMinutes == 45
+			},
+		},
 		{
 			name: "job with continue-on-error",
 			job: &runner.ExtractedJob{
@@ -424,6 +526,21 @@ func TestBuilder_buildJob(t *testing.T) {
 	}
 }
 
+func TestBuilder_buildJob_PermissionsTypeMismatch(t *testing.T) {
+	b := NewBuilder()
+
+	_, err := b.buildJob(&runner.ExtractedJob{
+		Name: "Release",
+		Data: map[string]any{
+			"RunsOn":      "ubuntu-latest",
+			"Permissions": map[string]any{"Contents": true},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "permissions") {
+		t.Errorf("buildJob() error = %v, want the permissions decode error", err)
+	}
+}
+
 func TestBuilder_buildWorkflow(t *testing.T) {
 	b := NewBuilder()
 
@@ -482,6 +599,24 @@ func TestBuilder_buildWorkflow(t *testing.T) {
 				return wf.Env != nil && wf.Env["NODE_VERSION"] == "18"
 			},
 		},
+		{
+			name: "workflow with empty permissions",
+			discovered: discover.DiscoveredWorkflow{
+				Name: "CI",
+				Jobs: []string{},
+			},
+			data: map[string]any{
+				"Name":        "CI",
+				"Permissions": map[string]any{},
+			},
+			jobNames:   []string{},
+			jobMap:     map[string]*runner.ExtractedJob{},
+			sortedJobs: []string{},
+			wantErr:    false,
+			validate: func(wf *workflow.Workflow) bool {
+				return wf.Permissions != nil && *wf.Permissions == workflow.Permissions{}
+			},
+		},
 		{
 			name: "workflow with triggers as workflow.Triggers",
 			discovered: discover.DiscoveredWorkflow{
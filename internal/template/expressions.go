@@ -0,0 +1,78 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// checkExpressions reports malformed ${{ }} expressions in extracted
+// workflows and jobs. If conditions may also be bare expressions.
+func (b *Builder) checkExpressions(discovered *discover.DiscoveryResult, extracted *runner.ExtractionResult, jobMap map[string]*runner.ExtractedJob) []string {
+	var errors []string
+
+	for _, ew := range extracted.Workflows {
+		for key, value := range ew.Data {
+			// Jobs are checked on their own below
+			if key == "Jobs" {
+				continue
+			}
+			for _, err := range expressionErrors(key, value) {
+				errors = append(errors, fmt.Sprintf("workflow %s: %v", ew.Name, err))
+			}
+		}
+	}
+
+	for _, dj := range discovered.Jobs {
+		ej, ok := jobMap[dj.Name]
+		if !ok {
+			continue
+		}
+		for key, value := range ej.Data {
+			// Unresolved Uses may hold a whole workflow
+			if key == "Uses" {
+				continue
+			}
+			for _, err := range expressionErrors(key, value) {
+				errors = append(errors, fmt.Sprintf("job %s: %v", dj.Name, err))
+			}
+		}
+	}
+
+	sort.Strings(errors)
+	return errors
+}
+
+// expressionErrors parses the expressions in a value stored under key.
+func expressionErrors(key string, v any) []error {
+	var errors []error
+
+	switch val := v.(type) {
+	case string:
+		var err error
+		if key == "If" && !strings.Contains(val, "${{") {
+			if strings.TrimSpace(val) != "" {
+				_, err = workflow.ParseExpression(val)
+			}
+		} else {
+			err = workflow.ValidateExpressions(val)
+		}
+		if err != nil {
+			errors = append(errors, err)
+		}
+	case []any:
+		for _, item := range val {
+			errors = append(errors, expressionErrors(key, item)...)
+		}
+	case map[string]any:
+		for k, item := range val {
+			errors = append(errors, expressionErrors(k, item)...)
+		}
+	}
+
+	return errors
+}
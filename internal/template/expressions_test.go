@@ -0,0 +1,55 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+)
+
+func TestBuilder_Build_MalformedExpressions(t *testing.T) {
+	discovered := &discover.DiscoveryResult{
+		Workflows: []discover.DiscoveredWorkflow{{Name: "CI", Jobs: []string{"Build"}}},
+		Jobs:      []discover.DiscoveredJob{{Name: "Build"}},
+	}
+
+	extracted := &runner.ExtractionResult{
+		Workflows: []runner.ExtractedWorkflow{{Name: "CI", Data: map[string]any{
+			"Name": "CI",
+			"Env":  map[string]any{"REF": "${{ github.ref }"},
+		}}},
+		Jobs: []runner.ExtractedJob{{Name: "Build", Data: map[string]any{
+			"If": "github.ref == ",
+			"Steps": []any{
+				map[string]any{"Run": "echo ${{ matrix.os }}", "If": "${{ success() }}"},
+				map[string]any{"Run": "echo ${{ contains(github.ref, 'x' }}"},
+			},
+		}}},
+	}
+
+	result, err := NewBuilder().Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	wantErrs := []string{
+		`workflow CI: invalid expression "${{ github.ref }": unterminated ${{`,
+		`job Build: invalid expression "github.ref == ": unexpected end of expression`,
+		`job Build: invalid expression "contains(github.ref, 'x'": expected ')' or ','`,
+	}
+	for _, want := range wantErrs {
+		found := false
+		for _, e := range result.Errors {
+			if strings.HasPrefix(e, want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Build() errors = %v, want one starting with %q", result.Errors, want)
+		}
+	}
+	if len(result.Errors) != len(wantErrs) {
+		t.Errorf("len(Build() errors) = %d, want %d: %v", len(result.Errors), len(wantErrs), result.Errors)
+	}
+}
@@ -0,0 +1,173 @@
+package template
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// jobOutputRef matches the needs expressions produced by Job.Output before
+// their placeholder job IDs are resolved.
+var jobOutputRef = regexp.MustCompile(`needs\.(` + regexp.QuoteMeta(workflow.JobRefPrefix) + `[0-9a-fx]+)\.outputs\.([A-Za-z0-9_-]+)`)
+
+// resolveJobOutputs rewrites output references created with Job.Output to
+// needs.<job-id>.outputs.<name>. It checks that the producing job declares
+// the output and that the consuming job needs it, directly or transitively.
+func (b *Builder) resolveJobOutputs(discovered *discover.DiscoveryResult, extracted *runner.ExtractionResult, jobMap map[string]*runner.ExtractedJob) []string {
+	var errors []string
+
+	deps := make(map[string][]string)
+	for _, dj := range discovered.Jobs {
+		deps[dj.Name] = dj.Dependencies
+	}
+
+	for _, dj := range discovered.Jobs {
+		ej, ok := jobMap[dj.Name]
+		if !ok {
+			continue
+		}
+
+		for key, value := range ej.Data {
+			// Uses may hold a whole workflow, whose jobs are not this job
+			if key == "Uses" {
+				continue
+			}
+			ej.Data[key] = mapStrings(value, func(s string) string {
+				return jobOutputRef.ReplaceAllStringFunc(s, func(match string) string {
+					m := jobOutputRef.FindStringSubmatch(match)
+					producer, ok := extracted.JobRefs[m[1]]
+					if !ok {
+						errors = append(errors, fmt.Sprintf("job %s: output %q references a job that is not a package-level variable", dj.Name, m[2]))
+						return match
+					}
+					if err := checkJobOutput(dj.Name, producer, m[2], jobMap, deps); err != "" {
+						errors = append(errors, err)
+						return match
+					}
+					return fmt.Sprintf("needs.%s.outputs.%s", jobID(producer, jobMap[producer]), m[2])
+				})
+			})
+		}
+	}
+
+	sort.Strings(errors)
+	return compactStrings(errors)
+}
+
+// resolveNeeds rewrites Job values in each job's Needs to the IDs the
+// needed jobs are written under. Extracted Job values arrive as maps; those
+// without a Name are matched back to the variables named in the Needs field.
+func (b *Builder) resolveNeeds(discovered *discover.DiscoveryResult, jobMap map[string]*runner.ExtractedJob) {
+	resolved := make(map[string][]any)
+
+	for _, dj := range discovered.Jobs {
+		ej, ok := jobMap[dj.Name]
+		if !ok {
+			continue
+		}
+		needs, ok := ej.Data["Needs"].([]any)
+		if !ok {
+			continue
+		}
+
+		ids := make([]any, len(needs))
+		for i, need := range needs {
+			needMap, ok := need.(map[string]any)
+			if !ok {
+				ids[i] = need
+				continue
+			}
+			name, _ := needMap["Name"].(string)
+			ids[i] = name
+			if name != "" {
+				continue
+			}
+			for _, dep := range dj.Dependencies {
+				if depJob, ok := jobMap[dep]; ok && reflect.DeepEqual(depJob.Data, needMap) {
+					ids[i] = dep
+					break
+				}
+			}
+		}
+		resolved[dj.Name] = ids
+	}
+
+	// Assign after matching so every comparison sees the original data
+	for name, ids := range resolved {
+		jobMap[name].Data["Needs"] = ids
+	}
+}
+
+// checkJobOutput reports why consumer may not reference the named output of
+// producer, or returns "" if the reference is valid.
+func checkJobOutput(consumer, producer, output string, jobMap map[string]*runner.ExtractedJob, deps map[string][]string) string {
+	ej, ok := jobMap[producer]
+	if !ok {
+		return fmt.Sprintf("job %s: output %q references unknown job %s", consumer, output, producer)
+	}
+	outputs, _ := ej.Data["Outputs"].(map[string]any)
+	if _, ok := outputs[output]; !ok {
+		return fmt.Sprintf("job %s: job %s has no output %q", consumer, producer, output)
+	}
+	if !needsJob(consumer, producer, deps, map[string]bool{}) {
+		return fmt.Sprintf("job %s: uses output %q of job %s but does not need it", consumer, output, producer)
+	}
+	return ""
+}
+
+// needsJob reports whether job depends on target, directly or transitively.
+func needsJob(job, target string, deps map[string][]string, visited map[string]bool) bool {
+	if visited[job] {
+		return false
+	}
+	visited[job] = true
+	for _, dep := range deps[job] {
+		if dep == target || needsJob(dep, target, deps, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// jobID returns the key a job is written under in the generated workflow.
+func jobID(name string, ej *runner.ExtractedJob) string {
+	if id, ok := ej.Data["Name"].(string); ok && id != "" {
+		return id
+	}
+	return name
+}
+
+// mapStrings applies fn to every string in a value extracted from JSON.
+func mapStrings(v any, fn func(string) string) any {
+	switch val := v.(type) {
+	case string:
+		return fn(val)
+	case []any:
+		for i, item := range val {
+			val[i] = mapStrings(item, fn)
+		}
+		return val
+	case map[string]any:
+		for k, item := range val {
+			val[k] = mapStrings(item, fn)
+		}
+		return val
+	}
+	return v
+}
+
+// compactStrings removes consecutive duplicates from a sorted slice.
+func compactStrings(s []string) []string {
+	var result []string
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,171 @@
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+)
+
+// jobOutputFixture returns discovery and extraction results for a Build job
+// with a "version" output and a Deploy job that references it.
+func jobOutputFixture(deployDeps []string, deployIf string) (*discover.DiscoveryResult, *runner.ExtractionResult) {
+	const ref = "_wetwire_job_0xc000010000"
+
+	discovered := &discover.DiscoveryResult{
+		Workflows: []discover.DiscoveredWorkflow{{Name: "CI", Jobs: []string{"Build", "Lint", "Deploy"}}},
+		Jobs: []discover.DiscoveredJob{
+			{Name: "Build"},
+			{Name: "Lint", Dependencies: []string{"Build"}},
+			{Name: "Deploy", Dependencies: deployDeps},
+		},
+	}
+
+	extracted := &runner.ExtractionResult{
+		Workflows: []runner.ExtractedWorkflow{{Name: "CI", Data: map[string]any{"Name": "CI"}}},
+		Jobs: []runner.ExtractedJob{
+			{Name: "Build", Data: map[string]any{
+				"Name":    "build",
+				"Outputs": map[string]any{"version": "${{ steps.v.outputs.version }}"},
+			}},
+			{Name: "Lint", Data: map[string]any{}},
+			{Name: "Deploy", Data: map[string]any{
+				"If": "${{ " + strings.ReplaceAll(deployIf, "REF", ref) + " }}",
+				"Steps": []any{
+					map[string]any{"Run": "echo ${{ needs." + ref + ".outputs.version }}"},
+				},
+			}},
+		},
+		JobRefs: map[string]string{ref: "Build"},
+	}
+
+	return discovered, extracted
+}
+
+func TestBuilder_Build_JobOutputs(t *testing.T) {
+	tests := []struct {
+		name string
+		deps []string
+	}{
+		{name: "direct", deps: []string{"Build"}},
+		{name: "transitive", deps: []string{"Lint"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovered, extracted := jobOutputFixture(tt.deps, "needs.REF.outputs.version != ''")
+
+			result, err := NewBuilder().Build(discovered, extracted)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if len(result.Errors) > 0 {
+				t.Fatalf("Build() errors = %v", result.Errors)
+			}
+
+			yaml := string(result.Workflows[0].YAML)
+			for _, want := range []string{
+				"if: needs.build.outputs.version != ''",
+				"run: echo ${{ needs.build.outputs.version }}",
+			} {
+				if !strings.Contains(yaml, want) {
+					t.Errorf("YAML missing %q:\n%s", want, yaml)
+				}
+			}
+		})
+	}
+}
+
+func TestBuilder_Build_JobOutputErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		deps    []string
+		ifCond  string
+		wantErr string
+	}{
+		{
+			name:    "undeclared output",
+			deps:    []string{"Build"},
+			ifCond:  "needs.REF.outputs.missing",
+			wantErr: `job Deploy: job Build has no output "missing"`,
+		},
+		{
+			name:    "not needed",
+			deps:    nil,
+			ifCond:  "needs.REF.outputs.version",
+			wantErr: `job Deploy: uses output "version" of job Build but does not need it`,
+		},
+		{
+			name:    "unknown job",
+			deps:    []string{"Build"},
+			ifCond:  "needs._wetwire_job_0xc0000ffff0.outputs.version",
+			wantErr: `job Deploy: output "version" references a job that is not a package-level variable`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovered, extracted := jobOutputFixture(tt.deps, tt.ifCond)
+
+			result, err := NewBuilder().Build(discovered, extracted)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			found := false
+			for _, e := range result.Errors {
+				if e == tt.wantErr {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Build() errors = %v, want %q", result.Errors, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuilder_Build_NeedsJobValues(t *testing.T) {
+	discovered := &discover.DiscoveryResult{
+		Workflows: []discover.DiscoveredWorkflow{{Name: "CI", Jobs: []string{"Build", "Lint", "Deploy"}}},
+		Jobs: []discover.DiscoveredJob{
+			{Name: "Build"},
+			{Name: "Lint"},
+			{Name: "Deploy", Dependencies: []string{"Build", "Lint"}},
+		},
+	}
+
+	// Job values in Needs arrive as the JSON form of the job
+	build := map[string]any{"Name": "build", "RunsOn": "ubuntu-latest"}
+	lint := map[string]any{"RunsOn": "ubuntu-latest"}
+	extracted := &runner.ExtractionResult{
+		Workflows: []runner.ExtractedWorkflow{{Name: "CI", Data: map[string]any{"Name": "CI"}}},
+		Jobs: []runner.ExtractedJob{
+			{Name: "Build", Data: build},
+			{Name: "Lint", Data: lint},
+			{Name: "Deploy", Data: map[string]any{
+				"Needs": []any{
+					map[string]any{"Name": "build", "RunsOn": "ubuntu-latest"},
+					map[string]any{"RunsOn": "ubuntu-latest"},
+					"setup",
+				},
+			}},
+		},
+	}
+
+	result, err := NewBuilder().Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Build() errors = %v", result.Errors)
+	}
+
+	got := result.Workflows[0].Workflow.Jobs["Deploy"].Needs
+	want := []any{"build", "Lint", "setup"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Needs = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package template
+
+import (
+	"github.com/lex00/wetwire-github-go/action"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// pinWorkflow returns a copy of wf with locked action and reusable workflow
+// references replaced by their commit SHAs. wf is returned unchanged when
+// the project has no lock file.
+func (b *Builder) pinWorkflow(wf *workflow.Workflow) *workflow.Workflow {
+	if b.Lock == nil {
+		return wf
+	}
+
+	pinned := *wf
+	pinned.Jobs = make(map[string]workflow.Job, len(wf.Jobs))
+	for id, job := range wf.Jobs {
+		if uses, ok := job.Uses.(string); ok {
+			job.Uses = b.Lock.Apply(uses)
+		}
+		job.Steps = b.pinSteps(job.Steps)
+		pinned.Jobs[id] = job
+	}
+	return &pinned
+}
+
+// pinAction returns a copy of a with locked references in composite steps
+// replaced by their commit SHAs.
+func (b *Builder) pinAction(a *action.Action) *action.Action {
+	if b.Lock == nil || len(a.Runs.Steps) == 0 {
+		return a
+	}
+
+	pinned := *a
+	pinned.Runs.Steps = b.pinSteps(a.Runs.Steps)
+	return &pinned
+}
+
+func (b *Builder) pinSteps(steps []any) []any {
+	pinned := make([]any, len(steps))
+	for i, s := range steps {
+		var step workflow.Step
+		switch v := s.(type) {
+		case workflow.Step:
+			step = v
+		case *workflow.Step:
+			step = *v
+		case workflow.StepAction:
+			step = workflow.ToStep(v)
+		default:
+			pinned[i] = s
+			continue
+		}
+		step.Uses = b.Lock.Apply(step.Uses)
+		pinned[i] = step
+	}
+	return pinned
+}
@@ -0,0 +1,91 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/action"
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/pin"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+const pinsTestSHA = "11bd71901bbe5b1630ceea73d27597364c9af683"
+
+func pinsTestLock() *pin.Lock {
+	return &pin.Lock{Actions: map[string]pin.Entry{
+		"actions/checkout@v4":                    {SHA: pinsTestSHA, Version: "v4.2.2"},
+		"org/shared/.github/workflows/ci.yml@v1": {SHA: pinsTestSHA},
+	}}
+}
+
+func TestBuilder_Build_PinnedUses(t *testing.T) {
+	discovered := &discover.DiscoveryResult{
+		Workflows: []discover.DiscoveredWorkflow{{Name: "CI", Jobs: []string{"Build", "Shared"}}},
+		Jobs:      []discover.DiscoveredJob{{Name: "Build"}, {Name: "Shared"}},
+	}
+	extracted := &runner.ExtractionResult{
+		Workflows: []runner.ExtractedWorkflow{{Name: "CI", Data: map[string]any{"Name": "CI"}}},
+		Jobs: []runner.ExtractedJob{
+			{Name: "Build", Data: map[string]any{
+				"RunsOn": "ubuntu-latest",
+				"Steps": []any{
+					map[string]any{"Uses": "actions/checkout@v4"},
+					map[string]any{"Uses": "actions/setup-go@v5"},
+				},
+			}},
+			{Name: "Shared", Data: map[string]any{
+				"Uses": "org/shared/.github/workflows/ci.yml@v1",
+			}},
+		},
+	}
+
+	b := NewBuilder()
+	b.Lock = pinsTestLock()
+	result, err := b.Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("Build() errors = %v", result.Errors)
+	}
+
+	yaml := string(result.Workflows[0].YAML)
+	for _, want := range []string{
+		"uses: actions/checkout@" + pinsTestSHA + " # v4.2.2",
+		"uses: actions/setup-go@v5\n",
+		"uses: org/shared/.github/workflows/ci.yml@" + pinsTestSHA + " # v1",
+	} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("YAML missing %q:\n%s", want, yaml)
+		}
+	}
+
+	// The built model keeps the references as declared
+	step := result.Workflows[0].Workflow.Jobs["Build"].Steps[0].(workflow.Step)
+	if step.Uses != "actions/checkout@v4" {
+		t.Errorf("model step uses = %q, want unpinned reference", step.Uses)
+	}
+}
+
+func TestBuilder_pinAction(t *testing.T) {
+	b := NewBuilder()
+	b.Lock = pinsTestLock()
+
+	a := &action.Action{
+		Name: "Setup",
+		Runs: action.Composite(
+			workflow.Step{Uses: "actions/checkout@v4"},
+			workflow.Step{Run: "make", Shell: "bash"},
+		),
+	}
+
+	pinned := b.pinAction(a)
+	if got := pinned.Runs.Steps[0].(workflow.Step).Uses; got != "actions/checkout@"+pinsTestSHA+" # v4.2.2" {
+		t.Errorf("pinned uses = %q", got)
+	}
+	if got := a.Runs.Steps[0].(workflow.Step).Uses; got != "actions/checkout@v4" {
+		t.Errorf("original action was modified: %q", got)
+	}
+}
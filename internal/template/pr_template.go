@@ -40,11 +40,12 @@ func (b *Builder) BuildPRTemplates(discovered *discover.PRTemplateDiscoveryResul
 	// Process each template
 	for _, dt := range discovered.Templates {
 		// Find the extracted template data
-		var content string
+		var content, templateName string
 		var found bool
 		for _, et := range extracted.Templates {
 			if et.Name == dt.Name {
 				content = et.Content
+				templateName = et.TemplateName
 				found = true
 				break
 			}
@@ -55,9 +56,9 @@ func (b *Builder) BuildPRTemplates(discovered *discover.PRTemplateDiscoveryResul
 			continue
 		}
 
-		// Create the PRTemplate
+		// Create the PRTemplate; its Name selects the output filename
 		tmpl := &templates.PRTemplate{
-			Name:    dt.Name,
+			Name:    templateName,
 			Content: content,
 		}
 
@@ -188,14 +188,10 @@ func TestBuilder_BuildPRTemplates_Filename(t *testing.T) {
 			}
 			extracted := &runner.PRTemplateExtractionResult{
 				Templates: []runner.ExtractedPRTemplate{
-					{Name: "Template", Content: "content"},
+					{Name: "Template", TemplateName: tt.templateName, Content: "content"},
 				},
 			}
 
-			// Mock the template name by modifying extracted data
-			// The Name field in PRTemplate determines the filename
-			discovered.Templates[0].Name = "Template"
-
 			result, err := b.BuildPRTemplates(discovered, extracted)
 			if err != nil {
 				t.Fatalf("BuildPRTemplates() error = %v", err)
@@ -207,6 +203,9 @@ func TestBuilder_BuildPRTemplates_Filename(t *testing.T) {
 
 			// The filename is determined by the PRTemplate.Filename() method
 			// which uses the Name field from the template
+			if result.Templates[0].Filename != tt.wantFilename {
+				t.Errorf("Filename = %q, want %q", result.Templates[0].Filename, tt.wantFilename)
+			}
 		})
 	}
 }
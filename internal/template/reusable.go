@@ -0,0 +1,232 @@
+package template
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// resolveWorkflowCalls rewrites jobs whose Uses field references a workflow
+// variable to the local path of its generated file, and checks that the call
+// supplies every required input and secret of the called workflow.
+func (b *Builder) resolveWorkflowCalls(discovered *discover.DiscoveryResult, extracted *runner.ExtractionResult, jobMap map[string]*runner.ExtractedJob) []string {
+	var errors []string
+
+	workflowData := make(map[string]map[string]any)
+	for _, ew := range extracted.Workflows {
+		workflowData[ew.Name] = ew.Data
+	}
+
+	for _, dj := range discovered.Jobs {
+		ej, ok := jobMap[dj.Name]
+		if !ok {
+			continue
+		}
+
+		// Uses may name a string constant rather than a workflow
+		uses := ej.Data["Uses"]
+		if _, ok := uses.(string); ok {
+			continue
+		}
+
+		// Anything else that is not a workflow of this module, such as a
+		// workflow from another module, would be dropped from the YAML
+		data, ok := workflowData[dj.Uses]
+		if !ok {
+			if uses != nil {
+				errors = append(errors, fmt.Sprintf("job %s: Uses must be a string or a workflow declared in this module", dj.Name))
+			}
+			continue
+		}
+
+		var triggers workflow.Triggers
+		if onMap, ok := data["On"].(map[string]any); ok {
+			var err error
+			if triggers, err = b.reconstructTriggers(onMap); err != nil {
+				errors = append(errors, fmt.Sprintf("job %s: workflow %s: %v", dj.Name, dj.Uses, err))
+				continue
+			}
+		}
+		if triggers.WorkflowCall == nil {
+			errors = append(errors, fmt.Sprintf("job %s: workflow %s has no workflow_call trigger", dj.Name, dj.Uses))
+			continue
+		}
+
+		ej.Data["Uses"] = b.localWorkflowPath(dj.Uses)
+
+		job, err := b.buildJob(ej)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("job %s: %v", dj.Name, err))
+			continue
+		}
+		errors = append(errors, validateWorkflowCall(dj.Name, dj.Uses, job, triggers.WorkflowCall)...)
+	}
+
+	return errors
+}
+
+// validateWorkflowCall checks a caller job's inputs and secrets against the
+// workflow_call trigger of the workflow it calls.
+func validateWorkflowCall(jobName, workflowName string, job *workflow.Job, call *workflow.WorkflowCallTrigger) []string {
+	var errors []string
+
+	for _, name := range sortedKeys(call.Inputs) {
+		if _, ok := job.With[name]; !ok && call.Inputs[name].Required {
+			errors = append(errors, fmt.Sprintf("job %s: missing required input %q for workflow %s", jobName, name, workflowName))
+		}
+	}
+	for _, name := range sortedKeys(job.With) {
+		if _, ok := call.Inputs[name]; !ok {
+			errors = append(errors, fmt.Sprintf("job %s: unknown input %q for workflow %s", jobName, name, workflowName))
+		}
+	}
+
+	// Inherited secrets are resolved by GitHub at run time
+	if job.Secrets == workflow.SecretsInherit {
+		return errors
+	}
+
+	secrets, _ := job.Secrets.(map[string]any)
+	for _, name := range sortedKeys(call.Secrets) {
+		if _, ok := secrets[name]; !ok && call.Secrets[name].Required {
+			errors = append(errors, fmt.Sprintf("job %s: missing required secret %q for workflow %s", jobName, name, workflowName))
+		}
+	}
+	for _, name := range sortedKeys(secrets) {
+		if _, ok := call.Secrets[name]; !ok {
+			errors = append(errors, fmt.Sprintf("job %s: unknown secret %q for workflow %s", jobName, name, workflowName))
+		}
+	}
+
+	return errors
+}
+
+// localWorkflowPath returns the path a caller uses to reference a workflow
+// generated from the given variable name.
+func (b *Builder) localWorkflowPath(name string) string {
+	dir := b.WorkflowDir
+	if dir == "" {
+		dir = ".github/workflows"
+	}
+	return "./" + path.Join(dir, ToFilename(name)+".yml")
+}
+
+// RepoWorkflowDir returns the WorkflowDir of workflows generated in
+// workflowDir for the module in modDir: ".github/workflows" when
+// workflowDir is a .github/workflows directory, whose parent is the
+// repository root, and workflowDir relative to the module otherwise.
+func RepoWorkflowDir(modDir, workflowDir string) string {
+	if filepath.Base(workflowDir) == "workflows" && filepath.Base(filepath.Dir(workflowDir)) == ".github" {
+		return ".github/workflows"
+	}
+	rel, err := filepath.Rel(modDir, workflowDir)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ToFilename converts a variable name to the kebab-case file name used for
+// generated output, e.g. "MyWorkflow" -> "my-workflow".
+func ToFilename(name string) string {
+	var result strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			result.WriteRune('-')
+		}
+		result.WriteRune(r)
+	}
+	return strings.ToLower(result.String())
+}
+
+// reconstructWorkflowInputs builds workflow inputs from a generic map.
+func reconstructWorkflowInputs(data any) map[string]workflow.WorkflowInput {
+	inputsMap, ok := data.(map[string]any)
+	if !ok || len(inputsMap) == 0 {
+		return nil
+	}
+
+	inputs := make(map[string]workflow.WorkflowInput, len(inputsMap))
+	for name, v := range inputsMap {
+		inputMap, _ := v.(map[string]any)
+		input := workflow.WorkflowInput{}
+		if desc, ok := inputMap["Description"].(string); ok {
+			input.Description = desc
+		}
+		if required, ok := inputMap["Required"].(bool); ok {
+			input.Required = required
+		}
+		if def, ok := inputMap["Default"]; ok {
+			input.Default = def
+		}
+		if typ, ok := inputMap["Type"].(string); ok {
+			input.Type = typ
+		}
+		if options, ok := inputMap["Options"].([]any); ok {
+			input.Options = anySliceToStrings(options)
+		}
+		inputs[name] = input
+	}
+	return inputs
+}
+
+// reconstructWorkflowOutputs builds workflow_call outputs from a generic map.
+func reconstructWorkflowOutputs(data any) map[string]workflow.WorkflowOutput {
+	outputsMap, ok := data.(map[string]any)
+	if !ok || len(outputsMap) == 0 {
+		return nil
+	}
+
+	outputs := make(map[string]workflow.WorkflowOutput, len(outputsMap))
+	for name, v := range outputsMap {
+		outputMap, _ := v.(map[string]any)
+		output := workflow.WorkflowOutput{}
+		if desc, ok := outputMap["Description"].(string); ok {
+			output.Description = desc
+		}
+		// Values are extracted in ${{ }} form; Expression adds its own
+		if value, ok := unwrapCondition(outputMap["Value"]).(string); ok {
+			output.Value = workflow.Expression(value)
+		}
+		outputs[name] = output
+	}
+	return outputs
+}
+
+// reconstructWorkflowSecrets builds workflow_call secrets from a generic map.
+func reconstructWorkflowSecrets(data any) map[string]workflow.WorkflowSecret {
+	secretsMap, ok := data.(map[string]any)
+	if !ok || len(secretsMap) == 0 {
+		return nil
+	}
+
+	secrets := make(map[string]workflow.WorkflowSecret, len(secretsMap))
+	for name, v := range secretsMap {
+		secretMap, _ := v.(map[string]any)
+		secret := workflow.WorkflowSecret{}
+		if desc, ok := secretMap["Description"].(string); ok {
+			secret.Description = desc
+		}
+		if required, ok := secretMap["Required"].(bool); ok {
+			secret.Required = required
+		}
+		secrets[name] = secret
+	}
+	return secrets
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,269 @@
+package template
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// sharedWorkflowData returns extracted data for a reusable workflow, in the
+// shape produced by the runner's JSON round-trip.
+func sharedWorkflowData() map[string]any {
+	return map[string]any{
+		"Name": "Shared",
+		"On": map[string]any{
+			"WorkflowCall": map[string]any{
+				"Inputs": map[string]any{
+					"version": map[string]any{"Required": true, "Type": "string"},
+					"debug":   map[string]any{"Type": "boolean", "Default": false},
+				},
+				"Secrets": map[string]any{
+					"token": map[string]any{"Required": true},
+				},
+			},
+		},
+	}
+}
+
+func TestBuilder_Build_ReusableWorkflowCall(t *testing.T) {
+	b := NewBuilder()
+
+	discovered := &discover.DiscoveryResult{
+		Workflows: []discover.DiscoveredWorkflow{
+			{Name: "SharedRelease", Jobs: []string{}},
+			{Name: "CI", Jobs: []string{"Release"}},
+		},
+		Jobs: []discover.DiscoveredJob{
+			{Name: "Release", Uses: "SharedRelease"},
+		},
+	}
+
+	extracted := &runner.ExtractionResult{
+		Workflows: []runner.ExtractedWorkflow{
+			{Name: "SharedRelease", Data: sharedWorkflowData()},
+			{Name: "CI", Data: map[string]any{"Name": "CI"}},
+		},
+		Jobs: []runner.ExtractedJob{
+			{
+				Name: "Release",
+				Data: map[string]any{
+					"Name":    "release",
+					"Uses":    sharedWorkflowData(),
+					"With":    map[string]any{"version": "1.2.3"},
+					"Secrets": map[string]any{"token": "${{ secrets.TOKEN }}"},
+				},
+			},
+		},
+	}
+
+	result, err := b.Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Build() errors = %v", result.Errors)
+	}
+
+	var ci *BuiltWorkflow
+	for i := range result.Workflows {
+		if result.Workflows[i].Name == "CI" {
+			ci = &result.Workflows[i]
+		}
+	}
+	if ci == nil {
+		t.Fatal("CI workflow not built")
+	}
+
+	job := ci.Workflow.Jobs["release"]
+	if job.Uses != "./.github/workflows/shared-release.yml" {
+		t.Errorf("Uses = %v, want ./.github/workflows/shared-release.yml", job.Uses)
+	}
+	if !strings.Contains(string(ci.YAML), "uses: ./.github/workflows/shared-release.yml") {
+		t.Errorf("YAML missing uses:\n%s", ci.YAML)
+	}
+}
+
+func TestBuilder_Build_ReusableWorkflowCallErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		called  map[string]any
+		jobData map[string]any
+		wantErr string
+	}{
+		{
+			name:    "not callable",
+			called:  map[string]any{"Name": "Shared", "On": map[string]any{"Push": map[string]any{}}},
+			jobData: map[string]any{},
+			wantErr: "has no workflow_call trigger",
+		},
+		{
+			name:   "missing required input",
+			called: sharedWorkflowData(),
+			jobData: map[string]any{
+				"Secrets": map[string]any{"token": "x"},
+			},
+			wantErr: `missing required input "version"`,
+		},
+		{
+			name:   "missing required secret",
+			called: sharedWorkflowData(),
+			jobData: map[string]any{
+				"With": map[string]any{"version": "1"},
+			},
+			wantErr: `missing required secret "token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder()
+			discovered := &discover.DiscoveryResult{
+				Workflows: []discover.DiscoveredWorkflow{{Name: "Shared"}},
+				Jobs:      []discover.DiscoveredJob{{Name: "Call", Uses: "Shared"}},
+			}
+			extracted := &runner.ExtractionResult{
+				Workflows: []runner.ExtractedWorkflow{{Name: "Shared", Data: tt.called}},
+				Jobs:      []runner.ExtractedJob{{Name: "Call", Data: tt.jobData}},
+			}
+
+			result, err := b.Build(discovered, extracted)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if !strings.Contains(strings.Join(result.Errors, "\n"), tt.wantErr) {
+				t.Errorf("Build() errors = %v, want %q", result.Errors, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuilder_Build_ReusableWorkflowCallUnresolved(t *testing.T) {
+	b := NewBuilder()
+	discovered := &discover.DiscoveryResult{
+		Workflows: []discover.DiscoveredWorkflow{{Name: "CI", Jobs: []string{"Call", "Remote"}}},
+		Jobs: []discover.DiscoveredJob{
+			{Name: "Call", Uses: "Deploy"},
+			{Name: "Remote", Uses: "RemoteRef"},
+		},
+	}
+	extracted := &runner.ExtractionResult{
+		Workflows: []runner.ExtractedWorkflow{{Name: "CI", Data: map[string]any{"Name": "CI"}}},
+		Jobs: []runner.ExtractedJob{
+			// A workflow from another module is not discovered
+			{Name: "Call", Data: map[string]any{"Uses": sharedWorkflowData()}},
+			{Name: "Remote", Data: map[string]any{"Uses": "org/repo/.github/workflows/ci.yml@v1"}},
+		},
+	}
+
+	result, err := b.Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := []string{"job Call: Uses must be a string or a workflow declared in this module"}
+	if !reflect.DeepEqual(result.Errors, want) {
+		t.Errorf("Build() errors = %v, want %v", result.Errors, want)
+	}
+}
+
+func TestValidateWorkflowCall(t *testing.T) {
+	call := &workflow.WorkflowCallTrigger{
+		Inputs: map[string]workflow.WorkflowInput{
+			"version": {Required: true},
+			"debug":   {},
+		},
+		Secrets: map[string]workflow.WorkflowSecret{
+			"token":    {Required: true},
+			"optional": {},
+		},
+	}
+
+	tests := []struct {
+		name string
+		job  workflow.Job
+		want []string
+	}{
+		{
+			name: "all supplied",
+			job: workflow.Job{
+				With:    map[string]any{"version": "1"},
+				Secrets: map[string]any{"token": "x"},
+			},
+		},
+		{
+			name: "inherit secrets",
+			job: workflow.Job{
+				With:    map[string]any{"version": "1"},
+				Secrets: workflow.SecretsInherit,
+			},
+		},
+		{
+			name: "missing everything",
+			job:  workflow.Job{},
+			want: []string{
+				`job Call: missing required input "version" for workflow Shared`,
+				`job Call: missing required secret "token" for workflow Shared`,
+			},
+		},
+		{
+			name: "unknown input and secret",
+			job: workflow.Job{
+				With:    map[string]any{"version": "1", "verbose": true},
+				Secrets: map[string]any{"token": "x", "extra": "y"},
+			},
+			want: []string{
+				`job Call: unknown input "verbose" for workflow Shared`,
+				`job Call: unknown secret "extra" for workflow Shared`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateWorkflowCall("Call", "Shared", &tt.job, call)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateWorkflowCall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalWorkflowPath(t *testing.T) {
+	tests := map[string]string{
+		"CI":            "./.github/workflows/c-i.yml",
+		"SharedRelease": "./.github/workflows/shared-release.yml",
+		"deploy":        "./.github/workflows/deploy.yml",
+	}
+	b := NewBuilder()
+	for name, want := range tests {
+		if got := b.localWorkflowPath(name); got != want {
+			t.Errorf("localWorkflowPath(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	b.WorkflowDir = "out/workflows/"
+	if got, want := b.localWorkflowPath("SharedRelease"), "./out/workflows/shared-release.yml"; got != want {
+		t.Errorf("localWorkflowPath() with WorkflowDir = %q, want %q", got, want)
+	}
+}
+
+func TestRepoWorkflowDir(t *testing.T) {
+	tests := []struct {
+		workflowDir string
+		want        string
+	}{
+		{"/repo/.github/workflows", ".github/workflows"},
+		{"/repo/site/.github/workflows", ".github/workflows"},
+		{"/repo/out", "out"},
+		{"/repo/out/workflows", "out/workflows"},
+	}
+	for _, tt := range tests {
+		if got := RepoWorkflowDir("/repo", filepath.FromSlash(tt.workflowDir)); got != tt.want {
+			t.Errorf("RepoWorkflowDir(%q) = %q, want %q", tt.workflowDir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/inventory"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// checkRunners reports runs-on values that are malformed, retired, or
+// missing from the runner inventory. Deprecated labels still build; the
+// linter warns about them. Without an inventory, retired images only get
+// a lint warning too, since the build can't tell whether a label names a
+// self-hosted runner.
+func (b *Builder) checkRunners(discovered *discover.DiscoveryResult, jobMap map[string]*runner.ExtractedJob) []string {
+	var errors []string
+
+	for _, dj := range discovered.Jobs {
+		ej, ok := jobMap[dj.Name]
+		if !ok {
+			continue
+		}
+		value, ok := ej.Data["RunsOn"]
+		if !ok || value == nil {
+			continue
+		}
+
+		runsOn, ok := workflow.ParseRunsOn(value)
+		if !ok {
+			errors = append(errors, fmt.Sprintf("job %s: unsupported runs-on value %v", dj.Name, value))
+			continue
+		}
+
+		for _, f := range b.Runners.Check(runsOn) {
+			if f.Problem == inventory.ProblemDeprecated || (f.Problem == inventory.ProblemRetired && b.Runners == nil) {
+				continue
+			}
+			errors = append(errors, fmt.Sprintf("job %s: %s", dj.Name, f.Message))
+		}
+	}
+
+	sort.Strings(errors)
+	return errors
+}
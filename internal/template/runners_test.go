@@ -0,0 +1,104 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/inventory"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+)
+
+func runnersTestInput(runsOn map[string]any) (*discover.DiscoveryResult, *runner.ExtractionResult) {
+	discovered := &discover.DiscoveryResult{
+		Workflows: []discover.DiscoveredWorkflow{{Name: "CI"}},
+	}
+	extracted := &runner.ExtractionResult{
+		Workflows: []runner.ExtractedWorkflow{{Name: "CI", Data: map[string]any{"Name": "CI"}}},
+	}
+	for name, value := range runsOn {
+		discovered.Workflows[0].Jobs = append(discovered.Workflows[0].Jobs, name)
+		discovered.Jobs = append(discovered.Jobs, discover.DiscoveredJob{Name: name})
+		extracted.Jobs = append(extracted.Jobs, runner.ExtractedJob{Name: name, Data: map[string]any{
+			"RunsOn": value,
+			"Steps":  []any{map[string]any{"Run": "make"}},
+		}})
+	}
+	return discovered, extracted
+}
+
+func TestBuilder_Build_RunsOn(t *testing.T) {
+	discovered, extracted := runnersTestInput(map[string]any{
+		"Group":  map[string]any{"group": "fleet", "labels": []any{"linux"}},
+		"Labels": []any{"self-hosted", "gpu"},
+		"Matrix": "${{ matrix.os }}",
+	})
+
+	result, err := NewBuilder().Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("Build() errors = %v", result.Errors)
+	}
+
+	yaml := string(result.Workflows[0].YAML)
+	for _, want := range []string{
+		"group: fleet",
+		"- self-hosted",
+		"runs-on: ${{ matrix.os }}",
+	} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("YAML missing %q:\n%s", want, yaml)
+		}
+	}
+}
+
+func TestBuilder_Build_RunnerInventory(t *testing.T) {
+	discovered, extracted := runnersTestInput(map[string]any{
+		"Old":        "ubuntu-20.04",
+		"Unknown":    []any{"self-hosted", "tpu"},
+		"Deprecated": "ubuntu-22.04",
+		"Known":      map[string]any{"group": "fleet"},
+		"Invalid":    42,
+	})
+
+	b := NewBuilder()
+	b.Runners = &inventory.Inventory{
+		Groups:     []string{"fleet"},
+		Deprecated: map[string]string{"ubuntu-22.04": ""},
+	}
+
+	result, err := b.Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	wantErrs := []string{
+		`job Invalid: unsupported runs-on value 42`,
+		`job Old: runner label "ubuntu-20.04" is retired`,
+		`job Unknown: runner label "tpu" is not a GitHub-hosted image`,
+	}
+	if len(result.Errors) != len(wantErrs) {
+		t.Fatalf("Build() errors = %v, want %d", result.Errors, len(wantErrs))
+	}
+	for i, want := range wantErrs {
+		if !strings.HasPrefix(result.Errors[i], want) {
+			t.Errorf("error %d = %q, want prefix %q", i, result.Errors[i], want)
+		}
+	}
+}
+
+func TestBuilder_Build_RetiredWithoutInventory(t *testing.T) {
+	discovered, extracted := runnersTestInput(map[string]any{
+		"Old": "ubuntu-20.04",
+	})
+
+	result, err := NewBuilder().Build(discovered, extracted)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Build() errors = %v, want retired images left to the linter", result.Errors)
+	}
+}
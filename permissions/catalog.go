@@ -0,0 +1,187 @@
+package permissions
+
+import (
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+const (
+	read  = workflow.PermissionRead
+	write = workflow.PermissionWrite
+)
+
+// Requirement describes the GITHUB_TOKEN scopes an action needs.
+type Requirement struct {
+	// Permissions are the scopes the action needs with its default inputs.
+	Permissions workflow.Permissions
+
+	// UnlessInputs lists inputs that replace GITHUB_TOKEN or OIDC with
+	// another credential. When any of them is set, Permissions are not needed.
+	UnlessInputs []string
+
+	// When adds scopes needed only for some input values.
+	When []Condition
+
+	// Dynamic marks actions whose needs depend on user code, such as
+	// actions/github-script. Jobs using them cannot be fully inferred.
+	Dynamic bool
+}
+
+// Condition adds scopes when an input is set.
+type Condition struct {
+	// Input is the input name.
+	Input string
+
+	// Value is the input value that triggers the condition. Empty matches
+	// any value other than "" and "false".
+	Value string
+
+	// Permissions are the additional scopes needed.
+	Permissions workflow.Permissions
+}
+
+// catalog maps action references, without the version, to their
+// requirements. Every wrapper in actions/* has an entry.
+var catalog = map[string]Requirement{
+	"actions-rs/cargo":                      {},
+	"actions-rs/toolchain":                  {},
+	"actions/add-to-project":                {UnlessInputs: []string{"github-token"}},
+	"actions/attest-build-provenance":       {Permissions: workflow.Permissions{IDToken: write, Attestations: write}},
+	"actions/cache":                         {},
+	"actions/checkout":                      {Permissions: workflow.Permissions{Contents: read}, UnlessInputs: []string{"token", "ssh-key"}},
+	"actions/configure-pages":               {Permissions: workflow.Permissions{Pages: read}, UnlessInputs: []string{"token"}},
+	"actions/create-github-app-token":       {},
+	"actions/create-release":                {Permissions: workflow.Permissions{Contents: write}},
+	"actions/dependency-review-action":      {Permissions: workflow.Permissions{Contents: read}, When: []Condition{{Input: "comment-summary-in-pr", Permissions: workflow.Permissions{PullRequests: write}}}},
+	"actions/deploy-pages":                  {Permissions: workflow.Permissions{Pages: write, IDToken: write}},
+	"actions/download-artifact":             {When: []Condition{{Input: "run-id", Permissions: workflow.Permissions{Actions: read}}}},
+	"actions/first-interaction":             {Permissions: workflow.Permissions{Issues: write, PullRequests: write}},
+	"actions/github-script":                 {Dynamic: true},
+	"actions/labeler":                       {Permissions: workflow.Permissions{Contents: read, PullRequests: write}, UnlessInputs: []string{"repo-token"}},
+	"actions/setup-dotnet":                  {},
+	"actions/setup-go":                      {},
+	"actions/setup-java":                    {},
+	"actions/setup-node":                    {},
+	"actions/setup-python":                  {},
+	"actions/stale":                         {Permissions: workflow.Permissions{Issues: write, PullRequests: write}, UnlessInputs: []string{"repo-token"}},
+	"actions/upload-artifact":               {},
+	"actions/upload-pages-artifact":         {},
+	"actions/upload-release-asset":          {Permissions: workflow.Permissions{Contents: write}},
+	"anothrNick/github-tag-action":          {Permissions: workflow.Permissions{Contents: write}},
+	"aquasecurity/trivy-action":             {},
+	"aws-actions/amazon-ecr-login":          {},
+	"aws-actions/configure-aws-credentials": {Permissions: workflow.Permissions{IDToken: write}, UnlessInputs: []string{"aws-access-key-id"}},
+	"azure/docker-login":                    {},
+	"azure/k8s-set-context":                 {},
+	"azure/login":                           {Permissions: workflow.Permissions{IDToken: write}, UnlessInputs: []string{"creds"}},
+	"azure/setup-helm":                      {},
+	"azure/webapps-deploy":                  {},
+	"codecov/codecov-action":                {When: []Condition{{Input: "use_oidc", Permissions: workflow.Permissions{IDToken: write}}}},
+	"crazy-max/ghaction-import-gpg":         {},
+	"dawidd6/action-download-artifact":      {Permissions: workflow.Permissions{Actions: read}, UnlessInputs: []string{"github_token"}},
+	"docker/build-push-action":              {},
+	"docker/login-action":                   {When: []Condition{{Input: "registry", Value: "ghcr.io", Permissions: workflow.Permissions{Packages: write}}}},
+	"docker/metadata-action":                {Permissions: workflow.Permissions{Contents: read}},
+	"docker/setup-buildx-action":            {},
+	"dtolnay/rust-toolchain":                {},
+	"EndBug/add-and-commit":                 {Permissions: workflow.Permissions{Contents: write}},
+	"fossas/fossa-action":                   {},
+	"github/codeql-action/analyze":          {Permissions: workflow.Permissions{Actions: read, Contents: read, SecurityEvents: write}},
+	"github/codeql-action/init":             {Permissions: workflow.Permissions{Contents: read}},
+	"github/codeql-action/upload-sarif":     {Permissions: workflow.Permissions{SecurityEvents: write}},
+	"golangci/golangci-lint-action":         {Permissions: workflow.Permissions{Contents: read}, When: []Condition{{Input: "only-new-issues", Permissions: workflow.Permissions{PullRequests: read}}}},
+	"google-github-actions/auth":            {Permissions: workflow.Permissions{IDToken: write}, UnlessInputs: []string{"credentials_json"}},
+	"google-github-actions/deploy-cloudrun": {},
+	"google-github-actions/setup-gcloud":    {},
+	"hashicorp/setup-terraform":             {},
+	"helm/chart-releaser-action":            {Permissions: workflow.Permissions{Contents: write}},
+	"helm/kind-action":                      {},
+	"JamesIves/github-pages-deploy-action":  {Permissions: workflow.Permissions{Contents: write}, UnlessInputs: []string{"token", "ssh-key"}},
+	"mikepenz/action-junit-report":          {Permissions: workflow.Permissions{Checks: write}, UnlessInputs: []string{"token"}},
+	"ncipollo/release-action":               {Permissions: workflow.Permissions{Contents: write}, UnlessInputs: []string{"token"}},
+	"ossf/scorecard-action":                 {Permissions: workflow.Permissions{Actions: read, Contents: read}, When: []Condition{{Input: "publish_results", Permissions: workflow.Permissions{IDToken: write}}}},
+	"peaceiris/actions-gh-pages":            {Permissions: workflow.Permissions{Contents: write}, UnlessInputs: []string{"personal_token", "deploy_key"}},
+	"peaceiris/actions-hugo":                {},
+	"peter-evans/create-pull-request":       {Permissions: workflow.Permissions{Contents: write, PullRequests: write}, UnlessInputs: []string{"token"}},
+	"pre-commit/action":                     {},
+	"pulumi/actions":                        {When: []Condition{{Input: "comment-on-pr", Permissions: workflow.Permissions{PullRequests: write}}}},
+	"reviewdog/action-reviewdog":            {},
+	"reviewdog/action-setup":                {},
+	"ruby/setup-ruby":                       {},
+	"sigstore/cosign-installer":             {},
+	"slackapi/slack-github-action":          {},
+	"softprops/action-gh-release":           {Permissions: workflow.Permissions{Contents: write}, UnlessInputs: []string{"token"}},
+	"SonarSource/sonarcloud-github-action":  {},
+	"stefanprodan/kustomize-action":         {},
+	"super-linter/super-linter":             {Permissions: workflow.Permissions{Contents: read, Statuses: write}},
+}
+
+// Lookup returns the requirement for an action reference such as
+// "actions/checkout@v4". Matching ignores the version and the case of the
+// owner and repository.
+func Lookup(uses string) (Requirement, bool) {
+	ref := uses
+	if i := strings.Index(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+	if req, ok := catalog[ref]; ok {
+		return req, true
+	}
+	for key, req := range catalog {
+		if strings.EqualFold(key, ref) {
+			return req, true
+		}
+	}
+	return Requirement{}, false
+}
+
+// resolve returns the scopes an action needs with the given inputs.
+func (r Requirement) resolve(with map[string]any) Scopes {
+	scopes := Scopes{}
+
+	replaced := false
+	for _, input := range r.UnlessInputs {
+		if isSet(with[input], "") {
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		scopes.Merge(ScopesOf(&r.Permissions))
+	}
+
+	for _, c := range r.When {
+		if isSet(with[c.Input], c.Value) {
+			scopes.Merge(ScopesOf(&c.Permissions))
+		}
+	}
+
+	return scopes
+}
+
+// isSet reports whether an input value is set, or equals want if want is
+// not empty.
+func isSet(v any, want string) bool {
+	if v == nil {
+		return false
+	}
+	var s string
+	switch val := v.(type) {
+	case string:
+		s = val
+	case workflow.Expression:
+		s = val.String()
+	case bool:
+		if !val {
+			return false
+		}
+		s = "true"
+	default:
+		return true
+	}
+	if want != "" {
+		return s == want
+	}
+	return s != "" && s != "false"
+}
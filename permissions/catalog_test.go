@@ -0,0 +1,80 @@
+package permissions
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var actionRefPattern = regexp.MustCompile(`return "([^"@]+)@[^"]*"`)
+
+func TestCatalog_CoversWrappers(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "actions", "*", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range actionRefPattern.FindAllStringSubmatch(string(src), -1) {
+			found++
+			if _, ok := Lookup(m[1] + "@v1"); !ok {
+				t.Errorf("%s: %s has no catalog entry", file, m[1])
+			}
+		}
+	}
+	if found == 0 {
+		t.Fatal("no action wrappers found")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	req, ok := Lookup("peter-evans/create-pull-request@v7")
+	if !ok {
+		t.Fatal("create-pull-request not found")
+	}
+	if req.Permissions.Contents != write || req.Permissions.PullRequests != write {
+		t.Errorf("create-pull-request permissions = %+v", req.Permissions)
+	}
+
+	if _, ok := Lookup("Actions/Checkout@v4"); !ok {
+		t.Error("lookup should ignore case")
+	}
+	if _, ok := Lookup("example/unknown@v1"); ok {
+		t.Error("unknown action should not be found")
+	}
+}
+
+func TestRequirement_Resolve(t *testing.T) {
+	tests := []struct {
+		name string
+		uses string
+		with map[string]any
+		want string
+	}{
+		{"defaults", "actions/deploy-pages@v4", nil, "id-token: write, pages: write"},
+		{"replaced credential", "actions/checkout@v4", map[string]any{"token": "${{ secrets.PAT }}"}, ""},
+		{"condition met", "docker/login-action@v3", map[string]any{"registry": "ghcr.io"}, "packages: write"},
+		{"condition not met", "docker/login-action@v3", map[string]any{"registry": "docker.io"}, ""},
+		{"boolean condition", "codecov/codecov-action@v5", map[string]any{"use_oidc": true}, "id-token: write"},
+		{"false condition", "codecov/codecov-action@v5", map[string]any{"use_oidc": "false"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := Lookup(tt.uses)
+			if got := req.resolve(tt.with).String(); got != tt.want {
+				t.Errorf("resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
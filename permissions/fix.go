@@ -0,0 +1,211 @@
+package permissions
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// levelConstants maps access levels to the workflow package constants.
+var levelConstants = map[string]string{
+	workflow.PermissionRead:  "PermissionRead",
+	workflow.PermissionWrite: "PermissionWrite",
+	workflow.PermissionNone:  "PermissionNone",
+}
+
+// Fix writes the inferred permissions of each job that needs a change back
+// into its Go declaration. Jobs whose needs are not fully known are left
+// alone. It returns the files it changed.
+func Fix(reports []JobReport) ([]string, error) {
+	byFile := make(map[string]map[string]Scopes)
+	for _, r := range reports {
+		if r.OK() || !r.Fixable() || r.File == "" || r.Variable == "" {
+			continue
+		}
+		if byFile[r.File] == nil {
+			byFile[r.File] = make(map[string]Scopes)
+		}
+		byFile[r.File][r.Variable] = r.Required
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var changed []string
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return changed, err
+		}
+		fixed, err := FixSource(src, byFile[file])
+		if err != nil {
+			return changed, fmt.Errorf("%s: %w", file, err)
+		}
+		if string(fixed) == string(src) {
+			continue
+		}
+		if err := os.WriteFile(file, fixed, 0644); err != nil {
+			return changed, err
+		}
+		changed = append(changed, file)
+	}
+	return changed, nil
+}
+
+// FixSource sets the Permissions field of the named job variables in src,
+// replacing any existing value.
+func FixSource(src []byte, jobs map[string]Scopes) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				scopes, ok := jobs[name.Name]
+				if !ok || i >= len(vs.Values) {
+					continue
+				}
+				lit, qualifier, ok := jobLiteral(vs.Values[i])
+				if !ok {
+					continue
+				}
+				value := scopes.Literal(qualifier)
+
+				if kv := findField(lit, "Permissions"); kv != nil {
+					edits = append(edits, edit{
+						start: fset.Position(kv.Value.Pos()).Offset,
+						end:   fset.Position(kv.Value.End()).Offset,
+						text:  value,
+					})
+					continue
+				}
+
+				field := "Permissions: " + value
+				var ins edit
+				switch last := len(lit.Elts) - 1; {
+				case last < 0:
+					at := fset.Position(lit.Rbrace).Offset
+					ins = edit{start: at, end: at, text: "\n" + field + ",\n"}
+				default:
+					at := fset.Position(lit.Elts[last].End()).Offset
+					if comma := nextComma(src, at); comma >= 0 {
+						ins = edit{start: comma + 1, end: comma + 1, text: "\n" + field + ","}
+					} else {
+						ins = edit{start: at, end: at, text: ",\n" + field}
+					}
+				}
+				edits = append(edits, ins)
+			}
+		}
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	out := append([]byte(nil), src...)
+	for _, e := range edits {
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, fmt.Errorf("formatting fixed source: %w", err)
+	}
+	return formatted, nil
+}
+
+// jobLiteral returns the workflow.Job composite literal in expr and the
+// package qualifier used for it.
+func jobLiteral(expr ast.Expr) (*ast.CompositeLit, string, bool) {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil, "", false
+	}
+	switch t := lit.Type.(type) {
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if ok && t.Sel.Name == "Job" {
+			return lit, pkg.Name + ".", true
+		}
+	case *ast.Ident:
+		if t.Name == "Job" {
+			return lit, "", true
+		}
+	}
+	return nil, "", false
+}
+
+func findField(lit *ast.CompositeLit, name string) *ast.KeyValueExpr {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+				return kv
+			}
+		}
+	}
+	return nil
+}
+
+// nextComma returns the offset of a comma following offset, skipping
+// blanks, or -1 if the next character is something else.
+func nextComma(src []byte, offset int) int {
+	for i := offset; i < len(src); i++ {
+		switch src[i] {
+		case ' ', '\t':
+			continue
+		case ',':
+			return i
+		}
+		return -1
+	}
+	return -1
+}
+
+// Literal renders the scopes as a Go &workflow.Permissions{...} literal,
+// with fields in declaration order. The qualifier is the prefix of the
+// workflow package in the file, such as "workflow.", or "" for a dot import.
+func (s Scopes) Literal(qualifier string) string {
+	p := s.Permissions()
+	v := reflect.ValueOf(*p)
+	t := v.Type()
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		level := v.Field(i).String()
+		if level == "" {
+			continue
+		}
+		value := fmt.Sprintf("%q", level)
+		if c, ok := levelConstants[level]; ok {
+			value = qualifier + c
+		}
+		fields = append(fields, t.Field(i).Name+": "+value)
+	}
+	return "&" + qualifier + "Permissions{" + strings.Join(fields, ", ") + "}"
+}
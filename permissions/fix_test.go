@@ -0,0 +1,124 @@
+package permissions
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFixSource(t *testing.T) {
+	src := `package ci
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = workflow.Job{
+	RunsOn: "ubuntu-latest",
+	Steps:  BuildSteps,
+}
+
+var Inline = workflow.Job{RunsOn: "ubuntu-latest"}
+
+var Empty = workflow.Job{}
+
+var Release = workflow.Job{
+	RunsOn:      "ubuntu-latest",
+	Permissions: &workflow.Permissions{Contents: workflow.PermissionRead, Issues: workflow.PermissionWrite},
+}
+
+var Other = workflow.Job{RunsOn: "ubuntu-latest"}
+`
+	want := `package ci
+
+import "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = workflow.Job{
+	RunsOn:      "ubuntu-latest",
+	Steps:       BuildSteps,
+	Permissions: &workflow.Permissions{Contents: workflow.PermissionRead},
+}
+
+var Inline = workflow.Job{RunsOn: "ubuntu-latest",
+	Permissions: &workflow.Permissions{}}
+
+var Empty = workflow.Job{
+	Permissions: &workflow.Permissions{IDToken: workflow.PermissionWrite, Pages: workflow.PermissionWrite},
+}
+
+var Release = workflow.Job{
+	RunsOn:      "ubuntu-latest",
+	Permissions: &workflow.Permissions{Contents: workflow.PermissionWrite},
+}
+
+var Other = workflow.Job{RunsOn: "ubuntu-latest"}
+`
+
+	got, err := FixSource([]byte(src), map[string]Scopes{
+		"Build":   {"contents": "read"},
+		"Inline":  {},
+		"Empty":   {"pages": "write", "id-token": "write"},
+		"Release": {"contents": "write"},
+	})
+	if err != nil {
+		t.Fatalf("FixSource() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("FixSource() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFixSource_DotImport(t *testing.T) {
+	src := `package ci
+
+import . "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = Job{
+	RunsOn: "ubuntu-latest",
+}
+`
+	want := `package ci
+
+import . "github.com/lex00/wetwire-github-go/workflow"
+
+var Build = Job{
+	RunsOn:      "ubuntu-latest",
+	Permissions: &Permissions{Contents: PermissionRead},
+}
+`
+
+	got, err := FixSource([]byte(src), map[string]Scopes{"Build": {"contents": "read"}})
+	if err != nil {
+		t.Fatalf("FixSource() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("FixSource() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFix(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "jobs.go")
+	src := "package ci\n\nimport \"github.com/lex00/wetwire-github-go/workflow\"\n\nvar Build = workflow.Job{\n\tRunsOn: \"ubuntu-latest\",\n}\n"
+	if err := os.WriteFile(file, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reports := []JobReport{
+		{Workflow: "CI", Job: "build", Variable: "Build", File: file, Source: SourceDefault, Required: Scopes{"contents": "read"}},
+		{Workflow: "CI", Job: "lint", Variable: "Lint", File: file, Source: SourceDefault, Unknown: []string{"example/lint@v1"}},
+	}
+
+	changed, err := Fix(reports)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{file}) {
+		t.Errorf("changed = %v", changed)
+	}
+
+	data, _ := os.ReadFile(file)
+	if !strings.Contains(string(data), "Permissions: &workflow.Permissions{Contents: workflow.PermissionRead}") {
+		t.Errorf("fixed file:\n%s", data)
+	}
+}
@@ -0,0 +1,68 @@
+package permissions
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/internal/template"
+)
+
+// Load builds the workflows declared in a Go package and checks the
+// permissions of every job. Reports follow workflow declaration order and
+// point at the Go declaration of each job.
+func Load(dir string) ([]JobReport, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving path: %w", err)
+	}
+
+	discovered, err := discover.NewDiscoverer().Discover(dir)
+	if err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	if len(discovered.Workflows) == 0 {
+		return nil, fmt.Errorf("no workflows found in %s", dir)
+	}
+
+	extracted, err := runner.NewRunner().ExtractValues(dir, discovered)
+	if err != nil {
+		return nil, fmt.Errorf("extraction failed: %w", err)
+	}
+	if extracted.Error != "" {
+		return nil, fmt.Errorf("extraction failed: %s", extracted.Error)
+	}
+
+	built, err := template.NewBuilder().Build(discovered, extracted)
+	if err != nil {
+		return nil, fmt.Errorf("template build failed: %w", err)
+	}
+	if errs := append(discovered.Errors, built.Errors...); len(errs) > 0 {
+		return nil, fmt.Errorf("build failed: %s", strings.Join(errs, "; "))
+	}
+
+	jobs := make(map[string]discover.DiscoveredJob, len(discovered.Jobs))
+	for _, dj := range discovered.Jobs {
+		jobs[dj.Name] = dj
+	}
+
+	var reports []JobReport
+	for _, bw := range built.Workflows {
+		variables := make(map[string]string, len(bw.JobIDs))
+		for name, id := range bw.JobIDs {
+			variables[id] = name
+		}
+
+		for _, report := range Check(bw.Name, bw.Workflow) {
+			if name, ok := variables[report.Job]; ok {
+				report.Variable = name
+				report.File = jobs[name].File
+				report.Line = jobs[name].Line
+			}
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
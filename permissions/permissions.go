@@ -0,0 +1,311 @@
+// Package permissions infers the least-privilege GITHUB_TOKEN permissions
+// each job needs from the actions and commands it runs.
+//
+// Requirements come from a catalog of the typed action wrappers in actions/*
+// and from a few well-known patterns in run steps, such as git push. Jobs
+// are compared against the permissions they are granted to report over- and
+// under-granted scopes.
+package permissions
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// Scopes maps permission scope names, as they appear in YAML (e.g.
+// "pull-requests"), to access levels.
+type Scopes map[string]string
+
+// levelRank orders access levels.
+var levelRank = map[string]int{
+	"":                       0,
+	workflow.PermissionNone:  0,
+	workflow.PermissionRead:  1,
+	workflow.PermissionWrite: 2,
+}
+
+// ScopesOf returns the scopes set in p. A nil p has no scopes.
+func ScopesOf(p *workflow.Permissions) Scopes {
+	scopes := Scopes{}
+	if p == nil {
+		return scopes
+	}
+	v := reflect.ValueOf(*p)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if level := v.Field(i).String(); level != "" {
+			scopes[scopeName(t.Field(i))] = level
+		}
+	}
+	return scopes
+}
+
+// Permissions converts the scopes to workflow.Permissions. Unknown scope
+// names are ignored.
+func (s Scopes) Permissions() *workflow.Permissions {
+	p := &workflow.Permissions{}
+	v := reflect.ValueOf(p).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if level, ok := s[scopeName(t.Field(i))]; ok {
+			v.Field(i).SetString(level)
+		}
+	}
+	return p
+}
+
+// Merge raises each scope in s to at least the level in other.
+func (s Scopes) Merge(other Scopes) {
+	for scope, level := range other {
+		if levelRank[level] > levelRank[s[scope]] {
+			s[scope] = level
+		}
+	}
+}
+
+// Names returns the scope names in sorted order.
+func (s Scopes) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String formats the scopes as "contents: read, pull-requests: write".
+func (s Scopes) String() string {
+	parts := make([]string, 0, len(s))
+	for _, name := range s.Names() {
+		parts = append(parts, name+": "+s[name])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func scopeName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	return name
+}
+
+// Inference is the set of scopes a job needs.
+type Inference struct {
+	// Required are the scopes the job's steps need.
+	Required Scopes
+
+	// Unknown lists actions that are not in the catalog.
+	Unknown []string
+
+	// Uncertain lists steps whose needs cannot be determined, such as
+	// scripts that use the token directly.
+	Uncertain []string
+}
+
+// Complete reports whether every step's needs are known.
+func (inf Inference) Complete() bool {
+	return len(inf.Unknown) == 0 && len(inf.Uncertain) == 0
+}
+
+// Infer returns the scopes a job's steps need.
+func Infer(job workflow.Job) Inference {
+	inf := Inference{Required: Scopes{}}
+
+	for i, s := range job.Steps {
+		var step workflow.Step
+		switch v := s.(type) {
+		case workflow.Step:
+			step = v
+		case *workflow.Step:
+			step = *v
+		case workflow.StepAction:
+			step = workflow.Step{Uses: v.Action(), With: v.Inputs()}
+		default:
+			continue
+		}
+
+		label := stepLabel(i, step)
+
+		if step.Uses != "" {
+			req, ok := Lookup(step.Uses)
+			switch {
+			case strings.HasPrefix(step.Uses, "docker://"):
+				// Container steps do not receive the token unless passed in.
+			case !ok:
+				inf.Unknown = appendUnique(inf.Unknown, step.Uses)
+			case req.Dynamic:
+				inf.Uncertain = append(inf.Uncertain, label+" runs "+step.Uses)
+			default:
+				inf.Required.Merge(req.resolve(step.With))
+			}
+		}
+
+		if step.Run != "" {
+			if strings.Contains(step.Run, "git push") {
+				inf.Required.Merge(Scopes{"contents": workflow.PermissionWrite})
+			}
+			if usesToken(step.Run) || usesToken(fmt.Sprint(step.Env)) {
+				inf.Uncertain = append(inf.Uncertain, label+" uses the token directly")
+			}
+		}
+	}
+
+	return inf
+}
+
+// usesToken reports whether s references the GITHUB_TOKEN.
+func usesToken(s string) bool {
+	return strings.Contains(s, "secrets.GITHUB_TOKEN") || strings.Contains(s, "github.token")
+}
+
+func stepLabel(i int, step workflow.Step) string {
+	switch {
+	case step.Name != "":
+		return fmt.Sprintf("step %q", step.Name)
+	case step.ID != "":
+		return fmt.Sprintf("step %q", step.ID)
+	}
+	return fmt.Sprintf("step %d", i+1)
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// JobReport compares the permissions a job is granted with those it needs.
+type JobReport struct {
+	// Workflow is the workflow variable name
+	Workflow string `json:"workflow"`
+
+	// Job is the job ID
+	Job string `json:"job"`
+
+	// Variable is the job variable name
+	Variable string `json:"variable,omitempty"`
+
+	// File is the source file declaring the job
+	File string `json:"file,omitempty"`
+
+	// Line is the line of the job declaration
+	Line int `json:"line,omitempty"`
+
+	// Source tells where the granted permissions come from: "job",
+	// "workflow" or "default" when none are declared
+	Source string `json:"source"`
+
+	// Granted are the declared scopes
+	Granted Scopes `json:"granted"`
+
+	// Required are the inferred scopes
+	Required Scopes `json:"required"`
+
+	// Over are granted scopes above what the job needs
+	Over Scopes `json:"over,omitempty"`
+
+	// Under are needed scopes the job is not granted
+	Under Scopes `json:"under,omitempty"`
+
+	// Unknown lists actions missing from the catalog
+	Unknown []string `json:"unknown,omitempty"`
+
+	// Uncertain lists steps whose needs cannot be inferred
+	Uncertain []string `json:"uncertain,omitempty"`
+}
+
+// Sources of granted permissions.
+const (
+	SourceJob      = "job"
+	SourceWorkflow = "workflow"
+	SourceDefault  = "default"
+)
+
+// OK reports whether the job's permissions match its needs exactly.
+func (r JobReport) OK() bool {
+	return r.Source != SourceDefault && len(r.Over) == 0 && len(r.Under) == 0
+}
+
+// Fixable reports whether the inferred permissions are complete enough to
+// write back to the source.
+func (r JobReport) Fixable() bool {
+	return len(r.Unknown) == 0 && len(r.Uncertain) == 0
+}
+
+// Check compares each job in wf with the permissions it needs. Jobs that
+// call reusable workflows are skipped: their needs are declared by the
+// called workflow. Reports are ordered by job ID.
+func Check(name string, wf *workflow.Workflow) []JobReport {
+	ids := make([]string, 0, len(wf.Jobs))
+	for id := range wf.Jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var reports []JobReport
+	for _, id := range ids {
+		job := wf.Jobs[id]
+		if job.Uses != nil && job.Uses != "" {
+			continue
+		}
+
+		inf := Infer(job)
+		report := JobReport{
+			Workflow:  name,
+			Job:       id,
+			Required:  inf.Required,
+			Unknown:   inf.Unknown,
+			Uncertain: inf.Uncertain,
+		}
+
+		switch {
+		case job.Permissions != nil:
+			report.Source = SourceJob
+			report.Granted = ScopesOf(job.Permissions)
+		case wf.Permissions != nil:
+			report.Source = SourceWorkflow
+			report.Granted = ScopesOf(wf.Permissions)
+		default:
+			report.Source = SourceDefault
+		}
+
+		if report.Source != SourceDefault {
+			report.Over, report.Under = compare(report.Granted, report.Required)
+			if !inf.Complete() {
+				// Scopes the unknown steps may rely on are not over-granted.
+				report.Over = nil
+			}
+		}
+
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// compare returns granted scopes above the required level and required
+// scopes above the granted level.
+func compare(granted, required Scopes) (over, under Scopes) {
+	over, under = Scopes{}, Scopes{}
+	for scope, level := range granted {
+		if levelRank[level] > levelRank[required[scope]] {
+			over[scope] = level
+		}
+	}
+	for scope, level := range required {
+		if levelRank[level] > levelRank[granted[scope]] {
+			under[scope] = level
+		}
+	}
+	if len(over) == 0 {
+		over = nil
+	}
+	if len(under) == 0 {
+		under = nil
+	}
+	return over, under
+}
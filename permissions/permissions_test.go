@@ -0,0 +1,133 @@
+package permissions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/actions/checkout"
+	"github.com/lex00/wetwire-github-go/actions/create_pull_request"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+func TestScopes_RoundTrip(t *testing.T) {
+	p := &workflow.Permissions{Contents: "read", PullRequests: "write", IDToken: "write"}
+
+	scopes := ScopesOf(p)
+	want := Scopes{"contents": "read", "pull-requests": "write", "id-token": "write"}
+	if !reflect.DeepEqual(scopes, want) {
+		t.Errorf("ScopesOf() = %v, want %v", scopes, want)
+	}
+	if got := scopes.Permissions(); !reflect.DeepEqual(got, p) {
+		t.Errorf("Permissions() = %+v, want %+v", got, p)
+	}
+	if len(ScopesOf(nil)) != 0 {
+		t.Error("ScopesOf(nil) should be empty")
+	}
+}
+
+func TestScopes_Merge(t *testing.T) {
+	s := Scopes{"contents": "write", "issues": "read"}
+	s.Merge(Scopes{"contents": "read", "issues": "write", "pages": "read"})
+
+	want := Scopes{"contents": "write", "issues": "write", "pages": "read"}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Merge() = %v, want %v", s, want)
+	}
+}
+
+func TestInfer(t *testing.T) {
+	job := workflow.Job{
+		Steps: []any{
+			checkout.Checkout{},
+			create_pull_request.CreatePullRequest{Title: "Update"},
+			workflow.Step{Uses: "actions/deploy-pages@v4"},
+			workflow.Step{Run: "git push origin HEAD"},
+		},
+	}
+
+	inf := Infer(job)
+	want := Scopes{"contents": "write", "pull-requests": "write", "pages": "write", "id-token": "write"}
+	if !reflect.DeepEqual(inf.Required, want) {
+		t.Errorf("Required = %v, want %v", inf.Required, want)
+	}
+	if !inf.Complete() {
+		t.Errorf("inference should be complete: %+v", inf)
+	}
+}
+
+func TestInfer_Incomplete(t *testing.T) {
+	job := workflow.Job{
+		Steps: []any{
+			workflow.Step{Uses: "example/custom-action@v1"},
+			workflow.Step{Uses: "./.github/actions/setup"},
+			workflow.Step{Name: "Script", Uses: "actions/github-script@v7"},
+			workflow.Step{Run: "gh pr list", Env: map[string]any{"GH_TOKEN": "${{ github.token }}"}},
+			workflow.Step{Uses: "docker://alpine:3"},
+		},
+	}
+
+	inf := Infer(job)
+	if want := []string{"example/custom-action@v1", "./.github/actions/setup"}; !reflect.DeepEqual(inf.Unknown, want) {
+		t.Errorf("Unknown = %v, want %v", inf.Unknown, want)
+	}
+	if want := []string{`step "Script" runs actions/github-script@v7`, "step 4 uses the token directly"}; !reflect.DeepEqual(inf.Uncertain, want) {
+		t.Errorf("Uncertain = %v, want %v", inf.Uncertain, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	wf := &workflow.Workflow{
+		Permissions: &workflow.Permissions{Contents: "write"},
+		Jobs: map[string]workflow.Job{
+			"build": {
+				Steps: []any{checkout.Checkout{}},
+			},
+			"pages": {
+				Permissions: &workflow.Permissions{Pages: "write", IDToken: "write", Contents: "read"},
+				Steps:       []any{workflow.Step{Uses: "actions/deploy-pages@v4"}},
+			},
+			"release": {
+				Permissions: &workflow.Permissions{Contents: "read"},
+				Steps:       []any{workflow.Step{Uses: "softprops/action-gh-release@v2"}},
+			},
+			"call": {Uses: "./.github/workflows/deploy.yml"},
+		},
+	}
+
+	reports := Check("CI", wf)
+	if len(reports) != 3 {
+		t.Fatalf("got %d reports, want 3", len(reports))
+	}
+
+	build, pages, release := reports[0], reports[1], reports[2]
+
+	if build.Source != SourceWorkflow || !reflect.DeepEqual(build.Over, Scopes{"contents": "write"}) || build.Under != nil {
+		t.Errorf("build = %+v", build)
+	}
+	if pages.Source != SourceJob || !reflect.DeepEqual(pages.Over, Scopes{"contents": "read"}) || pages.Under != nil {
+		t.Errorf("pages = %+v", pages)
+	}
+	if release.Over != nil || !reflect.DeepEqual(release.Under, Scopes{"contents": "write"}) {
+		t.Errorf("release = %+v", release)
+	}
+	if build.OK() || !build.Fixable() {
+		t.Errorf("build should be fixable and not OK")
+	}
+}
+
+func TestCheck_Default(t *testing.T) {
+	wf := &workflow.Workflow{
+		Jobs: map[string]workflow.Job{
+			"test": {Steps: []any{workflow.Step{Uses: "example/custom@v1"}}},
+		},
+	}
+
+	reports := Check("CI", wf)
+	if len(reports) != 1 {
+		t.Fatalf("got %d reports, want 1", len(reports))
+	}
+	r := reports[0]
+	if r.Source != SourceDefault || r.OK() || r.Fixable() {
+		t.Errorf("report = %+v", r)
+	}
+}
@@ -0,0 +1,71 @@
+package simulate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/git"
+	"github.com/lex00/wetwire-github-go/internal/runner"
+	"github.com/lex00/wetwire-github-go/internal/template"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// Load builds the workflows declared in a Go package and returns them keyed
+// by workflow ID.
+func Load(dir string) (map[string]*workflow.Workflow, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving path: %w", err)
+	}
+
+	discovered, err := discover.NewDiscoverer().Discover(dir)
+	if err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	if len(discovered.Workflows) == 0 {
+		return nil, fmt.Errorf("no workflows found in %s", dir)
+	}
+
+	extracted, err := runner.NewRunner().ExtractValues(dir, discovered)
+	if err != nil {
+		return nil, fmt.Errorf("extraction failed: %w", err)
+	}
+	if extracted.Error != "" {
+		return nil, fmt.Errorf("extraction failed: %s", extracted.Error)
+	}
+
+	built, err := template.NewBuilder().Build(discovered, extracted)
+	if err != nil {
+		return nil, fmt.Errorf("template build failed: %w", err)
+	}
+	errs := make([]string, 0, len(discovered.Errors)+len(built.Errors))
+	errs = append(append(errs, discovered.Errors...), built.Errors...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("build failed: %s", strings.Join(errs, "; "))
+	}
+
+	workflows := make(map[string]*workflow.Workflow, len(built.Workflows))
+	for _, bw := range built.Workflows {
+		workflows[bw.Name] = bw.Workflow
+	}
+	return workflows, nil
+}
+
+// ChangedFilesFromGit lists the files changed in a git revision range such
+// as "main..HEAD", using the repository containing dir.
+func ChangedFilesFromGit(dir, revRange string) ([]string, error) {
+	out, err := git.Run(dir, "diff", "--name-only", revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,681 @@
+// Package simulate predicts which workflows and jobs a GitHub event would
+// start, without pushing anything to GitHub.
+//
+// Simulate matches an event against each workflow's triggers (branch and tag
+// filters, paths and paths-ignore, activity types and workflow_run chains),
+// then evaluates job-level If conditions in dependency order.
+package simulate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-github-go/internal/discover"
+	"github.com/lex00/wetwire-github-go/internal/glob"
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// maxWorkflowRunDepth is the number of workflow levels GitHub allows to be
+// chained together with workflow_run.
+const maxWorkflowRunDepth = 3
+
+// Event describes a GitHub event to simulate.
+type Event struct {
+	// Name is the event name, e.g. "push" or "pull_request".
+	Name string
+
+	// Payload is the webhook payload, available to conditions as github.event.
+	Payload map[string]any
+
+	// Ref overrides the git ref the event runs on, e.g. "refs/heads/main".
+	// By default it is derived from the payload.
+	Ref string
+
+	// ChangedFiles lists the files changed by the event, matched against
+	// paths and paths-ignore filters. When empty, the added, modified and
+	// removed files of the commits in a push payload are used.
+	ChangedFiles []string
+}
+
+// JobStatus is the predicted outcome for a job.
+type JobStatus string
+
+const (
+	// JobRuns means the job would start.
+	JobRuns JobStatus = "run"
+
+	// JobSkipped means the job would be skipped.
+	JobSkipped JobStatus = "skipped"
+
+	// JobUnknown means the job's condition cannot be evaluated locally.
+	JobUnknown JobStatus = "unknown"
+)
+
+// Result is the outcome of simulating an event.
+type Result struct {
+	Event     string           `json:"event"`
+	Ref       string           `json:"ref"`
+	Workflows []WorkflowResult `json:"workflows"`
+}
+
+// WorkflowResult describes whether a workflow would be triggered.
+type WorkflowResult struct {
+	// ID is the workflow variable name
+	ID string `json:"id"`
+
+	// Name is the workflow display name
+	Name string `json:"name,omitempty"`
+
+	// Triggered reports whether the workflow would run
+	Triggered bool `json:"triggered"`
+
+	// Reason explains why the workflow would or would not run
+	Reason string `json:"reason"`
+
+	// TriggeredBy is the ID of the workflow whose run starts this one
+	// through workflow_run
+	TriggeredBy string `json:"triggered_by,omitempty"`
+
+	// Jobs lists the workflow's jobs in the order they would start
+	Jobs []JobResult `json:"jobs,omitempty"`
+}
+
+// JobResult describes whether a job would run.
+type JobResult struct {
+	ID     string    `json:"id"`
+	Status JobStatus `json:"status"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Simulate predicts which of the given workflows, keyed by workflow ID, the
+// event would trigger, and which of their jobs would start. Workflows are
+// expected in built form, with Needs listing job IDs.
+func Simulate(workflows map[string]*workflow.Workflow, event Event) (*Result, error) {
+	if event.Name == "" {
+		return nil, fmt.Errorf("event name is required")
+	}
+	if !isEventName(event.Name) {
+		return nil, fmt.Errorf("unknown event %q", event.Name)
+	}
+
+	s := newSimulation(event)
+	result := &Result{Event: event.Name, Ref: s.ref}
+
+	ids := make([]string, 0, len(workflows))
+	for id := range workflows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	results := make(map[string]*WorkflowResult, len(ids))
+	var triggered []string
+	for _, id := range ids {
+		wf := workflows[id]
+		wr := &WorkflowResult{ID: id, Name: wf.Name}
+		wr.Triggered, wr.Reason = s.matchTriggers(wf)
+		if wr.Triggered {
+			jobs, err := s.evalJobs(wf)
+			if err != nil {
+				return nil, fmt.Errorf("workflow %s: %w", id, err)
+			}
+			wr.Jobs = jobs
+			triggered = append(triggered, id)
+		}
+		results[id] = wr
+	}
+
+	// Completed runs start the workflows listening for them with workflow_run
+	for depth := 1; depth < maxWorkflowRunDepth && len(triggered) > 0; depth++ {
+		var next []string
+		for _, parent := range triggered {
+			for _, id := range ids {
+				wr := results[id]
+				if wr.Triggered || workflows[id].On.WorkflowRun == nil {
+					continue
+				}
+				// A run sends both requested and completed activity types
+				var chained *simulation
+				ok, reason := false, ""
+				for _, action := range []string{"requested", "completed"} {
+					chained = s.workflowRunSimulation(displayName(parent, workflows[parent]), action)
+					if ok, reason = chained.matchTriggers(workflows[id]); ok {
+						break
+					}
+				}
+				if !ok {
+					continue
+				}
+				jobs, err := chained.evalJobs(workflows[id])
+				if err != nil {
+					return nil, fmt.Errorf("workflow %s: %w", id, err)
+				}
+				wr.Triggered, wr.Reason, wr.TriggeredBy, wr.Jobs = true, reason, parent, jobs
+				next = append(next, id)
+			}
+		}
+		triggered = next
+	}
+
+	for _, id := range ids {
+		result.Workflows = append(result.Workflows, *results[id])
+	}
+	return result, nil
+}
+
+// simulation holds the contexts derived from an event.
+type simulation struct {
+	event      Event
+	payload    map[string]any
+	ref        string
+	baseRef    string
+	headRef    string
+	files      []string
+	defaultRef string
+}
+
+func newSimulation(event Event) *simulation {
+	s := &simulation{event: event, payload: event.Payload}
+	if s.payload == nil {
+		s.payload = map[string]any{}
+	}
+
+	defaultBranch := lookupString(s.payload, "repository", "default_branch")
+	if defaultBranch == "" {
+		defaultBranch = "main"
+	}
+	s.defaultRef = "refs/heads/" + defaultBranch
+
+	switch event.Name {
+	case "pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment":
+		s.baseRef = lookupString(s.payload, "pull_request", "base", "ref")
+		s.headRef = lookupString(s.payload, "pull_request", "head", "ref")
+		if number := lookupValue(s.payload, "pull_request", "number"); number != nil && event.Name != "pull_request_target" {
+			s.ref = fmt.Sprintf("refs/pull/%v/merge", number)
+		} else if s.baseRef != "" {
+			s.ref = "refs/heads/" + s.baseRef
+		}
+	default:
+		s.ref = lookupString(s.payload, "ref")
+		if s.ref != "" && !strings.HasPrefix(s.ref, "refs/") {
+			s.ref = "refs/heads/" + s.ref
+		}
+	}
+	if event.Ref != "" {
+		s.ref = event.Ref
+	}
+	if s.ref == "" {
+		s.ref = s.defaultRef
+	}
+
+	s.files = event.ChangedFiles
+	if len(s.files) == 0 {
+		s.files = payloadFiles(s.payload)
+	}
+	return s
+}
+
+// workflowRunSimulation returns the simulation of a workflow_run event sent
+// for a successful run of the named workflow.
+func (s *simulation) workflowRunSimulation(name, action string) *simulation {
+	headBranch := s.headRef
+	if headBranch == "" {
+		headBranch = shortRef(s.ref)
+	}
+	return newSimulation(Event{
+		Name: "workflow_run",
+		Ref:  s.defaultRef,
+		Payload: map[string]any{
+			"action":     action,
+			"repository": s.payload["repository"],
+			"workflow":   map[string]any{"name": name},
+			"workflow_run": map[string]any{
+				"name":        name,
+				"event":       s.event.Name,
+				"head_branch": headBranch,
+				"conclusion":  "success",
+			},
+		},
+	})
+}
+
+// matchTriggers reports whether the workflow's triggers select the event.
+func (s *simulation) matchTriggers(wf *workflow.Workflow) (bool, string) {
+	on := wf.On
+
+	switch s.event.Name {
+	case "push":
+		if on.Push == nil {
+			return false, "no push trigger"
+		}
+		return s.matchPush(on.Push)
+
+	case "pull_request":
+		if on.PullRequest == nil {
+			return false, "no pull_request trigger"
+		}
+		t := on.PullRequest
+		return s.matchPullRequest(t.Types, t.Branches, t.BranchesIgnore, t.Paths, t.PathsIgnore)
+
+	case "pull_request_target":
+		if on.PullRequestTarget == nil {
+			return false, "no pull_request_target trigger"
+		}
+		t := on.PullRequestTarget
+		return s.matchPullRequest(t.Types, t.Branches, t.BranchesIgnore, t.Paths, t.PathsIgnore)
+
+	case "workflow_run":
+		if on.WorkflowRun == nil {
+			return false, "no workflow_run trigger"
+		}
+		return s.matchWorkflowRun(on.WorkflowRun)
+
+	case "schedule":
+		if len(on.Schedule) == 0 {
+			return false, "no schedule trigger"
+		}
+		cron, _ := s.payload["schedule"].(string)
+		for _, sched := range on.Schedule {
+			if cron == "" || sched.Cron == cron {
+				return true, fmt.Sprintf("schedule %q", sched.Cron)
+			}
+		}
+		return false, fmt.Sprintf("no schedule matches cron %q", cron)
+
+	case "workflow_call":
+		return false, "workflow_call workflows run only as part of their caller"
+	}
+
+	trigger := triggerField(on, s.event.Name)
+	if !trigger.IsValid() || trigger.IsNil() {
+		return false, fmt.Sprintf("no %s trigger", s.event.Name)
+	}
+	if types := trigger.Elem().FieldByName("Types"); types.IsValid() {
+		return matchTypes(types.Interface().([]string), s.action())
+	}
+	return true, fmt.Sprintf("%s trigger", s.event.Name)
+}
+
+// matchPush applies push branch, tag and path filters.
+func (s *simulation) matchPush(t *workflow.PushTrigger) (bool, string) {
+	name := shortRef(s.ref)
+	hasBranchFilters := len(t.Branches) > 0 || len(t.BranchesIgnore) > 0
+	hasTagFilters := len(t.Tags) > 0 || len(t.TagsIgnore) > 0
+
+	if strings.HasPrefix(s.ref, "refs/tags/") {
+		if hasBranchFilters && !hasTagFilters {
+			return false, fmt.Sprintf("tag %q is not selected by branch filters", name)
+		}
+		if ok, reason := matchRefFilters("tag", "tags", name, t.Tags, t.TagsIgnore); !ok {
+			return false, reason
+		}
+		// Path filters are not evaluated for pushes of tags
+		return true, fmt.Sprintf("push to tag %q", name)
+	}
+
+	if hasTagFilters && !hasBranchFilters {
+		return false, fmt.Sprintf("branch %q is not selected by tag filters", name)
+	}
+	if ok, reason := matchRefFilters("branch", "branches", name, t.Branches, t.BranchesIgnore); !ok {
+		return false, reason
+	}
+	return s.withPaths(fmt.Sprintf("push to branch %q", name), t.Paths, t.PathsIgnore)
+}
+
+// matchPullRequest applies pull request activity type, base branch and path
+// filters.
+func (s *simulation) matchPullRequest(types, branches, branchesIgnore, paths, pathsIgnore []string) (bool, string) {
+	if len(types) == 0 {
+		types = []string{"opened", "synchronize", "reopened"}
+	}
+	if ok, reason := matchTypes(types, s.action()); !ok {
+		return false, reason
+	}
+	if ok, reason := matchRefFilters("base branch", "branches", s.baseRef, branches, branchesIgnore); !ok {
+		return false, reason
+	}
+	return s.withPaths(fmt.Sprintf("%s %s into %q", s.event.Name, s.action(), s.baseRef), paths, pathsIgnore)
+}
+
+// matchWorkflowRun applies workflow_run workflow, type and branch filters.
+func (s *simulation) matchWorkflowRun(t *workflow.WorkflowRunTrigger) (bool, string) {
+	name := lookupString(s.payload, "workflow_run", "name")
+	if name == "" {
+		name = lookupString(s.payload, "workflow", "name")
+	}
+	if !containsString(t.Workflows, name) {
+		return false, fmt.Sprintf("workflow %q is not in workflows", name)
+	}
+	if ok, reason := matchTypes(t.Types, s.action()); !ok {
+		return false, reason
+	}
+	branch := lookupString(s.payload, "workflow_run", "head_branch")
+	if ok, reason := matchRefFilters("branch", "branches", branch, t.Branches, nil); !ok {
+		return false, reason
+	}
+	return true, fmt.Sprintf("workflow_run after %q %s", name, s.action())
+}
+
+// withPaths applies path filters to a trigger that otherwise matched.
+func (s *simulation) withPaths(reason string, paths, pathsIgnore []string) (bool, string) {
+	if len(paths) == 0 && len(pathsIgnore) == 0 {
+		return true, reason
+	}
+	if len(s.files) == 0 {
+		return false, "no changed files to match path filters"
+	}
+
+	if len(paths) > 0 {
+		for _, file := range s.files {
+			if glob.MatchFilters(paths, file) {
+				return true, fmt.Sprintf("%s; %s matches paths", reason, file)
+			}
+		}
+		return false, fmt.Sprintf("no changed file matches paths %v", paths)
+	}
+
+	for _, file := range s.files {
+		if !glob.MatchFilters(pathsIgnore, file) {
+			return true, fmt.Sprintf("%s; %s is not in paths-ignore", reason, file)
+		}
+	}
+	return false, fmt.Sprintf("every changed file matches paths-ignore %v", pathsIgnore)
+}
+
+// action returns the activity type of the event.
+func (s *simulation) action() string {
+	action, _ := s.payload["action"].(string)
+	if action == "" && strings.HasPrefix(s.event.Name, "pull_request") {
+		return "opened"
+	}
+	return action
+}
+
+// evalJobs evaluates the If condition of each job in dependency order.
+func (s *simulation) evalJobs(wf *workflow.Workflow) ([]JobResult, error) {
+	var jobs []discover.DiscoveredJob
+	for id, job := range wf.Jobs {
+		dj := discover.DiscoveredJob{Name: id}
+		for _, need := range job.Needs {
+			if dep, ok := need.(string); ok {
+				if _, exists := wf.Jobs[dep]; exists {
+					dj.Dependencies = append(dj.Dependencies, dep)
+				}
+			}
+		}
+		jobs = append(jobs, dj)
+	}
+
+	order, err := discover.NewDependencyGraph(jobs).TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string][]string, len(jobs))
+	for _, dj := range jobs {
+		deps[dj.Name] = dj.Dependencies
+	}
+
+	github := s.githubContext()
+	inputs := s.inputsContext(wf)
+	statuses := make(map[string]JobStatus, len(order))
+	results := make([]JobResult, 0, len(order))
+
+	for _, id := range order {
+		job := wf.Jobs[id]
+
+		needs := make(map[string]any)
+		status, skippedNeed := "success", ""
+		for _, dep := range deps[id] {
+			result := "success"
+			if statuses[dep] == JobSkipped {
+				result, status = "skipped", "skipped"
+				if skippedNeed == "" {
+					skippedNeed = dep
+				}
+			}
+			needs[dep] = map[string]any{"result": result, "outputs": map[string]any{}}
+		}
+
+		ctx := &workflow.EvalContext{
+			GitHub: github,
+			Env:    wf.Env,
+			Needs:  needs,
+			Inputs: inputs,
+			Status: status,
+		}
+
+		jr := JobResult{ID: id}
+		cond := conditionText(job.If)
+		ok, err := workflow.EvalCondition(job.If, ctx)
+		switch {
+		case err != nil:
+			jr.Status, jr.Reason = JobUnknown, err.Error()
+		case skippedNeed != "" && !ok:
+			jr.Status, jr.Reason = JobSkipped, fmt.Sprintf("needs %s, which is skipped", skippedNeed)
+		case usesRuntimeValues(cond):
+			jr.Status, jr.Reason = JobUnknown, fmt.Sprintf("if: %s depends on values known only at run time", cond)
+		case ok && cond != "":
+			jr.Status, jr.Reason = JobRuns, fmt.Sprintf("if: %s is true", cond)
+		case ok:
+			jr.Status = JobRuns
+		default:
+			jr.Status, jr.Reason = JobSkipped, fmt.Sprintf("if: %s is false", cond)
+		}
+		statuses[id] = jr.Status
+		results = append(results, jr)
+	}
+
+	return results, nil
+}
+
+// githubContext returns the github context for the event.
+func (s *simulation) githubContext() map[string]any {
+	refType := "branch"
+	if strings.HasPrefix(s.ref, "refs/tags/") {
+		refType = "tag"
+	}
+	sha := lookupString(s.payload, "after")
+	if sha == "" {
+		sha = lookupString(s.payload, "pull_request", "head", "sha")
+	}
+	return map[string]any{
+		"event_name": s.event.Name,
+		"event":      s.payload,
+		"ref":        s.ref,
+		"ref_name":   shortRef(s.ref),
+		"ref_type":   refType,
+		"base_ref":   s.baseRef,
+		"head_ref":   s.headRef,
+		"sha":        sha,
+		"repository": lookupString(s.payload, "repository", "full_name"),
+		"actor":      lookupString(s.payload, "sender", "login"),
+	}
+}
+
+// inputsContext returns workflow_dispatch inputs, with defaults applied.
+func (s *simulation) inputsContext(wf *workflow.Workflow) map[string]any {
+	inputs := make(map[string]any)
+	if s.event.Name == "workflow_dispatch" && wf.On.WorkflowDispatch != nil {
+		for name, input := range wf.On.WorkflowDispatch.Inputs {
+			if input.Default != nil {
+				inputs[name] = input.Default
+			}
+		}
+	}
+	if supplied, ok := s.payload["inputs"].(map[string]any); ok {
+		for name, v := range supplied {
+			inputs[name] = v
+		}
+	}
+	return inputs
+}
+
+// conditionText returns an If condition as expression text.
+func conditionText(cond any) string {
+	switch c := cond.(type) {
+	case nil:
+		return ""
+	case workflow.Expression:
+		return c.Raw()
+	case fmt.Stringer:
+		return c.String()
+	default:
+		return fmt.Sprint(c)
+	}
+}
+
+// usesRuntimeValues reports whether a condition reads step or job outputs,
+// variables or secrets, which are not known before the workflow runs.
+func usesRuntimeValues(cond string) bool {
+	exprs := []string{cond}
+	if strings.Contains(cond, "${{") {
+		exprs, _ = workflow.ExtractExpressions(cond)
+	}
+
+	found := false
+	for _, expr := range exprs {
+		node, err := workflow.ParseExpression(expr)
+		if err != nil {
+			continue
+		}
+		workflow.WalkExpr(node, func(n workflow.ExprNode) bool {
+			switch n := n.(type) {
+			case *workflow.VariableNode:
+				switch strings.ToLower(n.Name) {
+				case "steps", "vars", "secrets":
+					found = true
+				}
+			case *workflow.PropertyNode:
+				// needs.<job>.outputs
+				if job, ok := n.Receiver.(*workflow.PropertyNode); ok && strings.EqualFold(n.Name, "outputs") {
+					if v, ok := job.Receiver.(*workflow.VariableNode); ok && strings.EqualFold(v.Name, "needs") {
+						found = true
+					}
+				}
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// matchRefFilters applies an include and ignore filter pair to a branch or
+// tag name.
+func matchRefFilters(kind, key, name string, include, ignore []string) (bool, string) {
+	if len(include) > 0 && !glob.MatchFilters(include, name) {
+		return false, fmt.Sprintf("%s %q does not match %s %v", kind, name, key, include)
+	}
+	if len(ignore) > 0 && glob.MatchFilters(ignore, name) {
+		return false, fmt.Sprintf("%s %q matches %s-ignore %v", kind, name, key, ignore)
+	}
+	return true, ""
+}
+
+// matchTypes checks an activity type against a trigger's types. An empty
+// list selects every type.
+func matchTypes(types []string, action string) (bool, string) {
+	if len(types) == 0 || containsString(types, action) {
+		if action == "" {
+			return true, "event trigger"
+		}
+		return true, fmt.Sprintf("activity type %q", action)
+	}
+	return false, fmt.Sprintf("activity type %q is not in types %v", action, types)
+}
+
+// triggerField returns the Triggers field for an event name, found by its
+// yaml tag.
+func triggerField(on workflow.Triggers, event string) reflect.Value {
+	v := reflect.ValueOf(on)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if yamlName(t.Field(i)) == event {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// isEventName reports whether event names a workflow trigger.
+func isEventName(event string) bool {
+	t := reflect.TypeOf(workflow.Triggers{})
+	for i := 0; i < t.NumField(); i++ {
+		if yamlName(t.Field(i)) == event {
+			return true
+		}
+	}
+	return false
+}
+
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	return name
+}
+
+// displayName returns the name workflow_run filters use for a workflow.
+func displayName(id string, wf *workflow.Workflow) string {
+	if wf.Name != "" {
+		return wf.Name
+	}
+	return id
+}
+
+// payloadFiles returns the files changed by the commits of a push payload.
+func payloadFiles(payload map[string]any) []string {
+	commits, _ := payload["commits"].([]any)
+	seen := make(map[string]bool)
+	var files []string
+	for _, c := range commits {
+		commit, _ := c.(map[string]any)
+		for _, key := range []string{"added", "modified", "removed"} {
+			list, _ := commit[key].([]any)
+			for _, f := range list {
+				if file, ok := f.(string); ok && !seen[file] {
+					seen[file] = true
+					files = append(files, file)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// shortRef strips the refs/heads/ or refs/tags/ prefix from a ref.
+func shortRef(ref string) string {
+	for _, prefix := range []string{"refs/heads/", "refs/tags/"} {
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix)
+		}
+	}
+	return ref
+}
+
+// lookupValue follows a path of keys through nested maps.
+func lookupValue(m map[string]any, keys ...string) any {
+	var v any = m
+	for _, key := range keys {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+	return v
+}
+
+// lookupString follows a path of keys and returns the string found there.
+func lookupString(m map[string]any, keys ...string) string {
+	s, _ := lookupValue(m, keys...).(string)
+	return s
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
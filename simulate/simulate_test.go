@@ -0,0 +1,480 @@
+package simulate
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+func testWorkflows() map[string]*workflow.Workflow {
+	return map[string]*workflow.Workflow{
+		"Pipeline": {
+			Name: "CI",
+			On: workflow.Triggers{
+				Push: &workflow.PushTrigger{
+					Branches:    []string{"main", "releases/**"},
+					PathsIgnore: []string{"docs/**", "**.md"},
+				},
+				PullRequest: &workflow.PullRequestTrigger{
+					Branches: []string{"main"},
+					Paths:    []string{"services/api/**"},
+				},
+			},
+			Jobs: map[string]workflow.Job{
+				"build": {},
+				"test":  {Needs: []any{"build"}},
+				"deploy": {
+					Needs: []any{"test"},
+					If:    "github.ref == 'refs/heads/main' && github.event_name == 'push'",
+				},
+				"notify": {Needs: []any{"deploy"}, If: "always()"},
+				"report": {Needs: []any{"deploy"}},
+			},
+		},
+		"Release": {
+			On: workflow.Triggers{
+				Push: &workflow.PushTrigger{Tags: []string{"v*"}},
+			},
+			Jobs: map[string]workflow.Job{"publish": {}},
+		},
+		"Deploy": {
+			Name: "Deploy",
+			On: workflow.Triggers{
+				WorkflowRun: &workflow.WorkflowRunTrigger{
+					Workflows: []string{"CI"},
+					Types:     []string{"completed"},
+					Branches:  []string{"main"},
+				},
+			},
+			Jobs: map[string]workflow.Job{
+				"rollout": {If: "github.event.workflow_run.conclusion == 'success'"},
+			},
+		},
+		"Smoke": {
+			Name: "Smoke",
+			On: workflow.Triggers{
+				WorkflowRun: &workflow.WorkflowRunTrigger{Workflows: []string{"Deploy"}},
+			},
+			Jobs: map[string]workflow.Job{"check": {}},
+		},
+		"Audit": {
+			On: workflow.Triggers{
+				WorkflowRun: &workflow.WorkflowRunTrigger{Workflows: []string{"Smoke"}},
+			},
+		},
+	}
+}
+
+func findWorkflow(t *testing.T, result *Result, id string) WorkflowResult {
+	t.Helper()
+	for _, wr := range result.Workflows {
+		if wr.ID == id {
+			return wr
+		}
+	}
+	t.Fatalf("workflow %s not in result", id)
+	return WorkflowResult{}
+}
+
+func TestSimulate_PushTriggers(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     Event
+		workflow  string
+		triggered bool
+		reason    string
+	}{
+		{
+			name:      "branch and code change",
+			event:     Event{Name: "push", Ref: "refs/heads/main", ChangedFiles: []string{"README.md", "cmd/main.go"}},
+			workflow:  "Pipeline",
+			triggered: true,
+			reason:    "cmd/main.go is not in paths-ignore",
+		},
+		{
+			name:      "only docs changed",
+			event:     Event{Name: "push", Ref: "refs/heads/main", ChangedFiles: []string{"docs/guide.md", "README.md"}},
+			workflow:  "Pipeline",
+			triggered: false,
+			reason:    "every changed file matches paths-ignore",
+		},
+		{
+			name:      "branch not selected",
+			event:     Event{Name: "push", Ref: "refs/heads/feature/x", ChangedFiles: []string{"main.go"}},
+			workflow:  "Pipeline",
+			triggered: false,
+			reason:    `branch "feature/x" does not match branches`,
+		},
+		{
+			name:      "glob branch",
+			event:     Event{Name: "push", Ref: "refs/heads/releases/1.2", ChangedFiles: []string{"main.go"}},
+			workflow:  "Pipeline",
+			triggered: true,
+		},
+		{
+			name:      "tag push skips branch-only workflow",
+			event:     Event{Name: "push", Ref: "refs/tags/v1.0.0"},
+			workflow:  "Pipeline",
+			triggered: false,
+			reason:    "not selected by branch filters",
+		},
+		{
+			name:      "tag push",
+			event:     Event{Name: "push", Ref: "refs/tags/v1.0.0"},
+			workflow:  "Release",
+			triggered: true,
+			reason:    `push to tag "v1.0.0"`,
+		},
+		{
+			name:      "branch push skips tag-only workflow",
+			event:     Event{Name: "push", Ref: "refs/heads/main"},
+			workflow:  "Release",
+			triggered: false,
+		},
+		{
+			name: "files from payload commits",
+			event: Event{Name: "push", Payload: map[string]any{
+				"ref":     "refs/heads/main",
+				"commits": []any{map[string]any{"modified": []any{"docs/a.md"}, "added": []any{"api/x.go"}}},
+			}},
+			workflow:  "Pipeline",
+			triggered: true,
+		},
+		{
+			name:      "no files for path filters",
+			event:     Event{Name: "push", Ref: "refs/heads/main"},
+			workflow:  "Pipeline",
+			triggered: false,
+			reason:    "no changed files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Simulate(testWorkflows(), tt.event)
+			if err != nil {
+				t.Fatalf("Simulate() error = %v", err)
+			}
+			wr := findWorkflow(t, result, tt.workflow)
+			if wr.Triggered != tt.triggered {
+				t.Errorf("Triggered = %v, want %v (%s)", wr.Triggered, tt.triggered, wr.Reason)
+			}
+			if !strings.Contains(wr.Reason, tt.reason) {
+				t.Errorf("Reason = %q, want containing %q", wr.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestSimulate_PullRequest(t *testing.T) {
+	payload := func(action, base string) map[string]any {
+		return map[string]any{
+			"action": action,
+			"pull_request": map[string]any{
+				"number": 42,
+				"base":   map[string]any{"ref": base},
+				"head":   map[string]any{"ref": "feature/x"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		payload   map[string]any
+		files     []string
+		triggered bool
+		reason    string
+	}{
+		{name: "opened", payload: payload("opened", "main"), files: []string{"services/api/h.go"}, triggered: true},
+		{name: "default types", payload: payload("closed", "main"), files: []string{"services/api/h.go"}, reason: `activity type "closed" is not in types`},
+		{name: "base branch", payload: payload("opened", "develop"), files: []string{"services/api/h.go"}, reason: `base branch "develop" does not match branches`},
+		{name: "paths", payload: payload("synchronize", "main"), files: []string{"services/web/app.ts"}, reason: "no changed file matches paths"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Simulate(testWorkflows(), Event{Name: "pull_request", Payload: tt.payload, ChangedFiles: tt.files})
+			if err != nil {
+				t.Fatalf("Simulate() error = %v", err)
+			}
+			if result.Ref != "refs/pull/42/merge" {
+				t.Errorf("Ref = %q, want refs/pull/42/merge", result.Ref)
+			}
+			wr := findWorkflow(t, result, "Pipeline")
+			if wr.Triggered != tt.triggered {
+				t.Errorf("Triggered = %v, want %v (%s)", wr.Triggered, tt.triggered, wr.Reason)
+			}
+			if !strings.Contains(wr.Reason, tt.reason) {
+				t.Errorf("Reason = %q, want containing %q", wr.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestSimulate_Jobs(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want []JobResult
+	}{
+		{
+			name: "main",
+			ref:  "refs/heads/main",
+			want: []JobResult{
+				{ID: "build", Status: JobRuns},
+				{ID: "test", Status: JobRuns},
+				{ID: "deploy", Status: JobRuns, Reason: "if: github.ref == 'refs/heads/main' && github.event_name == 'push' is true"},
+				{ID: "notify", Status: JobRuns, Reason: "if: always() is true"},
+				{ID: "report", Status: JobRuns},
+			},
+		},
+		{
+			name: "release branch",
+			ref:  "refs/heads/releases/2",
+			want: []JobResult{
+				{ID: "build", Status: JobRuns},
+				{ID: "test", Status: JobRuns},
+				{ID: "deploy", Status: JobSkipped, Reason: "if: github.ref == 'refs/heads/main' && github.event_name == 'push' is false"},
+				{ID: "notify", Status: JobRuns, Reason: "if: always() is true"},
+				{ID: "report", Status: JobSkipped, Reason: "needs deploy, which is skipped"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Simulate(testWorkflows(), Event{Name: "push", Ref: tt.ref, ChangedFiles: []string{"main.go"}})
+			if err != nil {
+				t.Fatalf("Simulate() error = %v", err)
+			}
+			wr := findWorkflow(t, result, "Pipeline")
+			if !reflect.DeepEqual(wr.Jobs, tt.want) {
+				t.Errorf("Jobs = %+v, want %+v", wr.Jobs, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulate_WorkflowRunChain(t *testing.T) {
+	result, err := Simulate(testWorkflows(), Event{Name: "push", Ref: "refs/heads/main", ChangedFiles: []string{"main.go"}})
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	deploy := findWorkflow(t, result, "Deploy")
+	if !deploy.Triggered || deploy.TriggeredBy != "Pipeline" {
+		t.Errorf("Deploy = %+v, want triggered by Pipeline", deploy)
+	}
+	if want := []JobResult{{ID: "rollout", Status: JobRuns, Reason: "if: github.event.workflow_run.conclusion == 'success' is true"}}; !reflect.DeepEqual(deploy.Jobs, want) {
+		t.Errorf("Deploy jobs = %+v, want %+v", deploy.Jobs, want)
+	}
+
+	smoke := findWorkflow(t, result, "Smoke")
+	if !smoke.Triggered || smoke.TriggeredBy != "Deploy" {
+		t.Errorf("Smoke = %+v, want triggered by Deploy", smoke)
+	}
+
+	// GitHub stops workflow_run chains after three levels
+	if audit := findWorkflow(t, result, "Audit"); audit.Triggered {
+		t.Errorf("Audit should not be triggered: %+v", audit)
+	}
+
+	// workflow_run branch filters apply to the branch of the first run
+	result, err = Simulate(testWorkflows(), Event{Name: "push", Ref: "refs/heads/releases/1", ChangedFiles: []string{"main.go"}})
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+	if deploy := findWorkflow(t, result, "Deploy"); deploy.Triggered {
+		t.Errorf("Deploy should not run for releases/1: %+v", deploy)
+	}
+}
+
+func TestSimulate_ActivityTypes(t *testing.T) {
+	workflows := map[string]*workflow.Workflow{
+		"Triage": {
+			On: workflow.Triggers{
+				Issues: &workflow.IssuesTrigger{Types: []string{"opened", "labeled"}},
+				Fork:   &workflow.ForkTrigger{},
+			},
+			Jobs: map[string]workflow.Job{
+				"label": {If: "contains(github.event.issue.title, 'bug')"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		event     Event
+		triggered bool
+		jobStatus JobStatus
+	}{
+		{
+			name:      "selected type",
+			event:     Event{Name: "issues", Payload: map[string]any{"action": "opened", "issue": map[string]any{"title": "A bug"}}},
+			triggered: true,
+			jobStatus: JobRuns,
+		},
+		{
+			name:      "condition false",
+			event:     Event{Name: "issues", Payload: map[string]any{"action": "labeled", "issue": map[string]any{"title": "Question"}}},
+			triggered: true,
+			jobStatus: JobSkipped,
+		},
+		{
+			name:  "other type",
+			event: Event{Name: "issues", Payload: map[string]any{"action": "closed"}},
+		},
+		{
+			name:      "event without types",
+			event:     Event{Name: "fork"},
+			triggered: true,
+			jobStatus: JobSkipped,
+		},
+		{
+			name:  "missing trigger",
+			event: Event{Name: "release", Payload: map[string]any{"action": "published"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Simulate(workflows, tt.event)
+			if err != nil {
+				t.Fatalf("Simulate() error = %v", err)
+			}
+			wr := result.Workflows[0]
+			if wr.Triggered != tt.triggered {
+				t.Fatalf("Triggered = %v, want %v (%s)", wr.Triggered, tt.triggered, wr.Reason)
+			}
+			if tt.triggered && wr.Jobs[0].Status != tt.jobStatus {
+				t.Errorf("job status = %s, want %s (%s)", wr.Jobs[0].Status, tt.jobStatus, wr.Jobs[0].Reason)
+			}
+		})
+	}
+}
+
+func TestSimulate_DispatchAndRuntimeValues(t *testing.T) {
+	workflows := map[string]*workflow.Workflow{
+		"Manual": {
+			On: workflow.Triggers{
+				WorkflowDispatch: &workflow.WorkflowDispatchTrigger{
+					Inputs: map[string]workflow.WorkflowInput{
+						"environment": {Default: "staging"},
+						"dry_run":     {Default: false},
+					},
+				},
+			},
+			Jobs: map[string]workflow.Job{
+				"detect":  {},
+				"staging": {If: workflow.Expression("inputs.environment == 'staging' && !inputs.dry_run")},
+				"build":   {Needs: []any{"detect"}, If: "needs.detect.outputs.changed == 'true'"},
+				"hash":    {If: "hashFiles('go.sum') != ''"},
+			},
+		},
+	}
+
+	result, err := Simulate(workflows, Event{Name: "workflow_dispatch"})
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	got := make(map[string]JobStatus)
+	for _, job := range result.Workflows[0].Jobs {
+		got[job.ID] = job.Status
+	}
+	want := map[string]JobStatus{
+		"detect":  JobRuns,
+		"staging": JobRuns,
+		"build":   JobUnknown,
+		"hash":    JobUnknown,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("job statuses = %v, want %v", got, want)
+	}
+
+	// Supplied inputs override defaults
+	result, err = Simulate(workflows, Event{Name: "workflow_dispatch", Payload: map[string]any{
+		"inputs": map[string]any{"environment": "production"},
+	}})
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+	for _, job := range result.Workflows[0].Jobs {
+		if job.ID == "staging" && job.Status != JobSkipped {
+			t.Errorf("staging = %s, want skipped", job.Status)
+		}
+	}
+}
+
+func TestSimulate_Errors(t *testing.T) {
+	if _, err := Simulate(testWorkflows(), Event{}); err == nil {
+		t.Error("Simulate() should fail without an event name")
+	}
+	if _, err := Simulate(testWorkflows(), Event{Name: "pushed"}); err == nil || !strings.Contains(err.Error(), `unknown event "pushed"`) {
+		t.Errorf("Simulate() error = %v, want unknown event", err)
+	}
+
+	cyclic := map[string]*workflow.Workflow{
+		"Loop": {
+			On: workflow.Triggers{WorkflowDispatch: &workflow.WorkflowDispatchTrigger{}},
+			Jobs: map[string]workflow.Job{
+				"a": {Needs: []any{"b"}},
+				"b": {Needs: []any{"a"}},
+			},
+		},
+	}
+	if _, err := Simulate(cyclic, Event{Name: "workflow_dispatch"}); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Simulate() error = %v, want cycle error", err)
+	}
+}
+
+func TestChangedFilesFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("README.md", "one")
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+	write("services/api/main.go", "package main")
+	write("README.md", "two")
+	git("add", "-A")
+	git("commit", "-q", "-m", "second")
+
+	files, err := ChangedFilesFromGit(dir, "HEAD~1..HEAD")
+	if err != nil {
+		t.Fatalf("ChangedFilesFromGit() error = %v", err)
+	}
+	if want := []string{"README.md", "services/api/main.go"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("ChangedFilesFromGit() = %v, want %v", files, want)
+	}
+
+	if _, err := ChangedFilesFromGit(dir, "nope..HEAD"); err == nil {
+		t.Error("ChangedFilesFromGit() should fail on an unknown revision")
+	}
+}
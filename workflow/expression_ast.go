@@ -0,0 +1,204 @@
+package workflow
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ExprNode is a node in a parsed GitHub Actions expression.
+// String prints the node back to expression syntax, adding parentheses only
+// where operator precedence requires them.
+type ExprNode interface {
+	String() string
+	exprNode()
+}
+
+// NullNode is the null literal.
+type NullNode struct{}
+
+// BoolNode is a true or false literal.
+type BoolNode struct {
+	Value bool
+}
+
+// NumberNode is a number literal. All numbers are float64, as in GitHub.
+type NumberNode struct {
+	Value float64
+}
+
+// StringNode is a single-quoted string literal.
+type StringNode struct {
+	Value string
+}
+
+// VariableNode is a context name such as github or matrix.
+type VariableNode struct {
+	Name string
+}
+
+// PropertyNode is a property dereference: Receiver.Name.
+type PropertyNode struct {
+	Receiver ExprNode
+	Name     string
+}
+
+// IndexNode is an index access: Receiver[Index].
+type IndexNode struct {
+	Receiver ExprNode
+	Index    ExprNode
+}
+
+// FilterNode is an object filter: Receiver.*
+type FilterNode struct {
+	Receiver ExprNode
+}
+
+// CallNode is a function call such as contains(a, b).
+type CallNode struct {
+	Name string
+	Args []ExprNode
+}
+
+// NotNode is a logical negation: !Operand.
+type NotNode struct {
+	Operand ExprNode
+}
+
+// CompareNode is a comparison using one of ==, !=, <, <=, > or >=.
+type CompareNode struct {
+	Op    string
+	Left  ExprNode
+	Right ExprNode
+}
+
+// LogicalNode is a logical operation using && or ||.
+type LogicalNode struct {
+	Op    string
+	Left  ExprNode
+	Right ExprNode
+}
+
+func (*NullNode) exprNode()     {}
+func (*BoolNode) exprNode()     {}
+func (*NumberNode) exprNode()   {}
+func (*StringNode) exprNode()   {}
+func (*VariableNode) exprNode() {}
+func (*PropertyNode) exprNode() {}
+func (*IndexNode) exprNode()    {}
+func (*FilterNode) exprNode()   {}
+func (*CallNode) exprNode()     {}
+func (*NotNode) exprNode()      {}
+func (*CompareNode) exprNode()  {}
+func (*LogicalNode) exprNode()  {}
+
+// Operator precedence, lowest first.
+const (
+	precOr = iota + 1
+	precAnd
+	precEquality
+	precComparison
+	precNot
+	precPostfix
+)
+
+// precedence returns the binding strength of a node's outermost operator.
+func precedence(n ExprNode) int {
+	switch n := n.(type) {
+	case *LogicalNode:
+		if n.Op == "||" {
+			return precOr
+		}
+		return precAnd
+	case *CompareNode:
+		if n.Op == "==" || n.Op == "!=" {
+			return precEquality
+		}
+		return precComparison
+	case *NotNode:
+		return precNot
+	}
+	return precPostfix
+}
+
+// printOperand prints n, parenthesized if it binds more loosely than min.
+func printOperand(n ExprNode, min int) string {
+	if precedence(n) < min {
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+func (*NullNode) String() string { return "null" }
+
+func (n *BoolNode) String() string { return strconv.FormatBool(n.Value) }
+
+func (n *NumberNode) String() string { return strconv.FormatFloat(n.Value, 'f', -1, 64) }
+
+func (n *StringNode) String() string {
+	return "'" + strings.ReplaceAll(n.Value, "'", "''") + "'"
+}
+
+func (n *VariableNode) String() string { return n.Name }
+
+func (n *PropertyNode) String() string {
+	return printOperand(n.Receiver, precPostfix) + "." + n.Name
+}
+
+func (n *IndexNode) String() string {
+	return printOperand(n.Receiver, precPostfix) + "[" + n.Index.String() + "]"
+}
+
+func (n *FilterNode) String() string {
+	return printOperand(n.Receiver, precPostfix) + ".*"
+}
+
+func (n *CallNode) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (n *NotNode) String() string {
+	return "!" + printOperand(n.Operand, precNot)
+}
+
+func (n *CompareNode) String() string {
+	prec := precedence(n)
+	return printOperand(n.Left, prec) + " " + n.Op + " " + printOperand(n.Right, prec+1)
+}
+
+func (n *LogicalNode) String() string {
+	prec := precedence(n)
+	return printOperand(n.Left, prec) + " " + n.Op + " " + printOperand(n.Right, prec+1)
+}
+
+// WalkExpr calls fn for n and each of its descendants in depth-first order.
+// If fn returns false, the children of that node are skipped.
+func WalkExpr(n ExprNode, fn func(ExprNode) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	switch n := n.(type) {
+	case *PropertyNode:
+		WalkExpr(n.Receiver, fn)
+	case *IndexNode:
+		WalkExpr(n.Receiver, fn)
+		WalkExpr(n.Index, fn)
+	case *FilterNode:
+		WalkExpr(n.Receiver, fn)
+	case *CallNode:
+		for _, arg := range n.Args {
+			WalkExpr(arg, fn)
+		}
+	case *NotNode:
+		WalkExpr(n.Operand, fn)
+	case *CompareNode:
+		WalkExpr(n.Left, fn)
+		WalkExpr(n.Right, fn)
+	case *LogicalNode:
+		WalkExpr(n.Left, fn)
+		WalkExpr(n.Right, fn)
+	}
+}
@@ -0,0 +1,637 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EvalContext supplies the context values an expression is evaluated
+// against. Values use the types produced by encoding/json: nil, bool,
+// float64, string, []any and map[string]any.
+//
+// EvalContext unmarshals from a JSON object keyed by context name:
+//
+//	{"github": {"ref": "refs/heads/main"}, "matrix": {"os": "ubuntu-latest"}}
+type EvalContext struct {
+	GitHub  map[string]any `json:"github,omitempty"`
+	Env     map[string]any `json:"env,omitempty"`
+	Matrix  map[string]any `json:"matrix,omitempty"`
+	Needs   map[string]any `json:"needs,omitempty"`
+	Steps   map[string]any `json:"steps,omitempty"`
+	Inputs  map[string]any `json:"inputs,omitempty"`
+	Vars    map[string]any `json:"vars,omitempty"`
+	Secrets map[string]any `json:"secrets,omitempty"`
+	Runner  map[string]any `json:"runner,omitempty"`
+
+	// Status is the current job status seen by success(), failure() and
+	// cancelled(): "success", "failure" or "cancelled". Empty means "success".
+	Status string `json:"status,omitempty"`
+}
+
+// EvalContextFromJSON decodes an EvalContext from a JSON object keyed by
+// context name.
+func EvalContextFromJSON(data []byte) (*EvalContext, error) {
+	ctx := &EvalContext{}
+	if err := json.Unmarshal(data, ctx); err != nil {
+		return nil, fmt.Errorf("decoding contexts: %w", err)
+	}
+	return ctx, nil
+}
+
+// Evaluate parses and evaluates the expression.
+func (e Expression) Evaluate(ctx *EvalContext) (any, error) {
+	node, err := e.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return EvalExpression(node, ctx)
+}
+
+// EvalExpression evaluates a parsed expression with GitHub Actions semantics.
+func EvalExpression(node ExprNode, ctx *EvalContext) (any, error) {
+	if ctx == nil {
+		ctx = &EvalContext{}
+	}
+	v, err := (&evaluator{ctx: ctx}).eval(node)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := v.(filteredArray); ok {
+		return []any(f), nil
+	}
+	return v, nil
+}
+
+// EvalCondition evaluates a job or step If condition. The condition may be
+// an Expression, a StringCondition, or a string with or without the ${{ }}
+// wrapper. As on GitHub, a condition without a status function is checked
+// as success() && (condition), and an empty condition as success().
+func EvalCondition(cond any, ctx *EvalContext) (bool, error) {
+	var s string
+	switch c := cond.(type) {
+	case nil:
+	case Expression:
+		s = c.Raw()
+	case StringCondition:
+		s = c.String()
+	case string:
+		s = c
+	default:
+		return false, fmt.Errorf("unsupported condition type %T", cond)
+	}
+
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "${{") {
+		if _, end, err := findPlaceholder(s, 0); err == nil && end == len(s) {
+			s = strings.TrimSpace(s[3 : len(s)-2])
+		}
+	}
+	if strings.Contains(s, "${{") {
+		// A condition with embedded placeholders is a string template
+		rendered, err := Interpolate(s, ctx)
+		if err != nil {
+			return false, err
+		}
+		return rendered != "", nil
+	}
+	if s == "" {
+		s = "success()"
+	}
+
+	node, err := ParseExpression(s)
+	if err != nil {
+		return false, err
+	}
+	if !hasStatusCall(node) {
+		node = &LogicalNode{Op: "&&", Left: &CallNode{Name: "success"}, Right: node}
+	}
+	v, err := EvalExpression(node, ctx)
+	if err != nil {
+		return false, err
+	}
+	return truthy(v), nil
+}
+
+// Interpolate replaces each ${{ }} placeholder in s with its value
+// converted to a string.
+func Interpolate(s string, ctx *EvalContext) (string, error) {
+	var sb strings.Builder
+	offset := 0
+	for {
+		start, end, err := findPlaceholder(s, offset)
+		if err != nil {
+			return "", err
+		}
+		if start < 0 {
+			sb.WriteString(s[offset:])
+			return sb.String(), nil
+		}
+		v, err := Expression(strings.TrimSpace(s[start+3 : end-2])).Evaluate(ctx)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s[offset:start])
+		sb.WriteString(toString(v))
+		offset = end
+	}
+}
+
+// statusFunctions are the functions that replace the implicit success() check.
+var statusFunctions = []string{"success", "failure", "cancelled", "always"}
+
+// hasStatusCall reports whether node calls a status function.
+func hasStatusCall(node ExprNode) bool {
+	found := false
+	WalkExpr(node, func(n ExprNode) bool {
+		if call, ok := n.(*CallNode); ok && containsString(statusFunctions, strings.ToLower(call.Name)) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// filteredArray is the result of a .* filter. Property access on it is
+// applied to each element, unlike property access on a plain array.
+type filteredArray []any
+
+// evaluator evaluates expression nodes against a context.
+type evaluator struct {
+	ctx *EvalContext
+}
+
+func (ev *evaluator) eval(node ExprNode) (any, error) {
+	switch n := node.(type) {
+	case *NullNode:
+		return nil, nil
+	case *BoolNode:
+		return n.Value, nil
+	case *NumberNode:
+		return n.Value, nil
+	case *StringNode:
+		return n.Value, nil
+	case *VariableNode:
+		return ev.context(n.Name)
+	case *PropertyNode:
+		receiver, err := ev.eval(n.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		return property(receiver, n.Name), nil
+	case *IndexNode:
+		receiver, err := ev.eval(n.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		index, err := ev.eval(n.Index)
+		if err != nil {
+			return nil, err
+		}
+		return indexValue(receiver, index), nil
+	case *FilterNode:
+		receiver, err := ev.eval(n.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		return filter(receiver), nil
+	case *CallNode:
+		return ev.call(n)
+	case *NotNode:
+		v, err := ev.eval(n.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(v), nil
+	case *CompareNode:
+		left, err := ev.eval(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := ev.eval(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return compare(n.Op, left, right), nil
+	case *LogicalNode:
+		left, err := ev.eval(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		// && and || short-circuit and return an operand, not a bool
+		if truthy(left) == (n.Op == "||") {
+			return left, nil
+		}
+		return ev.eval(n.Right)
+	}
+	return nil, fmt.Errorf("unsupported expression node %T", node)
+}
+
+// context returns the named context. Context names are case-insensitive.
+func (ev *evaluator) context(name string) (any, error) {
+	contexts := map[string]map[string]any{
+		"github":  ev.ctx.GitHub,
+		"env":     ev.ctx.Env,
+		"matrix":  ev.ctx.Matrix,
+		"needs":   ev.ctx.Needs,
+		"steps":   ev.ctx.Steps,
+		"inputs":  ev.ctx.Inputs,
+		"vars":    ev.ctx.Vars,
+		"secrets": ev.ctx.Secrets,
+		"runner":  ev.ctx.Runner,
+	}
+	c, ok := contexts[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown context %q", name)
+	}
+	if c == nil {
+		return map[string]any{}, nil
+	}
+	return c, nil
+}
+
+// call evaluates a function call. Function names are case-insensitive.
+func (ev *evaluator) call(n *CallNode) (any, error) {
+	name := strings.ToLower(n.Name)
+
+	if containsString(statusFunctions, name) {
+		if len(n.Args) != 0 {
+			return nil, fmt.Errorf("%s() takes no arguments", n.Name)
+		}
+		status := strings.ToLower(ev.ctx.Status)
+		if status == "" {
+			status = "success"
+		}
+		switch name {
+		case "always":
+			return true, nil
+		case "success":
+			return status == "success", nil
+		case "failure":
+			return status == "failure", nil
+		}
+		return status == "cancelled", nil
+	}
+
+	args := make([]any, len(n.Args))
+	for i, arg := range n.Args {
+		v, err := ev.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := v.(filteredArray); ok {
+			v = []any(f)
+		}
+		args[i] = v
+	}
+
+	arity := func(min, max int) error {
+		if len(args) < min || len(args) > max {
+			return fmt.Errorf("%s() called with %d arguments", n.Name, len(args))
+		}
+		return nil
+	}
+
+	switch name {
+	case "contains":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		if arr, ok := args[0].([]any); ok {
+			for _, item := range arr {
+				if looseEqual(item, args[1]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return strings.Contains(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "startswith":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasPrefix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "endswith":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasSuffix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "format":
+		if err := arity(1, math.MaxInt); err != nil {
+			return nil, err
+		}
+		return format(toString(args[0]), args[1:])
+	case "join":
+		if err := arity(1, 2); err != nil {
+			return nil, err
+		}
+		sep := ","
+		if len(args) == 2 {
+			sep = toString(args[1])
+		}
+		arr, ok := args[0].([]any)
+		if !ok {
+			return toString(args[0]), nil
+		}
+		parts := make([]string, len(arr))
+		for i, item := range arr {
+			parts[i] = toString(item)
+		}
+		return strings.Join(parts, sep), nil
+	case "tojson":
+		if err := arity(1, 1); err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(args[0], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("toJSON: %w", err)
+		}
+		return string(data), nil
+	case "fromjson":
+		if err := arity(1, 1); err != nil {
+			return nil, err
+		}
+		var v any
+		if err := json.Unmarshal([]byte(toString(args[0])), &v); err != nil {
+			return nil, fmt.Errorf("fromJSON: %w", err)
+		}
+		return v, nil
+	case "hashfiles":
+		return nil, fmt.Errorf("hashFiles() needs a workspace and cannot be evaluated locally")
+	}
+
+	return nil, fmt.Errorf("unknown function %s()", n.Name)
+}
+
+// format implements format(): {N} is replaced by argument N, and {{ and }}
+// stand for literal braces.
+func format(s string, args []any) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "{{"):
+			sb.WriteByte('{')
+			i++
+		case strings.HasPrefix(s[i:], "}}"):
+			sb.WriteByte('}')
+			i++
+		case s[i] == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("format: unclosed '{' in %q", s)
+			}
+			n, err := strconv.Atoi(s[i+1 : i+end])
+			if err != nil || n < 0 || n >= len(args) {
+				return "", fmt.Errorf("format: invalid placeholder %q in %q", s[i:i+end+1], s)
+			}
+			sb.WriteString(toString(args[n]))
+			i += end
+		case s[i] == '}':
+			return "", fmt.Errorf("format: unmatched '}' in %q", s)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// property returns a property of an object, matching keys case-insensitively.
+// On a filtered array it returns the property of each element that has it.
+func property(v any, name string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return lookupKey(val, name)
+	case filteredArray:
+		var result filteredArray
+		for _, item := range val {
+			if obj, ok := item.(map[string]any); ok {
+				if p := lookupKey(obj, name); p != nil {
+					result = append(result, p)
+				}
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// indexValue returns array[index] or object[index].
+func indexValue(v, index any) any {
+	if f, ok := v.(filteredArray); ok {
+		v = []any(f)
+	}
+	switch val := v.(type) {
+	case []any:
+		n := toNumber(index)
+		if math.IsNaN(n) || n < 0 || n != math.Trunc(n) || int(n) >= len(val) {
+			return nil
+		}
+		return val[int(n)]
+	case map[string]any:
+		if key, ok := index.(string); ok {
+			return lookupKey(val, key)
+		}
+	}
+	return nil
+}
+
+// filter implements .*: the values of an object or the elements of an array.
+func filter(v any) any {
+	switch val := v.(type) {
+	case []any:
+		return filteredArray(val)
+	case filteredArray:
+		return val
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		result := make(filteredArray, len(keys))
+		for i, k := range keys {
+			result[i] = val[k]
+		}
+		return result
+	}
+	return filteredArray{}
+}
+
+// lookupKey returns obj[key], falling back to a case-insensitive match.
+func lookupKey(obj map[string]any, key string) any {
+	if v, ok := obj[key]; ok {
+		return v
+	}
+	for k, v := range obj {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return nil
+}
+
+// compare applies a comparison operator with GitHub's loose typing.
+func compare(op string, left, right any) bool {
+	switch op {
+	case "==":
+		return looseEqual(left, right)
+	case "!=":
+		return !looseEqual(left, right)
+	}
+
+	var cmp int
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if lok && rok {
+		cmp = strings.Compare(strings.ToLower(ls), strings.ToLower(rs))
+	} else {
+		ln, rn := toNumber(left), toNumber(right)
+		if math.IsNaN(ln) || math.IsNaN(rn) {
+			return false
+		}
+		switch {
+		case ln < rn:
+			cmp = -1
+		case ln > rn:
+			cmp = 1
+		}
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+// looseEqual compares values as GitHub does: strings ignore case, values of
+// different primitive types are compared as numbers, and arrays and objects
+// are only equal to themselves.
+func looseEqual(left, right any) bool {
+	if f, ok := left.(filteredArray); ok {
+		left = []any(f)
+	}
+	if f, ok := right.(filteredArray); ok {
+		right = []any(f)
+	}
+
+	switch left.(type) {
+	case []any, map[string]any:
+		switch right.(type) {
+		case []any, map[string]any:
+			return sameReference(left, right)
+		}
+		return false
+	}
+	switch right.(type) {
+	case []any, map[string]any:
+		return false
+	}
+
+	if ls, ok := left.(string); ok {
+		if rs, ok := right.(string); ok {
+			return strings.EqualFold(ls, rs)
+		}
+	}
+	if left == nil && right == nil {
+		return true
+	}
+	if lb, ok := left.(bool); ok {
+		if rb, ok := right.(bool); ok {
+			return lb == rb
+		}
+	}
+	// NaN is never equal, so unparseable strings only match themselves
+	return toNumber(left) == toNumber(right)
+}
+
+// sameReference reports whether two arrays or objects share their storage.
+// Empty arrays have no storage of their own to compare, so they are never
+// the same reference.
+func sameReference(a, b any) bool {
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ra.Kind() != rb.Kind() || ra.Len() != rb.Len() {
+		return false
+	}
+	if ra.Kind() == reflect.Slice && ra.Len() == 0 {
+		return false
+	}
+	return ra.Pointer() != 0 && ra.Pointer() == rb.Pointer()
+}
+
+// truthy reports whether a value is truthy: false, 0, -0, "", null and NaN
+// are falsy; everything else, including empty arrays and objects, is truthy.
+func truthy(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return val
+	case float64:
+		return val != 0 && !math.IsNaN(val)
+	case string:
+		return val != ""
+	}
+	return true
+}
+
+// toNumber converts a value to a number: null is 0, booleans are 0 or 1,
+// and strings are parsed (empty is 0, unparseable is NaN).
+func toNumber(v any) float64 {
+	switch val := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if val {
+			return 1
+		}
+		return 0
+	case float64:
+		return val
+	case string:
+		s := strings.TrimSpace(val)
+		if s == "" {
+			return 0
+		}
+		if hex, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+			if n, err := strconv.ParseUint(hex, 16, 64); err == nil {
+				return float64(n)
+			}
+			return math.NaN()
+		}
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return n
+	}
+	return math.NaN()
+}
+
+// toString converts a value to a string: null is empty, numbers have no
+// trailing zeros, and arrays and objects are "Array" and "Object".
+func toString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case string:
+		return val
+	case []any, filteredArray:
+		return "Array"
+	case map[string]any:
+		return "Object"
+	}
+	return fmt.Sprint(v)
+}
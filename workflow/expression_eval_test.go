@@ -0,0 +1,172 @@
+package workflow_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+// testEvalContext returns contexts for a push to main.
+func testEvalContext(t *testing.T) *workflow.EvalContext {
+	t.Helper()
+	ctx, err := workflow.EvalContextFromJSON([]byte(`{
+		"github": {
+			"ref": "refs/heads/main",
+			"event_name": "push",
+			"event": {
+				"commits": [{"message": "fix: one"}, {"message": "feat: two"}],
+				"head_commit": {"message": "release [skip ci]"}
+			}
+		},
+		"matrix": {"os": "ubuntu-latest", "node": 20},
+		"needs": {"build": {"result": "success", "outputs": {"version": "1.2.3"}}},
+		"steps": {"meta": {"outputs": {"tags": "app:1"}}},
+		"inputs": {"debug": false, "count": "3"},
+		"vars": {"REGION": "us-east-1"},
+		"secrets": {"TOKEN": "s3cret"},
+		"env": {"CONFIG": "{\"enabled\": true, \"list\": [1, 2]}"}
+	}`))
+	if err != nil {
+		t.Fatalf("EvalContextFromJSON() error = %v", err)
+	}
+	return ctx
+}
+
+func TestExpression_Evaluate(t *testing.T) {
+	ctx := testEvalContext(t)
+
+	tests := []struct {
+		expr string
+		want any
+	}{
+		// Literals and contexts
+		{expr: "null", want: nil},
+		{expr: "0xff", want: 255.0},
+		{expr: "github.ref", want: "refs/heads/main"},
+		{expr: "GitHub.Event_Name", want: "push"},
+		{expr: "matrix['os']", want: "ubuntu-latest"},
+		{expr: "github.event.commits[1].message", want: "feat: two"},
+		{expr: "github.event.commits[5]", want: nil},
+		{expr: "github.missing.deeply", want: nil},
+		{expr: "github.event.commits.*.message", want: []any{"fix: one", "feat: two"}},
+		{expr: "needs.*.result", want: []any{"success"}},
+
+		// Loose equality and comparison
+		{expr: "github.ref == 'REFS/HEADS/MAIN'", want: true},
+		{expr: "inputs.count == 3", want: true},
+		{expr: "inputs.debug == 0", want: true},
+		{expr: "null == 0", want: true},
+		{expr: "'abc' == 0", want: false},
+		{expr: "matrix.node >= 18", want: true},
+		{expr: "'b' > 'A'", want: true},
+		{expr: "github.event == github.event", want: true},
+		{expr: "fromJSON('{}') == fromJSON('{}')", want: false},
+		{expr: "fromJSON('[]') == fromJSON('[]')", want: false},
+		{expr: "github.event.commits == github.event.commits", want: true},
+
+		// Logical operators return operands
+		{expr: "inputs.debug || 'fallback'", want: "fallback"},
+		{expr: "vars.REGION && secrets.TOKEN", want: "s3cret"},
+		{expr: "vars.MISSING && secrets.TOKEN", want: nil},
+		{expr: "!''", want: true},
+		{expr: "!fromJSON('[]')", want: false},
+
+		// Functions
+		{expr: "contains(github.event.head_commit.message, '[SKIP CI]')", want: true},
+		{expr: "contains(fromJSON('[\"a\", 1]'), '1')", want: true},
+		{expr: "startsWith(github.ref, 'refs/heads/')", want: true},
+		{expr: "endsWith(github.ref, 'Main')", want: true},
+		{expr: "format('{0}-{1}-{{x}}', matrix.os, matrix.node)", want: "ubuntu-latest-20-{x}"},
+		{expr: "join(github.event.commits.*.message, '; ')", want: "fix: one; feat: two"},
+		{expr: "join('single')", want: "single"},
+		{expr: "toJSON(matrix.node)", want: "20"},
+		{expr: "fromJSON(env.CONFIG).list[1]", want: 2.0},
+		{expr: "fromJSON(env.CONFIG).enabled", want: true},
+		{expr: "success() && !failure() && !cancelled() && always()", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := workflow.Expression(tt.expr).Evaluate(ctx)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_Evaluate_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "job.status", wantErr: `unknown context "job"`},
+		{expr: "nope()", wantErr: "unknown function nope()"},
+		{expr: "contains('a')", wantErr: "contains() called with 1 arguments"},
+		{expr: "format('{1}', 'a')", wantErr: "invalid placeholder"},
+		{expr: "fromJSON('{')", wantErr: "fromJSON"},
+		{expr: "hashFiles('**/go.sum')", wantErr: "cannot be evaluated locally"},
+		{expr: "github.ref ==", wantErr: "invalid expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := workflow.Expression(tt.expr).Evaluate(nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Evaluate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvalCondition(t *testing.T) {
+	ctx := testEvalContext(t)
+
+	tests := []struct {
+		name   string
+		cond   any
+		status string
+		want   bool
+	}{
+		{name: "builder", cond: workflow.OnMainBranch().And(workflow.IsPush()), want: true},
+		{name: "builder false", cond: workflow.OnMainBranch().And(workflow.IsPullRequest()), want: false},
+		{name: "tag", cond: workflow.IsTag(), want: false},
+		{name: "wrapped string", cond: "${{ matrix.os == 'ubuntu-latest' }}", want: true},
+		{name: "string condition", cond: workflow.StringCondition("inputs.debug"), want: false},
+		{name: "empty", cond: nil, want: true},
+		{name: "implicit success after failure", cond: workflow.Branch("main"), status: "failure", want: false},
+		{name: "failure", cond: workflow.Failure(), status: "failure", want: true},
+		{name: "always", cond: workflow.Always(), status: "cancelled", want: true},
+		{name: "template", cond: "${{ matrix.os }} and more", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx.Status = tt.status
+			got, err := workflow.EvalCondition(tt.cond, ctx)
+			if err != nil {
+				t.Fatalf("EvalCondition() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EvalCondition(%v) = %v, want %v", tt.cond, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	ctx := testEvalContext(t)
+
+	got, err := workflow.Interpolate("deploy ${{ needs.build.outputs.version }} to ${{ vars.REGION }}${{ null }}", ctx)
+	if err != nil {
+		t.Fatalf("Interpolate() error = %v", err)
+	}
+	if want := "deploy 1.2.3 to us-east-1"; got != want {
+		t.Errorf("Interpolate() = %q, want %q", got, want)
+	}
+}
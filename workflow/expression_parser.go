@@ -0,0 +1,482 @@
+package workflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExpressionError describes a malformed expression.
+type ExpressionError struct {
+	// Expr is the expression being parsed
+	Expr string
+
+	// Offset is the byte offset of the error within Expr
+	Offset int
+
+	// Message describes the problem
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("invalid expression %q: %s at offset %d", e.Expr, e.Message, e.Offset)
+}
+
+// Parse parses the expression into an AST.
+func (e Expression) Parse() (ExprNode, error) {
+	return ParseExpression(string(e))
+}
+
+// ParseExpression parses GitHub Actions expression syntax, without the
+// ${{ }} wrapper, into an AST.
+//
+// Operators bind in this order, tightest first: property, index and filter
+// access; !; < <= > >=; == !=; &&; ||.
+func ParseExpression(s string) (ExprNode, error) {
+	p := &exprParser{lex: exprLexer{src: s}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return node, nil
+}
+
+// ExtractExpressions returns the contents of each ${{ }} placeholder in s,
+// in order. A closing }} inside a string literal does not end a placeholder.
+func ExtractExpressions(s string) ([]string, error) {
+	var exprs []string
+	offset := 0
+	for {
+		start, end, err := findPlaceholder(s, offset)
+		if err != nil {
+			return nil, err
+		}
+		if start < 0 {
+			return exprs, nil
+		}
+		exprs = append(exprs, strings.TrimSpace(s[start+3:end-2]))
+		offset = end
+	}
+}
+
+// findPlaceholder returns the bounds of the first ${{ }} placeholder in s at
+// or after offset, or -1 if there is none.
+func findPlaceholder(s string, offset int) (start, end int, err error) {
+	start = strings.Index(s[offset:], "${{")
+	if start < 0 {
+		return -1, -1, nil
+	}
+	start += offset
+
+	inString := false
+	for i := start + 3; i < len(s); i++ {
+		if s[i] == '\'' {
+			inString = !inString
+		} else if !inString && strings.HasPrefix(s[i:], "}}") {
+			return start, i + 2, nil
+		}
+	}
+	return -1, -1, &ExpressionError{Expr: s, Offset: start, Message: "unterminated ${{"}
+}
+
+// ValidateExpressions checks that every ${{ }} placeholder in s parses.
+func ValidateExpressions(s string) error {
+	exprs, err := ExtractExpressions(s)
+	if err != nil {
+		return err
+	}
+	for _, expr := range exprs {
+		if _, err := ParseExpression(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tokenKind identifies the type of an expression token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokDot
+	tokComma
+	tokStar
+	tokNot
+	tokCompare
+	tokAnd
+	tokOr
+)
+
+// exprToken is a single lexical token.
+type exprToken struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func (t exprToken) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// singleCharTokens maps one-character punctuation to token kinds.
+var singleCharTokens = map[byte]tokenKind{
+	'(': tokLParen, ')': tokRParen, '[': tokLBracket, ']': tokRBracket,
+	'.': tokDot, ',': tokComma, '*': tokStar, '!': tokNot,
+	'<': tokCompare, '>': tokCompare,
+}
+
+// exprLexer splits expression source into tokens.
+type exprLexer struct {
+	src string
+	pos int
+}
+
+// next returns the next token in the source.
+func (l *exprLexer) next() (exprToken, error) {
+	for l.pos < len(l.src) && isExprSpace(l.src[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	if start >= len(l.src) {
+		return exprToken{kind: tokEOF, pos: start}, nil
+	}
+
+	c := l.src[start]
+	switch {
+	case c == '\'':
+		return l.lexString()
+	case isDigit(c) || c == '-' && start+1 < len(l.src) && isDigit(l.src[start+1]):
+		return l.lexNumber()
+	case isIdentStart(c):
+		for l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+			l.pos++
+		}
+		return exprToken{kind: tokIdent, text: l.src[start:l.pos], pos: start}, nil
+	}
+
+	for _, op := range []string{"==", "!=", "<=", ">=", "&&", "||"} {
+		if strings.HasPrefix(l.src[start:], op) {
+			l.pos += 2
+			kind := tokCompare
+			if op == "&&" {
+				kind = tokAnd
+			} else if op == "||" {
+				kind = tokOr
+			}
+			return exprToken{kind: kind, text: op, pos: start}, nil
+		}
+	}
+
+	if kind, ok := singleCharTokens[c]; ok {
+		l.pos++
+		return exprToken{kind: kind, text: string(c), pos: start}, nil
+	}
+
+	return exprToken{}, &ExpressionError{Expr: l.src, Offset: start, Message: fmt.Sprintf("unexpected character %q", c)}
+}
+
+// lexString reads a single-quoted string, in which a doubled quote
+// stands for one quote.
+func (l *exprLexer) lexString() (exprToken, error) {
+	start := l.pos
+	var sb strings.Builder
+	l.pos++
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		l.pos++
+		if c != '\'' {
+			sb.WriteByte(c)
+			continue
+		}
+		if l.pos < len(l.src) && l.src[l.pos] == '\'' {
+			sb.WriteByte('\'')
+			l.pos++
+			continue
+		}
+		return exprToken{kind: tokString, text: l.src[start:l.pos], value: sb.String(), pos: start}, nil
+	}
+	return exprToken{}, &ExpressionError{Expr: l.src, Offset: start, Message: "unterminated string"}
+}
+
+// lexNumber reads a decimal or hexadecimal number.
+func (l *exprLexer) lexNumber() (exprToken, error) {
+	start := l.pos
+	l.pos++ // a digit or the sign
+	for l.pos < len(l.src) && isNumberPart(l.src[l.pos], l.src[l.pos-1]) {
+		l.pos++
+	}
+	text := l.src[start:l.pos]
+
+	var value float64
+	if hex, ok := strings.CutPrefix(strings.ToLower(text), "0x"); ok {
+		n, err := strconv.ParseUint(hex, 16, 64)
+		if err != nil {
+			return exprToken{}, &ExpressionError{Expr: l.src, Offset: start, Message: fmt.Sprintf("invalid number %q", text)}
+		}
+		value = float64(n)
+	} else {
+		n, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return exprToken{}, &ExpressionError{Expr: l.src, Offset: start, Message: fmt.Sprintf("invalid number %q", text)}
+		}
+		value = n
+	}
+	return exprToken{kind: tokNumber, text: text, value: value, pos: start}, nil
+}
+
+func isExprSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool { return isIdentStart(c) || isDigit(c) || c == '-' }
+
+// isNumberPart reports whether c continues a number whose previous byte is prev.
+func isNumberPart(c, prev byte) bool {
+	switch {
+	case isDigit(c), c == '.':
+		return true
+	case c == 'x' || c == 'X' || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F'):
+		return true
+	case c == '+' || c == '-':
+		return prev == 'e' || prev == 'E'
+	}
+	return false
+}
+
+// exprParser is a recursive descent parser over exprLexer tokens.
+type exprParser struct {
+	lex exprLexer
+	tok exprToken
+}
+
+// next advances to the next token.
+func (p *exprParser) next() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// expect consumes a token of the given kind.
+func (p *exprParser) expect(kind tokenKind, what string) error {
+	if p.tok.kind != kind {
+		return p.errorf("expected %s, found %s", what, p.tok)
+	}
+	return p.next()
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return &ExpressionError{Expr: p.lex.src, Offset: p.tok.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) parseOr() (ExprNode, error) {
+	return p.parseBinary(tokOr, p.parseAnd)
+}
+
+func (p *exprParser) parseAnd() (ExprNode, error) {
+	return p.parseBinary(tokAnd, p.parseEquality)
+}
+
+// parseBinary parses a left-associative chain of logical operators.
+func (p *exprParser) parseBinary(kind tokenKind, operand func() (ExprNode, error)) (ExprNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == kind {
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &LogicalNode{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseEquality() (ExprNode, error) {
+	return p.parseCompare([]string{"==", "!="}, p.parseComparison)
+}
+
+func (p *exprParser) parseComparison() (ExprNode, error) {
+	return p.parseCompare([]string{"<", "<=", ">", ">="}, p.parseNot)
+}
+
+// parseCompare parses a left-associative chain of the given comparison operators.
+func (p *exprParser) parseCompare(ops []string, operand func() (ExprNode, error)) (ExprNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokCompare && containsString(ops, p.tok.text) {
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &CompareNode{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (ExprNode, error) {
+	if p.tok.kind != tokNot {
+		return p.parsePostfix()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return &NotNode{Operand: operand}, nil
+}
+
+// parsePostfix parses a primary followed by property, filter and index access.
+func (p *exprParser) parsePostfix() (ExprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.tok.kind {
+		case tokDot:
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			switch p.tok.kind {
+			case tokStar:
+				node = &FilterNode{Receiver: node}
+			case tokIdent:
+				node = &PropertyNode{Receiver: node, Name: p.tok.text}
+			default:
+				return nil, p.errorf("expected property name or * after '.', found %s", p.tok)
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		case tokLBracket:
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind == tokStar {
+				if err := p.next(); err != nil {
+					return nil, err
+				}
+				node = &FilterNode{Receiver: node}
+			} else {
+				index, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				node = &IndexNode{Receiver: node, Index: index}
+			}
+			if err := p.expect(tokRBracket, "']'"); err != nil {
+				return nil, err
+			}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *exprParser) parsePrimary() (ExprNode, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		return &NumberNode{Value: tok.value.(float64)}, p.next()
+	case tokString:
+		return &StringNode{Value: tok.value.(string)}, p.next()
+	case tokLParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(tokRParen, "')'")
+	case tokIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokLParen {
+			return p.parseCall(tok.text)
+		}
+		switch tok.text {
+		case "null":
+			return &NullNode{}, nil
+		case "true":
+			return &BoolNode{Value: true}, nil
+		case "false":
+			return &BoolNode{Value: false}, nil
+		}
+		return &VariableNode{Name: tok.text}, nil
+	}
+	return nil, p.errorf("unexpected %s", tok)
+}
+
+// parseCall parses the argument list of a function call.
+func (p *exprParser) parseCall(name string) (ExprNode, error) {
+	call := &CallNode{Name: name}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokRParen {
+		return call, p.next()
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+		if p.tok.kind != tokComma {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	return call, p.expect(tokRParen, "')' or ','")
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
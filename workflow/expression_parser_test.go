@@ -0,0 +1,278 @@
+package workflow_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want workflow.ExprNode
+	}{
+		{
+			name: "null",
+			expr: "null",
+			want: &workflow.NullNode{},
+		},
+		{
+			name: "bool",
+			expr: "true",
+			want: &workflow.BoolNode{Value: true},
+		},
+		{
+			name: "negative float",
+			expr: "-9.5",
+			want: &workflow.NumberNode{Value: -9.5},
+		},
+		{
+			name: "hex",
+			expr: "0xff",
+			want: &workflow.NumberNode{Value: 255},
+		},
+		{
+			name: "exponent",
+			expr: "2e-3",
+			want: &workflow.NumberNode{Value: 0.002},
+		},
+		{
+			name: "escaped string",
+			expr: "'it''s'",
+			want: &workflow.StringNode{Value: "it's"},
+		},
+		{
+			name: "property with hyphen",
+			expr: "steps.my-step.outputs.value",
+			want: &workflow.PropertyNode{
+				Receiver: &workflow.PropertyNode{
+					Receiver: &workflow.PropertyNode{
+						Receiver: &workflow.VariableNode{Name: "steps"},
+						Name:     "my-step",
+					},
+					Name: "outputs",
+				},
+				Name: "value",
+			},
+		},
+		{
+			name: "index",
+			expr: "github.event['ref']",
+			want: &workflow.IndexNode{
+				Receiver: &workflow.PropertyNode{Receiver: &workflow.VariableNode{Name: "github"}, Name: "event"},
+				Index:    &workflow.StringNode{Value: "ref"},
+			},
+		},
+		{
+			name: "filter",
+			expr: "github.event.commits.*.message",
+			want: &workflow.PropertyNode{
+				Receiver: &workflow.FilterNode{
+					Receiver: &workflow.PropertyNode{
+						Receiver: &workflow.PropertyNode{Receiver: &workflow.VariableNode{Name: "github"}, Name: "event"},
+						Name:     "commits",
+					},
+				},
+				Name: "message",
+			},
+		},
+		{
+			name: "call",
+			expr: "contains(github.ref, 'main')",
+			want: &workflow.CallNode{Name: "contains", Args: []workflow.ExprNode{
+				&workflow.PropertyNode{Receiver: &workflow.VariableNode{Name: "github"}, Name: "ref"},
+				&workflow.StringNode{Value: "main"},
+			}},
+		},
+		{
+			name: "call without arguments",
+			expr: "always()",
+			want: &workflow.CallNode{Name: "always"},
+		},
+		{
+			name: "and binds tighter than or",
+			expr: "a || b && c",
+			want: &workflow.LogicalNode{
+				Op:   "||",
+				Left: &workflow.VariableNode{Name: "a"},
+				Right: &workflow.LogicalNode{
+					Op:    "&&",
+					Left:  &workflow.VariableNode{Name: "b"},
+					Right: &workflow.VariableNode{Name: "c"},
+				},
+			},
+		},
+		{
+			name: "comparison binds tighter than equality",
+			expr: "a == b < c",
+			want: &workflow.CompareNode{
+				Op:   "==",
+				Left: &workflow.VariableNode{Name: "a"},
+				Right: &workflow.CompareNode{
+					Op:    "<",
+					Left:  &workflow.VariableNode{Name: "b"},
+					Right: &workflow.VariableNode{Name: "c"},
+				},
+			},
+		},
+		{
+			name: "not binds tighter than equality",
+			expr: "!a == b",
+			want: &workflow.CompareNode{
+				Op:    "==",
+				Left:  &workflow.NotNode{Operand: &workflow.VariableNode{Name: "a"}},
+				Right: &workflow.VariableNode{Name: "b"},
+			},
+		},
+		{
+			name: "parentheses",
+			expr: "(a || b) && c",
+			want: &workflow.LogicalNode{
+				Op: "&&",
+				Left: &workflow.LogicalNode{
+					Op:    "||",
+					Left:  &workflow.VariableNode{Name: "a"},
+					Right: &workflow.VariableNode{Name: "b"},
+				},
+				Right: &workflow.VariableNode{Name: "c"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := workflow.ParseExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q) error = %v", tt.expr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseExpression(%q) = %#v, want %#v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpression_Errors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+	}{
+		{expr: "", offset: 0},
+		{expr: "github.", offset: 7},
+		{expr: "a &&", offset: 4},
+		{expr: "a = b", offset: 2},
+		{expr: "'unterminated", offset: 0},
+		{expr: "contains(a, b", offset: 13},
+		{expr: "a b", offset: 2},
+		{expr: "${{ github.ref }}", offset: 0},
+		{expr: "matrix[0", offset: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := workflow.ParseExpression(tt.expr)
+			var exprErr *workflow.ExpressionError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("ParseExpression(%q) error = %v, want ExpressionError", tt.expr, err)
+			}
+			if exprErr.Offset != tt.offset {
+				t.Errorf("ParseExpression(%q) offset = %d, want %d (%v)", tt.expr, exprErr.Offset, tt.offset, err)
+			}
+		})
+	}
+}
+
+func TestExprNode_String(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "github.ref=='refs/heads/main'", want: "github.ref == 'refs/heads/main'"},
+		{expr: "((a || b)) && c", want: "(a || b) && c"},
+		{expr: "a || (b && c)", want: "a || b && c"},
+		{expr: "a && (b && c)", want: "a && (b && c)"},
+		{expr: "!(a == b)", want: "!(a == b)"},
+		{expr: "!!a", want: "!!a"},
+		{expr: "'it''s'", want: "'it''s'"},
+		{expr: "github.event.commits[*].message", want: "github.event.commits.*.message"},
+		{expr: "format('{0}-{1}', 1.50, null)", want: "format('{0}-{1}', 1.5, null)"},
+		{expr: "matrix['os'] != false", want: "matrix['os'] != false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			node, err := workflow.ParseExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q) error = %v", tt.expr, err)
+			}
+			if got := node.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+
+			// Printing must round-trip to the same tree
+			reparsed, err := workflow.ParseExpression(node.String())
+			if err != nil {
+				t.Fatalf("reparse error = %v", err)
+			}
+			if !reflect.DeepEqual(reparsed, node) {
+				t.Errorf("reparse of %q = %#v, want %#v", node.String(), reparsed, node)
+			}
+		})
+	}
+}
+
+func TestExpression_Parse(t *testing.T) {
+	expr := workflow.Branch("main").And(workflow.Push())
+	node, err := expr.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if _, ok := node.(*workflow.LogicalNode); !ok {
+		t.Errorf("Parse() = %T, want *LogicalNode", node)
+	}
+}
+
+func TestExtractExpressions(t *testing.T) {
+	got, err := workflow.ExtractExpressions("v${{ matrix.version }}-${{ format('{0}}}', github.sha) }}")
+	if err != nil {
+		t.Fatalf("ExtractExpressions() error = %v", err)
+	}
+	want := []string{"matrix.version", "format('{0}}}', github.sha)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractExpressions() = %q, want %q", got, want)
+	}
+
+	if _, err := workflow.ExtractExpressions("echo ${{ github.ref"); err == nil {
+		t.Error("ExtractExpressions() should fail on an unterminated placeholder")
+	}
+}
+
+func TestValidateExpressions(t *testing.T) {
+	if err := workflow.ValidateExpressions("echo ${{ github.ref }} ${{ secrets.TOKEN }}"); err != nil {
+		t.Errorf("ValidateExpressions() error = %v", err)
+	}
+	if err := workflow.ValidateExpressions("echo ${{ github.ref == }}"); err == nil {
+		t.Error("ValidateExpressions() should fail on a malformed expression")
+	}
+}
+
+func TestWalkExpr(t *testing.T) {
+	node, err := workflow.ParseExpression("contains(github.ref, 'x') && !inputs.skip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vars []string
+	workflow.WalkExpr(node, func(n workflow.ExprNode) bool {
+		if v, ok := n.(*workflow.VariableNode); ok {
+			vars = append(vars, v.Name)
+		}
+		return true
+	})
+	if want := []string{"github", "inputs"}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("variables = %v, want %v", vars, want)
+	}
+}
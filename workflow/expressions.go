@@ -1,6 +1,10 @@
 package workflow
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // Expression wraps a GitHub Actions expression string.
 // When serialized to YAML, becomes ${{ expression }}.
@@ -16,6 +20,12 @@ func (e Expression) Raw() string {
 	return string(e)
 }
 
+// MarshalJSON encodes the expression in its ${{ }} form, so expressions
+// keep their meaning when workflow values are extracted as JSON.
+func (e Expression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
 // And combines this expression with another using &&.
 func (e Expression) And(other Expression) Expression {
 	return Expression(fmt.Sprintf("(%s) && (%s)", e.Raw(), other.Raw()))
@@ -215,39 +225,39 @@ func PullRequest() Expression {
 
 // Contains returns an expression that checks if a value contains a substring.
 func Contains(haystack, needle Expression) Expression {
-	return Expression(fmt.Sprintf("contains(%s, %s)", haystack, needle))
+	return Expression(fmt.Sprintf("contains(%s, %s)", haystack.Raw(), needle.Raw()))
 }
 
 // StartsWith returns an expression that checks if a value starts with a prefix.
 func StartsWith(value, prefix Expression) Expression {
-	return Expression(fmt.Sprintf("startsWith(%s, %s)", value, prefix))
+	return Expression(fmt.Sprintf("startsWith(%s, %s)", value.Raw(), prefix.Raw()))
 }
 
 // EndsWith returns an expression that checks if a value ends with a suffix.
 func EndsWith(value, suffix Expression) Expression {
-	return Expression(fmt.Sprintf("endsWith(%s, %s)", value, suffix))
+	return Expression(fmt.Sprintf("endsWith(%s, %s)", value.Raw(), suffix.Raw()))
 }
 
 // Format returns an expression that formats a string with arguments.
 func Format(formatStr string, args ...Expression) Expression {
-	argStrs := make([]any, len(args))
+	argStrs := make([]string, len(args))
 	for i, arg := range args {
-		argStrs[i] = string(arg)
+		argStrs[i] = arg.Raw()
 	}
-	return Expression(fmt.Sprintf("format('%s', %s)", formatStr, fmt.Sprint(argStrs...)))
+	return Expression(fmt.Sprintf("format('%s', %s)", formatStr, strings.Join(argStrs, ", ")))
 }
 
 // Join returns an expression that joins an array with a separator.
 func Join(array Expression, separator string) Expression {
-	return Expression(fmt.Sprintf("join(%s, '%s')", array, separator))
+	return Expression(fmt.Sprintf("join(%s, '%s')", array.Raw(), separator))
 }
 
 // ToJSON returns an expression that converts a value to JSON.
 func ToJSON(value Expression) Expression {
-	return Expression(fmt.Sprintf("toJSON(%s)", value))
+	return Expression(fmt.Sprintf("toJSON(%s)", value.Raw()))
 }
 
 // FromJSON returns an expression that parses JSON.
 func FromJSON(json Expression) Expression {
-	return Expression(fmt.Sprintf("fromJSON(%s)", json))
+	return Expression(fmt.Sprintf("fromJSON(%s)", json.Raw()))
 }
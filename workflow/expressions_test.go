@@ -1,6 +1,7 @@
 package workflow_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/lex00/wetwire-github-go/workflow"
@@ -21,6 +22,20 @@ func TestExpressionRaw(t *testing.T) {
 	}
 }
 
+func TestExpressionMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(map[string]any{
+		"token": workflow.Secrets.Get("TOKEN"),
+		"tag":   workflow.Step{ID: "meta"}.Output("tags"),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	expected := `{"tag":"${{ steps.meta.outputs.tags }}","token":"${{ secrets.TOKEN }}"}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
 func TestExpressionAnd(t *testing.T) {
 	expr := workflow.Branch("main").And(workflow.Push())
 	expected := "(github.ref == 'refs/heads/main') && (github.event_name == 'push')"
@@ -292,8 +307,7 @@ func TestStringFunctions(t *testing.T) {
 			workflow.GitHub.Ref(),
 			workflow.Expression("'refs/heads/main'"),
 		)
-		// The function uses Expression.String() which wraps in ${{ }}
-		expected := "contains(${{ github.ref }}, ${{ 'refs/heads/main' }})"
+		expected := "contains(github.ref, 'refs/heads/main')"
 		if expr.Raw() != expected {
 			t.Errorf("expected %q, got %q", expected, expr.Raw())
 		}
@@ -304,7 +318,7 @@ func TestStringFunctions(t *testing.T) {
 			workflow.GitHub.Ref(),
 			workflow.Expression("'refs/tags/'"),
 		)
-		expected := "startsWith(${{ github.ref }}, ${{ 'refs/tags/' }})"
+		expected := "startsWith(github.ref, 'refs/tags/')"
 		if expr.Raw() != expected {
 			t.Errorf("expected %q, got %q", expected, expr.Raw())
 		}
@@ -315,7 +329,7 @@ func TestStringFunctions(t *testing.T) {
 			workflow.GitHub.RefName(),
 			workflow.Expression("'-rc'"),
 		)
-		expected := "endsWith(${{ github.ref_name }}, ${{ '-rc' }})"
+		expected := "endsWith(github.ref_name, '-rc')"
 		if expr.Raw() != expected {
 			t.Errorf("expected %q, got %q", expected, expr.Raw())
 		}
@@ -332,12 +346,24 @@ func TestStringFunctions(t *testing.T) {
 		}
 	})
 
+	t.Run("Format with multiple arguments", func(t *testing.T) {
+		expr := workflow.Format(
+			"{0}-{1}",
+			workflow.GitHub.RefName(),
+			workflow.GitHub.SHA(),
+		)
+		expected := "format('{0}-{1}', github.ref_name, github.sha)"
+		if expr.Raw() != expected {
+			t.Errorf("expected %q, got %q", expected, expr.Raw())
+		}
+	})
+
 	t.Run("Join", func(t *testing.T) {
 		expr := workflow.Join(
 			workflow.Expression("github.event.commits.*.message"),
 			", ",
 		)
-		expected := "join(${{ github.event.commits.*.message }}, ', ')"
+		expected := "join(github.event.commits.*.message, ', ')"
 		if expr.Raw() != expected {
 			t.Errorf("expected %q, got %q", expected, expr.Raw())
 		}
@@ -345,7 +371,7 @@ func TestStringFunctions(t *testing.T) {
 
 	t.Run("ToJSON", func(t *testing.T) {
 		expr := workflow.ToJSON(workflow.GitHub.Event("pull_request"))
-		expected := "toJSON(${{ github.event.pull_request }})"
+		expected := "toJSON(github.event.pull_request)"
 		if expr.Raw() != expected {
 			t.Errorf("expected %q, got %q", expected, expr.Raw())
 		}
@@ -353,7 +379,7 @@ func TestStringFunctions(t *testing.T) {
 
 	t.Run("FromJSON", func(t *testing.T) {
 		expr := workflow.FromJSON(workflow.Steps.Get("config", "json"))
-		expected := "fromJSON(${{ steps.config.outputs.json }})"
+		expected := "fromJSON(steps.config.outputs.json)"
 		if expr.Raw() != expected {
 			t.Errorf("expected %q, got %q", expected, expr.Raw())
 		}
@@ -1,12 +1,15 @@
 package workflow
 
+import "fmt"
+
 // Job represents a workflow job.
 type Job struct {
 	// Name is the display name for this job.
 	Name string `yaml:"name,omitempty"`
 
 	// RunsOn specifies the runner environment.
-	// Can be a string ("ubuntu-latest") or expression (Matrix.Get("os")).
+	// Can be a string ("ubuntu-latest"), an expression (Matrix.Get("os")),
+	// or a RunsOn built with Hosted, SelfHosted, RunnerGroup or RunsOnExpr.
 	RunsOn any `yaml:"runs-on"`
 
 	// Needs lists jobs that must complete before this job runs.
@@ -16,6 +19,20 @@ type Job struct {
 	// If is a conditional expression to determine if this job runs.
 	If any `yaml:"if,omitempty"`
 
+	// Uses calls a reusable workflow instead of running steps.
+	// Can be a string ("org/repo/.github/workflows/ci.yml@v1") or a
+	// workflow.Workflow variable with a WorkflowCall trigger declared in
+	// the same module, in this package or another, which resolves to its
+	// generated file.
+	Uses any `yaml:"uses,omitempty"`
+
+	// With passes inputs to the called reusable workflow.
+	With map[string]any `yaml:"with,omitempty"`
+
+	// Secrets passes secrets to the called reusable workflow.
+	// Can be SecretsInherit or a map[string]any of secret values.
+	Secrets any `yaml:"secrets,omitempty"`
+
 	// Permissions sets GITHUB_TOKEN permissions for this job.
 	Permissions *Permissions `yaml:"permissions,omitempty"`
 
@@ -54,9 +71,40 @@ type Job struct {
 	ContinueOnError bool `yaml:"continue-on-error,omitempty"`
 }
 
+// Output returns a reference to one of this job's outputs, for use in jobs
+// that need it: ${{ needs.<job-id>.outputs.<name> }}.
+//
+// The job ID is resolved at build time, so Output must be called on a
+// package-level Job variable. The build fails if
+// the output is not declared in Outputs or the consuming job does not need
+// this job, directly or transitively.
+//
+// Example:
+//
+//	var Test = workflow.Job{
+//		Needs: []any{Build},
+//		If:    Build.Output("changed").Raw() + " == 'true'",
+//	}
+func (j *Job) Output(name string) Expression {
+	return Needs.Get(JobRef(j), name)
+}
+
+// JobRef returns the placeholder job ID used by Output for a Job variable.
+// The build pipeline replaces it with the job's ID in the workflow.
+func JobRef(j *Job) string {
+	return fmt.Sprintf("%s%p", JobRefPrefix, j)
+}
+
+// JobRefPrefix starts every placeholder job ID returned by JobRef.
+const JobRefPrefix = "_wetwire_job_"
+
+// SecretsInherit passes all of the caller's secrets to a reusable workflow.
+const SecretsInherit = "inherit"
+
 // Permissions configures GITHUB_TOKEN permissions.
 type Permissions struct {
 	Actions            string `yaml:"actions,omitempty"`
+	Attestations       string `yaml:"attestations,omitempty"`
 	Checks             string `yaml:"checks,omitempty"`
 	Contents           string `yaml:"contents,omitempty"`
 	Deployments        string `yaml:"deployments,omitempty"`
@@ -1,6 +1,7 @@
 package workflow_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lex00/wetwire-github-go/workflow"
@@ -386,3 +387,21 @@ func TestStrategy(t *testing.T) {
 		}
 	})
 }
+
+func TestJob_Output(t *testing.T) {
+	var build, test workflow.Job
+
+	ref := workflow.JobRef(&build)
+	if !strings.HasPrefix(ref, workflow.JobRefPrefix) {
+		t.Errorf("JobRef() = %q, want prefix %q", ref, workflow.JobRefPrefix)
+	}
+	if ref == workflow.JobRef(&test) {
+		t.Error("JobRef() returned the same ID for different jobs")
+	}
+
+	got := build.Output("version")
+	want := workflow.Expression("needs." + ref + ".outputs.version")
+	if got != want {
+		t.Errorf("Output() = %q, want %q", got, want)
+	}
+}
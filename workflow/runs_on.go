@@ -0,0 +1,157 @@
+package workflow
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// RunsOn selects the runner a job executes on.
+//
+// Job.RunsOn still accepts plain strings and expressions; RunsOn covers the
+// forms GitHub accepts beyond a single label: label sets, runner groups and
+// expressions that pick a runner at run time.
+//
+// Example:
+//
+//	RunsOn: workflow.Hosted("ubuntu-24.04")
+//	RunsOn: workflow.SelfHosted("linux", "gpu")
+//	RunsOn: workflow.RunnerGroup("build-fleet", "linux")
+//	RunsOn: workflow.RunsOnExpr(workflow.MatrixContext.Get("os"))
+type RunsOn struct {
+	// Group is the runner group to pick a runner from.
+	Group string `yaml:"group,omitempty"`
+
+	// Labels are the labels a runner must have. When Group is empty, a
+	// single label is emitted as a string and several as a list.
+	Labels []string `yaml:"labels,omitempty"`
+
+	// Expr selects the runner at run time, e.g. from a matrix value.
+	// When set, Group and Labels are ignored.
+	Expr Expression `yaml:"-"`
+}
+
+// Hosted returns RunsOn for a GitHub-hosted runner image or a larger runner
+// label, e.g. "ubuntu-latest" or "macos-15-xlarge".
+func Hosted(image string) RunsOn {
+	return RunsOn{Labels: []string{image}}
+}
+
+// SelfHosted returns RunsOn for a self-hosted runner with all of the given
+// labels. The "self-hosted" label is added automatically.
+func SelfHosted(labels ...string) RunsOn {
+	all := []string{"self-hosted"}
+	for _, l := range labels {
+		if l != "self-hosted" {
+			all = append(all, l)
+		}
+	}
+	return RunsOn{Labels: all}
+}
+
+// RunnerGroup returns RunsOn for a runner in the given group, optionally
+// restricted to runners with all of the given labels.
+func RunnerGroup(group string, labels ...string) RunsOn {
+	return RunsOn{Group: group, Labels: labels}
+}
+
+// RunsOnExpr returns RunsOn for a runner chosen by an expression.
+func RunsOnExpr(expr Expression) RunsOn {
+	return RunsOn{Expr: expr}
+}
+
+// Value returns the runs-on value as it appears in YAML: a string, a list
+// of labels, or a map with group and labels.
+func (r RunsOn) Value() any {
+	if r.Expr != "" {
+		return r.Expr.String()
+	}
+	if r.Group != "" {
+		m := map[string]any{"group": r.Group}
+		if len(r.Labels) > 0 {
+			m["labels"] = r.Labels
+		}
+		return m
+	}
+	if len(r.Labels) == 1 {
+		return r.Labels[0]
+	}
+	return r.Labels
+}
+
+// MarshalJSON encodes the runs-on value in its YAML form, so extracted
+// workflows carry it unchanged.
+func (r RunsOn) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Value())
+}
+
+// ParseRunsOn converts a runs-on value in any accepted form to RunsOn:
+// a string, an Expression, a list of labels, a RunsOn, or a group map.
+// It returns false for values it does not recognize.
+func ParseRunsOn(v any) (RunsOn, bool) {
+	switch val := v.(type) {
+	case RunsOn:
+		return val, true
+	case *RunsOn:
+		if val == nil {
+			return RunsOn{}, false
+		}
+		return *val, true
+	case Expression:
+		return RunsOn{Expr: val}, true
+	case string:
+		if expr, ok := unwrapExpression(val); ok {
+			return RunsOn{Expr: Expression(expr)}, true
+		}
+		return RunsOn{Labels: []string{val}}, true
+	case []string:
+		return RunsOn{Labels: val}, true
+	case []any:
+		labels, ok := stringList(val)
+		return RunsOn{Labels: labels}, ok
+	case map[string]any:
+		r := RunsOn{}
+		if group, ok := val["group"].(string); ok {
+			r.Group = group
+		}
+		switch labels := val["labels"].(type) {
+		case string:
+			r.Labels = []string{labels}
+		case []string:
+			r.Labels = labels
+		case []any:
+			list, ok := stringList(labels)
+			if !ok {
+				return RunsOn{}, false
+			}
+			r.Labels = list
+		}
+		return r, r.Group != "" || len(r.Labels) > 0
+	}
+	return RunsOn{}, false
+}
+
+// stringList converts a []any holding only strings to []string.
+func stringList(items []any) ([]string, bool) {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		list = append(list, s)
+	}
+	return list, true
+}
+
+// unwrapExpression returns the contents of s if s is a single ${{ }}
+// placeholder.
+func unwrapExpression(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "${{") {
+		return "", false
+	}
+	if _, end, err := findPlaceholder(s, 0); err != nil || end != len(s) {
+		return "", false
+	}
+	return strings.TrimSpace(s[3 : len(s)-2]), true
+}
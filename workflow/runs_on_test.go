@@ -0,0 +1,77 @@
+package workflow_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/lex00/wetwire-github-go/workflow"
+)
+
+func TestRunsOn_Value(t *testing.T) {
+	tests := []struct {
+		name   string
+		runsOn workflow.RunsOn
+		want   any
+	}{
+		{"hosted", workflow.Hosted("ubuntu-24.04"), "ubuntu-24.04"},
+		{"self-hosted", workflow.SelfHosted("linux", "gpu"), []string{"self-hosted", "linux", "gpu"}},
+		{"self-hosted not repeated", workflow.SelfHosted("self-hosted", "linux"), []string{"self-hosted", "linux"}},
+		{"group", workflow.RunnerGroup("fleet"), map[string]any{"group": "fleet"}},
+		{"group with labels", workflow.RunnerGroup("fleet", "linux"), map[string]any{"group": "fleet", "labels": []string{"linux"}}},
+		{"expression", workflow.RunsOnExpr(workflow.MatrixContext.Get("os")), "${{ matrix.os }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.runsOn.Value(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunsOn_MarshalJSON(t *testing.T) {
+	job := workflow.Job{RunsOn: workflow.RunnerGroup("fleet", "linux")}
+
+	data, err := json.Marshal(job.RunsOn)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"group":"fleet","labels":["linux"]}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+}
+
+func TestParseRunsOn(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		want   workflow.RunsOn
+		wantOK bool
+	}{
+		{"string", "ubuntu-latest", workflow.Hosted("ubuntu-latest"), true},
+		{"wrapped expression", "${{ matrix.os }}", workflow.RunsOnExpr("matrix.os"), true},
+		{"template string", "linux-${{ matrix.arch }}", workflow.RunsOn{Labels: []string{"linux-${{ matrix.arch }}"}}, true},
+		{"expression", workflow.MatrixContext.Get("os"), workflow.RunsOnExpr("matrix.os"), true},
+		{"label list", []any{"self-hosted", "gpu"}, workflow.SelfHosted("gpu"), true},
+		{"group map", map[string]any{"group": "fleet", "labels": []any{"linux"}}, workflow.RunnerGroup("fleet", "linux"), true},
+		{"group map with single label", map[string]any{"group": "fleet", "labels": "linux"}, workflow.RunnerGroup("fleet", "linux"), true},
+		{"typed", workflow.SelfHosted("gpu"), workflow.SelfHosted("gpu"), true},
+		{"mixed list", []any{"linux", 3}, workflow.RunsOn{}, false},
+		{"empty map", map[string]any{}, workflow.RunsOn{}, false},
+		{"number", 42, workflow.RunsOn{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := workflow.ParseRunsOn(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseRunsOn() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRunsOn() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
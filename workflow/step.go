@@ -41,7 +41,7 @@ type Step struct {
 //
 // Example:
 //
-//	checkoutStep := checkout.Checkout{}.ToStepWithID("checkout")
+//	checkoutStep := workflow.ToStepWithID(checkout.Checkout{}, "checkout")
 //	// Later reference: checkoutStep.Output("ref")
 func (s Step) Output(name string) OutputRef {
 	return OutputRef{StepID: s.ID, Output: name}
@@ -64,6 +64,11 @@ func (o OutputRef) Expression() Expression {
 	return Steps.Get(o.StepID, o.Output)
 }
 
+// MarshalJSON encodes the reference as its ${{ }} expression.
+func (o OutputRef) MarshalJSON() ([]byte, error) {
+	return o.Expression().MarshalJSON()
+}
+
 // StepAction is implemented by action wrappers for serialization.
 // Action wrappers implement this interface to be used directly in Steps.
 type StepAction interface {
@@ -80,3 +85,48 @@ func ToStep(a StepAction) Step {
 		With: a.Inputs(),
 	}
 }
+
+// ToStepWithID converts a StepAction to a Step with the given ID, so that
+// later steps can reference its outputs.
+//
+// Example:
+//
+//	meta := docker_metadata.DockerMetadata{Images: "ghcr.io/org/app"}
+//	steps := []any{
+//		workflow.ToStepWithID(meta, "meta"),
+//		docker_build_push.DockerBuildPush{Tags: meta.Outputs("meta").Tags().String()},
+//	}
+func ToStepWithID(a StepAction, id string) Step {
+	step := ToStep(a)
+	step.ID = id
+	return step
+}
+
+// ActionStep converts a StepAction to a Step with the settings of step, such
+// as its Name, If or Env. Inputs in step.With are added to the wrapper's
+// inputs and take precedence, which keeps inputs the wrapper has no field
+// for, or cannot express such as a zero fetch-depth.
+//
+// Example:
+//
+//	workflow.ActionStep(checkout.Checkout{Submodules: "recursive"}, workflow.Step{
+//		Name: "Checkout",
+//		With: workflow.With{"fetch-depth": 0},
+//	})
+func ActionStep(a StepAction, step Step) Step {
+	with := a.Inputs()
+	if len(step.With) > 0 {
+		if with == nil {
+			with = make(map[string]any, len(step.With))
+		}
+		for k, v := range step.With {
+			with[k] = v
+		}
+	}
+	if len(with) == 0 {
+		with = nil
+	}
+	step.Uses = a.Action()
+	step.With = with
+	return step
+}
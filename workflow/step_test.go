@@ -200,3 +200,39 @@ func TestToStep(t *testing.T) {
 		t.Errorf("expected param='value', got %v", step.With["param"])
 	}
 }
+
+func TestActionStep(t *testing.T) {
+	step := workflow.ActionStep(mockAction{}, workflow.Step{
+		Name: "Mock",
+		If:   "success()",
+		With: workflow.With{"depth": 0},
+	})
+
+	if step.Uses != "actions/mock@v1" || step.Name != "Mock" || step.If != "success()" {
+		t.Errorf("unexpected step: %+v", step)
+	}
+	if step.With["param"] != "value" || step.With["depth"] != 0 {
+		t.Errorf("expected wrapper and extra inputs, got %v", step.With)
+	}
+
+	step = workflow.ActionStep(mockAction{}, workflow.Step{With: workflow.With{"param": "override"}})
+	if step.With["param"] != "override" {
+		t.Errorf("expected step.With to take precedence, got %v", step.With)
+	}
+}
+
+func TestToStepWithID(t *testing.T) {
+	step := workflow.ToStepWithID(mockAction{}, "mock")
+
+	if step.ID != "mock" {
+		t.Errorf("expected ID='mock', got %q", step.ID)
+	}
+	if step.Uses != "actions/mock@v1" {
+		t.Errorf("expected Uses='actions/mock@v1', got %q", step.Uses)
+	}
+
+	expected := "${{ steps.mock.outputs.result }}"
+	if got := step.Output("result").String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}